@@ -5,11 +5,15 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"archie-core-shopify-layer/graph"
@@ -18,6 +22,8 @@ import (
 	"archie-core-shopify-layer/internal/application/webhook_handlers"
 	"archie-core-shopify-layer/internal/domain"
 	apiinfra "archie-core-shopify-layer/internal/infrastructure/api"
+	"archie-core-shopify-layer/internal/infrastructure/auth"
+	"archie-core-shopify-layer/internal/infrastructure/cache"
 	"archie-core-shopify-layer/internal/infrastructure/encryption"
 	"archie-core-shopify-layer/internal/infrastructure/pubsub"
 	"archie-core-shopify-layer/internal/infrastructure/repository"
@@ -84,10 +90,19 @@ func main() {
 
 	// Initialize repositories
 	repo := repository.NewMongoRepository(db)
-	sessionRepo := repository.NewSessionRepository(db)
+	prepareShopStorage(db, repo, logger)
+	sessionRepo, err := newSessionRepository(db, logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to initialize session store")
+	}
 	configRepo := repository.NewMongoShopifyConfigRepository(db)
 	webhookSubscriptionRepo := repository.NewMongoWebhookSubscriptionRepository(db)
 	integrationRepo := repository.NewMongoIntegrationRepository(db)
+	prepareIntegrationStorage(integrationRepo, logger)
+	installationRepo := repository.NewMongoInstallationRepository(db)
+	prepareInstallationStorage(installationRepo, logger)
+	bulkOperationRepo := repository.NewMongoBulkOperationRepository(db)
+	storefrontTokenRepo := repository.NewMongoStorefrontTokenRepository(db)
 
 	// Initialize rate limiter and retry config for Shopify API
 	rateLimiter := shopifyinfra.NewRateLimiter(logger)
@@ -96,15 +111,25 @@ func main() {
 	// Initialize client pool with rate limiting and retry
 	clientPool := shopifyinfra.NewClientPoolWithOptions(logger, rateLimiter, retryConfig)
 
+	// Initialize token manager for refreshing expiring offline tokens
+	tokenManager := shopifyinfra.NewTokenManager(encryptionService, repo, logger)
+
 	// Initialize application services
 	shopifyService := application.NewShopifyService(
 		repo,
 		configRepo,
 		encryptionService,
 		clientPool,
+		tokenManager,
 		logger,
 		appURL,
 	)
+	if err := enableShopifyGraphQL(shopifyService, retryConfig, logger); err != nil {
+		logger.Fatal().Err(err).Msg("Failed to configure Shopify GraphQL API")
+	}
+	shopifyService.EnableInstallationTracking(installationRepo)
+	bulkOperationService := application.NewBulkOperationService(bulkOperationRepo, shopifyService, shopifyinfra.NewBulkFileClient(logger), logger)
+	storefrontService := application.NewStorefrontService(storefrontTokenRepo, shopifyService, shopifyinfra.NewStorefrontClient(logger), logger)
 
 	credentialsService := application.NewCredentialsService(
 		configRepo,
@@ -118,10 +143,25 @@ func main() {
 		logger,
 	)
 
+	installationService := application.NewInstallationService(
+		installationRepo,
+		logger,
+	)
+
+	environmentService := application.NewEnvironmentService(
+		configRepo,
+		installationRepo,
+		webhookSubscriptionRepo,
+		encryptionService,
+		logger,
+		appURL,
+	)
+
 	webhookManager := application.NewWebhookManager(
 		shopifyService,
+		webhookSubscriptionRepo,
 		logger,
-		appURL+"/webhooks/shopify",
+		appURL,
 	)
 
 	// Initialize webhook dispatcher and register handlers
@@ -129,21 +169,35 @@ func main() {
 	webhookDispatcher.RegisterHandler(webhook_handlers.NewOrderHandler(logger))
 	webhookDispatcher.RegisterHandler(webhook_handlers.NewProductHandler(logger))
 	webhookDispatcher.RegisterHandler(webhook_handlers.NewCustomerHandler(logger))
-	webhookDispatcher.RegisterHandler(webhook_handlers.NewAppUninstalledHandler(logger, repo, webhookSubscriptionRepo, shopifyService))
+	webhookDispatcher.RegisterHandler(webhook_handlers.NewAppUninstalledHandler(logger, repo, webhookSubscriptionRepo, shopifyService, installationService))
+	webhookDispatcher.RegisterHandler(webhook_handlers.NewAppScopesUpdateHandler(logger, installationService))
+	webhookDispatcher.RegisterHandler(webhook_handlers.NewBulkOperationsFinishHandler(logger, bulkOperationService))
 
 	// Initialize webhook pub/sub for GraphQL subscriptions
 	webhookPubSub := pubsub.NewWebhookPubSub(logger)
 
+	// Initialize inbound rate limiting and usage accounting
+	rateLimitService, err := newRateLimitService(logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to configure rate limiting")
+	}
+
 	// Create GraphQL resolver
-	resolver := graph.NewResolver(shopifyService, credentialsService, webhookPubSub, sessionRepo, integrationService)
+	resolver := graph.NewResolver(shopifyService, credentialsService, webhookPubSub, sessionRepo, integrationService, installationService, rateLimitService, environmentService, bulkOperationService, storefrontService)
 
 	// Create GraphQL executable schema
 	execSchema := generated.NewExecutableSchema(generated.Config{
 		Resolvers: resolver,
+		Directives: generated.DirectiveRoot{
+			HasPermission: graph.HasPermissionDirective(logger),
+		},
 	})
 
 	// Create GraphQL handler
 	srv := handler.NewDefaultServer(execSchema)
+	srv.AroundRootFields(graph.KeyScopeGuard)
+	srv.AroundOperations(graph.OnDemandFetchBudget)
+	srv.SetErrorPresenter(graph.ErrorPresenter(logger))
 
 	// Setup router
 	r := chi.NewRouter()
@@ -163,10 +217,15 @@ func main() {
 		AllowCredentials: true,
 	}))
 
-	// Add tenant ID middleware (extracts project ID and environment from headers)
-	// This middleware supports both X-Project-ID (existing) and X-Integration-Key (new) authentication
+	// Add authentication middleware (resolves the project and environment from the caller's credential)
+	// Integration keys, platform JWTs and service tokens are supported, raw X-Project-ID only in development
 	// This middleware will skip public routes like /health and /swagger/*
-	r.Use(createTenantIDMiddleware(integrationService, logger))
+	authenticators, err := newAuthenticatorChain(integrationService, logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to configure authentication")
+	}
+	r.Use(createAuthMiddleware(authenticators, logger))
+	r.Use(createRateLimitMiddleware(rateLimitService, logger))
 
 	// Public routes (no tenant ID required)
 	// Health check - must be public for monitoring
@@ -191,11 +250,21 @@ func main() {
 	r.Handle("/query", srv)
 
 	// OAuth routes
-	r.Get("/auth/shopify", oauthInitHandler(sessionRepo, shopifyService, appURL, logger))
-	r.Get("/auth/callback", oauthCallbackHandler(sessionRepo, shopifyService, webhookManager, integrationService, encryptionService, logger))
+	r.Get("/auth/shopify", oauthInitHandler(sessionRepo, shopifyService, installationService, appURL, logger))
+	r.Get("/auth/callback", oauthCallbackHandler(sessionRepo, shopifyService, webhookManager, integrationService, installationService, encryptionService, logger))
+
+	// Embedded app routes: authenticated with App Bridge session tokens instead of tenant headers
+	r.Route("/embedded", func(er chi.Router) {
+		er.Use(createSessionTokenMiddleware(configRepo, encryptionService, shopifyService, installationService, logger))
+		er.Use(createRateLimitMiddleware(rateLimitService, logger))
+		er.Handle("/query", srv)
+		er.Post("/token-exchange", sessionTokenExchangeHandler(shopifyService, installationService, logger))
+	})
 
 	// Webhook endpoint: POST /webhooks/shopify/{projectId}/{environment}
+	// Additional apps of an environment receive webhooks on /webhooks/shopify/{projectId}/{environment}/{appHandle}
 	r.Post("/webhooks/shopify/{projectId}/{environment}", webhookHandler(shopifyService, webhookDispatcher, webhookPubSub, logger))
+	r.Post("/webhooks/shopify/{projectId}/{environment}/{appHandle}", webhookHandler(shopifyService, webhookDispatcher, webhookPubSub, logger))
 
 	// REST API Proxy: /api/v1/{project}/{environment}/shopify/*
 	// Note: project and environment are extracted from headers by middleware
@@ -203,6 +272,20 @@ func main() {
 	r.HandleFunc("/api/v1/{project}/{environment}/shopify/*", restProxy.HandleProxyRequest)
 	r.HandleFunc("/api/v1/shopify/*", restProxy.HandleProxyRequest)
 
+	// Admin GraphQL passthrough: /api/v1/{project}/{environment}/graphql
+	graphQLProxy := apiinfra.NewGraphQLProxy(shopifyService, logger)
+	r.Post("/api/v1/{project}/{environment}/graphql", graphQLProxy.HandleRequest)
+	r.Post("/api/v1/graphql", graphQLProxy.HandleRequest)
+
+	// Storefront API proxy: /api/v1/{project}/{environment}/storefront
+	storefrontTTL, err := storefrontCacheTTL()
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to configure Storefront proxy")
+	}
+	storefrontProxy := apiinfra.NewStorefrontProxy(storefrontService, storefrontTTL, logger)
+	r.Post("/api/v1/{project}/{environment}/storefront", storefrontProxy.HandleRequest)
+	r.Post("/api/v1/storefront", storefrontProxy.HandleRequest)
+
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080"
@@ -217,7 +300,7 @@ func main() {
 }
 
 // oauthInitHandler initiates the OAuth flow
-func oauthInitHandler(sessionRepo *repository.SessionRepository, shopifyService *application.ShopifyService, appURL string, logger zerolog.Logger) http.HandlerFunc {
+func oauthInitHandler(sessionRepo ports.SessionRepository, shopifyService *application.ShopifyService, installationService *application.InstallationService, appURL string, logger zerolog.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
@@ -267,6 +350,7 @@ func oauthInitHandler(sessionRepo *repository.SessionRepository, shopifyService
 			Scopes:      []string{"read_products", "write_products", "read_orders", "write_orders"},
 			ProjectID:   projectID,
 			Environment: environment,
+			AppHandle:   config.AppHandle,
 			ReturnURL:   returnURL,
 			ExpiresAt:   time.Now().Add(10 * time.Minute),
 		}
@@ -277,6 +361,11 @@ func oauthInitHandler(sessionRepo *repository.SessionRepository, shopifyService
 			return
 		}
 
+		// Track the pending install
+		if _, err := installationService.StartInstall(ctx, domain.NewShopKey(projectID, environment, config.AppHandle, shop), session.Scopes); err != nil {
+			logger.Warn().Err(err).Str("shop", shop).Msg("Failed to record pending installation")
+		}
+
 		// Build authorization URL using API key from config
 		scopes := "read_products,write_products,read_orders,write_orders"
 		redirectURI := appURL + "/auth/callback"
@@ -295,10 +384,11 @@ func oauthInitHandler(sessionRepo *repository.SessionRepository, shopifyService
 
 // oauthCallbackHandler handles the OAuth callback
 func oauthCallbackHandler(
-	sessionRepo *repository.SessionRepository,
+	sessionRepo ports.SessionRepository,
 	shopifyService *application.ShopifyService,
 	webhookManager *application.WebhookManager,
 	integrationService *application.IntegrationService,
+	installationService *application.InstallationService,
 	encryptionService *encryption.Service,
 	logger zerolog.Logger,
 ) http.HandlerFunc {
@@ -315,8 +405,9 @@ func oauthCallbackHandler(
 			return
 		}
 
-		// Verify state and get session first (before getting config)
-		session, err := sessionRepo.GetSession(ctx, state)
+		// Verify state and consume the session first (before getting config)
+		// Consuming is atomic, so a replayed state finds no session
+		session, err := sessionRepo.ConsumeSession(ctx, state)
 		if err != nil {
 			logger.Error().Err(err).Msg("Failed to get session")
 			http.Error(w, "Internal server error", http.StatusInternalServerError)
@@ -338,10 +429,13 @@ func oauthCallbackHandler(
 			environment = domain.DefaultEnvironment
 		}
 
-		// Set project ID and environment in context for downstream handlers
+		// Set project ID, environment and app in context for downstream handlers
 		ctx = domain.WithProjectID(ctx, projectID)
 		ctx = domain.WithEnvironment(ctx, environment)
 		ctx = domain.WithTenantID(ctx, projectID)
+		if session.AppHandle != "" {
+			ctx = domain.WithAppHandle(ctx, session.AppHandle)
+		}
 
 		// Store session in context so ExchangeToken can access scopes
 		ctx = context.WithValue(ctx, oauthSessionKey, session)
@@ -359,9 +453,6 @@ func oauthCallbackHandler(
 			return
 		}
 
-		// Delete session (but keep it in context for ExchangeToken)
-		sessionRepo.DeleteSession(ctx, state)
-
 		// Log requested scopes for debugging
 		logger.Info().
 			Str("shop", shop).
@@ -388,6 +479,11 @@ func oauthCallbackHandler(
 			Strs("stored_scopes", shopDomain.Scopes).
 			Msg("OAuth token exchange completed - scopes stored")
 
+		// Move the installation out of PENDING_OAUTH
+		if _, err := installationService.CompleteInstall(ctx, shopDomain.Key(), shopDomain.Scopes, "oauth_completed"); err != nil {
+			logger.Warn().Err(err).Str("shop", shop).Msg("Failed to record completed installation")
+		}
+
 		// Subscribe to webhooks
 		topics := webhookManager.GetDefaultTopics()
 		// Note: We would need the access token here to subscribe to webhooks
@@ -409,6 +505,7 @@ func oauthCallbackHandler(
 			ProjectID:   projectID,
 			Environment: environment,
 			ShopDomain:  shopDomain.Domain,
+			AppHandle:   shopDomain.AppHandle,
 		})
 		if err != nil {
 			logger.Error().Err(err).Msg("Failed to create integration after OAuth")
@@ -418,7 +515,7 @@ func oauthCallbackHandler(
 				Str("projectID", projectID).
 				Str("environment", environment).
 				Str("shopDomain", shopDomain.Domain).
-				Str("integrationKey", integration.Key).
+				Str("keyPrefix", integration.KeyPrefix).
 				Msg("Created integration after successful OAuth")
 		}
 
@@ -442,15 +539,108 @@ func oauthCallbackHandler(
 			redirectURL += "&integration_key=" + url.QueryEscape(integration.Key)
 		}
 
-		logger.Info().
+		// The redirect URL carries the access token and integration key, so it is never logged
+		event := logger.Info().
 			Str("shop", shop).
-			Str("returnURL", redirectURL).
-			Msg("Redirecting to frontend after successful OAuth")
+			Str("domain", shopDomain.Domain)
+		if integration != nil {
+			event = event.Str("keyPrefix", integration.KeyPrefix)
+		}
+		event.Msg("Redirecting to frontend after successful OAuth")
 
 		http.Redirect(w, r, redirectURL, http.StatusFound)
 	}
 }
 
+// prepareShopStorage creates the shop indexes and scopes shops stored before tenant isolation
+// The service does not start without the unique shop index; migration failures are logged and retried on the next start
+func prepareShopStorage(db *mongo.Database, repo *repository.MongoRepository, logger zerolog.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	// The unique shop index must exist before migrating, so migrated documents cannot duplicate a tenant's shop
+	if err := repo.EnsureIndexes(ctx); err != nil {
+		logger.Fatal().Err(err).Msg("Failed to ensure shop indexes, shop_tenant_unique is required")
+	}
+
+	result, err := repository.MigrateLegacyShops(ctx, db)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to migrate legacy shops")
+		return
+	}
+	if result.Migrated > 0 {
+		logger.Info().
+			Int("migrated", result.Migrated).
+			Int("scoped", result.Scoped).
+			Msg("Migrated legacy shops to tenant-scoped storage")
+	}
+	if len(result.Unresolved) > 0 {
+		logger.Warn().
+			Strs("unresolved", result.Unresolved).
+			Msg("Legacy shops without a single owning project were not migrated, their projects must reinstall the app")
+	}
+}
+
+// prepareIntegrationStorage replaces the plaintext key index with the key hash indexes
+func prepareIntegrationStorage(integrationRepo *repository.MongoIntegrationRepository, logger zerolog.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := integrationRepo.EnsureIndexes(ctx); err != nil {
+		logger.Warn().Err(err).Msg("Failed to ensure integration indexes")
+	}
+}
+
+// prepareInstallationStorage keys installations by app as well as shop
+func prepareInstallationStorage(installationRepo *repository.MongoInstallationRepository, logger zerolog.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := installationRepo.EnsureIndexes(ctx); err != nil {
+		logger.Warn().Err(err).Msg("Failed to ensure installation indexes")
+	}
+}
+
+// newSessionRepository selects the OAuth session store
+// SESSION_STORE=redis keeps sessions in Redis (REDIS_URL), otherwise they live in MongoDB with a TTL index
+// and a cleanup scheduler (SESSION_CLEANUP_INTERVAL, default 15m)
+func newSessionRepository(db *mongo.Database, logger zerolog.Logger) (ports.SessionRepository, error) {
+	if os.Getenv("SESSION_STORE") == "redis" {
+		redisURL := os.Getenv("REDIS_URL")
+		if redisURL == "" {
+			return nil, fmt.Errorf("REDIS_URL is required when SESSION_STORE=redis")
+		}
+		redisClient, err := cache.NewRedisClientFromURL(redisURL)
+		if err != nil {
+			return nil, err
+		}
+		logger.Info().Msg("Using Redis session store")
+		return repository.NewRedisSessionRepository(redisClient), nil
+	}
+
+	sessionRepo := repository.NewMongoSessionRepository(db)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := sessionRepo.EnsureIndexes(ctx); err != nil {
+		// Sessions still work without indexes, the scheduler purges expired ones
+		logger.Warn().Err(err).Msg("Failed to ensure session indexes")
+	}
+
+	interval := application.DefaultSessionCleanupInterval
+	if value := os.Getenv("SESSION_CLEANUP_INTERVAL"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SESSION_CLEANUP_INTERVAL: %w", err)
+		}
+		interval = parsed
+	}
+	application.NewSessionCleanupScheduler(sessionRepo, interval, logger).Start(context.Background())
+
+	logger.Info().Msg("Using MongoDB session store")
+	return sessionRepo, nil
+}
+
 // webhookHandler handles Shopify webhook requests
 func webhookHandler(
 	shopifyService *application.ShopifyService,
@@ -474,8 +664,10 @@ func webhookHandler(
 		}
 
 		// Add to context (type-safe)
+		appHandle := domain.NormalizeAppHandle(chi.URLParam(r, "appHandle"))
 		ctx = domain.WithProjectID(ctx, projectID)
 		ctx = domain.WithEnvironment(ctx, environment)
+		ctx = domain.WithAppHandle(ctx, appHandle)
 
 		// Get Shopify configuration to retrieve webhook secret
 		config, err := shopifyService.GetConfig(ctx, projectID)
@@ -536,10 +728,13 @@ func webhookHandler(
 
 		// Process webhook event using dispatcher
 		event := &domain.WebhookEvent{
-			Topic:    topic,
-			Shop:     shop,
-			Payload:  payload,
-			Verified: true,
+			ProjectID:   projectID,
+			Environment: environment,
+			AppHandle:   appHandle,
+			Topic:       topic,
+			Shop:        shop,
+			Payload:     payload,
+			Verified:    true,
 		}
 
 		// Log webhook event first
@@ -572,91 +767,398 @@ func webhookHandler(
 	}
 }
 
-// createTenantIDMiddleware creates middleware that supports both X-Project-ID and X-Integration-Key authentication
-func createTenantIDMiddleware(integrationService *application.IntegrationService, logger zerolog.Logger) func(http.Handler) http.Handler {
+// newAuthenticatorChain builds the request authenticators from the environment
+// PLATFORM_JWT_SECRET enables platform JWTs, SERVICE_TOKENS enables service tokens and
+// ALLOW_DEV_PROJECT_HEADER=true accepts a bare X-Project-ID header (local development only)
+func newAuthenticatorChain(integrationService *application.IntegrationService, logger zerolog.Logger) (*auth.Chain, error) {
+	authenticators := []auth.Authenticator{auth.NewIntegrationKeyAuthenticator(integrationService)}
+
+	if tokens := os.Getenv("SERVICE_TOKENS"); tokens != "" {
+		parsed, err := auth.ParseServiceTokens(tokens)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SERVICE_TOKENS: %w", err)
+		}
+		authenticators = append(authenticators, auth.NewServiceTokenAuthenticator(parsed))
+	}
+
+	if secret := os.Getenv("PLATFORM_JWT_SECRET"); secret != "" {
+		authenticators = append(authenticators, auth.NewPlatformJWTAuthenticator(
+			secret,
+			os.Getenv("PLATFORM_JWT_ISSUER"),
+			os.Getenv("PLATFORM_JWT_AUDIENCE"),
+		))
+	}
+
+	if os.Getenv("ALLOW_DEV_PROJECT_HEADER") == "true" {
+		logger.Warn().Msg("ALLOW_DEV_PROJECT_HEADER is enabled: X-Project-ID is trusted without credentials, never use this in production")
+		authenticators = append(authenticators, auth.NewDevHeaderAuthenticator())
+	}
+
+	chain := auth.NewChain(authenticators...)
+	logger.Info().Strs("authenticators", chain.Names()).Msg("Authentication configured")
+	return chain, nil
+}
+
+// newRateLimitService configures inbound rate limits from the environment
+// RATE_LIMIT_DEFAULT_TIER (default standard) applies to every project, RATE_LIMIT_PROJECT_TIERS assigns
+// tiers per project and RATE_LIMIT_TIERS adds or overrides tiers. Counters live in Redis when REDIS_URL is set,
+// otherwise in memory per instance. RATE_LIMIT_ENABLED=false disables limits, usage is still counted.
+func newRateLimitService(logger zerolog.Logger) (*application.RateLimitService, error) {
+	tiers, err := application.ParseRateLimitTiers(os.Getenv("RATE_LIMIT_TIERS"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_TIERS: %w", err)
+	}
+	projectTiers, err := application.ParseProjectTiers(os.Getenv("RATE_LIMIT_PROJECT_TIERS"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_PROJECT_TIERS: %w", err)
+	}
+	defaultTier := os.Getenv("RATE_LIMIT_DEFAULT_TIER")
+	if defaultTier == "" {
+		defaultTier = domain.DefaultRateLimitTier
+	}
+	if os.Getenv("RATE_LIMIT_ENABLED") == "false" {
+		// Tiers without limits keep usage accounting
+		for name := range tiers {
+			tiers[name] = domain.RateLimitTier{Name: name}
+		}
+		logger.Warn().Msg("RATE_LIMIT_ENABLED is false: inbound requests are not limited")
+	}
+
+	var usageRepo ports.UsageRepository
+	if redisURL := os.Getenv("REDIS_URL"); redisURL != "" {
+		redisClient, err := cache.NewRedisClientFromURL(redisURL)
+		if err != nil {
+			return nil, err
+		}
+		usageRepo = repository.NewRedisUsageRepository(redisClient)
+		logger.Info().Msg("Using Redis for rate limits and usage counters")
+	} else {
+		usageRepo = repository.NewMemoryUsageRepository()
+		logger.Warn().Msg("REDIS_URL is not set: rate limits and usage counters are kept per instance in memory")
+	}
+
+	return application.NewRateLimitService(usageRepo, tiers, projectTiers, defaultTier, logger)
+}
+
+// enableShopifyGraphQL sets up the Admin GraphQL adapter, routing the operations in SHOPIFY_API_ROUTES to it
+// e.g. "getProduct:graphql,getOrder:graphql" or "*:graphql"; other operations keep using the REST API
+func enableShopifyGraphQL(shopifyService *application.ShopifyService, retryConfig shopifyinfra.RetryConfig, logger zerolog.Logger) error {
+	routes, err := application.ParseShopifyAPIRoutes(os.Getenv("SHOPIFY_API_ROUTES"))
+	if err != nil {
+		return fmt.Errorf("invalid SHOPIFY_API_ROUTES: %w", err)
+	}
+
+	throttle := shopifyinfra.NewGraphQLThrottle(logger)
+	shopifyService.EnableGraphQL(shopifyinfra.NewGraphQLClient(throttle, retryConfig, logger), routes)
+	for operation, api := range routes {
+		logger.Info().Str("operation", string(operation)).Str("api", string(api)).Msg("Routing Shopify operation")
+	}
+	return nil
+}
+
+// storefrontCacheTTL reads how long the Storefront proxy caches catalog queries from STOREFRONT_CACHE_TTL
+// e.g. "5m"; "0" disables the cache
+func storefrontCacheTTL() (time.Duration, error) {
+	value := os.Getenv("STOREFRONT_CACHE_TTL")
+	if value == "" {
+		return apiinfra.DefaultStorefrontCacheTTL, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid STOREFRONT_CACHE_TTL: %w", err)
+	}
+	return ttl, nil
+}
+
+// createRateLimitMiddleware creates middleware that limits the requests of authenticated callers per project
+// and integration key, answering 429 with Retry-After when a limit or quota is reached
+// Unauthenticated routes are not limited; if the counter store fails, requests are let through
+func createRateLimitMiddleware(rateLimitService *application.RateLimitService, logger zerolog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal := domain.GetPrincipalFromContext(r.Context())
+			if principal == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			decision, err := rateLimitService.Allow(r.Context(), principal)
+			if err != nil {
+				logger.Error().Err(err).Str("projectID", principal.ProjectID).Msg("Rate limit check failed, allowing request")
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if decision.Limit > 0 {
+				w.Header().Set("X-RateLimit-Limit", strconv.Itoa(decision.Limit))
+				w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+				w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(decision.ResetAt.Unix(), 10))
+			}
+
+			if !decision.Allowed {
+				retryAfter := int(math.Ceil(decision.RetryAfter.Seconds()))
+				logger.Warn().
+					Str("projectID", principal.ProjectID).
+					Str("subject", principal.Subject).
+					Str("reason", decision.Reason).
+					Int("retryAfter", retryAfter).
+					Msg("Rate limit exceeded")
+
+				w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusTooManyRequests)
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"error": map[string]interface{}{
+						"code":       "RATE_LIMITED",
+						"message":    decision.Reason,
+						"retryAfter": retryAfter,
+					},
+				})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// createAuthMiddleware creates middleware that authenticates the caller and sets its tenant in context
+// The principal's project and environment cannot be overridden by headers; X-App-Handle only selects
+// an app when the credential is not bound to one
+func createAuthMiddleware(authenticators *auth.Chain, logger zerolog.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Skip middleware for public routes and OAuth routes
+			// Skip middleware for public routes, OAuth callbacks and webhooks (verified by HMAC)
 			path := r.URL.Path
 			if path == "/health" ||
 				path == "/swagger/doc.json" ||
 				path == "/auth/callback" ||
+				strings.HasPrefix(path, "/webhooks/") ||
+				strings.HasPrefix(path, "/embedded/") || // Authenticated by session tokens
 				(len(path) > 8 && path[:9] == "/swagger/") {
 				next.ServeHTTP(w, r)
 				return
 			}
 
-			ctx := r.Context()
-			var projectID, environment string
-
-			// Check for integration key first (new method)
-			integrationKey := r.Header.Get("X-Integration-Key")
-			if integrationKey != "" {
-				integration, err := integrationService.GetIntegrationByKey(ctx, integrationKey)
-				if err != nil {
-					logger.Error().Err(err).Str("key", integrationKey).Msg("Failed to get integration by key")
-					http.Error(w, "Invalid integration key", http.StatusUnauthorized)
-					return
-				}
-
-				projectID = integration.ProjectID
-				environment = integration.Environment
-
-				logger.Debug().
-					Str("integrationKey", integrationKey).
-					Str("projectID", projectID).
-					Str("environment", environment).
-					Str("shopDomain", integration.ShopDomain).
-					Msg("Authenticated using integration key")
-			} else {
-				// Fallback to X-Project-ID (existing method)
-				projectID = r.Header.Get("X-Project-ID")
-				if projectID == "" {
-					http.Error(w, "X-Project-ID or X-Integration-Key header is required", http.StatusBadRequest)
-					return
-				}
+			principal, method, err := authenticators.Authenticate(r)
+			if errors.Is(err, auth.ErrNoCredentials) {
+				http.Error(w, "Authentication required", http.StatusUnauthorized)
+				return
+			}
+			if err != nil {
+				logger.Warn().Err(err).Str("method", method).Str("ip", r.RemoteAddr).Msg("Authentication failed")
+				http.Error(w, "Invalid credentials", http.StatusUnauthorized)
+				return
+			}
 
-				// Extract environment from header (defaults to "master" if not provided)
-				environment = r.Header.Get("environment")
-				if environment == "" {
-					environment = domain.DefaultEnvironment // Default environment
-				}
+			environment := principal.Environment
+			if environment == "" {
+				environment = domain.DefaultEnvironment // Default environment
+			}
+			appHandle := principal.AppHandle
+			if appHandle == "" {
+				// Optional app selection for environments with several apps
+				appHandle = r.Header.Get(auth.AppHandleHeader)
 			}
 
 			// Add to context (type-safe)
-			ctx = domain.WithProjectID(ctx, projectID)
+			ctx := r.Context()
+			ctx = domain.WithPrincipal(ctx, principal)
+			ctx = domain.WithProjectID(ctx, principal.ProjectID)
 			ctx = domain.WithEnvironment(ctx, environment)
 			// Keep tenantId for backward compatibility (using projectID)
-			ctx = domain.WithTenantID(ctx, projectID)
+			ctx = domain.WithTenantID(ctx, principal.ProjectID)
+			if appHandle != "" {
+				ctx = domain.WithAppHandle(ctx, appHandle)
+			}
+
+			logger.Debug().
+				Str("method", method).
+				Str("subject", principal.Subject).
+				Str("projectID", principal.ProjectID).
+				Str("environment", environment).
+				Msg("Request authenticated")
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
+// extractSessionToken reads the App Bridge session token from the Authorization header or the id_token query parameter
+func extractSessionToken(r *http.Request) string {
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+	}
+	return r.URL.Query().Get("id_token")
+}
+
+// createSessionTokenMiddleware creates middleware that authenticates embedded app requests with App Bridge session tokens
+// The token's aud selects the project config whose API secret verifies it, and dest is mapped to the shop
+// Shops without an offline token get one through Shopify's token-exchange grant
+func createSessionTokenMiddleware(
+	configRepo ports.ShopifyConfigRepository,
+	encryptionService *encryption.Service,
+	shopifyService *application.ShopifyService,
+	installationService *application.InstallationService,
+	logger zerolog.Logger,
+) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			// Ask App Bridge to retry with a fresh session token on authentication failures
+			unauthorized := func(message string) {
+				w.Header().Set("X-Shopify-Retry-Invalid-Session-Request", "1")
+				http.Error(w, message, http.StatusUnauthorized)
+			}
+
+			sessionToken := extractSessionToken(r)
+			if sessionToken == "" {
+				unauthorized("Session token is required")
+				return
+			}
+
+			// Find the app the token was issued for
+			unverified, err := shopifyinfra.ParseSessionTokenUnverified(sessionToken)
+			if err != nil {
+				logger.Warn().Err(err).Msg("Failed to parse session token")
+				unauthorized("Invalid session token")
+				return
+			}
+
+			config, err := configRepo.GetByAPIKey(ctx, unverified.Aud)
+			if err != nil {
+				logger.Error().Err(err).Msg("Failed to get Shopify config for session token")
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+			if config == nil {
+				unauthorized("Invalid session token")
+				return
+			}
+
+			apiSecret, err := encryptionService.Decrypt(config.EncryptedKey)
+			if err != nil {
+				logger.Error().Err(err).Str("projectID", config.ProjectID).Msg("Failed to decrypt API secret")
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+
+			claims, err := shopifyinfra.VerifySessionToken(sessionToken, config.APIKey, apiSecret, time.Now())
+			if err != nil {
+				logger.Warn().Err(err).Str("projectID", config.ProjectID).Msg("Session token verification failed")
+				unauthorized("Invalid session token")
+				return
+			}
+
+			// Add to context (type-safe)
+			ctx = domain.WithProjectID(ctx, config.ProjectID)
+			ctx = domain.WithEnvironment(ctx, config.Environment)
+			ctx = domain.WithTenantID(ctx, config.ProjectID)
+			ctx = domain.WithAppHandle(ctx, config.AppHandle)
+			ctx = domain.WithSessionTokenClaims(ctx, claims)
+			ctx = domain.WithPrincipal(ctx, &domain.Principal{
+				Type:        domain.PrincipalTypeSessionToken,
+				Subject:     claims.Sub,
+				ProjectID:   config.ProjectID,
+				Environment: config.Environment,
+				AppHandle:   config.AppHandle,
+				ShopDomain:  claims.ShopDomain(),
+			})
+
+			// Make sure the shop has an offline token for Admin API calls
+			shopDomain := claims.ShopDomain()
+			shop, exchanged, err := shopifyService.EnsureOfflineToken(ctx, shopDomain, sessionToken)
+			if err != nil {
+				logger.Error().Err(err).Str("shop", shopDomain).Msg("Failed to obtain offline token for embedded app")
+				http.Error(w, "Failed to authorize shop", http.StatusBadGateway)
+				return
+			}
+			if exchanged {
+				// A first token exchange is a managed install
+				if _, err := installationService.CompleteInstall(ctx, shop.Key(), shop.Scopes, "token_exchange"); err != nil {
+					logger.Warn().Err(err).Str("shop", shopDomain).Msg("Failed to record completed installation")
+				}
+			}
+
+			logger.Debug().
+				Str("projectID", config.ProjectID).
+				Str("environment", config.Environment).
+				Str("shop", shopDomain).
+				Str("user", claims.Sub).
+				Msg("Authenticated using session token")
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// sessionTokenExchangeHandler exchanges the request's session token for the shop's offline access token
+// Online tokens are rejected: shops store a single token, which an online token would overwrite
+// The token itself is stored, never returned
+func sessionTokenExchangeHandler(shopifyService *application.ShopifyService, installationService *application.InstallationService, logger zerolog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		claims := domain.GetSessionTokenClaimsFromContext(ctx)
+		if claims == nil {
+			http.Error(w, "Session token is required", http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Query().Get("requested_token_type") {
+		case "", "offline":
+		case "online":
+			http.Error(w, "Online access tokens are not supported, request an offline token", http.StatusBadRequest)
+			return
+		default:
+			http.Error(w, "requested_token_type must be offline", http.StatusBadRequest)
+			return
+		}
+
+		shop, err := shopifyService.ExchangeSessionToken(ctx, claims.ShopDomain(), extractSessionToken(r))
+		if err != nil {
+			logger.Error().Err(err).Str("shop", claims.ShopDomain()).Msg("Failed to exchange session token")
+			http.Error(w, "Failed to exchange session token", http.StatusBadGateway)
+			return
+		}
+
+		// Offline tokens carry the app's granted scopes
+		if _, err := installationService.CompleteInstall(ctx, shop.Key(), shop.Scopes, "token_exchange"); err != nil {
+			logger.Warn().Err(err).Str("shop", shop.Domain).Msg("Failed to record completed installation")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"shop":        shop.Domain,
+			"scopes":      shop.Scopes,
+			"tokenStatus": shop.TokenStatus(time.Now()),
+			"expiresAt":   shop.AccessTokenExpiresAt,
+		})
+	}
+}
+
 // validateProjectHandler validates that a project ID exists and can make requests
 func validateProjectHandler(configRepo ports.ShopifyConfigRepository, logger zerolog.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		projectID := r.Header.Get("X-Project-ID")
-		environment := r.Header.Get("environment")
+		// Project and environment come from the authenticated principal (set by middleware)
+		ctx := r.Context()
+		projectID := domain.GetProjectIDFromContext(ctx)
+		environment := domain.GetEnvironmentFromContext(ctx)
 		if environment == "" {
 			environment = domain.DefaultEnvironment
 		}
 
 		if projectID == "" {
 			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
+			w.WriteHeader(http.StatusUnauthorized)
 			json.NewEncoder(w).Encode(map[string]interface{}{
 				"valid": false,
-				"error": "X-Project-ID header is required",
+				"error": "authentication is required",
 			})
 			return
 		}
 
-		// Create context with project ID
-		ctx := domain.WithProjectID(r.Context(), projectID)
-		ctx = domain.WithEnvironment(ctx, environment)
-
 		// Check if project exists by trying to get config
 		config, err := configRepo.GetByTenantID(ctx, projectID)
 		if err != nil {
@@ -0,0 +1,136 @@
+package graph
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"archie-core-shopify-layer/graph/model"
+	"archie-core-shopify-layer/internal/domain"
+)
+
+// bulkOperationWait is how long shopify_bulkOperation polls an operation when asked to wait
+const bulkOperationWait = 30 * time.Second
+
+// lineCursor is the cursor kind of the lines of a bulk operation results file
+const lineCursor = "line"
+
+// parseBulkOperationID parses the numeric ID of a bulk operation
+func parseBulkOperationID(id string) (uint64, error) {
+	operationID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil || operationID == 0 {
+		if err == nil {
+			err = fmt.Errorf("bulk operation ID must be positive")
+		}
+		return 0, invalidIDError("id", err)
+	}
+	return operationID, nil
+}
+
+// bulkResultLimit validates the page size of bulk operation results
+func bulkResultLimit(v *inputValidator, first *int) int {
+	if first == nil {
+		return defaultPageSize
+	}
+	if *first < 1 || *first > maxPageSize {
+		v.reject("first", fmt.Sprintf("must be between 1 and %d", maxPageSize))
+		return defaultPageSize
+	}
+	return *first
+}
+
+// decodeLineCursor reads the line offset of a results cursor
+func decodeLineCursor(v *inputValidator, after *string) int {
+	if after == nil || *after == "" {
+		return 0
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(*after)
+	if err == nil {
+		kind, value, ok := strings.Cut(string(decoded), ":")
+		if offset, convErr := strconv.Atoi(value); ok && kind == lineCursor && convErr == nil && offset >= 0 {
+			return offset
+		}
+	}
+	v.reject("after", "must be a cursor returned by a previous page")
+	return 0
+}
+
+// toBulkMutationVariables checks that each line of bulk mutation variables is a JSON object
+func toBulkMutationVariables(v *inputValidator, variables []any) []map[string]interface{} {
+	lines := make([]map[string]interface{}, 0, len(variables))
+	for i, value := range variables {
+		line, ok := value.(map[string]interface{})
+		if !ok {
+			v.reject(fmt.Sprintf("variables[%d]", i), "must be a JSON object")
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// toModelBulkOperation converts a domain bulk operation to its GraphQL model
+func toModelBulkOperation(shop string, op *domain.BulkOperation) *model.BulkOperation {
+	result := &model.BulkOperation{
+		ID:              strconv.FormatUint(op.ID, 10),
+		Type:            model.BulkOperationType(op.Type),
+		Status:          model.BulkOperationStatus(op.Status),
+		Query:           op.Query,
+		ObjectCount:     int(op.ObjectCount),
+		RootObjectCount: int(op.RootObjectCount),
+		FileSize:        int(op.FileSize),
+		CreatedAt:       optionalTime(op.CreatedAt),
+		CompletedAt:     optionalTime(op.CompletedAt),
+		Shop:            shop,
+	}
+	if op.ErrorCode != "" {
+		result.ErrorCode = &op.ErrorCode
+	}
+	if op.URL != "" {
+		result.URL = &op.URL
+	}
+	if op.PartialDataURL != "" {
+		result.PartialDataURL = &op.PartialDataURL
+	}
+	if op.StartedBy != "" {
+		result.StartedBy = &op.StartedBy
+	}
+	return result
+}
+
+// toModelStagedUploadTarget converts a domain staged upload target to its GraphQL model
+func toModelStagedUploadTarget(target *domain.StagedUploadTarget) *model.StagedUploadTarget {
+	result := &model.StagedUploadTarget{
+		URL:              target.URL,
+		StagedUploadPath: target.Path(),
+		Parameters:       make([]*model.StagedUploadParameter, 0, len(target.Parameters)),
+	}
+	if target.ResourceURL != "" {
+		result.ResourceURL = &target.ResourceURL
+	}
+	for _, parameter := range target.Parameters {
+		result.Parameters = append(result.Parameters, &model.StagedUploadParameter{Name: parameter.Name, Value: parameter.Value})
+	}
+	return result
+}
+
+// toBulkOperationResultConnection builds a page of results lines starting at line offset
+func toBulkOperationResultConnection(lines []json.RawMessage, offset int, hasMore bool) *model.BulkOperationResultConnection {
+	connection := &model.BulkOperationResultConnection{
+		Nodes:    make([]any, 0, len(lines)),
+		PageInfo: &model.PageInfo{HasNextPage: hasMore, HasPreviousPage: offset > 0},
+	}
+	for _, line := range lines {
+		connection.Nodes = append(connection.Nodes, line)
+	}
+	if len(lines) > 0 {
+		start := encodeCursor(lineCursor, strconv.Itoa(offset))
+		end := encodeCursor(lineCursor, strconv.Itoa(offset+len(lines)))
+		connection.PageInfo.StartCursor = &start
+		connection.PageInfo.EndCursor = &end
+	}
+	return connection
+}
@@ -0,0 +1,143 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.84
+
+import (
+	"archie-core-shopify-layer/graph/generated"
+	"archie-core-shopify-layer/graph/model"
+	"context"
+)
+
+// Results is the resolver for the results field.
+func (r *bulkOperationResolver) Results(ctx context.Context, obj *model.BulkOperation, first *int, after *string) (*model.BulkOperationResultConnection, error) {
+	id, err := parseBulkOperationID(obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &inputValidator{}
+	limit := bulkResultLimit(v, first)
+	offset := decodeLineCursor(v, after)
+	if err := v.result("invalid bulk operation results query"); err != nil {
+		return nil, err
+	}
+
+	lines, hasMore, err := r.bulkOperationService.GetBulkOperationResults(ctx, obj.Shop, id, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return toBulkOperationResultConnection(lines, offset, hasMore), nil
+}
+
+// ShopifyRunBulkQuery is the resolver for the shopify_runBulkQuery field.
+func (r *mutationResolver) ShopifyRunBulkQuery(ctx context.Context, domain string, query string) (*model.BulkOperation, error) {
+	op, err := r.bulkOperationService.RunBulkQuery(ctx, domain, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelBulkOperation(domain, op), nil
+}
+
+// ShopifyCreateBulkMutationUpload is the resolver for the shopify_createBulkMutationUpload field.
+func (r *mutationResolver) ShopifyCreateBulkMutationUpload(ctx context.Context, domain string, filename *string) (*model.StagedUploadTarget, error) {
+	target, err := r.bulkOperationService.CreateBulkMutationUpload(ctx, domain, stringValue(filename))
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelStagedUploadTarget(target), nil
+}
+
+// ShopifyRunBulkMutation is the resolver for the shopify_runBulkMutation field.
+func (r *mutationResolver) ShopifyRunBulkMutation(ctx context.Context, input model.BulkMutationInput) (*model.BulkOperation, error) {
+	v := &inputValidator{}
+	stagedUploadPath := stringValue(input.StagedUploadPath)
+	if (len(input.Variables) == 0) == (stagedUploadPath == "") {
+		v.reject("variables", "exactly one of variables and stagedUploadPath must be set")
+	}
+	variables := toBulkMutationVariables(v, input.Variables)
+	if err := v.result("invalid bulk mutation input"); err != nil {
+		return nil, err
+	}
+
+	if stagedUploadPath != "" {
+		op, err := r.bulkOperationService.RunBulkMutation(ctx, input.Domain, input.Mutation, stagedUploadPath)
+		if err != nil {
+			return nil, err
+		}
+		return toModelBulkOperation(input.Domain, op), nil
+	}
+
+	op, err := r.bulkOperationService.RunBulkMutationWithVariables(ctx, input.Domain, input.Mutation, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelBulkOperation(input.Domain, op), nil
+}
+
+// ShopifyCancelBulkOperation is the resolver for the shopify_cancelBulkOperation field.
+func (r *mutationResolver) ShopifyCancelBulkOperation(ctx context.Context, domain string, id string) (*model.BulkOperation, error) {
+	operationID, err := parseBulkOperationID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := r.bulkOperationService.CancelBulkOperation(ctx, domain, operationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelBulkOperation(domain, op), nil
+}
+
+// ShopifyBulkOperations is the resolver for the shopify_bulkOperations field.
+func (r *queryResolver) ShopifyBulkOperations(ctx context.Context, domain string, first *int) ([]*model.BulkOperation, error) {
+	v := &inputValidator{}
+	limit := bulkResultLimit(v, first)
+	if err := v.result("invalid bulk operations query"); err != nil {
+		return nil, err
+	}
+
+	ops, err := r.bulkOperationService.ListBulkOperations(ctx, domain, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.BulkOperation, 0, len(ops))
+	for _, op := range ops {
+		result = append(result, toModelBulkOperation(domain, op))
+	}
+	return result, nil
+}
+
+// ShopifyBulkOperation is the resolver for the shopify_bulkOperation field.
+func (r *queryResolver) ShopifyBulkOperation(ctx context.Context, domain string, id string, wait *bool) (*model.BulkOperation, error) {
+	operationID, err := parseBulkOperationID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := r.bulkOperationService.GetBulkOperation(ctx, domain, operationID)
+	if err != nil {
+		return nil, err
+	}
+	if boolValue(wait) && !op.Status.Done() {
+		op, err = r.bulkOperationService.WaitForBulkOperation(ctx, domain, operationID, bulkOperationWait)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return toModelBulkOperation(domain, op), nil
+}
+
+// BulkOperation returns generated.BulkOperationResolver implementation.
+func (r *Resolver) BulkOperation() generated.BulkOperationResolver { return &bulkOperationResolver{r} }
+
+type bulkOperationResolver struct{ *Resolver }
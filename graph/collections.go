@@ -0,0 +1,348 @@
+package graph
+
+import (
+	"strings"
+	"time"
+
+	"archie-core-shopify-layer/graph/model"
+	"archie-core-shopify-layer/internal/domain"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+)
+
+// collectionListOptions are the Shopify options of shopify_collections
+type collectionListOptions struct {
+	goshopify.ListOptions
+	Title           string    `url:"title,omitempty"`
+	Handle          string    `url:"handle,omitempty"`
+	ProductID       uint64    `url:"product_id,omitempty"`
+	PublishedStatus string    `url:"published_status,omitempty"`
+	PublishedAtMin  time.Time `url:"published_at_min,omitempty"`
+	PublishedAtMax  time.Time `url:"published_at_max,omitempty"`
+}
+
+// toCollectionListOptions builds the Shopify options of shopify_collections
+func toCollectionListOptions(v *inputValidator, request pageRequest, filter *model.CollectionFilter) *collectionListOptions {
+	options := &collectionListOptions{ListOptions: request.options}
+	if filter == nil || !request.filtered {
+		return options
+	}
+
+	options.Ids = filterIDs(v, "filter.ids", filter.Ids)
+	options.Title = stringValue(filter.Title)
+	options.Handle = stringValue(filter.Handle)
+	options.ProductID = v.id("filter.productId", filter.ProductID)
+	if filter.PublishedStatus != nil {
+		options.PublishedStatus = shopifyEnum(string(*filter.PublishedStatus))
+	}
+	options.UpdatedAtMin = filterTime(filter.UpdatedAtMin)
+	options.UpdatedAtMax = filterTime(filter.UpdatedAtMax)
+	options.PublishedAtMin = filterTime(filter.PublishedAtMin)
+	options.PublishedAtMax = filterTime(filter.PublishedAtMax)
+	return options
+}
+
+// collectionSortOrder converts a GraphQL sort order to Shopify's, such as ALPHA_ASC to alpha-asc
+func collectionSortOrder(order model.CollectionSortOrder) string {
+	return strings.ReplaceAll(shopifyEnum(string(order)), "_", "-")
+}
+
+// toModelCollectionSortOrder converts a Shopify sort order, nil when empty or unknown
+func toModelCollectionSortOrder(order string) *model.CollectionSortOrder {
+	return toModelEnum[model.CollectionSortOrder](strings.ReplaceAll(order, "-", "_"))
+}
+
+// toModelCollectionImage converts a collection image, nil when the collection has none
+func toModelCollectionImage(image goshopify.Image) *model.CollectionImage {
+	if image.Src == "" {
+		return nil
+	}
+	result := &model.CollectionImage{
+		Src:       image.Src,
+		Alt:       optionalString(image.Alt),
+		CreatedAt: optionalTime(image.CreatedAt),
+	}
+	if image.Width > 0 && image.Height > 0 {
+		result.Width = &image.Width
+		result.Height = &image.Height
+	}
+	return result
+}
+
+// toModelCustomCollection converts a Shopify custom collection
+func toModelCustomCollection(shop string, collection *goshopify.CustomCollection) *model.Collection {
+	return &model.Collection{
+		ID:             shopifyID(collection.Id),
+		Type:           model.CollectionTypeCustom,
+		Title:          collection.Title,
+		Handle:         optionalString(collection.Handle),
+		BodyHTML:       optionalString(collection.BodyHTML),
+		SortOrder:      toModelCollectionSortOrder(collection.SortOrder),
+		TemplateSuffix: optionalString(collection.TemplateSuffix),
+		Published:      collection.PublishedAt != nil,
+		PublishedAt:    optionalTime(collection.PublishedAt),
+		PublishedScope: optionalString(collection.PublishedScope),
+		Image:          toModelCollectionImage(collection.Image),
+		Rules:          []*model.CollectionRule{},
+		UpdatedAt:      optionalTime(collection.UpdatedAt),
+		Shop:           shop,
+	}
+}
+
+// toModelSmartCollection converts a Shopify smart collection
+func toModelSmartCollection(shop string, collection *goshopify.SmartCollection) *model.Collection {
+	rules := make([]*model.CollectionRule, 0, len(collection.Rules))
+	for _, rule := range collection.Rules {
+		column := toModelEnum[model.CollectionRuleColumn](rule.Column)
+		relation := toModelEnum[model.CollectionRuleRelation](rule.Relation)
+		if column == nil || relation == nil {
+			continue
+		}
+		rules = append(rules, &model.CollectionRule{Column: *column, Relation: *relation, Condition: rule.Condition})
+	}
+	return &model.Collection{
+		ID:             shopifyID(collection.Id),
+		Type:           model.CollectionTypeSmart,
+		Title:          collection.Title,
+		Handle:         optionalString(collection.Handle),
+		BodyHTML:       optionalString(collection.BodyHTML),
+		SortOrder:      toModelCollectionSortOrder(collection.SortOrder),
+		TemplateSuffix: optionalString(collection.TemplateSuffix),
+		Published:      collection.PublishedAt != nil,
+		PublishedAt:    optionalTime(collection.PublishedAt),
+		PublishedScope: optionalString(collection.PublishedScope),
+		Image:          toModelCollectionImage(collection.Image),
+		Rules:          rules,
+		Disjunctive:    collection.Disjunctive,
+		UpdatedAt:      optionalTime(collection.UpdatedAt),
+		Shop:           shop,
+	}
+}
+
+// toModelCollects converts Shopify collects
+func toModelCollects(collects []goshopify.Collect) []*model.Collect {
+	result := make([]*model.Collect, len(collects))
+	for i, collect := range collects {
+		result[i] = &model.Collect{
+			ID:           shopifyID(collect.Id),
+			CollectionID: shopifyID(collect.CollectionId),
+			ProductID:    shopifyID(collect.ProductId),
+			SortValue:    optionalString(collect.SortValue),
+			CreatedAt:    optionalTime(collect.CreatedAt),
+			UpdatedAt:    optionalTime(collect.UpdatedAt),
+		}
+		if collect.Position > 0 {
+			position := collect.Position
+			result[i].Position = &position
+		}
+	}
+	return result
+}
+
+// collectionFields are the fields shared by custom and smart collections
+// Shopify's collection payloads have no omitempty, so updates start from the current collection
+type collectionFields struct {
+	title          *string
+	handle         *string
+	bodyHTML       *string
+	sortOrder      *string
+	templateSuffix *string
+	published      *bool
+	image          *goshopify.Image
+}
+
+// validateCollectionInput checks a collection input and returns its ID, 0 on create
+func validateCollectionInput(v *inputValidator, input model.CollectionInput, update bool) uint64 {
+	id := v.id("id", input.ID)
+	if update {
+		v.requiredID(id)
+		if len(input.ProductIds) > 0 {
+			v.reject("productIds", "use shopify_addProductsToCollection to add products to an existing collection")
+		}
+	} else {
+		if input.ID != nil {
+			v.reject("id", "must not be set on create")
+		}
+		if strings.TrimSpace(stringValue(input.Title)) == "" {
+			v.reject("title", "is required")
+		}
+	}
+	if input.Title != nil && strings.TrimSpace(*input.Title) == "" && !v.rejected("title") {
+		v.reject("title", "must not be empty")
+	}
+	if input.Image != nil && strings.TrimSpace(input.Image.Src) == "" {
+		v.reject("image.src", "is required")
+	}
+
+	switch input.Type {
+	case model.CollectionTypeCustom:
+		if input.Rules != nil {
+			v.reject("rules", "only applies to smart collections")
+		}
+		if input.Disjunctive != nil {
+			v.reject("disjunctive", "only applies to smart collections")
+		}
+	case model.CollectionTypeSmart:
+		if len(input.ProductIds) > 0 {
+			v.reject("productIds", "smart collections select products with rules")
+		}
+		if (!update || input.Rules != nil) && len(input.Rules) == 0 {
+			v.reject("rules", "must have at least one rule")
+		}
+		for i, rule := range input.Rules {
+			if strings.TrimSpace(rule.Condition) == "" && rule.Relation != model.CollectionRuleRelationIsSet && rule.Relation != model.CollectionRuleRelationIsNotSet {
+				v.reject(fieldPath("rules", i, "condition"), "is required")
+			}
+		}
+	}
+	return id
+}
+
+// applyCollectionInput sets the shared fields that are present in the input
+func applyCollectionInput(input model.CollectionInput, fields collectionFields) {
+	if input.Title != nil {
+		*fields.title = strings.TrimSpace(*input.Title)
+	}
+	if input.Handle != nil {
+		*fields.handle = *input.Handle
+	}
+	if input.BodyHTML != nil {
+		*fields.bodyHTML = *input.BodyHTML
+	}
+	if input.SortOrder != nil {
+		*fields.sortOrder = collectionSortOrder(*input.SortOrder)
+	}
+	if input.TemplateSuffix != nil {
+		*fields.templateSuffix = *input.TemplateSuffix
+	}
+	if input.Published != nil {
+		*fields.published = *input.Published
+	}
+	if input.Image != nil {
+		*fields.image = goshopify.Image{Src: input.Image.Src, Alt: stringValue(input.Image.Alt)}
+	}
+}
+
+// toShopifyCustomCollection validates a custom collection input and applies it to the current collection, nil on create
+// It also returns the products to add on create
+func toShopifyCustomCollection(input model.CollectionInput, current *goshopify.CustomCollection) (*goshopify.CustomCollection, []int64, error) {
+	v := &inputValidator{}
+	id := validateCollectionInput(v, input, current != nil)
+	productIDs := collectionProductIDs(v, "productIds", input.ProductIds)
+	if err := v.result("invalid collection input"); err != nil {
+		return nil, nil, err
+	}
+
+	collection := &goshopify.CustomCollection{Published: true}
+	if current != nil {
+		collection = current
+		collection.Id = id
+		collection.Published = current.PublishedAt != nil
+	}
+	applyCollectionInput(input, collectionFields{
+		title:          &collection.Title,
+		handle:         &collection.Handle,
+		bodyHTML:       &collection.BodyHTML,
+		sortOrder:      &collection.SortOrder,
+		templateSuffix: &collection.TemplateSuffix,
+		published:      &collection.Published,
+		image:          &collection.Image,
+	})
+	collection.Metafields = nil
+	return collection, productIDs, nil
+}
+
+// toShopifySmartCollection validates a smart collection input and applies it to the current collection, nil on create
+func toShopifySmartCollection(input model.CollectionInput, current *goshopify.SmartCollection) (*goshopify.SmartCollection, error) {
+	v := &inputValidator{}
+	id := validateCollectionInput(v, input, current != nil)
+	if err := v.result("invalid collection input"); err != nil {
+		return nil, err
+	}
+
+	collection := &goshopify.SmartCollection{Published: true}
+	if current != nil {
+		collection = current
+		collection.Id = id
+		collection.Published = current.PublishedAt != nil
+	}
+	applyCollectionInput(input, collectionFields{
+		title:          &collection.Title,
+		handle:         &collection.Handle,
+		bodyHTML:       &collection.BodyHTML,
+		sortOrder:      &collection.SortOrder,
+		templateSuffix: &collection.TemplateSuffix,
+		published:      &collection.Published,
+		image:          &collection.Image,
+	})
+	if input.Rules != nil {
+		collection.Rules = make([]goshopify.Rule, len(input.Rules))
+		for i, rule := range input.Rules {
+			collection.Rules[i] = goshopify.Rule{
+				Column:    shopifyEnum(string(rule.Column)),
+				Relation:  shopifyEnum(string(rule.Relation)),
+				Condition: rule.Condition,
+			}
+		}
+	}
+	if input.Disjunctive != nil {
+		collection.Disjunctive = *input.Disjunctive
+	}
+	collection.Metafields = nil
+	return collection, nil
+}
+
+// collectionProductIDs parses the product IDs of a collection input
+func collectionProductIDs(v *inputValidator, field string, ids []string) []int64 {
+	parsed := filterIDs(v, field, ids)
+	result := make([]int64, len(parsed))
+	for i, id := range parsed {
+		result[i] = int64(id)
+	}
+	return result
+}
+
+// collectionProductsInput parses the collection and product IDs of shopify_addProductsToCollection and shopify_removeProductsFromCollection
+func collectionProductsInput(input model.CollectionProductsInput) (int64, []int64, error) {
+	v := &inputValidator{}
+	collectionID := v.id("collectionId", &input.CollectionID)
+	if collectionID == 0 && !v.rejected("collectionId") {
+		v.reject("collectionId", "is required")
+	}
+	if len(input.ProductIds) == 0 {
+		v.reject("productIds", "must have at least one product")
+	}
+	productIDs := collectionProductIDs(v, "productIds", input.ProductIds)
+	if err := v.result("invalid collection products input"); err != nil {
+		return 0, nil, err
+	}
+	return int64(collectionID), productIDs, nil
+}
+
+// toCustomCollectionConnection converts a page of Shopify custom collections
+func toCustomCollectionConnection(shop string, collections []goshopify.CustomCollection, pagination *goshopify.Pagination) *model.CollectionConnection {
+	nodes := make([]*model.Collection, len(collections))
+	edges := make([]*model.CollectionEdge, len(collections))
+	for i := range collections {
+		nodes[i] = toModelCustomCollection(shop, &collections[i])
+		edges[i] = &model.CollectionEdge{Cursor: idCursor(collections[i].Id), Node: nodes[i]}
+	}
+	return &model.CollectionConnection{Edges: edges, Nodes: nodes, PageInfo: toModelPageInfo(pagination)}
+}
+
+// toSmartCollectionConnection converts a page of Shopify smart collections
+func toSmartCollectionConnection(shop string, collections []goshopify.SmartCollection, pagination *goshopify.Pagination) *model.CollectionConnection {
+	nodes := make([]*model.Collection, len(collections))
+	edges := make([]*model.CollectionEdge, len(collections))
+	for i := range collections {
+		nodes[i] = toModelSmartCollection(shop, &collections[i])
+		edges[i] = &model.CollectionEdge{Cursor: idCursor(collections[i].Id), Node: nodes[i]}
+	}
+	return &model.CollectionConnection{Edges: edges, Nodes: nodes, PageInfo: toModelPageInfo(pagination)}
+}
+
+// isNotFoundError reports whether err is a not found AppError
+func isNotFoundError(err error) bool {
+	appErr, ok := domain.AsAppError(err)
+	return ok && appErr.Type == domain.ErrorTypeNotFound
+}
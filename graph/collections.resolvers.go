@@ -0,0 +1,201 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.84
+
+import (
+	"archie-core-shopify-layer/graph/generated"
+	"archie-core-shopify-layer/graph/model"
+	"context"
+	"fmt"
+)
+
+// Products is the resolver for the products field.
+func (r *collectionResolver) Products(ctx context.Context, obj *model.Collection, first *int, after *string, before *string, fields []string) (*model.ProductConnection, error) {
+	var cid int64
+	if _, err := fmt.Sscanf(obj.ID, "%d", &cid); err != nil {
+		return nil, invalidIDError("collectionId", err)
+	}
+
+	v := &inputValidator{}
+	request := newPageRequest(v, first, after, before, fields)
+	// Products keep the collection's sort order, so only page_info cursors can resume it
+	request.options.Order = ""
+	if request.options.SinceId != nil {
+		v.reject("after", "must be a pageInfo.endCursor")
+	}
+	if err := v.result("invalid collection products query"); err != nil {
+		return nil, err
+	}
+
+	products, pagination, err := r.shopifyService.GetCollectionProducts(ctx, obj.Shop, cid, &request.options)
+	if err != nil {
+		return nil, err
+	}
+
+	return toProductConnection(obj.Shop, products, pagination), nil
+}
+
+// ShopifyCreateCollection is the resolver for the shopify_createCollection field.
+func (r *mutationResolver) ShopifyCreateCollection(ctx context.Context, input model.CollectionInput) (*model.CollectionPayload, error) {
+	if input.Type == model.CollectionTypeSmart {
+		collection, err := toShopifySmartCollection(input, nil)
+		if err != nil {
+			return nil, err
+		}
+		created, err := r.shopifyService.CreateSmartCollection(ctx, input.Domain, collection)
+		if err != nil {
+			return nil, err
+		}
+		return &model.CollectionPayload{Collection: toModelSmartCollection(input.Domain, created)}, nil
+	}
+
+	collection, productIDs, err := toShopifyCustomCollection(input, nil)
+	if err != nil {
+		return nil, err
+	}
+	created, err := r.shopifyService.CreateCustomCollection(ctx, input.Domain, collection, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &model.CollectionPayload{Collection: toModelCustomCollection(input.Domain, created)}, nil
+}
+
+// ShopifyUpdateCollection is the resolver for the shopify_updateCollection field.
+func (r *mutationResolver) ShopifyUpdateCollection(ctx context.Context, input model.CollectionInput) (*model.CollectionPayload, error) {
+	v := &inputValidator{}
+	id := v.id("id", input.ID)
+	v.requiredID(id)
+	if err := v.result("invalid collection input"); err != nil {
+		return nil, err
+	}
+	cid := int64(id)
+
+	if input.Type == model.CollectionTypeSmart {
+		current, err := r.shopifyService.GetSmartCollection(ctx, input.Domain, cid)
+		if err != nil {
+			return nil, err
+		}
+		collection, err := toShopifySmartCollection(input, current)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := r.shopifyService.UpdateSmartCollection(ctx, input.Domain, collection)
+		if err != nil {
+			return nil, err
+		}
+		return &model.CollectionPayload{Collection: toModelSmartCollection(input.Domain, updated)}, nil
+	}
+
+	current, err := r.shopifyService.GetCustomCollection(ctx, input.Domain, cid)
+	if err != nil {
+		return nil, err
+	}
+	collection, _, err := toShopifyCustomCollection(input, current)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := r.shopifyService.UpdateCustomCollection(ctx, input.Domain, collection)
+	if err != nil {
+		return nil, err
+	}
+	return &model.CollectionPayload{Collection: toModelCustomCollection(input.Domain, updated)}, nil
+}
+
+// ShopifyDeleteCollection is the resolver for the shopify_deleteCollection field.
+func (r *mutationResolver) ShopifyDeleteCollection(ctx context.Context, input model.DeleteCollectionInput) (bool, error) {
+	var cid int64
+	if _, err := fmt.Sscanf(input.CollectionID, "%d", &cid); err != nil {
+		return false, invalidIDError("collectionId", err)
+	}
+
+	var err error
+	if input.Type == model.CollectionTypeSmart {
+		err = r.shopifyService.DeleteSmartCollection(ctx, input.Domain, cid)
+	} else {
+		err = r.shopifyService.DeleteCustomCollection(ctx, input.Domain, cid)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ShopifyAddProductsToCollection is the resolver for the shopify_addProductsToCollection field.
+func (r *mutationResolver) ShopifyAddProductsToCollection(ctx context.Context, input model.CollectionProductsInput) ([]*model.Collect, error) {
+	cid, productIDs, err := collectionProductsInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	collects, err := r.shopifyService.AddProductsToCollection(ctx, input.Domain, cid, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelCollects(collects), nil
+}
+
+// ShopifyRemoveProductsFromCollection is the resolver for the shopify_removeProductsFromCollection field.
+func (r *mutationResolver) ShopifyRemoveProductsFromCollection(ctx context.Context, input model.CollectionProductsInput) (int, error) {
+	cid, productIDs, err := collectionProductsInput(input)
+	if err != nil {
+		return 0, err
+	}
+
+	return r.shopifyService.RemoveProductsFromCollection(ctx, input.Domain, cid, productIDs)
+}
+
+// ShopifyCollections is the resolver for the shopify_collections field.
+func (r *queryResolver) ShopifyCollections(ctx context.Context, domain string, typeArg model.CollectionType, first *int, after *string, before *string, filter *model.CollectionFilter) (*model.CollectionConnection, error) {
+	v := &inputValidator{}
+	options := toCollectionListOptions(v, newPageRequest(v, first, after, before, nil), filter)
+	if err := v.result("invalid collections query"); err != nil {
+		return nil, err
+	}
+
+	if typeArg == model.CollectionTypeSmart {
+		collections, pagination, err := r.shopifyService.ListSmartCollections(ctx, domain, options)
+		if err != nil {
+			return nil, err
+		}
+		return toSmartCollectionConnection(domain, collections, pagination), nil
+	}
+
+	collections, pagination, err := r.shopifyService.ListCustomCollections(ctx, domain, options)
+	if err != nil {
+		return nil, err
+	}
+	return toCustomCollectionConnection(domain, collections, pagination), nil
+}
+
+// ShopifyCollection is the resolver for the shopify_collection field.
+func (r *queryResolver) ShopifyCollection(ctx context.Context, domain string, collectionID string) (*model.Collection, error) {
+	var cid int64
+	if _, err := fmt.Sscanf(collectionID, "%d", &cid); err != nil {
+		return nil, invalidIDError("collectionId", err)
+	}
+
+	// The ID does not say which kind of collection it is, so try custom collections first
+	custom, err := r.shopifyService.GetCustomCollection(ctx, domain, cid)
+	if err == nil {
+		return toModelCustomCollection(domain, custom), nil
+	}
+	if !isNotFoundError(err) {
+		return nil, err
+	}
+
+	smart, err := r.shopifyService.GetSmartCollection(ctx, domain, cid)
+	if err != nil {
+		return nil, err
+	}
+	return toModelSmartCollection(domain, smart), nil
+}
+
+// Collection returns generated.CollectionResolver implementation.
+func (r *Resolver) Collection() generated.CollectionResolver { return &collectionResolver{r} }
+
+type collectionResolver struct{ *Resolver }
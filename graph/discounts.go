@@ -0,0 +1,436 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"archie-core-shopify-layer/graph/model"
+	"archie-core-shopify-layer/internal/domain"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+	"github.com/shopspring/decimal"
+)
+
+// discountCodeBatchWait is how long shopify_createDiscountCodeBatch polls a batch when asked to wait
+const discountCodeBatchWait = 30 * time.Second
+
+// priceRuleListOptions are the Shopify options of shopify_priceRules
+type priceRuleListOptions struct {
+	goshopify.ListOptions
+	StartsAtMin time.Time `url:"starts_at_min,omitempty"`
+	StartsAtMax time.Time `url:"starts_at_max,omitempty"`
+	EndsAtMin   time.Time `url:"ends_at_min,omitempty"`
+	EndsAtMax   time.Time `url:"ends_at_max,omitempty"`
+	TimesUsed   *int      `url:"times_used,omitempty"`
+}
+
+// toPriceRuleListOptions builds the Shopify options of shopify_priceRules
+func toPriceRuleListOptions(request pageRequest, filter *model.PriceRuleFilter) *priceRuleListOptions {
+	options := &priceRuleListOptions{ListOptions: request.options}
+	if filter == nil || !request.filtered {
+		return options
+	}
+
+	options.CreatedAtMin = filterTime(filter.CreatedAtMin)
+	options.CreatedAtMax = filterTime(filter.CreatedAtMax)
+	options.UpdatedAtMin = filterTime(filter.UpdatedAtMin)
+	options.UpdatedAtMax = filterTime(filter.UpdatedAtMax)
+	options.StartsAtMin = filterTime(filter.StartsAtMin)
+	options.StartsAtMax = filterTime(filter.StartsAtMax)
+	options.EndsAtMin = filterTime(filter.EndsAtMin)
+	options.EndsAtMax = filterTime(filter.EndsAtMax)
+	options.TimesUsed = filter.TimesUsed
+	return options
+}
+
+// discountCodeCountOptions are the Shopify options of shopify_discountCodeCount
+type discountCodeCountOptions struct {
+	TimesUsed    *int `url:"times_used,omitempty"`
+	TimesUsedMin *int `url:"times_used_min,omitempty"`
+	TimesUsedMax *int `url:"times_used_max,omitempty"`
+}
+
+// toDiscountCodeCountOptions builds the Shopify options of shopify_discountCodeCount
+func toDiscountCodeCountOptions(filter *model.DiscountCodeCountFilter) *discountCodeCountOptions {
+	if filter == nil {
+		return &discountCodeCountOptions{}
+	}
+	return &discountCodeCountOptions{TimesUsed: filter.TimesUsed, TimesUsedMin: filter.TimesUsedMin, TimesUsedMax: filter.TimesUsedMax}
+}
+
+// toModelPriceRule converts a Shopify price rule
+func toModelPriceRule(shop string, priceRule *goshopify.PriceRule) *model.PriceRule {
+	result := &model.PriceRule{
+		ID:                shopifyID(priceRule.Id),
+		Title:             priceRule.Title,
+		ValueType:         toModelEnum[model.PriceRuleValueType](priceRule.ValueType),
+		Value:             decimalString(priceRule.Value),
+		TargetType:        toModelEnum[model.PriceRuleTargetType](priceRule.TargetType),
+		TargetSelection:   toModelEnum[model.PriceRuleTargetSelection](priceRule.TargetSelection),
+		AllocationMethod:  toModelEnum[model.PriceRuleAllocationMethod](priceRule.AllocationMethod),
+		CustomerSelection: toModelEnum[model.PriceRuleCustomerSelection](priceRule.CustomerSelection),
+		OncePerCustomer:   priceRule.OncePerCustomer,
+		StartsAt:          optionalTime(priceRule.StartsAt),
+		EndsAt:            optionalTime(priceRule.EndsAt),
+		Entitlements: &model.PriceRuleEntitlements{
+			ProductIds:    shopifyIDs(priceRule.EntitledProductIds),
+			VariantIds:    shopifyIDs(priceRule.EntitledVariantIds),
+			CollectionIds: shopifyIDs(priceRule.EntitledCollectionIds),
+			CountryIds:    shopifyIDs(priceRule.EntitledCountryIds),
+		},
+		Prerequisites: &model.PriceRulePrerequisites{
+			ProductIds:     shopifyIDs(priceRule.PrerequisiteProductIds),
+			VariantIds:     shopifyIDs(priceRule.PrerequisiteVariantIds),
+			CollectionIds:  shopifyIDs(priceRule.PrerequisiteCollectionIds),
+			CustomerIds:    shopifyIDs(priceRule.PrerequisiteCustomerIds),
+			SavedSearchIds: shopifyIDs(priceRule.PrerequisiteSavedSearchIds),
+		},
+		CreatedAt: optionalTime(priceRule.CreatedAt),
+		UpdatedAt: optionalTime(priceRule.UpdatedAt),
+		Shop:      shop,
+	}
+	if priceRule.AllocationLimit > 0 {
+		result.AllocationLimit = &priceRule.AllocationLimit
+	}
+	if priceRule.UsageLimit > 0 {
+		result.UsageLimit = &priceRule.UsageLimit
+	}
+	if r := priceRule.PrerequisiteSubtotalRange; r != nil {
+		result.Prerequisites.SubtotalMin = optionalString(r.GreaterThanOrEqualTo)
+	}
+	if r := priceRule.PrerequisiteQuantityRange; r != nil && r.GreaterThanOrEqualTo > 0 {
+		result.Prerequisites.QuantityMin = &r.GreaterThanOrEqualTo
+	}
+	if r := priceRule.PrerequisiteShippingPriceRange; r != nil {
+		result.Prerequisites.ShippingPriceMax = optionalString(r.LessThanOrEqualTo)
+	}
+	if r := priceRule.PrerequisiteToEntitlementQuantityRatio; r != nil && (r.PrerequisiteQuantity > 0 || r.EntitledQuantity > 0) {
+		result.Prerequisites.ToEntitlementQuantityRatio = &model.PriceRuleQuantityRatio{
+			PrerequisiteQuantity: r.PrerequisiteQuantity,
+			EntitledQuantity:     r.EntitledQuantity,
+		}
+	}
+	return result
+}
+
+// toPriceRuleConnection converts a page of Shopify price rules
+func toPriceRuleConnection(shop string, priceRules []goshopify.PriceRule, pagination *goshopify.Pagination) *model.PriceRuleConnection {
+	nodes := make([]*model.PriceRule, len(priceRules))
+	edges := make([]*model.PriceRuleEdge, len(priceRules))
+	for i := range priceRules {
+		nodes[i] = toModelPriceRule(shop, &priceRules[i])
+		edges[i] = &model.PriceRuleEdge{Cursor: idCursor(priceRules[i].Id), Node: nodes[i]}
+	}
+	return &model.PriceRuleConnection{Edges: edges, Nodes: nodes, PageInfo: toModelPageInfo(pagination)}
+}
+
+// toModelDiscountCode converts a Shopify discount code
+func toModelDiscountCode(code *goshopify.PriceRuleDiscountCode) *model.DiscountCode {
+	return &model.DiscountCode{
+		ID:          shopifyID(code.Id),
+		PriceRuleID: shopifyID(code.PriceRuleId),
+		Code:        code.Code,
+		UsageCount:  code.UsageCount,
+		CreatedAt:   optionalTime(code.CreatedAt),
+		UpdatedAt:   optionalTime(code.UpdatedAt),
+	}
+}
+
+// toDiscountCodeConnection converts a page of Shopify discount codes
+func toDiscountCodeConnection(codes []goshopify.PriceRuleDiscountCode, pagination *goshopify.Pagination) *model.DiscountCodeConnection {
+	nodes := make([]*model.DiscountCode, len(codes))
+	edges := make([]*model.DiscountCodeEdge, len(codes))
+	for i := range codes {
+		nodes[i] = toModelDiscountCode(&codes[i])
+		edges[i] = &model.DiscountCodeEdge{Cursor: idCursor(codes[i].Id), Node: nodes[i]}
+	}
+	return &model.DiscountCodeConnection{Edges: edges, Nodes: nodes, PageInfo: toModelPageInfo(pagination)}
+}
+
+// toModelDiscountCodeBatch converts a discount code batch
+func toModelDiscountCodeBatch(shop string, batch *domain.DiscountCodeBatch) *model.DiscountCodeBatch {
+	status := model.DiscountCodeBatchStatusQueued
+	if converted := toModelEnum[model.DiscountCodeBatchStatus](string(batch.Status)); converted != nil {
+		status = *converted
+	}
+	return &model.DiscountCodeBatch{
+		ID:            shopifyID(batch.ID),
+		PriceRuleID:   shopifyID(batch.PriceRuleID),
+		Status:        status,
+		CodesCount:    batch.CodesCount,
+		ImportedCount: batch.ImportedCount,
+		FailedCount:   batch.FailedCount,
+		Logs:          nonNilStrings(batch.Logs),
+		StartedAt:     optionalTime(batch.StartedAt),
+		CompletedAt:   optionalTime(batch.CompletedAt),
+		CreatedAt:     optionalTime(batch.CreatedAt),
+		UpdatedAt:     optionalTime(batch.UpdatedAt),
+		Shop:          shop,
+	}
+}
+
+// toModelDiscountCodeBatchCodes converts the codes of a discount code batch, with their errors sorted by field
+func toModelDiscountCodeBatchCodes(codes []domain.DiscountCodeBatchCode) []*model.DiscountCodeBatchCode {
+	result := make([]*model.DiscountCodeBatchCode, len(codes))
+	for i, code := range codes {
+		fields := make([]string, 0, len(code.Errors))
+		for field := range code.Errors {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
+		errors := []*model.DiscountCodeBatchError{}
+		for _, field := range fields {
+			for _, message := range code.Errors[field] {
+				errors = append(errors, &model.DiscountCodeBatchError{Field: field, Message: message})
+			}
+		}
+		result[i] = &model.DiscountCodeBatchCode{
+			ID:         optionalID(code.ID),
+			Code:       code.Code,
+			UsageCount: code.UsageCount,
+			Errors:     errors,
+		}
+	}
+	return result
+}
+
+// shopifyIDs formats Shopify numeric IDs
+func shopifyIDs(ids []uint64) []string {
+	result := make([]string, len(ids))
+	for i, id := range ids {
+		result[i] = shopifyID(id)
+	}
+	return result
+}
+
+// toShopifyPriceRule validates a price rule input and applies it to the current price rule, nil on create
+func toShopifyPriceRule(input model.PriceRuleInput, current *goshopify.PriceRule) (*goshopify.PriceRule, error) {
+	v := &inputValidator{}
+	update := current != nil
+	id := v.id("id", input.ID)
+	if update {
+		v.requiredID(id)
+	} else {
+		if input.ID != nil {
+			v.reject("id", "must not be set on create")
+		}
+		required := map[string]bool{
+			"title":             strings.TrimSpace(stringValue(input.Title)) != "",
+			"valueType":         input.ValueType != nil,
+			"value":             input.Value != nil,
+			"targetType":        input.TargetType != nil,
+			"targetSelection":   input.TargetSelection != nil,
+			"allocationMethod":  input.AllocationMethod != nil,
+			"customerSelection": input.CustomerSelection != nil,
+			"startsAt":          input.StartsAt != nil,
+		}
+		for _, field := range []string{"title", "valueType", "value", "targetType", "targetSelection", "allocationMethod", "customerSelection", "startsAt"} {
+			if !required[field] {
+				v.reject(field, "is required")
+			}
+		}
+	}
+
+	priceRule := &goshopify.PriceRule{}
+	if update {
+		priceRule = current
+		priceRule.Id = id
+	}
+	if input.Title != nil {
+		if strings.TrimSpace(*input.Title) == "" && !v.rejected("title") {
+			v.reject("title", "must not be empty")
+		}
+		priceRule.Title = strings.TrimSpace(*input.Title)
+	}
+	if input.ValueType != nil {
+		priceRule.ValueType = shopifyEnum(string(*input.ValueType))
+	}
+	if value := v.amount("value", input.Value); value != nil {
+		if value.IsPositive() {
+			negated := value.Neg()
+			value = &negated
+		}
+		priceRule.Value = value
+	}
+	if input.TargetType != nil {
+		priceRule.TargetType = shopifyEnum(string(*input.TargetType))
+	}
+	if input.TargetSelection != nil {
+		priceRule.TargetSelection = shopifyEnum(string(*input.TargetSelection))
+	}
+	if input.AllocationMethod != nil {
+		priceRule.AllocationMethod = shopifyEnum(string(*input.AllocationMethod))
+	}
+	if input.AllocationLimit != nil {
+		if *input.AllocationLimit < 0 {
+			v.reject("allocationLimit", "must not be negative")
+		}
+		priceRule.AllocationLimit = *input.AllocationLimit
+	}
+	if input.CustomerSelection != nil {
+		priceRule.CustomerSelection = shopifyEnum(string(*input.CustomerSelection))
+	}
+	if input.OncePerCustomer != nil {
+		priceRule.OncePerCustomer = *input.OncePerCustomer
+	}
+	if input.UsageLimit != nil {
+		if *input.UsageLimit < 0 {
+			v.reject("usageLimit", "must not be negative")
+		}
+		priceRule.UsageLimit = *input.UsageLimit
+	}
+	if input.StartsAt != nil {
+		startsAt := time.Time(*input.StartsAt)
+		priceRule.StartsAt = &startsAt
+	}
+	if input.EndsAt != nil {
+		endsAt := time.Time(*input.EndsAt)
+		priceRule.EndsAt = &endsAt
+	}
+	if input.Entitlements != nil {
+		applyPriceRuleEntitlements(v, priceRule, input.Entitlements)
+	}
+	if input.Prerequisites != nil {
+		applyPriceRulePrerequisites(v, priceRule, input.Prerequisites)
+	}
+	validatePriceRule(v, priceRule)
+
+	if err := v.result("invalid price rule input"); err != nil {
+		return nil, err
+	}
+	return priceRule, nil
+}
+
+// applyPriceRuleEntitlements replaces the entitlements of a price rule
+func applyPriceRuleEntitlements(v *inputValidator, priceRule *goshopify.PriceRule, input *model.PriceRuleEntitlementsInput) {
+	priceRule.EntitledProductIds = filterIDs(v, "entitlements.productIds", input.ProductIds)
+	priceRule.EntitledVariantIds = filterIDs(v, "entitlements.variantIds", input.VariantIds)
+	priceRule.EntitledCollectionIds = filterIDs(v, "entitlements.collectionIds", input.CollectionIds)
+	priceRule.EntitledCountryIds = filterIDs(v, "entitlements.countryIds", input.CountryIds)
+}
+
+// applyPriceRulePrerequisites replaces the prerequisites of a price rule
+func applyPriceRulePrerequisites(v *inputValidator, priceRule *goshopify.PriceRule, input *model.PriceRulePrerequisitesInput) {
+	priceRule.PrerequisiteProductIds = filterIDs(v, "prerequisites.productIds", input.ProductIds)
+	priceRule.PrerequisiteVariantIds = filterIDs(v, "prerequisites.variantIds", input.VariantIds)
+	priceRule.PrerequisiteCollectionIds = filterIDs(v, "prerequisites.collectionIds", input.CollectionIds)
+	priceRule.PrerequisiteCustomerIds = filterIDs(v, "prerequisites.customerIds", input.CustomerIds)
+	priceRule.PrerequisiteSavedSearchIds = filterIDs(v, "prerequisites.savedSearchIds", input.SavedSearchIds)
+
+	subtotal := v.amount("prerequisites.subtotalMin", input.SubtotalMin)
+	v.nonNegative("prerequisites.subtotalMin", subtotal)
+	priceRule.SetPrerequisiteSubtotalRange(rangeAmount(subtotal))
+
+	shipping := v.amount("prerequisites.shippingPriceMax", input.ShippingPriceMax)
+	v.nonNegative("prerequisites.shippingPriceMax", shipping)
+	priceRule.SetPrerequisiteShippingPriceRange(rangeAmount(shipping))
+
+	if input.QuantityMin != nil && *input.QuantityMin <= 0 {
+		v.reject("prerequisites.quantityMin", "must be positive")
+	}
+	priceRule.SetPrerequisiteQuantityRange(input.QuantityMin)
+
+	if ratio := input.ToEntitlementQuantityRatio; ratio != nil {
+		if ratio.PrerequisiteQuantity <= 0 {
+			v.reject("prerequisites.toEntitlementQuantityRatio.prerequisiteQuantity", "must be positive")
+		}
+		if ratio.EntitledQuantity <= 0 {
+			v.reject("prerequisites.toEntitlementQuantityRatio.entitledQuantity", "must be positive")
+		}
+		priceRule.SetPrerequisiteToEntitlementQuantityRatio(&ratio.PrerequisiteQuantity, &ratio.EntitledQuantity)
+	} else {
+		priceRule.SetPrerequisiteToEntitlementQuantityRatio(nil, nil)
+	}
+}
+
+// rangeAmount formats the bound of a prerequisite range, nil when unset
+func rangeAmount(amount *decimal.Decimal) *string {
+	if amount == nil {
+		return nil
+	}
+	formatted := amount.String()
+	return &formatted
+}
+
+// validatePriceRule checks the combination of price rule fields Shopify would reject
+func validatePriceRule(v *inputValidator, priceRule *goshopify.PriceRule) {
+	if priceRule.Value != nil && priceRule.ValueType == "percentage" && priceRule.Value.LessThan(decimal.NewFromInt(-100)) {
+		v.reject("value", "must not exceed 100 percent")
+	}
+	if priceRule.TargetType == "shipping_line" && priceRule.AllocationMethod == "across" {
+		v.reject("allocationMethod", "must be EACH for shipping line price rules")
+	}
+	if priceRule.TargetSelection == "entitled" {
+		entitled := len(priceRule.EntitledProductIds) + len(priceRule.EntitledVariantIds) + len(priceRule.EntitledCollectionIds)
+		if priceRule.TargetType == "shipping_line" {
+			entitled = len(priceRule.EntitledCountryIds)
+		}
+		if entitled == 0 {
+			v.reject("entitlements", "must list what the price rule discounts when targetSelection is ENTITLED")
+		}
+	}
+	if priceRule.CustomerSelection == "prerequisite" && len(priceRule.PrerequisiteCustomerIds)+len(priceRule.PrerequisiteSavedSearchIds) == 0 {
+		v.reject("prerequisites", "must list customerIds or savedSearchIds when customerSelection is PREREQUISITE")
+	}
+	if priceRule.PrerequisiteToEntitlementQuantityRatio != nil && priceRule.TargetSelection != "entitled" && !v.rejected("targetSelection") {
+		v.reject("targetSelection", "must be ENTITLED for buy X get Y price rules")
+	}
+	if priceRule.StartsAt != nil && priceRule.EndsAt != nil && !priceRule.EndsAt.After(*priceRule.StartsAt) {
+		v.reject("endsAt", "must be after startsAt")
+	}
+}
+
+// validateDiscountCode checks a discount code string
+func validateDiscountCode(v *inputValidator, field string, code string) string {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		v.reject(field, "must not be empty")
+	} else if len(code) > 255 {
+		v.reject(field, "must be at most 255 characters")
+	}
+	return code
+}
+
+// toShopifyDiscountCode validates a discount code input, with its ID required on update
+func toShopifyDiscountCode(input model.DiscountCodeInput, update bool) (int64, *goshopify.PriceRuleDiscountCode, error) {
+	v := &inputValidator{}
+	priceRuleID := requiredInputID(v, "priceRuleId", input.PriceRuleID)
+	id := v.id("id", input.ID)
+	if update {
+		v.requiredID(id)
+	} else if input.ID != nil {
+		v.reject("id", "must not be set on create")
+	}
+	code := validateDiscountCode(v, "code", input.Code)
+	if err := v.result("invalid discount code input"); err != nil {
+		return 0, nil, err
+	}
+	return int64(priceRuleID), &goshopify.PriceRuleDiscountCode{Id: id, PriceRuleId: priceRuleID, Code: code}, nil
+}
+
+// toDiscountCodeBatchCodes validates a discount code batch input
+func toDiscountCodeBatchCodes(input model.DiscountCodeBatchInput) (int64, []string, error) {
+	v := &inputValidator{}
+	priceRuleID := requiredInputID(v, "priceRuleId", input.PriceRuleID)
+	if len(input.Codes) == 0 || len(input.Codes) > domain.MaxDiscountCodeBatchSize {
+		v.reject("codes", fmt.Sprintf("must have between 1 and %d codes", domain.MaxDiscountCodeBatchSize))
+	}
+	codes := make([]string, len(input.Codes))
+	seen := make(map[string]bool, len(input.Codes))
+	for i, code := range input.Codes {
+		field := fmt.Sprintf("codes[%d]", i)
+		codes[i] = validateDiscountCode(v, field, code)
+		key := strings.ToLower(codes[i])
+		if key != "" && seen[key] {
+			v.reject(field, "is a duplicate")
+		}
+		seen[key] = true
+	}
+	if err := v.result("invalid discount code batch input"); err != nil {
+		return 0, nil, err
+	}
+	return int64(priceRuleID), codes, nil
+}
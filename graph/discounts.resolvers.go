@@ -0,0 +1,274 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.84
+
+import (
+	"archie-core-shopify-layer/graph/generated"
+	"archie-core-shopify-layer/graph/model"
+	"context"
+	"fmt"
+)
+
+// Codes is the resolver for the codes field.
+func (r *discountCodeBatchResolver) Codes(ctx context.Context, obj *model.DiscountCodeBatch) ([]*model.DiscountCodeBatchCode, error) {
+	var pid, bid int64
+	if _, err := fmt.Sscanf(obj.PriceRuleID, "%d", &pid); err != nil {
+		return nil, invalidIDError("priceRuleId", err)
+	}
+	if _, err := fmt.Sscanf(obj.ID, "%d", &bid); err != nil {
+		return nil, invalidIDError("batchId", err)
+	}
+
+	codes, err := r.shopifyService.GetDiscountCodeBatchCodes(ctx, obj.Shop, pid, bid)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelDiscountCodeBatchCodes(codes), nil
+}
+
+// ShopifyCreatePriceRule is the resolver for the shopify_createPriceRule field.
+func (r *mutationResolver) ShopifyCreatePriceRule(ctx context.Context, input model.PriceRuleInput) (*model.PriceRule, error) {
+	priceRule, err := toShopifyPriceRule(input, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := r.shopifyService.CreatePriceRule(ctx, input.Domain, priceRule)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelPriceRule(input.Domain, created), nil
+}
+
+// ShopifyUpdatePriceRule is the resolver for the shopify_updatePriceRule field.
+func (r *mutationResolver) ShopifyUpdatePriceRule(ctx context.Context, input model.PriceRuleInput) (*model.PriceRule, error) {
+	v := &inputValidator{}
+	id := v.id("id", input.ID)
+	v.requiredID(id)
+	if err := v.result("invalid price rule input"); err != nil {
+		return nil, err
+	}
+
+	current, err := r.shopifyService.GetPriceRule(ctx, input.Domain, int64(id))
+	if err != nil {
+		return nil, err
+	}
+	priceRule, err := toShopifyPriceRule(input, current)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := r.shopifyService.UpdatePriceRule(ctx, input.Domain, priceRule)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelPriceRule(input.Domain, updated), nil
+}
+
+// ShopifyDeletePriceRule is the resolver for the shopify_deletePriceRule field.
+func (r *mutationResolver) ShopifyDeletePriceRule(ctx context.Context, domain string, priceRuleID string) (bool, error) {
+	var pid int64
+	if _, err := fmt.Sscanf(priceRuleID, "%d", &pid); err != nil {
+		return false, invalidIDError("priceRuleId", err)
+	}
+
+	if err := r.shopifyService.DeletePriceRule(ctx, domain, pid); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ShopifyCreateDiscountCode is the resolver for the shopify_createDiscountCode field.
+func (r *mutationResolver) ShopifyCreateDiscountCode(ctx context.Context, input model.DiscountCodeInput) (*model.DiscountCode, error) {
+	pid, discountCode, err := toShopifyDiscountCode(input, false)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := r.shopifyService.CreateDiscountCode(ctx, input.Domain, pid, discountCode.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelDiscountCode(created), nil
+}
+
+// ShopifyUpdateDiscountCode is the resolver for the shopify_updateDiscountCode field.
+func (r *mutationResolver) ShopifyUpdateDiscountCode(ctx context.Context, input model.DiscountCodeInput) (*model.DiscountCode, error) {
+	pid, discountCode, err := toShopifyDiscountCode(input, true)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := r.shopifyService.UpdateDiscountCode(ctx, input.Domain, pid, discountCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelDiscountCode(updated), nil
+}
+
+// ShopifyDeleteDiscountCode is the resolver for the shopify_deleteDiscountCode field.
+func (r *mutationResolver) ShopifyDeleteDiscountCode(ctx context.Context, domain string, priceRuleID string, discountCodeID string) (bool, error) {
+	var pid, did int64
+	if _, err := fmt.Sscanf(priceRuleID, "%d", &pid); err != nil {
+		return false, invalidIDError("priceRuleId", err)
+	}
+	if _, err := fmt.Sscanf(discountCodeID, "%d", &did); err != nil {
+		return false, invalidIDError("discountCodeId", err)
+	}
+
+	if err := r.shopifyService.DeleteDiscountCode(ctx, domain, pid, did); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ShopifyCreateDiscountCodeBatch is the resolver for the shopify_createDiscountCodeBatch field.
+func (r *mutationResolver) ShopifyCreateDiscountCodeBatch(ctx context.Context, input model.DiscountCodeBatchInput) (*model.DiscountCodeBatch, error) {
+	pid, codes, err := toDiscountCodeBatchCodes(input)
+	if err != nil {
+		return nil, err
+	}
+
+	batch, err := r.shopifyService.CreateDiscountCodeBatch(ctx, input.Domain, pid, codes)
+	if err != nil {
+		return nil, err
+	}
+	if boolValue(input.Wait) && !batch.Done() {
+		batch, err = r.shopifyService.WaitForDiscountCodeBatch(ctx, input.Domain, pid, int64(batch.ID), discountCodeBatchWait)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return toModelDiscountCodeBatch(input.Domain, batch), nil
+}
+
+// DiscountCodes is the resolver for the discountCodes field.
+func (r *priceRuleResolver) DiscountCodes(ctx context.Context, obj *model.PriceRule, first *int, after *string, before *string) (*model.DiscountCodeConnection, error) {
+	var pid int64
+	if _, err := fmt.Sscanf(obj.ID, "%d", &pid); err != nil {
+		return nil, invalidIDError("priceRuleId", err)
+	}
+
+	v := &inputValidator{}
+	request := newPageRequest(v, first, after, before, nil)
+	if err := v.result("invalid discount codes query"); err != nil {
+		return nil, err
+	}
+
+	codes, pagination, err := r.shopifyService.ListDiscountCodes(ctx, obj.Shop, pid, &request.options)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDiscountCodeConnection(codes, pagination), nil
+}
+
+// ShopifyPriceRules is the resolver for the shopify_priceRules field.
+func (r *queryResolver) ShopifyPriceRules(ctx context.Context, domain string, first *int, after *string, before *string, filter *model.PriceRuleFilter) (*model.PriceRuleConnection, error) {
+	v := &inputValidator{}
+	options := toPriceRuleListOptions(newPageRequest(v, first, after, before, nil), filter)
+	if err := v.result("invalid price rules query"); err != nil {
+		return nil, err
+	}
+
+	priceRules, pagination, err := r.shopifyService.ListPriceRules(ctx, domain, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPriceRuleConnection(domain, priceRules, pagination), nil
+}
+
+// ShopifyPriceRule is the resolver for the shopify_priceRule field.
+func (r *queryResolver) ShopifyPriceRule(ctx context.Context, domain string, priceRuleID string) (*model.PriceRule, error) {
+	var pid int64
+	if _, err := fmt.Sscanf(priceRuleID, "%d", &pid); err != nil {
+		return nil, invalidIDError("priceRuleId", err)
+	}
+
+	priceRule, err := r.shopifyService.GetPriceRule(ctx, domain, pid)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelPriceRule(domain, priceRule), nil
+}
+
+// ShopifyDiscountCodes is the resolver for the shopify_discountCodes field.
+func (r *queryResolver) ShopifyDiscountCodes(ctx context.Context, domain string, priceRuleID string, first *int, after *string, before *string) (*model.DiscountCodeConnection, error) {
+	var pid int64
+	if _, err := fmt.Sscanf(priceRuleID, "%d", &pid); err != nil {
+		return nil, invalidIDError("priceRuleId", err)
+	}
+
+	v := &inputValidator{}
+	request := newPageRequest(v, first, after, before, nil)
+	if err := v.result("invalid discount codes query"); err != nil {
+		return nil, err
+	}
+
+	codes, pagination, err := r.shopifyService.ListDiscountCodes(ctx, domain, pid, &request.options)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDiscountCodeConnection(codes, pagination), nil
+}
+
+// ShopifyDiscountCodeByCode is the resolver for the shopify_discountCodeByCode field.
+func (r *queryResolver) ShopifyDiscountCodeByCode(ctx context.Context, domain string, code string) (*model.DiscountCode, error) {
+	discountCode, err := r.shopifyService.LookupDiscountCode(ctx, domain, code)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return toModelDiscountCode(discountCode), nil
+}
+
+// ShopifyDiscountCodeCount is the resolver for the shopify_discountCodeCount field.
+func (r *queryResolver) ShopifyDiscountCodeCount(ctx context.Context, domain string, filter *model.DiscountCodeCountFilter) (int, error) {
+	return r.shopifyService.CountDiscountCodes(ctx, domain, toDiscountCodeCountOptions(filter))
+}
+
+// ShopifyDiscountCodeBatch is the resolver for the shopify_discountCodeBatch field.
+func (r *queryResolver) ShopifyDiscountCodeBatch(ctx context.Context, domain string, priceRuleID string, batchID string) (*model.DiscountCodeBatch, error) {
+	var pid, bid int64
+	if _, err := fmt.Sscanf(priceRuleID, "%d", &pid); err != nil {
+		return nil, invalidIDError("priceRuleId", err)
+	}
+	if _, err := fmt.Sscanf(batchID, "%d", &bid); err != nil {
+		return nil, invalidIDError("batchId", err)
+	}
+
+	batch, err := r.shopifyService.GetDiscountCodeBatch(ctx, domain, pid, bid)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelDiscountCodeBatch(domain, batch), nil
+}
+
+// DiscountCodeBatch returns generated.DiscountCodeBatchResolver implementation.
+func (r *Resolver) DiscountCodeBatch() generated.DiscountCodeBatchResolver {
+	return &discountCodeBatchResolver{r}
+}
+
+// PriceRule returns generated.PriceRuleResolver implementation.
+func (r *Resolver) PriceRule() generated.PriceRuleResolver { return &priceRuleResolver{r} }
+
+type discountCodeBatchResolver struct{ *Resolver }
+type priceRuleResolver struct{ *Resolver }
@@ -0,0 +1,300 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	"archie-core-shopify-layer/graph/model"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+	"github.com/shopspring/decimal"
+)
+
+// draftOrderCompleted is the Shopify status of a draft order turned into an order
+const draftOrderCompleted = "completed"
+
+// draftOrderListOptions are the Shopify options of shopify_draftOrders
+type draftOrderListOptions struct {
+	goshopify.ListOptions
+	Status string `url:"status,omitempty"`
+}
+
+// toDraftOrderListOptions builds the Shopify options of shopify_draftOrders
+func toDraftOrderListOptions(v *inputValidator, request pageRequest, filter *model.DraftOrderFilter) *draftOrderListOptions {
+	options := &draftOrderListOptions{ListOptions: request.options}
+	if filter == nil || !request.filtered {
+		return options
+	}
+
+	options.Ids = filterIDs(v, "filter.ids", filter.Ids)
+	if filter.Status != nil {
+		options.Status = shopifyEnum(string(*filter.Status))
+	}
+	options.UpdatedAtMin = filterTime(filter.UpdatedAtMin)
+	options.UpdatedAtMax = filterTime(filter.UpdatedAtMax)
+	return options
+}
+
+// toModelDraftOrder converts a Shopify draft order
+func toModelDraftOrder(shop string, draftOrder *goshopify.DraftOrder) *model.DraftOrder {
+	result := &model.DraftOrder{
+		ID:              shopifyID(draftOrder.Id),
+		Name:            optionalString(draftOrder.Name),
+		Status:          toModelEnum[model.DraftOrderStatus](draftOrder.Status),
+		Email:           optionalString(draftOrder.Email),
+		Note:            optionalString(draftOrder.Note),
+		Tags:            splitTags(draftOrder.Tags),
+		Currency:        optionalString(draftOrder.Currency),
+		TaxesIncluded:   draftOrder.TaxesIncluded,
+		TaxExempt:       draftOrder.TaxExempt != nil && *draftOrder.TaxExempt,
+		SubtotalPrice:   decimalString(draftOrder.SubtotalPrice),
+		TotalTax:        optionalString(draftOrder.TotalTax),
+		TotalPrice:      optionalString(draftOrder.TotalPrice),
+		InvoiceURL:      optionalString(draftOrder.InvoiceURL),
+		InvoiceSentAt:   optionalTime(draftOrder.InvoiceSentAt),
+		BillingAddress:  toModelMailingAddress(draftOrder.BillingAddress),
+		ShippingAddress: toModelMailingAddress(draftOrder.ShippingAddress),
+		LineItems:       toModelDraftOrderLineItems(draftOrder.LineItems),
+		ShippingLine:    toModelDraftOrderShippingLine(draftOrder.ShippingLine),
+		AppliedDiscount: toModelDraftOrderAppliedDiscount(draftOrder.AppliedDiscount),
+		TaxLines:        toModelTaxLines(draftOrder.TaxLines),
+		NoteAttributes:  toModelNoteAttributes(draftOrder.NoteAttributes),
+		OrderID:         optionalID(draftOrder.OrderId),
+		CompletedAt:     optionalTime(draftOrder.CompletedAt),
+		CreatedAt:       optionalTime(draftOrder.CreatedAt),
+		UpdatedAt:       optionalTime(draftOrder.UpdatedAt),
+		Shop:            shop,
+	}
+	if draftOrder.Customer != nil && draftOrder.Customer.Id != 0 {
+		result.Customer = toModelCustomer(shop, draftOrder.Customer)
+	}
+	return result
+}
+
+// toDraftOrderConnection converts a page of Shopify draft orders
+func toDraftOrderConnection(shop string, draftOrders []goshopify.DraftOrder, pagination *goshopify.Pagination) *model.DraftOrderConnection {
+	nodes := make([]*model.DraftOrder, len(draftOrders))
+	edges := make([]*model.DraftOrderEdge, len(draftOrders))
+	for i := range draftOrders {
+		nodes[i] = toModelDraftOrder(shop, &draftOrders[i])
+		edges[i] = &model.DraftOrderEdge{Cursor: idCursor(draftOrders[i].Id), Node: nodes[i]}
+	}
+	return &model.DraftOrderConnection{Edges: edges, Nodes: nodes, PageInfo: toModelPageInfo(pagination)}
+}
+
+// toModelDraftOrderLineItems converts the line items of a Shopify draft order
+func toModelDraftOrderLineItems(items []goshopify.LineItem) []*model.DraftOrderLineItem {
+	result := make([]*model.DraftOrderLineItem, len(items))
+	for i, item := range items {
+		result[i] = &model.DraftOrderLineItem{
+			ProductID:        optionalID(item.ProductId),
+			VariantID:        optionalID(item.VariantId),
+			Title:            item.Title,
+			VariantTitle:     optionalString(item.VariantTitle),
+			Name:             optionalString(item.Name),
+			Sku:              optionalString(item.SKU),
+			Vendor:           optionalString(item.Vendor),
+			Quantity:         item.Quantity,
+			Price:            decimalString(item.Price),
+			Grams:            item.Grams,
+			Taxable:          item.Taxable,
+			RequiresShipping: item.RequiresShipping,
+			GiftCard:         item.GiftCard,
+			AppliedDiscount:  toModelDraftOrderAppliedDiscount(item.AppliedDiscount),
+			Properties:       toModelNoteAttributes(item.Properties),
+			TaxLines:         toModelTaxLines(item.TaxLines),
+		}
+	}
+	return result
+}
+
+// toModelDraftOrderShippingLine converts the shipping line of a Shopify draft order, nil when absent
+func toModelDraftOrderShippingLine(line *goshopify.ShippingLines) *model.DraftOrderShippingLine {
+	if line == nil || line.Title == "" {
+		return nil
+	}
+	return &model.DraftOrderShippingLine{
+		Title: line.Title,
+		Code:  optionalString(line.Code),
+		Price: decimalString(line.Price),
+	}
+}
+
+// toModelDraftOrderAppliedDiscount converts a Shopify applied discount, nil when absent
+func toModelDraftOrderAppliedDiscount(discount *goshopify.AppliedDiscount) *model.DraftOrderAppliedDiscount {
+	if discount == nil || discount.Value == "" {
+		return nil
+	}
+	return &model.DraftOrderAppliedDiscount{
+		Title:       optionalString(discount.Title),
+		Description: optionalString(discount.Description),
+		Value:       discount.Value,
+		ValueType:   toModelEnum[model.DraftOrderDiscountValueType](discount.ValueType),
+		Amount:      optionalString(discount.Amount),
+	}
+}
+
+// toModelDraftOrderInvoice converts a sent Shopify draft order invoice
+func toModelDraftOrderInvoice(invoice *goshopify.DraftOrderInvoice) *model.DraftOrderInvoice {
+	return &model.DraftOrderInvoice{
+		To:            optionalString(invoice.To),
+		From:          optionalString(invoice.From),
+		Subject:       optionalString(invoice.Subject),
+		CustomMessage: optionalString(invoice.CustomMessage),
+		Bcc:           nonNilStrings(invoice.Bcc),
+	}
+}
+
+// toShopifyDraftOrder builds and validates the draft order of a create or update mutation
+// current is the draft order being updated, nil on create; its discount and shipping line are kept unless replaced
+func toShopifyDraftOrder(input model.DraftOrderInput, current *goshopify.DraftOrder) (*goshopify.DraftOrder, error) {
+	v := &inputValidator{}
+	draftOrder := &goshopify.DraftOrder{
+		Id:                        v.id("id", input.ID),
+		Email:                     stringValue(input.Email),
+		Note:                      stringValue(input.Note),
+		Tags:                      strings.Join(input.Tags, ", "),
+		TaxExempt:                 input.TaxExempt,
+		UseCustomerDefaultAddress: boolValue(input.UseCustomerDefaultAddress),
+		BillingAddress:            addressFromInput(input.BillingAddress),
+		ShippingAddress:           addressFromInput(input.ShippingAddress),
+		NoteAttributes:            noteAttributesFromInput(input.NoteAttributes),
+		ShippingLine:              draftOrderShippingLineFromInput(v, "shippingLine", input.ShippingLine),
+		AppliedDiscount:           appliedDiscountFromInput(v, "appliedDiscount", input.AppliedDiscount),
+	}
+	if input.CustomerID != nil {
+		draftOrder.Customer = &goshopify.Customer{Id: v.id("customerId", input.CustomerID)}
+	}
+
+	for i, item := range input.LineItems {
+		lineItem := goshopify.LineItem{
+			VariantId:        v.id(fieldPath("lineItems", i, "variantId"), item.VariantID),
+			Title:            stringValue(item.Title),
+			Price:            v.amount(fieldPath("lineItems", i, "price"), item.Price),
+			Quantity:         item.Quantity,
+			SKU:              stringValue(item.Sku),
+			Grams:            intValue(item.Grams),
+			Taxable:          boolValue(item.Taxable),
+			RequiresShipping: boolValue(item.RequiresShipping),
+			Properties:       noteAttributesFromInput(item.Properties),
+			AppliedDiscount:  appliedDiscountFromInput(v, fieldPath("lineItems", i, "appliedDiscount"), item.AppliedDiscount),
+		}
+		if lineItem.Quantity < 1 {
+			v.reject(fieldPath("lineItems", i, "quantity"), "must be at least 1")
+		}
+		if lineItem.VariantId == 0 && !v.rejected(fieldPath("lineItems", i, "variantId")) && (lineItem.Title == "" || lineItem.Price == nil) {
+			v.reject(fieldPath("lineItems", i, "variantId"), "a variantId, or a title and a price, is required")
+		}
+		v.nonNegative(fieldPath("lineItems", i, "price"), lineItem.Price)
+		draftOrder.LineItems = append(draftOrder.LineItems, lineItem)
+	}
+
+	if current != nil {
+		v.requiredID(draftOrder.Id)
+		if current.Status == draftOrderCompleted {
+			v.reject("id", "completed draft orders cannot be updated")
+		}
+		if input.AppliedDiscount == nil {
+			draftOrder.AppliedDiscount = current.AppliedDiscount
+		}
+		if input.ShippingLine == nil {
+			draftOrder.ShippingLine = current.ShippingLine
+		}
+	} else if len(draftOrder.LineItems) == 0 {
+		v.reject("lineItems", "at least one line item is required")
+	}
+	v.email("email", draftOrder.Email)
+	if draftOrder.BillingAddress != nil {
+		v.countryCode("billingAddress.countryCode", draftOrder.BillingAddress.CountryCode)
+	}
+	if draftOrder.ShippingAddress != nil {
+		v.countryCode("shippingAddress.countryCode", draftOrder.ShippingAddress.CountryCode)
+	}
+	if draftOrder.UseCustomerDefaultAddress && draftOrder.Customer == nil {
+		v.reject("useCustomerDefaultAddress", "requires a customerId")
+	}
+
+	if err := v.result("invalid draft order input"); err != nil {
+		return nil, err
+	}
+	return draftOrder, nil
+}
+
+// toShopifyAppliedDiscount validates the discount of shopify_applyDraftOrderDiscount, nil removes the discount
+func toShopifyAppliedDiscount(input *model.DraftOrderAppliedDiscountInput) (*goshopify.AppliedDiscount, error) {
+	v := &inputValidator{}
+	discount := appliedDiscountFromInput(v, "discount", input)
+	if err := v.result("invalid draft order discount"); err != nil {
+		return nil, err
+	}
+	return discount, nil
+}
+
+// toShopifyDraftOrderShippingLine validates the shipping line of shopify_setDraftOrderShippingLine, nil removes it
+func toShopifyDraftOrderShippingLine(input *model.DraftOrderShippingLineInput) (*goshopify.ShippingLines, error) {
+	v := &inputValidator{}
+	line := draftOrderShippingLineFromInput(v, "shippingLine", input)
+	if err := v.result("invalid draft order shipping line"); err != nil {
+		return nil, err
+	}
+	return line, nil
+}
+
+// appliedDiscountFromInput converts and checks an optional draft order or line item discount
+func appliedDiscountFromInput(v *inputValidator, field string, input *model.DraftOrderAppliedDiscountInput) *goshopify.AppliedDiscount {
+	if input == nil {
+		return nil
+	}
+	value := v.amount(field+".value", &input.Value)
+	if value != nil && !value.IsPositive() {
+		v.reject(field+".value", "must be greater than zero")
+	} else if value != nil && input.ValueType == model.DraftOrderDiscountValueTypePercentage && value.GreaterThan(decimal.NewFromInt(100)) {
+		v.reject(field+".value", "must not exceed 100 for a percentage")
+	}
+	return &goshopify.AppliedDiscount{
+		Title:       stringValue(input.Title),
+		Description: stringValue(input.Description),
+		Value:       input.Value,
+		ValueType:   shopifyEnum(string(input.ValueType)),
+	}
+}
+
+// draftOrderShippingLineFromInput converts and checks an optional custom shipping line
+func draftOrderShippingLineFromInput(v *inputValidator, field string, input *model.DraftOrderShippingLineInput) *goshopify.ShippingLines {
+	if input == nil {
+		return nil
+	}
+	if strings.TrimSpace(input.Title) == "" {
+		v.reject(field+".title", "is required")
+	}
+	price := v.amount(field+".price", &input.Price)
+	v.nonNegative(field+".price", price)
+	return &goshopify.ShippingLines{
+		Title: input.Title,
+		Price: price,
+		Code:  stringValue(input.Code),
+	}
+}
+
+// toShopifyDraftOrderInvoice builds the invoice of shopify_sendDraftOrderInvoice
+func toShopifyDraftOrderInvoice(input model.DraftOrderInvoiceInput) (int64, goshopify.DraftOrderInvoice, error) {
+	v := &inputValidator{}
+	draftOrderID := requiredInputID(v, "draftOrderId", input.DraftOrderID)
+	invoice := goshopify.DraftOrderInvoice{
+		To:            stringValue(input.To),
+		From:          stringValue(input.From),
+		Subject:       stringValue(input.Subject),
+		CustomMessage: stringValue(input.CustomMessage),
+		Bcc:           input.Bcc,
+	}
+	v.email("to", invoice.To)
+	v.email("from", invoice.From)
+	for i, address := range invoice.Bcc {
+		v.email(fmt.Sprintf("bcc[%d]", i), address)
+	}
+
+	if err := v.result("invalid draft order invoice input"); err != nil {
+		return 0, invoice, err
+	}
+	return int64(draftOrderID), invoice, nil
+}
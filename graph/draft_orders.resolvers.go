@@ -0,0 +1,190 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.84
+
+import (
+	"archie-core-shopify-layer/graph/generated"
+	"archie-core-shopify-layer/graph/model"
+	"context"
+	"fmt"
+)
+
+// Order is the resolver for the order field.
+func (r *draftOrderResolver) Order(ctx context.Context, obj *model.DraftOrder) (*model.Order, error) {
+	if obj.OrderID == nil {
+		return nil, nil
+	}
+	var oid int64
+	if _, err := fmt.Sscanf(*obj.OrderID, "%d", &oid); err != nil {
+		return nil, invalidIDError("orderId", err)
+	}
+
+	order, err := r.shopifyService.GetOrder(ctx, obj.Shop, oid)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelOrder(obj.Shop, order), nil
+}
+
+// ShopifyCreateDraftOrder is the resolver for the shopify_createDraftOrder field.
+func (r *mutationResolver) ShopifyCreateDraftOrder(ctx context.Context, input model.DraftOrderInput) (*model.DraftOrder, error) {
+	draftOrder, err := toShopifyDraftOrder(input, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := r.shopifyService.CreateDraftOrder(ctx, input.Domain, draftOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelDraftOrder(input.Domain, created), nil
+}
+
+// ShopifyUpdateDraftOrder is the resolver for the shopify_updateDraftOrder field.
+func (r *mutationResolver) ShopifyUpdateDraftOrder(ctx context.Context, input model.DraftOrderInput) (*model.DraftOrder, error) {
+	v := &inputValidator{}
+	id := v.id("id", input.ID)
+	v.requiredID(id)
+	if err := v.result("invalid draft order input"); err != nil {
+		return nil, err
+	}
+
+	current, err := r.shopifyService.GetDraftOrder(ctx, input.Domain, int64(id))
+	if err != nil {
+		return nil, err
+	}
+	draftOrder, err := toShopifyDraftOrder(input, current)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := r.shopifyService.UpdateDraftOrder(ctx, input.Domain, draftOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelDraftOrder(input.Domain, updated), nil
+}
+
+// ShopifyDeleteDraftOrder is the resolver for the shopify_deleteDraftOrder field.
+func (r *mutationResolver) ShopifyDeleteDraftOrder(ctx context.Context, domain string, draftOrderID string) (bool, error) {
+	var did int64
+	if _, err := fmt.Sscanf(draftOrderID, "%d", &did); err != nil {
+		return false, invalidIDError("draftOrderId", err)
+	}
+
+	if err := r.shopifyService.DeleteDraftOrder(ctx, domain, did); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ShopifyApplyDraftOrderDiscount is the resolver for the shopify_applyDraftOrderDiscount field.
+func (r *mutationResolver) ShopifyApplyDraftOrderDiscount(ctx context.Context, domain string, draftOrderID string, discount *model.DraftOrderAppliedDiscountInput) (*model.DraftOrder, error) {
+	var did int64
+	if _, err := fmt.Sscanf(draftOrderID, "%d", &did); err != nil {
+		return nil, invalidIDError("draftOrderId", err)
+	}
+	applied, err := toShopifyAppliedDiscount(discount)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := r.shopifyService.ApplyDraftOrderDiscount(ctx, domain, did, applied)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelDraftOrder(domain, updated), nil
+}
+
+// ShopifySetDraftOrderShippingLine is the resolver for the shopify_setDraftOrderShippingLine field.
+func (r *mutationResolver) ShopifySetDraftOrderShippingLine(ctx context.Context, domain string, draftOrderID string, shippingLine *model.DraftOrderShippingLineInput) (*model.DraftOrder, error) {
+	var did int64
+	if _, err := fmt.Sscanf(draftOrderID, "%d", &did); err != nil {
+		return nil, invalidIDError("draftOrderId", err)
+	}
+	line, err := toShopifyDraftOrderShippingLine(shippingLine)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := r.shopifyService.SetDraftOrderShippingLine(ctx, domain, did, line)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelDraftOrder(domain, updated), nil
+}
+
+// ShopifySendDraftOrderInvoice is the resolver for the shopify_sendDraftOrderInvoice field.
+func (r *mutationResolver) ShopifySendDraftOrderInvoice(ctx context.Context, input model.DraftOrderInvoiceInput) (*model.DraftOrderInvoice, error) {
+	did, invoice, err := toShopifyDraftOrderInvoice(input)
+	if err != nil {
+		return nil, err
+	}
+
+	sent, err := r.shopifyService.SendDraftOrderInvoice(ctx, input.Domain, did, invoice)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelDraftOrderInvoice(sent), nil
+}
+
+// ShopifyCompleteDraftOrder is the resolver for the shopify_completeDraftOrder field.
+func (r *mutationResolver) ShopifyCompleteDraftOrder(ctx context.Context, domain string, draftOrderID string, paymentPending *bool) (*model.DraftOrder, error) {
+	var did int64
+	if _, err := fmt.Sscanf(draftOrderID, "%d", &did); err != nil {
+		return nil, invalidIDError("draftOrderId", err)
+	}
+
+	completed, err := r.shopifyService.CompleteDraftOrder(ctx, domain, did, boolValue(paymentPending))
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelDraftOrder(domain, completed), nil
+}
+
+// ShopifyDraftOrders is the resolver for the shopify_draftOrders field.
+func (r *queryResolver) ShopifyDraftOrders(ctx context.Context, domain string, first *int, after *string, before *string, filter *model.DraftOrderFilter) (*model.DraftOrderConnection, error) {
+	v := &inputValidator{}
+	options := toDraftOrderListOptions(v, newPageRequest(v, first, after, before, nil), filter)
+	if err := v.result("invalid draft orders query"); err != nil {
+		return nil, err
+	}
+
+	draftOrders, pagination, err := r.shopifyService.ListDraftOrders(ctx, domain, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDraftOrderConnection(domain, draftOrders, pagination), nil
+}
+
+// ShopifyDraftOrder is the resolver for the shopify_draftOrder field.
+func (r *queryResolver) ShopifyDraftOrder(ctx context.Context, domain string, draftOrderID string) (*model.DraftOrder, error) {
+	var did int64
+	if _, err := fmt.Sscanf(draftOrderID, "%d", &did); err != nil {
+		return nil, invalidIDError("draftOrderId", err)
+	}
+
+	draftOrder, err := r.shopifyService.GetDraftOrder(ctx, domain, did)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelDraftOrder(domain, draftOrder), nil
+}
+
+// DraftOrder returns generated.DraftOrderResolver implementation.
+func (r *Resolver) DraftOrder() generated.DraftOrderResolver { return &draftOrderResolver{r} }
+
+type draftOrderResolver struct{ *Resolver }
@@ -0,0 +1,104 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.84
+
+import (
+	"archie-core-shopify-layer/graph/model"
+	"archie-core-shopify-layer/internal/application"
+	"archie-core-shopify-layer/internal/domain"
+	"context"
+)
+
+// ShopifyCloneEnvironment is the resolver for the shopify_cloneEnvironment field.
+func (r *mutationResolver) ShopifyCloneEnvironment(ctx context.Context, input model.CloneEnvironmentInput) (*model.ShopifyConfig, error) {
+	tenantID := getTenantID(ctx)
+	if tenantID == "" {
+		return nil, domain.NewValidationError("project ID is required", nil)
+	}
+
+	config, err := r.environmentService.CloneEnvironment(ctx, application.CloneEnvironmentInput{
+		ProjectID:         tenantID,
+		SourceEnvironment: input.SourceEnvironment,
+		TargetEnvironment: input.TargetEnvironment,
+		AppHandle:         stringValue(input.AppHandle),
+		APIKey:            input.APIKey,
+		APISecret:         input.APISecret,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelShopifyConfig(config), nil
+}
+
+// ShopifyEnvironments is the resolver for the shopify_environments field.
+func (r *queryResolver) ShopifyEnvironments(ctx context.Context) ([]*model.ProjectEnvironment, error) {
+	tenantID := getTenantID(ctx)
+	if tenantID == "" {
+		return nil, domain.NewValidationError("project ID is required", nil)
+	}
+
+	environments, err := r.environmentService.ListEnvironments(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.ProjectEnvironment, len(environments))
+	for i, environment := range environments {
+		apps := make([]*model.ShopifyConfig, len(environment.Apps))
+		for j, config := range environment.Apps {
+			apps[j] = toModelShopifyConfig(config)
+		}
+		installations := []*model.InstallStateCount{}
+		for _, state := range model.AllInstallState {
+			if count := environment.InstallCounts[domain.InstallState(state)]; count > 0 {
+				installations = append(installations, &model.InstallStateCount{State: state, Count: count})
+			}
+		}
+		result[i] = &model.ProjectEnvironment{
+			Name:          environment.Name,
+			Apps:          apps,
+			Installations: installations,
+			ActiveShops:   environment.ActiveShops,
+		}
+	}
+
+	return result, nil
+}
+
+// ShopifyCompareEnvironments is the resolver for the shopify_compareEnvironments field.
+func (r *queryResolver) ShopifyCompareEnvironments(ctx context.Context, source string, target string) (*model.EnvironmentComparison, error) {
+	tenantID := getTenantID(ctx)
+	if tenantID == "" {
+		return nil, domain.NewValidationError("project ID is required", nil)
+	}
+
+	comparison, err := r.environmentService.CompareEnvironments(ctx, tenantID, source, target)
+	if err != nil {
+		return nil, err
+	}
+
+	apps := make([]*model.AppConfigDiff, len(comparison.Apps))
+	for i, app := range comparison.Apps {
+		apps[i] = &model.AppConfigDiff{
+			AppHandle:                   app.AppHandle,
+			InSource:                    app.InSource,
+			InTarget:                    app.InTarget,
+			SameAPIKey:                  app.SameAPIKey,
+			ExpiringOfflineTokensDiffer: app.ExpiringOfflineTokensDiffer,
+		}
+	}
+
+	return &model.EnvironmentComparison{
+		ProjectID: comparison.ProjectID,
+		Source:    comparison.Source,
+		Target:    comparison.Target,
+		Apps:      apps,
+		Topics:    toModelStringSetDiff(comparison.Topics),
+		Scopes:    toModelStringSetDiff(comparison.Scopes),
+		Shops:     toModelStringSetDiff(comparison.Shops),
+	}, nil
+}
@@ -0,0 +1,56 @@
+package graph
+
+import (
+	"context"
+
+	"archie-core-shopify-layer/internal/domain"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/rs/zerolog"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// ErrorPresenter maps domain.AppError into GraphQL errors: the error type becomes extensions.code, and the retry delay,
+// Shopify request ID and rejected fields are added when known
+// Internal and database errors are logged and presented without their cause
+func ErrorPresenter(logger zerolog.Logger) graphql.ErrorPresenterFunc {
+	return func(ctx context.Context, err error) *gqlerror.Error {
+		gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+
+		appErr, ok := domain.AsAppError(err)
+		if !ok {
+			return gqlErr
+		}
+
+		if gqlErr.Extensions == nil {
+			gqlErr.Extensions = map[string]interface{}{}
+		}
+		gqlErr.Extensions["code"] = string(appErr.Type)
+		if retryAfter := appErr.RetryAfter(); retryAfter > 0 {
+			gqlErr.Extensions["retryAfter"] = retryAfter
+		}
+		if status, ok := appErr.Context["status"].(int); ok {
+			gqlErr.Extensions["shopifyStatus"] = status
+		}
+		if appErr.RequestID != "" {
+			gqlErr.Extensions["requestId"] = appErr.RequestID
+		}
+		if len(appErr.Fields) > 0 {
+			fields := make([]map[string]interface{}, 0, len(appErr.Fields))
+			for _, field := range appErr.Fields {
+				fields = append(fields, map[string]interface{}{
+					"field":   field.Field,
+					"message": field.Message,
+				})
+			}
+			gqlErr.Extensions["fields"] = fields
+		}
+
+		if appErr.Type == domain.ErrorTypeInternal || appErr.Type == domain.ErrorTypeDatabase {
+			logger.Error().Err(err).Str("path", gqlErr.Path.String()).Msg("GraphQL request failed")
+			gqlErr.Message = appErr.Message
+		}
+
+		return gqlErr
+	}
+}
@@ -0,0 +1,237 @@
+package graph
+
+import (
+	"strings"
+
+	"archie-core-shopify-layer/graph/model"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+)
+
+// assignedFulfillmentOrderOptions are the Shopify options of shopify_assignedFulfillmentOrders
+type assignedFulfillmentOrderOptions struct {
+	AssignmentStatus string   `url:"assignment_status,omitempty"`
+	LocationIds      []uint64 `url:"location_ids,omitempty,comma"`
+}
+
+// toAssignedFulfillmentOrderOptions builds the Shopify options of shopify_assignedFulfillmentOrders
+func toAssignedFulfillmentOrderOptions(locationIDs []string, status *model.FulfillmentOrderAssignmentStatus) (*assignedFulfillmentOrderOptions, error) {
+	v := &inputValidator{}
+	options := &assignedFulfillmentOrderOptions{LocationIds: filterIDs(v, "locationIds", locationIDs)}
+	if status != nil {
+		options.AssignmentStatus = shopifyEnum(string(*status))
+	}
+	if err := v.result("invalid assigned fulfillment orders query"); err != nil {
+		return nil, err
+	}
+	return options, nil
+}
+
+// toModelFulfillmentOrder converts a Shopify fulfillment order
+func toModelFulfillmentOrder(fulfillmentOrder *goshopify.FulfillmentOrder) *model.FulfillmentOrder {
+	result := &model.FulfillmentOrder{
+		ID:                 shopifyID(fulfillmentOrder.Id),
+		OrderID:            shopifyID(fulfillmentOrder.OrderId),
+		AssignedLocationID: optionalID(fulfillmentOrder.AssignedLocationId),
+		AssignedLocation:   toModelFulfillmentOrderLocation(fulfillmentOrder.AssignedLocation),
+		Status:             toModelEnum[model.FulfillmentOrderStatus](fulfillmentOrder.Status),
+		RequestStatus:      toModelEnum[model.FulfillmentOrderRequestStatus](fulfillmentOrder.RequestStatus),
+		Destination:        toModelFulfillmentOrderDestination(fulfillmentOrder.Destination),
+		DeliveryMethod:     optionalString(strings.ToUpper(fulfillmentOrder.DeliveryMethod.MethodType)),
+		FulfillAt:          optionalTime(fulfillmentOrder.FulfillAt),
+		FulfillBy:          optionalTime(fulfillmentOrder.FulfillBy),
+		Holds:              make([]*model.FulfillmentHold, len(fulfillmentOrder.FulfillmentHolds)),
+		LineItems:          make([]*model.FulfillmentOrderLineItem, len(fulfillmentOrder.LineItems)),
+		MerchantRequests:   make([]*model.FulfillmentOrderMerchantRequest, len(fulfillmentOrder.MerchantRequests)),
+		SupportedActions:   nonNilStrings(fulfillmentOrder.SupportedActions),
+		CreatedAt:          optionalTime(fulfillmentOrder.CreatedAt),
+		UpdatedAt:          optionalTime(fulfillmentOrder.UpdatedAt),
+	}
+	for i, hold := range fulfillmentOrder.FulfillmentHolds {
+		result.Holds[i] = &model.FulfillmentHold{
+			Reason:      toModelEnum[model.FulfillmentHoldReason](string(hold.Reason)),
+			ReasonNotes: optionalString(hold.ReasonNotes),
+		}
+	}
+	for i, item := range fulfillmentOrder.LineItems {
+		result.LineItems[i] = &model.FulfillmentOrderLineItem{
+			ID:                  shopifyID(item.Id),
+			LineItemID:          shopifyID(item.LineItemId),
+			VariantID:           optionalID(item.VariantId),
+			InventoryItemID:     optionalID(item.InventoryItemId),
+			Quantity:            int(item.Quantity),
+			FulfillableQuantity: int(item.FulfillableQuantity),
+		}
+	}
+	for i, request := range fulfillmentOrder.MerchantRequests {
+		result.MerchantRequests[i] = &model.FulfillmentOrderMerchantRequest{
+			Kind:    optionalString(request.Kind),
+			Message: optionalString(request.Message),
+		}
+	}
+	return result
+}
+
+// toModelFulfillmentOrders converts Shopify fulfillment orders
+func toModelFulfillmentOrders(fulfillmentOrders []goshopify.FulfillmentOrder) []*model.FulfillmentOrder {
+	result := make([]*model.FulfillmentOrder, len(fulfillmentOrders))
+	for i := range fulfillmentOrders {
+		result[i] = toModelFulfillmentOrder(&fulfillmentOrders[i])
+	}
+	return result
+}
+
+// toModelFulfillmentOrderLocation converts the assigned location of a fulfillment order, nil when it has none
+func toModelFulfillmentOrderLocation(location goshopify.FulfillmentOrderAssignedLocation) *model.FulfillmentOrderLocation {
+	if location == (goshopify.FulfillmentOrderAssignedLocation{}) {
+		return nil
+	}
+	return &model.FulfillmentOrderLocation{
+		LocationID:  optionalID(location.LocationId),
+		Name:        optionalString(location.Name),
+		Address1:    optionalString(location.Address1),
+		Address2:    optionalString(location.Address2),
+		City:        optionalString(location.City),
+		Province:    optionalString(location.Province),
+		Zip:         optionalString(location.Zip),
+		CountryCode: optionalString(location.CountryCode),
+		Phone:       optionalString(location.Phone),
+	}
+}
+
+// toModelFulfillmentOrderDestination converts the destination of a fulfillment order, nil for pickups without one
+func toModelFulfillmentOrderDestination(destination goshopify.FulfillmentOrderDestination) *model.FulfillmentOrderDestination {
+	if destination == (goshopify.FulfillmentOrderDestination{}) {
+		return nil
+	}
+	return &model.FulfillmentOrderDestination{
+		FirstName: optionalString(destination.FirstName),
+		LastName:  optionalString(destination.LastName),
+		Company:   optionalString(destination.Company),
+		Address1:  optionalString(destination.Address1),
+		Address2:  optionalString(destination.Address2),
+		City:      optionalString(destination.City),
+		Province:  optionalString(destination.Province),
+		Zip:       optionalString(destination.Zip),
+		Country:   optionalString(destination.Country),
+		Email:     optionalString(destination.Email),
+		Phone:     optionalString(destination.Phone),
+	}
+}
+
+// requiredInputID parses a required Shopify ID of an input
+func requiredInputID(v *inputValidator, field string, value string) uint64 {
+	id := v.id(field, &value)
+	if id == 0 && !v.rejected(field) {
+		v.reject(field, "is required")
+	}
+	return id
+}
+
+// fulfillmentOrderLineItemQuantities parses quantities of fulfillment order line items
+func fulfillmentOrderLineItemQuantities(v *inputValidator, field string, items []*model.FulfillmentOrderLineItemInput) []goshopify.FulfillmentOrderLineItemQuantity {
+	result := make([]goshopify.FulfillmentOrderLineItemQuantity, 0, len(items))
+	for i, item := range items {
+		id := requiredInputID(v, fieldPath(field, i, "id"), item.ID)
+		if item.Quantity <= 0 {
+			v.reject(fieldPath(field, i, "quantity"), "must be positive")
+			continue
+		}
+		result = append(result, goshopify.FulfillmentOrderLineItemQuantity{Id: id, Quantity: uint64(item.Quantity)})
+	}
+	return result
+}
+
+// fulfillmentTracking converts tracking information, rejecting an empty one
+func fulfillmentTracking(v *inputValidator, field string, input *model.FulfillmentTrackingInput) goshopify.FulfillmentTrackingInfo {
+	tracking := goshopify.FulfillmentTrackingInfo{
+		Company: strings.TrimSpace(stringValue(input.Company)),
+		Number:  strings.TrimSpace(stringValue(input.Number)),
+		Url:     strings.TrimSpace(stringValue(input.URL)),
+	}
+	if tracking == (goshopify.FulfillmentTrackingInfo{}) {
+		v.reject(field, "must set a company, number or url")
+	}
+	if tracking.Url != "" && !strings.HasPrefix(tracking.Url, "http://") && !strings.HasPrefix(tracking.Url, "https://") {
+		v.reject(field+".url", "must be an http or https URL")
+	}
+	return tracking
+}
+
+// toShopifyFulfillment validates a fulfillment input and converts it to a fulfillment of fulfillment order line items
+func toShopifyFulfillment(input model.CreateFulfillmentInput) (*goshopify.Fulfillment, error) {
+	v := &inputValidator{}
+	if len(input.LineItemsByFulfillmentOrder) == 0 {
+		v.reject("lineItemsByFulfillmentOrder", "must have at least one fulfillment order")
+	}
+
+	fulfillment := &goshopify.Fulfillment{
+		LineItemsByFulfillmentOrder: make([]goshopify.LineItemByFulfillmentOrder, len(input.LineItemsByFulfillmentOrder)),
+		NotifyCustomer:              boolValue(input.NotifyCustomer),
+	}
+	for i, group := range input.LineItemsByFulfillmentOrder {
+		field := fieldPath("lineItemsByFulfillmentOrder", i, "lineItems")
+		quantities := fulfillmentOrderLineItemQuantities(v, field, group.LineItems)
+		items := make([]goshopify.LineItemByFulfillmentOrderItemQuantity, len(quantities))
+		for j, quantity := range quantities {
+			items[j] = goshopify.LineItemByFulfillmentOrderItemQuantity{Id: quantity.Id, Quantity: quantity.Quantity}
+		}
+		fulfillment.LineItemsByFulfillmentOrder[i] = goshopify.LineItemByFulfillmentOrder{
+			FulfillmentOrderId:        requiredInputID(v, fieldPath("lineItemsByFulfillmentOrder", i, "fulfillmentOrderId"), group.FulfillmentOrderID),
+			FulfillmentOrderLineItems: items,
+		}
+	}
+	if input.TrackingInfo != nil {
+		fulfillment.TrackingInfo = fulfillmentTracking(v, "trackingInfo", input.TrackingInfo)
+	}
+
+	if err := v.result("invalid fulfillment input"); err != nil {
+		return nil, err
+	}
+	return fulfillment, nil
+}
+
+// toShopifyFulfillmentRejection validates a rejection input and converts it to a fulfillment request
+func toShopifyFulfillmentRejection(input model.RejectFulfillmentRequestInput) (uint64, goshopify.FulfillmentRequest, error) {
+	v := &inputValidator{}
+	id := requiredInputID(v, "fulfillmentOrderId", input.FulfillmentOrderID)
+	request := goshopify.FulfillmentRequest{Message: stringValue(input.Message)}
+	if input.Reason != nil {
+		request.Reason = shopifyEnum(string(*input.Reason))
+	}
+	for i, item := range input.LineItems {
+		request.LineItems = append(request.LineItems, goshopify.FulfillmentRequestLineItem{
+			FulfillmentOrderLineItemId: requiredInputID(v, fieldPath("lineItems", i, "fulfillmentOrderLineItemId"), item.FulfillmentOrderLineItemID),
+			Message:                    stringValue(item.Message),
+		})
+	}
+	if err := v.result("invalid fulfillment rejection input"); err != nil {
+		return 0, request, err
+	}
+	return id, request, nil
+}
+
+// toShopifyFulfillmentOrderMove validates a move input and converts it to a move request
+func toShopifyFulfillmentOrderMove(input model.MoveFulfillmentOrderInput) (uint64, goshopify.FulfillmentOrderMoveRequest, error) {
+	v := &inputValidator{}
+	id := requiredInputID(v, "fulfillmentOrderId", input.FulfillmentOrderID)
+	move := goshopify.FulfillmentOrderMoveRequest{
+		NewLocationId: requiredInputID(v, "newLocationId", input.NewLocationID),
+		LineItems:     fulfillmentOrderLineItemQuantities(v, "lineItems", input.LineItems),
+	}
+	if input.LineItems != nil && len(input.LineItems) == 0 {
+		v.reject("lineItems", "must have at least one line item when set")
+	}
+	if err := v.result("invalid fulfillment order move input"); err != nil {
+		return 0, move, err
+	}
+	return id, move, nil
+}
+
+// toShopifyFulfillmentHold converts the hold of shopify_holdFulfillmentOrder
+func toShopifyFulfillmentHold(input model.HoldFulfillmentOrderInput) goshopify.FulfillmentOrderHold {
+	return goshopify.FulfillmentOrderHold{
+		Reason:      goshopify.FulfillmentOrderHoldReason(shopifyEnum(string(input.Reason))),
+		ReasonNotes: stringValue(input.ReasonNotes),
+	}
+}
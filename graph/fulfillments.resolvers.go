@@ -0,0 +1,206 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.84
+
+import (
+	"archie-core-shopify-layer/graph/model"
+	"context"
+	"fmt"
+)
+
+// ShopifyAcceptFulfillmentRequest is the resolver for the shopify_acceptFulfillmentRequest field.
+func (r *mutationResolver) ShopifyAcceptFulfillmentRequest(ctx context.Context, input model.FulfillmentRequestInput) (*model.FulfillmentOrder, error) {
+	var foid int64
+	if _, err := fmt.Sscanf(input.FulfillmentOrderID, "%d", &foid); err != nil {
+		return nil, invalidIDError("fulfillmentOrderId", err)
+	}
+
+	fulfillmentOrder, err := r.shopifyService.AcceptFulfillmentRequest(ctx, input.Domain, foid, stringValue(input.Message))
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelFulfillmentOrder(fulfillmentOrder), nil
+}
+
+// ShopifyRejectFulfillmentRequest is the resolver for the shopify_rejectFulfillmentRequest field.
+func (r *mutationResolver) ShopifyRejectFulfillmentRequest(ctx context.Context, input model.RejectFulfillmentRequestInput) (*model.FulfillmentOrder, error) {
+	foid, request, err := toShopifyFulfillmentRejection(input)
+	if err != nil {
+		return nil, err
+	}
+
+	fulfillmentOrder, err := r.shopifyService.RejectFulfillmentRequest(ctx, input.Domain, int64(foid), request)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelFulfillmentOrder(fulfillmentOrder), nil
+}
+
+// ShopifyHoldFulfillmentOrder is the resolver for the shopify_holdFulfillmentOrder field.
+func (r *mutationResolver) ShopifyHoldFulfillmentOrder(ctx context.Context, input model.HoldFulfillmentOrderInput) (*model.FulfillmentOrder, error) {
+	var foid int64
+	if _, err := fmt.Sscanf(input.FulfillmentOrderID, "%d", &foid); err != nil {
+		return nil, invalidIDError("fulfillmentOrderId", err)
+	}
+
+	fulfillmentOrder, err := r.shopifyService.HoldFulfillmentOrder(ctx, input.Domain, foid, toShopifyFulfillmentHold(input), boolValue(input.NotifyMerchant))
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelFulfillmentOrder(fulfillmentOrder), nil
+}
+
+// ShopifyReleaseFulfillmentOrderHold is the resolver for the shopify_releaseFulfillmentOrderHold field.
+func (r *mutationResolver) ShopifyReleaseFulfillmentOrderHold(ctx context.Context, domain string, fulfillmentOrderID string) (*model.FulfillmentOrder, error) {
+	var foid int64
+	if _, err := fmt.Sscanf(fulfillmentOrderID, "%d", &foid); err != nil {
+		return nil, invalidIDError("fulfillmentOrderId", err)
+	}
+
+	fulfillmentOrder, err := r.shopifyService.ReleaseFulfillmentOrderHold(ctx, domain, foid)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelFulfillmentOrder(fulfillmentOrder), nil
+}
+
+// ShopifyMoveFulfillmentOrder is the resolver for the shopify_moveFulfillmentOrder field.
+func (r *mutationResolver) ShopifyMoveFulfillmentOrder(ctx context.Context, input model.MoveFulfillmentOrderInput) (*model.FulfillmentOrderMoveResult, error) {
+	foid, move, err := toShopifyFulfillmentOrderMove(input)
+	if err != nil {
+		return nil, err
+	}
+
+	moved, err := r.shopifyService.MoveFulfillmentOrder(ctx, input.Domain, int64(foid), move)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.FulfillmentOrderMoveResult{
+		OriginalFulfillmentOrder: toModelFulfillmentOrder(&moved.OriginalFulfillmentOrder),
+		MovedFulfillmentOrder:    toModelFulfillmentOrder(&moved.MovedFulfillmentOrder),
+	}, nil
+}
+
+// ShopifyCreateFulfillment is the resolver for the shopify_createFulfillment field.
+func (r *mutationResolver) ShopifyCreateFulfillment(ctx context.Context, input model.CreateFulfillmentInput) (*model.Fulfillment, error) {
+	fulfillment, err := toShopifyFulfillment(input)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := r.shopifyService.CreateFulfillment(ctx, input.Domain, fulfillment)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelFulfillment(created), nil
+}
+
+// ShopifyUpdateFulfillmentTracking is the resolver for the shopify_updateFulfillmentTracking field.
+func (r *mutationResolver) ShopifyUpdateFulfillmentTracking(ctx context.Context, input model.UpdateFulfillmentTrackingInput) (*model.Fulfillment, error) {
+	var fid int64
+	if _, err := fmt.Sscanf(input.FulfillmentID, "%d", &fid); err != nil {
+		return nil, invalidIDError("fulfillmentId", err)
+	}
+
+	v := &inputValidator{}
+	tracking := fulfillmentTracking(v, "trackingInfo", input.TrackingInfo)
+	if err := v.result("invalid fulfillment tracking input"); err != nil {
+		return nil, err
+	}
+
+	updated, err := r.shopifyService.UpdateFulfillmentTracking(ctx, input.Domain, fid, tracking, boolValue(input.NotifyCustomer))
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelFulfillment(updated), nil
+}
+
+// ShopifyCancelFulfillment is the resolver for the shopify_cancelFulfillment field.
+func (r *mutationResolver) ShopifyCancelFulfillment(ctx context.Context, domain string, fulfillmentID string) (*model.Fulfillment, error) {
+	var fid int64
+	if _, err := fmt.Sscanf(fulfillmentID, "%d", &fid); err != nil {
+		return nil, invalidIDError("fulfillmentId", err)
+	}
+
+	cancelled, err := r.shopifyService.CancelFulfillment(ctx, domain, fid)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelFulfillment(cancelled), nil
+}
+
+// ShopifyFulfillmentOrders is the resolver for the shopify_fulfillmentOrders field.
+func (r *queryResolver) ShopifyFulfillmentOrders(ctx context.Context, domain string, orderID string, locationID *string) ([]*model.FulfillmentOrder, error) {
+	var oid, lid int64
+	if _, err := fmt.Sscanf(orderID, "%d", &oid); err != nil {
+		return nil, invalidIDError("orderId", err)
+	}
+	if locationID != nil {
+		if _, err := fmt.Sscanf(*locationID, "%d", &lid); err != nil {
+			return nil, invalidIDError("locationId", err)
+		}
+	}
+
+	fulfillmentOrders, err := r.shopifyService.ListFulfillmentOrders(ctx, domain, oid, lid)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelFulfillmentOrders(fulfillmentOrders), nil
+}
+
+// ShopifyAssignedFulfillmentOrders is the resolver for the shopify_assignedFulfillmentOrders field.
+func (r *queryResolver) ShopifyAssignedFulfillmentOrders(ctx context.Context, domain string, locationIds []string, assignmentStatus *model.FulfillmentOrderAssignmentStatus) ([]*model.FulfillmentOrder, error) {
+	options, err := toAssignedFulfillmentOrderOptions(locationIds, assignmentStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	fulfillmentOrders, err := r.shopifyService.ListAssignedFulfillmentOrders(ctx, domain, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelFulfillmentOrders(fulfillmentOrders), nil
+}
+
+// ShopifyFulfillmentOrder is the resolver for the shopify_fulfillmentOrder field.
+func (r *queryResolver) ShopifyFulfillmentOrder(ctx context.Context, domain string, fulfillmentOrderID string) (*model.FulfillmentOrder, error) {
+	var foid int64
+	if _, err := fmt.Sscanf(fulfillmentOrderID, "%d", &foid); err != nil {
+		return nil, invalidIDError("fulfillmentOrderId", err)
+	}
+
+	fulfillmentOrder, err := r.shopifyService.GetFulfillmentOrder(ctx, domain, foid)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelFulfillmentOrder(fulfillmentOrder), nil
+}
+
+// ShopifyOrderFulfillments is the resolver for the shopify_orderFulfillments field.
+func (r *queryResolver) ShopifyOrderFulfillments(ctx context.Context, domain string, orderID string) ([]*model.Fulfillment, error) {
+	var oid int64
+	if _, err := fmt.Sscanf(orderID, "%d", &oid); err != nil {
+		return nil, invalidIDError("orderId", err)
+	}
+
+	fulfillments, err := r.shopifyService.GetOrderFulfillments(ctx, domain, oid)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelFulfillments(fulfillments), nil
+}
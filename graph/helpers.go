@@ -2,8 +2,14 @@ package graph
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
+	"archie-core-shopify-layer/graph/model"
+	"archie-core-shopify-layer/graph/scalars"
 	"archie-core-shopify-layer/internal/domain"
+	"archie-core-shopify-layer/internal/infrastructure/pubsub"
 )
 
 // getTenantID extracts tenant ID (projectID) from context
@@ -26,3 +32,279 @@ func getEnvironment(ctx context.Context) string {
 	}
 	return domain.DefaultEnvironment // Default environment
 }
+
+// authorizeTenant checks that the authenticated principal may act on a project and environment
+// Empty arguments mean the principal's own project/environment and are always allowed
+func authorizeTenant(ctx context.Context, projectID string, environment string) error {
+	principal := domain.GetPrincipalFromContext(ctx)
+	if principal == nil {
+		return domain.NewUnauthorizedError("authentication required")
+	}
+
+	principalEnvironment := principal.Environment
+	if principalEnvironment == "" {
+		principalEnvironment = domain.DefaultEnvironment
+	}
+	if (projectID != "" && projectID != principal.ProjectID) || (environment != "" && environment != principalEnvironment) {
+		return domain.NewForbiddenError(fmt.Sprintf("not authorized for project %s and environment %s", projectID, environment))
+	}
+	return nil
+}
+
+// webhookEventFilter builds the pub/sub filter of a webhook subscription
+// Events are always limited to the principal's project and environment, and to its shop when it is bound to one
+func webhookEventFilter(ctx context.Context, filter *model.WebhookEventFilter) (*pubsub.WebhookEventFilter, error) {
+	principal := domain.GetPrincipalFromContext(ctx)
+	if principal == nil {
+		return nil, domain.NewUnauthorizedError("authentication required")
+	}
+
+	environment := principal.Environment
+	if environment == "" {
+		environment = getEnvironment(ctx)
+	}
+	result := &pubsub.WebhookEventFilter{
+		ProjectID:   principal.ProjectID,
+		Environment: environment,
+	}
+	if filter != nil {
+		result.Topics = filter.Topics
+		result.Shop = stringValue(filter.Shop)
+	}
+
+	if principal.ShopDomain != "" {
+		if result.Shop != "" && !strings.EqualFold(result.Shop, principal.ShopDomain) {
+			return nil, domain.NewForbiddenError("credential is bound to shop " + principal.ShopDomain)
+		}
+		result.Shop = principal.ShopDomain
+	}
+	return result, nil
+}
+
+// toModelShopifyConfig converts a domain Shopify config to its GraphQL model
+func toModelShopifyConfig(config *domain.ShopifyConfig) *model.ShopifyConfig {
+	return &model.ShopifyConfig{
+		ID:                    config.ID,
+		ProjectID:             config.ProjectID,
+		Environment:           config.Environment,
+		AppHandle:             config.AppHandle,
+		APIKey:                config.APIKey,
+		WebhookURL:            config.WebhookURL,
+		ExpiringOfflineTokens: config.ExpiringOfflineTokens,
+		CreatedAt:             scalars.Time(config.CreatedAt),
+		UpdatedAt:             scalars.Time(config.UpdatedAt),
+	}
+}
+
+// stringValue dereferences an optional string, returning "" for nil
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// intValue dereferences an optional int, returning 0 for nil
+func intValue(value *int) int {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
+// boolValue dereferences an optional bool, returning false for nil
+func boolValue(value *bool) bool {
+	if value == nil {
+		return false
+	}
+	return *value
+}
+
+// toModelShop converts a domain shop to its GraphQL model, including token status
+func toModelShop(shop *domain.Shop) *model.Shop {
+	result := &model.Shop{
+		ID:          shop.ID,
+		ProjectID:   shop.ProjectID,
+		Environment: shop.Environment,
+		Domain:      shop.Domain,
+		Scopes:      shop.Scopes,
+		AppHandle:   shop.AppHandle,
+		TokenStatus: model.TokenStatus(shop.TokenStatus(time.Now())),
+		CreatedAt:   scalars.Time(shop.CreatedAt),
+		UpdatedAt:   scalars.Time(shop.UpdatedAt),
+	}
+	if shop.AccessTokenExpiresAt != nil {
+		t := scalars.Time(*shop.AccessTokenExpiresAt)
+		result.AccessTokenExpiresAt = &t
+	}
+	if shop.RefreshTokenExpiresAt != nil {
+		t := scalars.Time(*shop.RefreshTokenExpiresAt)
+		result.RefreshTokenExpiresAt = &t
+	}
+	if shop.TokenRefreshedAt != nil {
+		t := scalars.Time(*shop.TokenRefreshedAt)
+		result.TokenRefreshedAt = &t
+	}
+	if shop.TokenRefreshError != "" {
+		result.TokenRefreshError = &shop.TokenRefreshError
+	}
+	return result
+}
+
+// toModelInstallation converts a domain installation to its GraphQL model
+func toModelInstallation(installation *domain.Installation) *model.Installation {
+	history := make([]*model.InstallTransition, len(installation.History))
+	for i, t := range installation.History {
+		transition := &model.InstallTransition{
+			To:     model.InstallState(t.To),
+			Reason: t.Reason,
+			At:     scalars.Time(t.At),
+		}
+		if t.From != "" {
+			from := model.InstallState(t.From)
+			transition.From = &from
+		}
+		history[i] = transition
+	}
+
+	result := &model.Installation{
+		ID:              installation.ID,
+		ProjectID:       installation.ProjectID,
+		Environment:     installation.Environment,
+		AppHandle:       installation.AppHandle,
+		ShopDomain:      installation.ShopDomain,
+		State:           model.InstallState(installation.State),
+		RequestedScopes: installation.RequestedScopes,
+		GrantedScopes:   installation.GrantedScopes,
+		MissingScopes:   domain.MissingScopes(installation.RequestedScopes, installation.GrantedScopes),
+		History:         history,
+		CreatedAt:       scalars.Time(installation.CreatedAt),
+		UpdatedAt:       scalars.Time(installation.UpdatedAt),
+	}
+	if result.RequestedScopes == nil {
+		result.RequestedScopes = []string{}
+	}
+	if result.GrantedScopes == nil {
+		result.GrantedScopes = []string{}
+	}
+	if installation.InstalledAt != nil {
+		t := scalars.Time(*installation.InstalledAt)
+		result.InstalledAt = &t
+	}
+	if installation.UninstalledAt != nil {
+		t := scalars.Time(*installation.UninstalledAt)
+		result.UninstalledAt = &t
+	}
+	return result
+}
+
+// toModelIntegration converts a domain integration to its GraphQL model
+// The plaintext key is only present right after it is issued
+func toModelIntegration(integration *domain.Integration) *model.Integration {
+	result := &model.Integration{
+		ID:          integration.ID,
+		KeyPrefix:   integration.KeyPrefix,
+		ProjectID:   integration.ProjectID,
+		Environment: integration.Environment,
+		ShopDomain:  integration.ShopDomain,
+		AppHandle:   integration.AppHandle,
+		Scopes:      integration.Scopes,
+		Status:      model.IntegrationStatus(integration.Status(time.Now())),
+		ExpiresAt:   optionalTime(integration.ExpiresAt),
+		LastUsedAt:  optionalTime(integration.LastUsedAt),
+		RevokedAt:   optionalTime(integration.RevokedAt),
+		CreatedAt:   scalars.Time(integration.CreatedAt),
+		UpdatedAt:   scalars.Time(integration.UpdatedAt),
+	}
+	if result.Scopes == nil {
+		result.Scopes = []string{}
+	}
+	if integration.Key != "" {
+		result.Key = &integration.Key
+	}
+	if integration.RotatedToID != "" {
+		result.RotatedToID = &integration.RotatedToID
+	}
+	return result
+}
+
+// optionalTime converts an optional domain time to an optional GraphQL time
+func optionalTime(value *time.Time) *scalars.Time {
+	if value == nil {
+		return nil
+	}
+	t := scalars.Time(*value)
+	return &t
+}
+
+// domainTime converts an optional GraphQL time to an optional domain time
+func domainTime(value *scalars.Time) *time.Time {
+	if value == nil {
+		return nil
+	}
+	t := time.Time(*value)
+	return &t
+}
+
+// toModelAPIUsage converts domain API usage to its GraphQL model
+func toModelAPIUsage(usage *domain.APIUsage) *model.APIUsage {
+	result := &model.APIUsage{
+		ProjectID: usage.ProjectID,
+		Tier: &model.RateLimitTier{
+			Name:                 usage.Tier.Name,
+			RequestsPerMinute:    optionalLimit(int64(usage.Tier.RequestsPerMinute)),
+			KeyRequestsPerMinute: optionalLimit(int64(usage.Tier.KeyRequestsPerMinute)),
+			DailyQuota:           optionalLimit(usage.Tier.DailyQuota),
+			MonthlyQuota:         optionalLimit(usage.Tier.MonthlyQuota),
+		},
+		Daily:   toModelUsageCounter(usage.Daily),
+		Monthly: toModelUsageCounter(usage.Monthly),
+	}
+	if usage.IntegrationID != "" {
+		result.IntegrationID = &usage.IntegrationID
+	}
+	return result
+}
+
+// toModelUsageCounter converts a domain usage counter to its GraphQL model
+func toModelUsageCounter(counter domain.UsageCounter) *model.UsageCounter {
+	result := &model.UsageCounter{
+		Period:   model.UsagePeriod(counter.Period),
+		Used:     int(counter.Used),
+		Limit:    optionalLimit(counter.Limit),
+		ResetsAt: scalars.Time(counter.ResetsAt),
+	}
+	if result.Limit != nil {
+		remaining := int(counter.Remaining())
+		result.Remaining = &remaining
+	}
+	return result
+}
+
+// optionalLimit converts a limit to an optional int, nil when unlimited (zero)
+func optionalLimit(value int64) *int {
+	if value <= 0 {
+		return nil
+	}
+	v := int(value)
+	return &v
+}
+
+// toModelStringSetDiff converts a domain set comparison to its GraphQL model
+func toModelStringSetDiff(diff domain.StringSetDiff) *model.StringSetDiff {
+	return &model.StringSetDiff{
+		OnlyInSource: diff.OnlyInSource,
+		OnlyInTarget: diff.OnlyInTarget,
+		Common:       diff.Common,
+	}
+}
+
+// invalidIDError reports an ID argument that is not a numeric Shopify ID
+func invalidIDError(field string, err error) error {
+	return domain.NewValidationError(fmt.Sprintf("invalid %s format", field), err).WithFieldError(field, "must be a numeric Shopify ID")
+}
+
+// invalidJSONError reports an input field that does not hold a valid JSON object
+func invalidJSONError(field string, err error) error {
+	return domain.NewValidationError(fmt.Sprintf("failed to parse %s JSON", field), err).WithFieldError(field, err.Error())
+}
@@ -0,0 +1,545 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"archie-core-shopify-layer/graph/model"
+	"archie-core-shopify-layer/internal/domain"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+	"github.com/shopspring/decimal"
+)
+
+// Shopify limits on product options and variants
+const (
+	maxProductOptions  = 3
+	maxProductVariants = 100
+)
+
+var (
+	countryCodePattern  = regexp.MustCompile(`^[A-Za-z]{2}$`)
+	currencyCodePattern = regexp.MustCompile(`^[A-Za-z]{3}$`)
+	phonePattern        = regexp.MustCompile(`^\+?[0-9 ().-]{7,20}$`)
+)
+
+// inputValidator collects the rejected fields of a mutation input
+type inputValidator struct {
+	fields []domain.FieldError
+}
+
+// reject records a rejected field, named by its path in the input such as variants[0].price
+func (v *inputValidator) reject(field string, message string) {
+	v.fields = append(v.fields, domain.FieldError{Field: field, Message: message})
+}
+
+// rejected reports whether a field was already rejected
+func (v *inputValidator) rejected(field string) bool {
+	for _, f := range v.fields {
+		if f.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// id parses an optional numeric Shopify ID, returning 0 when unset
+func (v *inputValidator) id(field string, value *string) uint64 {
+	if value == nil || *value == "" {
+		return 0
+	}
+	id, err := strconv.ParseUint(*value, 10, 64)
+	if err != nil || id == 0 {
+		v.reject(field, "must be a numeric Shopify ID")
+		return 0
+	}
+	return id
+}
+
+// amount parses an optional decimal amount, returning nil when unset
+func (v *inputValidator) amount(field string, value *string) *decimal.Decimal {
+	if value == nil {
+		return nil
+	}
+	amount, err := decimal.NewFromString(*value)
+	if err != nil {
+		v.reject(field, "must be a decimal amount such as 19.99")
+		return nil
+	}
+	return &amount
+}
+
+// email checks the format of an optional email address
+func (v *inputValidator) email(field string, value string) {
+	if value == "" {
+		return
+	}
+	if address, err := mail.ParseAddress(value); err != nil || address.Address != value {
+		v.reject(field, "must be a valid email address")
+	}
+}
+
+// phone checks the format of an optional phone number
+func (v *inputValidator) phone(field string, value string) {
+	if value != "" && !phonePattern.MatchString(value) {
+		v.reject(field, "must be a phone number such as +15145550123")
+	}
+}
+
+// countryCode checks an optional ISO 3166-1 alpha-2 country code
+func (v *inputValidator) countryCode(field string, value string) {
+	if value != "" && !countryCodePattern.MatchString(value) {
+		v.reject(field, "must be a two-letter country code")
+	}
+}
+
+// nonNegative checks that an optional amount is not negative
+func (v *inputValidator) nonNegative(field string, value *decimal.Decimal) {
+	if value != nil && value.IsNegative() && !v.rejected(field) {
+		v.reject(field, "must not be negative")
+	}
+}
+
+// requiredID rejects an update without an ID
+func (v *inputValidator) requiredID(id uint64) {
+	if id == 0 && !v.rejected("id") {
+		v.reject("id", "is required for updates")
+	}
+}
+
+// result returns a validation error listing the rejected fields, or nil when the input is valid
+func (v *inputValidator) result(message string) error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	appErr := domain.NewValidationError(message, nil)
+	appErr.Fields = v.fields
+	return appErr
+}
+
+// fieldPath names a field of a list element in an input, such as variants[0].price
+func fieldPath(list string, index int, field string) string {
+	return fmt.Sprintf("%s[%d].%s", list, index, field)
+}
+
+// shopifyEnum converts a GraphQL enum value to the lower-case value of the Shopify REST API
+func shopifyEnum(value string) string {
+	return strings.ToLower(value)
+}
+
+// decodeDeprecatedJSON decodes the deprecated JSON string of an input into target, which must still be empty
+// because the JSON string cannot be combined with the typed fields
+func decodeDeprecatedJSON(v *inputValidator, field string, value string, target interface{}) error {
+	if !reflect.ValueOf(target).Elem().IsZero() {
+		v.reject(field, "cannot be combined with the typed input fields")
+		return nil
+	}
+	if err := json.Unmarshal([]byte(value), target); err != nil {
+		return invalidJSONError(field, err)
+	}
+	return nil
+}
+
+// toShopifyProduct builds and validates the product of a create or update mutation, with the variant settings
+// the input sets explicitly
+// The deprecated product JSON is used when set, the typed fields otherwise
+func toShopifyProduct(input model.ProductInput, update bool) (*goshopify.Product, []domain.VariantSettings, error) {
+	v := &inputValidator{}
+	product, variantSettings := productFromInput(v, input)
+	if input.Product != nil {
+		if err := decodeDeprecatedJSON(v, "product", *input.Product, product); err != nil {
+			return nil, nil, err
+		}
+		variantSettings = variantSettingsFromJSON(*input.Product)
+	}
+
+	if update {
+		v.requiredID(product.Id)
+	} else if strings.TrimSpace(product.Title) == "" {
+		v.reject("title", "is required")
+	}
+
+	if len(product.Options) > maxProductOptions {
+		v.reject("options", fmt.Sprintf("at most %d options are allowed", maxProductOptions))
+	}
+	optionNames := map[string]bool{}
+	for i, option := range product.Options {
+		name := strings.ToLower(strings.TrimSpace(option.Name))
+		if name == "" {
+			v.reject(fieldPath("options", i, "name"), "is required")
+		} else if optionNames[name] {
+			v.reject(fieldPath("options", i, "name"), "must be unique")
+		}
+		optionNames[name] = true
+	}
+
+	if len(product.Variants) > maxProductVariants {
+		v.reject("variants", fmt.Sprintf("at most %d variants are allowed", maxProductVariants))
+	}
+	for i, variant := range product.Variants {
+		v.nonNegative(fieldPath("variants", i, "price"), variant.Price)
+		v.nonNegative(fieldPath("variants", i, "compareAtPrice"), variant.CompareAtPrice)
+		v.nonNegative(fieldPath("variants", i, "weight"), variant.Weight)
+	}
+
+	for i, image := range product.Images {
+		switch {
+		case image.Src != "" && image.Attachment != "":
+			v.reject(fieldPath("images", i, "src"), "cannot be combined with attachment")
+		case image.Src == "" && image.Attachment == "" && image.Id == 0:
+			v.reject(fieldPath("images", i, "src"), "a src or an attachment is required")
+		}
+	}
+
+	if err := v.result("invalid product input"); err != nil {
+		return nil, nil, err
+	}
+	return product, variantSettings, nil
+}
+
+// variantSettingsFromJSON returns the variant settings a deprecated product JSON sets explicitly
+func variantSettingsFromJSON(value string) []domain.VariantSettings {
+	var product struct {
+		Variants []struct {
+			RequiresShipping *bool `json:"requires_shipping"`
+			Taxable          *bool `json:"taxable"`
+		} `json:"variants"`
+	}
+	if err := json.Unmarshal([]byte(value), &product); err != nil {
+		return nil
+	}
+	settings := make([]domain.VariantSettings, len(product.Variants))
+	for i, variant := range product.Variants {
+		settings[i] = domain.VariantSettings{RequiresShipping: variant.RequiresShipping, Taxable: variant.Taxable}
+	}
+	return settings
+}
+
+// productFromInput converts the typed fields of a product input
+// requiresShipping and taxable are returned as variant settings, unset ones keep Shopify's default or current value
+func productFromInput(v *inputValidator, input model.ProductInput) (*goshopify.Product, []domain.VariantSettings) {
+	product := &goshopify.Product{
+		Id:          v.id("id", input.ID),
+		Title:       stringValue(input.Title),
+		BodyHTML:    stringValue(input.BodyHTML),
+		Vendor:      stringValue(input.Vendor),
+		ProductType: stringValue(input.ProductType),
+		Handle:      stringValue(input.Handle),
+		Tags:        strings.Join(input.Tags, ", "),
+	}
+	if input.Status != nil {
+		product.Status = goshopify.ProductStatus(shopifyEnum(string(*input.Status)))
+	}
+
+	for i, option := range input.Options {
+		product.Options = append(product.Options, goshopify.ProductOption{
+			Id:     v.id(fieldPath("options", i, "id"), option.ID),
+			Name:   option.Name,
+			Values: option.Values,
+		})
+	}
+
+	var variantSettings []domain.VariantSettings
+	for i, variant := range input.Variants {
+		converted := goshopify.Variant{
+			Id:                  v.id(fieldPath("variants", i, "id"), variant.ID),
+			Title:               stringValue(variant.Title),
+			Sku:                 stringValue(variant.Sku),
+			Barcode:             stringValue(variant.Barcode),
+			Price:               v.amount(fieldPath("variants", i, "price"), variant.Price),
+			CompareAtPrice:      v.amount(fieldPath("variants", i, "compareAtPrice"), variant.CompareAtPrice),
+			Option1:             stringValue(variant.Option1),
+			Option2:             stringValue(variant.Option2),
+			Option3:             stringValue(variant.Option3),
+			Position:            intValue(variant.Position),
+			InventoryManagement: stringValue(variant.InventoryManagement),
+		}
+		if variant.InventoryPolicy != nil {
+			converted.InventoryPolicy = goshopify.VariantInventoryPolicy(shopifyEnum(string(*variant.InventoryPolicy)))
+		}
+		if variant.Weight != nil {
+			weight := decimal.NewFromFloat(*variant.Weight)
+			converted.Weight = &weight
+		}
+		if variant.WeightUnit != nil {
+			converted.WeightUnit = shopifyEnum(string(*variant.WeightUnit))
+		}
+		product.Variants = append(product.Variants, converted)
+		variantSettings = append(variantSettings, domain.VariantSettings{
+			RequiresShipping: variant.RequiresShipping,
+			Taxable:          variant.Taxable,
+		})
+	}
+
+	for i, image := range input.Images {
+		product.Images = append(product.Images, goshopify.Image{
+			Id:         v.id(fieldPath("images", i, "id"), image.ID),
+			Src:        stringValue(image.Src),
+			Attachment: stringValue(image.Attachment),
+			Filename:   stringValue(image.Filename),
+			Alt:        stringValue(image.Alt),
+			Position:   intValue(image.Position),
+		})
+	}
+
+	return product, variantSettings
+}
+
+// toShopifyOrder builds and validates the order of a create or update mutation
+// The deprecated order JSON is used when set, the typed fields otherwise
+func toShopifyOrder(input model.OrderInput, update bool) (*goshopify.Order, error) {
+	v := &inputValidator{}
+	order := orderFromInput(v, input)
+	if input.Order != nil {
+		if err := decodeDeprecatedJSON(v, "order", *input.Order, order); err != nil {
+			return nil, err
+		}
+	}
+
+	if update {
+		v.requiredID(order.Id)
+	} else if len(order.LineItems) == 0 {
+		v.reject("lineItems", "at least one line item is required")
+	}
+	v.email("email", order.Email)
+	v.phone("phone", order.Phone)
+	if order.Currency != "" && !currencyCodePattern.MatchString(order.Currency) {
+		v.reject("currency", "must be a three-letter currency code")
+	}
+
+	for i, item := range order.LineItems {
+		if item.Quantity < 1 {
+			v.reject(fieldPath("lineItems", i, "quantity"), "must be at least 1")
+		}
+		if item.VariantId == 0 && !v.rejected(fieldPath("lineItems", i, "variantId")) && (item.Title == "" || item.Price == nil) {
+			v.reject(fieldPath("lineItems", i, "variantId"), "a variantId, or a title and a price, is required")
+		}
+		v.nonNegative(fieldPath("lineItems", i, "price"), item.Price)
+	}
+
+	if order.BillingAddress != nil {
+		v.countryCode("billingAddress.countryCode", order.BillingAddress.CountryCode)
+	}
+	if order.ShippingAddress != nil {
+		v.countryCode("shippingAddress.countryCode", order.ShippingAddress.CountryCode)
+	}
+
+	for i, transaction := range order.Transactions {
+		if transaction.Kind == "" {
+			v.reject(fieldPath("transactions", i, "kind"), "is required")
+		}
+		amountField := fieldPath("transactions", i, "amount")
+		if (transaction.Amount == nil || !transaction.Amount.IsPositive()) && !v.rejected(amountField) {
+			v.reject(amountField, "must be greater than zero")
+		}
+	}
+
+	if err := v.result("invalid order input"); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// orderFromInput converts the typed fields of an order input
+func orderFromInput(v *inputValidator, input model.OrderInput) *goshopify.Order {
+	order := &goshopify.Order{
+		Id:                     v.id("id", input.ID),
+		Email:                  stringValue(input.Email),
+		Phone:                  stringValue(input.Phone),
+		Note:                   stringValue(input.Note),
+		Tags:                   strings.Join(input.Tags, ", "),
+		Currency:               strings.ToUpper(stringValue(input.Currency)),
+		SendReceipt:            boolValue(input.SendReceipt),
+		SendFulfillmentReceipt: boolValue(input.SendFulfillmentReceipt),
+		Test:                   boolValue(input.Test),
+		BillingAddress:         addressFromInput(input.BillingAddress),
+		ShippingAddress:        addressFromInput(input.ShippingAddress),
+		NoteAttributes:         noteAttributesFromInput(input.NoteAttributes),
+	}
+	if input.FinancialStatus != nil {
+		order.FinancialStatus = goshopify.OrderFinancialStatus(shopifyEnum(string(*input.FinancialStatus)))
+	}
+	if input.InventoryBehaviour != nil {
+		order.InventoryBehaviour = goshopify.OrderInventoryBehaviour(shopifyEnum(string(*input.InventoryBehaviour)))
+	}
+	if input.CustomerID != nil {
+		order.Customer = &goshopify.Customer{Id: v.id("customerId", input.CustomerID)}
+	}
+
+	for i, item := range input.LineItems {
+		order.LineItems = append(order.LineItems, goshopify.LineItem{
+			VariantId:        v.id(fieldPath("lineItems", i, "variantId"), item.VariantID),
+			Title:            stringValue(item.Title),
+			Price:            v.amount(fieldPath("lineItems", i, "price"), item.Price),
+			Quantity:         item.Quantity,
+			SKU:              stringValue(item.Sku),
+			Grams:            intValue(item.Grams),
+			Taxable:          boolValue(item.Taxable),
+			RequiresShipping: boolValue(item.RequiresShipping),
+			Properties:       noteAttributesFromInput(item.Properties),
+		})
+	}
+
+	for i, transaction := range input.Transactions {
+		converted := goshopify.Transaction{
+			Kind:          shopifyEnum(string(transaction.Kind)),
+			Amount:        v.amount(fieldPath("transactions", i, "amount"), &transaction.Amount),
+			Gateway:       stringValue(transaction.Gateway),
+			Currency:      strings.ToUpper(stringValue(transaction.Currency)),
+			Authorization: stringValue(transaction.Authorization),
+		}
+		if transaction.Status != nil {
+			converted.Status = shopifyEnum(string(*transaction.Status))
+		}
+		order.Transactions = append(order.Transactions, converted)
+	}
+
+	return order
+}
+
+// addressFromInput converts an optional address input
+func addressFromInput(input *model.AddressInput) *goshopify.Address {
+	if input == nil {
+		return nil
+	}
+	return &goshopify.Address{
+		FirstName:    stringValue(input.FirstName),
+		LastName:     stringValue(input.LastName),
+		Company:      stringValue(input.Company),
+		Address1:     stringValue(input.Address1),
+		Address2:     stringValue(input.Address2),
+		City:         stringValue(input.City),
+		Province:     stringValue(input.Province),
+		ProvinceCode: stringValue(input.ProvinceCode),
+		Country:      stringValue(input.Country),
+		CountryCode:  strings.ToUpper(stringValue(input.CountryCode)),
+		Zip:          stringValue(input.Zip),
+		Phone:        stringValue(input.Phone),
+	}
+}
+
+// noteAttributesFromInput converts name/value attribute inputs
+func noteAttributesFromInput(inputs []*model.NoteAttributeInput) []goshopify.NoteAttribute {
+	var attributes []goshopify.NoteAttribute
+	for _, input := range inputs {
+		attributes = append(attributes, goshopify.NoteAttribute{Name: input.Name, Value: input.Value})
+	}
+	return attributes
+}
+
+// toShopifyCustomer builds and validates the customer of a create or update mutation
+// The deprecated customer JSON is used when set, the typed fields otherwise
+func toShopifyCustomer(input model.CustomerInput, update bool) (*goshopify.Customer, error) {
+	v := &inputValidator{}
+	customer := customerFromInput(v, input)
+	if input.Customer != nil {
+		if err := decodeDeprecatedJSON(v, "customer", *input.Customer, customer); err != nil {
+			return nil, err
+		}
+	}
+
+	if update {
+		v.requiredID(customer.Id)
+	} else if customer.Email == "" && customer.Phone == "" && customer.FirstName == "" && customer.LastName == "" {
+		v.reject("email", "an email, phone or name is required")
+	}
+	v.email("email", customer.Email)
+	v.phone("phone", customer.Phone)
+
+	defaults := 0
+	for i, address := range customer.Addresses {
+		if address == nil {
+			continue
+		}
+		v.countryCode(fieldPath("addresses", i, "countryCode"), address.CountryCode)
+		if address.Default {
+			defaults++
+		}
+	}
+	if defaults > 1 {
+		v.reject("addresses", "only one address can be the default")
+	}
+
+	if consent := customer.EmailMarketingConsent; consent != nil {
+		if consent.State == "" {
+			v.reject("emailMarketingConsent.state", "is required")
+		}
+		if !update && customer.Email == "" {
+			v.reject("emailMarketingConsent", "requires an email")
+		}
+	}
+	if consent := customer.SMSMarketingConsent; consent != nil {
+		if consent.State == "" {
+			v.reject("smsMarketingConsent.state", "is required")
+		}
+		if !update && customer.Phone == "" {
+			v.reject("smsMarketingConsent", "requires a phone number")
+		}
+	}
+
+	if err := v.result("invalid customer input"); err != nil {
+		return nil, err
+	}
+	return customer, nil
+}
+
+// customerFromInput converts the typed fields of a customer input
+func customerFromInput(v *inputValidator, input model.CustomerInput) *goshopify.Customer {
+	customer := &goshopify.Customer{
+		Id:            v.id("id", input.ID),
+		Email:         stringValue(input.Email),
+		Phone:         stringValue(input.Phone),
+		FirstName:     stringValue(input.FirstName),
+		LastName:      stringValue(input.LastName),
+		Note:          stringValue(input.Note),
+		Tags:          strings.Join(input.Tags, ", "),
+		TaxExempt:     boolValue(input.TaxExempt),
+		VerifiedEmail: boolValue(input.VerifiedEmail),
+	}
+
+	for i, address := range input.Addresses {
+		customer.Addresses = append(customer.Addresses, &goshopify.CustomerAddress{
+			Id:           v.id(fieldPath("addresses", i, "id"), address.ID),
+			FirstName:    stringValue(address.FirstName),
+			LastName:     stringValue(address.LastName),
+			Company:      stringValue(address.Company),
+			Address1:     stringValue(address.Address1),
+			Address2:     stringValue(address.Address2),
+			City:         stringValue(address.City),
+			Province:     stringValue(address.Province),
+			ProvinceCode: stringValue(address.ProvinceCode),
+			Country:      stringValue(address.Country),
+			CountryCode:  strings.ToUpper(stringValue(address.CountryCode)),
+			Zip:          stringValue(address.Zip),
+			Phone:        stringValue(address.Phone),
+			Default:      boolValue(address.Default),
+		})
+	}
+
+	if consent := input.EmailMarketingConsent; consent != nil {
+		customer.EmailMarketingConsent = &goshopify.EmailMarketingConsent{
+			State:            shopifyEnum(string(consent.State)),
+			ConsentUpdatedAt: domainTime(consent.ConsentUpdatedAt),
+		}
+		if consent.OptInLevel != nil {
+			customer.EmailMarketingConsent.OptInLevel = shopifyEnum(string(*consent.OptInLevel))
+		}
+	}
+	if consent := input.SmsMarketingConsent; consent != nil {
+		customer.SMSMarketingConsent = &goshopify.SMSMarketingConsent{
+			State:                shopifyEnum(string(consent.State)),
+			ConsentUpdatedAt:     domainTime(consent.ConsentUpdatedAt),
+			ConsentCollectedFrom: stringValue(consent.ConsentCollectedFrom),
+		}
+		if consent.OptInLevel != nil {
+			customer.SMSMarketingConsent.OptInLevel = shopifyEnum(string(*consent.OptInLevel))
+		}
+	}
+
+	return customer
+}
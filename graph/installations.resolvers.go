@@ -0,0 +1,56 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.84
+
+import (
+	"archie-core-shopify-layer/graph/model"
+	"archie-core-shopify-layer/internal/domain"
+	"context"
+)
+
+// ShopifyInstallations is the resolver for the shopify_installations field.
+func (r *queryResolver) ShopifyInstallations(ctx context.Context, state *model.InstallState) ([]*model.Installation, error) {
+	tenantID := getTenantID(ctx)
+	if tenantID == "" {
+		return nil, domain.NewValidationError("project ID is required", nil)
+	}
+
+	var filter *domain.InstallState
+	if state != nil {
+		s := domain.InstallState(*state)
+		filter = &s
+	}
+
+	installations, err := r.installationService.ListInstallations(ctx, tenantID, getEnvironment(ctx), filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.Installation, len(installations))
+	for i, installation := range installations {
+		result[i] = toModelInstallation(installation)
+	}
+
+	return result, nil
+}
+
+// ShopifyInstallation is the resolver for the shopify_installation field.
+func (r *queryResolver) ShopifyInstallation(ctx context.Context, shopDomain string) (*model.Installation, error) {
+	tenantID := getTenantID(ctx)
+	if tenantID == "" {
+		return nil, domain.NewValidationError("project ID is required", nil)
+	}
+
+	installation, err := r.installationService.GetInstallation(ctx, domain.NewShopKey(tenantID, getEnvironment(ctx), domain.GetAppHandleFromContext(ctx), shopDomain))
+	if err != nil {
+		return nil, err
+	}
+	if installation == nil {
+		return nil, nil
+	}
+
+	return toModelInstallation(installation), nil
+}
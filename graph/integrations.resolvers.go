@@ -0,0 +1,77 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.84
+
+import (
+	"archie-core-shopify-layer/graph/model"
+	"archie-core-shopify-layer/graph/scalars"
+	"archie-core-shopify-layer/internal/application"
+	"archie-core-shopify-layer/internal/domain"
+	"context"
+	"time"
+)
+
+// RotateIntegrationKey is the resolver for the rotateIntegrationKey field.
+func (r *mutationResolver) RotateIntegrationKey(ctx context.Context, id string, overlapSeconds *int, scopes []string, expiresAt *scalars.Time) (*model.CreateIntegrationPayload, error) {
+	if err := r.authorizeIntegration(ctx, id); err != nil {
+		return nil, err
+	}
+
+	overlap := domain.DefaultKeyRotationOverlap
+	if overlapSeconds != nil {
+		if *overlapSeconds < 0 {
+			return nil, domain.NewValidationError("overlapSeconds cannot be negative", nil).WithFieldError("overlapSeconds", "must not be negative")
+		}
+		overlap = time.Duration(*overlapSeconds) * time.Second
+	}
+
+	integration, err := r.integrationService.RotateIntegration(ctx, application.RotateIntegrationInput{
+		ID:        id,
+		Overlap:   overlap,
+		Scopes:    scopes,
+		ExpiresAt: domainTime(expiresAt),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.CreateIntegrationPayload{
+		Integration: toModelIntegration(integration),
+	}, nil
+}
+
+// RevokeIntegration is the resolver for the revokeIntegration field.
+func (r *mutationResolver) RevokeIntegration(ctx context.Context, id string) (*model.Integration, error) {
+	if err := r.authorizeIntegration(ctx, id); err != nil {
+		return nil, err
+	}
+
+	integration, err := r.integrationService.RevokeIntegration(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelIntegration(integration), nil
+}
+
+// ShopifyIntegrations is the resolver for the shopify_integrations field.
+func (r *queryResolver) ShopifyIntegrations(ctx context.Context, projectID string, environment string) ([]*model.Integration, error) {
+	if err := authorizeTenant(ctx, projectID, environment); err != nil {
+		return nil, err
+	}
+
+	integrations, err := r.integrationService.ListIntegrations(ctx, projectID, environment)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.Integration, len(integrations))
+	for i, integration := range integrations {
+		result[i] = toModelIntegration(integration)
+	}
+
+	return result, nil
+}
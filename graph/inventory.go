@@ -0,0 +1,197 @@
+package graph
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"archie-core-shopify-layer/graph/model"
+	"archie-core-shopify-layer/graph/scalars"
+	"archie-core-shopify-layer/internal/application"
+	"archie-core-shopify-layer/internal/domain"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+)
+
+// toModelLocation converts a Shopify location
+func toModelLocation(location *goshopify.Location) *model.Location {
+	return &model.Location{
+		ID:           shopifyID(location.Id),
+		Name:         location.Name,
+		Active:       location.Active,
+		Legacy:       location.Legacy,
+		Address1:     optionalString(location.Address1),
+		Address2:     optionalString(location.Address2),
+		City:         optionalString(location.City),
+		Province:     optionalString(location.Province),
+		ProvinceCode: optionalString(location.ProvinceCode),
+		Country:      optionalString(location.Country),
+		CountryCode:  optionalString(location.CountryCode),
+		Zip:          optionalString(location.Zip),
+		Phone:        optionalString(location.Phone),
+		CreatedAt:    locationTime(location.CreatedAt),
+		UpdatedAt:    locationTime(location.UpdatedAt),
+	}
+}
+
+// toModelLocations converts Shopify locations, only the active ones when activeOnly is set
+func toModelLocations(locations []goshopify.Location, activeOnly bool) []*model.Location {
+	result := make([]*model.Location, 0, len(locations))
+	for i := range locations {
+		if activeOnly && !locations[i].Active {
+			continue
+		}
+		result = append(result, toModelLocation(&locations[i]))
+	}
+	return result
+}
+
+// locationTime converts the non-pointer times of Shopify locations, nil when unset
+func locationTime(value time.Time) *scalars.Time {
+	if value.IsZero() {
+		return nil
+	}
+	return optionalTime(&value)
+}
+
+// toModelInventoryItem converts a Shopify inventory item
+func toModelInventoryItem(item *goshopify.InventoryItem) *model.InventoryItem {
+	return &model.InventoryItem{
+		ID:                           shopifyID(item.Id),
+		Sku:                          optionalString(item.SKU),
+		Cost:                         decimalString(item.Cost),
+		Tracked:                      item.Tracked != nil && *item.Tracked,
+		CountryCodeOfOrigin:          item.CountryCodeOfOrigin,
+		ProvinceCodeOfOrigin:         item.ProvinceCodeOfOrigin,
+		HarmonizedSystemCode:         item.HarmonizedSystemCode,
+		CountryHarmonizedSystemCodes: nonNilStrings(item.CountryHarmonizedSystemCodes),
+		CreatedAt:                    optionalTime(item.CreatedAt),
+		UpdatedAt:                    optionalTime(item.UpdatedAt),
+	}
+}
+
+// toModelInventoryItems converts Shopify inventory items
+func toModelInventoryItems(items []goshopify.InventoryItem) []*model.InventoryItem {
+	result := make([]*model.InventoryItem, len(items))
+	for i := range items {
+		result[i] = toModelInventoryItem(&items[i])
+	}
+	return result
+}
+
+// toModelInventoryLevel converts a Shopify inventory level
+func toModelInventoryLevel(level *goshopify.InventoryLevel) *model.InventoryLevel {
+	return toModelInventoryLevels([]goshopify.InventoryLevel{*level})[0]
+}
+
+// toModelVariantInventory converts a variant joined to its stock at each location
+func toModelVariantInventory(inventory *application.VariantInventory) *model.VariantInventory {
+	result := &model.VariantInventory{
+		VariantID: shopifyID(inventory.Variant.Id),
+		ProductID: shopifyID(inventory.Variant.ProductId),
+		Title:     inventory.Variant.Title,
+		Sku:       optionalString(inventory.Variant.Sku),
+		Levels:    make([]*model.LocationInventoryLevel, len(inventory.Levels)),
+	}
+	if inventory.InventoryItem != nil {
+		result.InventoryItem = toModelInventoryItem(inventory.InventoryItem)
+	}
+	for i, level := range inventory.Levels {
+		converted := &model.LocationInventoryLevel{Location: toModelLocation(&level.Location)}
+		if level.Level != nil {
+			available := level.Level.Available
+			converted.Stocked = true
+			converted.Available = &available
+			converted.UpdatedAt = optionalTime(level.Level.UpdatedAt)
+			result.TotalAvailable += available
+		}
+		result.Levels[i] = converted
+	}
+	return result
+}
+
+// toShopifyInventoryItem validates an inventory item input and applies it to the current inventory item
+func toShopifyInventoryItem(input model.InventoryItemInput, current *goshopify.InventoryItem) (*goshopify.InventoryItem, error) {
+	v := &inputValidator{}
+	item := &goshopify.InventoryItem{
+		Id:                   current.Id,
+		CountryCodeOfOrigin:  current.CountryCodeOfOrigin,
+		ProvinceCodeOfOrigin: current.ProvinceCodeOfOrigin,
+		HarmonizedSystemCode: current.HarmonizedSystemCode,
+	}
+	if input.Sku != nil {
+		item.SKU = strings.TrimSpace(*input.Sku)
+	}
+	cost := v.amount("cost", input.Cost)
+	v.nonNegative("cost", cost)
+	item.Cost = cost
+	item.Tracked = input.Tracked
+	if input.CountryCodeOfOrigin != nil {
+		code := strings.ToUpper(strings.TrimSpace(*input.CountryCodeOfOrigin))
+		v.countryCode("countryCodeOfOrigin", code)
+		item.CountryCodeOfOrigin = &code
+	}
+	if input.ProvinceCodeOfOrigin != nil {
+		code := strings.ToUpper(strings.TrimSpace(*input.ProvinceCodeOfOrigin))
+		item.ProvinceCodeOfOrigin = &code
+	}
+	if input.HarmonizedSystemCode != nil {
+		code := strings.TrimSpace(*input.HarmonizedSystemCode)
+		if code != "" && (len(code) < 6 || len(code) > 13) {
+			v.reject("harmonizedSystemCode", "must have between 6 and 13 digits")
+		}
+		item.HarmonizedSystemCode = &code
+	}
+	if err := v.result("invalid inventory item input"); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// inventoryLevelIDs parses the inventory item and location IDs of an inventory level input
+func inventoryLevelIDs(inventoryItemID string, locationID string) (int64, int64, error) {
+	v := &inputValidator{}
+	itemID := requiredInputID(v, "inventoryItemId", inventoryItemID)
+	lid := requiredInputID(v, "locationId", locationID)
+	if err := v.result("invalid inventory level input"); err != nil {
+		return 0, 0, err
+	}
+	return int64(itemID), int64(lid), nil
+}
+
+// toInventoryAdjustment validates a stock adjustment input
+func toInventoryAdjustment(input model.AdjustInventoryInput) (domain.InventoryAdjustment, error) {
+	v := &inputValidator{}
+	adjustment := domain.InventoryAdjustment{
+		InventoryItemID: requiredInputID(v, "inventoryItemId", input.InventoryItemID),
+		LocationID:      requiredInputID(v, "locationId", input.LocationID),
+		Delta:           input.Delta,
+		Reason:          domain.InventoryAdjustmentReason(shopifyEnum(string(input.Reason))),
+		Reference:       strings.TrimSpace(stringValue(input.Reference)),
+	}
+	if input.Delta == 0 {
+		v.reject("delta", "must not be zero")
+	}
+	if len(adjustment.Reference) > 255 {
+		v.reject("reference", "must be at most 255 characters")
+	} else if adjustment.Reference != "" {
+		// Shopify keeps the reference as the adjustment's reference document URI
+		if reference, err := url.Parse(adjustment.Reference); err != nil || reference.Scheme == "" {
+			v.reject("reference", "must be a URI, e.g. gid://shopify/Order/1 or https://erp.example.com/po/123")
+		}
+	}
+	if err := v.result("invalid inventory adjustment input"); err != nil {
+		return adjustment, err
+	}
+	return adjustment, nil
+}
+
+// toModelInventoryAdjustment converts the result of a stock adjustment
+func toModelInventoryAdjustment(adjustment domain.InventoryAdjustment, level *goshopify.InventoryLevel) *model.InventoryAdjustment {
+	return &model.InventoryAdjustment{
+		InventoryLevel: toModelInventoryLevel(level),
+		Delta:          adjustment.Delta,
+		Reason:         model.InventoryAdjustmentReason(strings.ToUpper(string(adjustment.Reason))),
+		Reference:      optionalString(adjustment.Reference),
+	}
+}
@@ -0,0 +1,172 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.84
+
+import (
+	"archie-core-shopify-layer/graph/model"
+	"context"
+	"fmt"
+)
+
+// ShopifyUpdateInventoryItem is the resolver for the shopify_updateInventoryItem field.
+func (r *mutationResolver) ShopifyUpdateInventoryItem(ctx context.Context, input model.InventoryItemInput) (*model.InventoryItem, error) {
+	v := &inputValidator{}
+	id := requiredInputID(v, "id", input.ID)
+	if err := v.result("invalid inventory item input"); err != nil {
+		return nil, err
+	}
+
+	current, err := r.shopifyService.GetInventoryItem(ctx, input.Domain, int64(id))
+	if err != nil {
+		return nil, err
+	}
+	item, err := toShopifyInventoryItem(input, current)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := r.shopifyService.UpdateInventoryItem(ctx, input.Domain, item)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelInventoryItem(updated), nil
+}
+
+// ShopifySetInventoryLevel is the resolver for the shopify_setInventoryLevel field.
+func (r *mutationResolver) ShopifySetInventoryLevel(ctx context.Context, input model.SetInventoryLevelInput) (*model.InventoryLevel, error) {
+	v := &inputValidator{}
+	itemID := requiredInputID(v, "inventoryItemId", input.InventoryItemID)
+	locationID := requiredInputID(v, "locationId", input.LocationID)
+	if input.Available < 0 {
+		v.reject("available", "must not be negative")
+	}
+	if err := v.result("invalid inventory level input"); err != nil {
+		return nil, err
+	}
+
+	level, err := r.shopifyService.SetInventoryLevel(ctx, input.Domain, int64(itemID), int64(locationID), input.Available, boolValue(input.DisconnectIfNecessary))
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelInventoryLevel(level), nil
+}
+
+// ShopifyAdjustInventory is the resolver for the shopify_adjustInventory field.
+func (r *mutationResolver) ShopifyAdjustInventory(ctx context.Context, input model.AdjustInventoryInput) (*model.InventoryAdjustment, error) {
+	adjustment, err := toInventoryAdjustment(input)
+	if err != nil {
+		return nil, err
+	}
+
+	level, err := r.shopifyService.AdjustInventory(ctx, input.Domain, adjustment)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelInventoryAdjustment(adjustment, level), nil
+}
+
+// ShopifyConnectInventoryLevel is the resolver for the shopify_connectInventoryLevel field.
+func (r *mutationResolver) ShopifyConnectInventoryLevel(ctx context.Context, input model.InventoryLevelLocationInput) (*model.InventoryLevel, error) {
+	itemID, locationID, err := inventoryLevelIDs(input.InventoryItemID, input.LocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	level, err := r.shopifyService.ConnectInventoryLevel(ctx, input.Domain, itemID, locationID, boolValue(input.RelocateIfNecessary))
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelInventoryLevel(level), nil
+}
+
+// ShopifyDisconnectInventoryLevel is the resolver for the shopify_disconnectInventoryLevel field.
+func (r *mutationResolver) ShopifyDisconnectInventoryLevel(ctx context.Context, domain string, inventoryItemID string, locationID string) (bool, error) {
+	itemID, lid, err := inventoryLevelIDs(inventoryItemID, locationID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.shopifyService.DisconnectInventoryLevel(ctx, domain, itemID, lid); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ShopifyLocations is the resolver for the shopify_locations field.
+func (r *queryResolver) ShopifyLocations(ctx context.Context, domain string, activeOnly *bool) ([]*model.Location, error) {
+	locations, err := r.shopifyService.ListLocations(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelLocations(locations, boolValue(activeOnly)), nil
+}
+
+// ShopifyLocation is the resolver for the shopify_location field.
+func (r *queryResolver) ShopifyLocation(ctx context.Context, domain string, locationID string) (*model.Location, error) {
+	var lid int64
+	if _, err := fmt.Sscanf(locationID, "%d", &lid); err != nil {
+		return nil, invalidIDError("locationId", err)
+	}
+
+	location, err := r.shopifyService.GetLocation(ctx, domain, lid)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelLocation(location), nil
+}
+
+// ShopifyInventoryItems is the resolver for the shopify_inventoryItems field.
+func (r *queryResolver) ShopifyInventoryItems(ctx context.Context, domain string, ids []string) ([]*model.InventoryItem, error) {
+	v := &inputValidator{}
+	itemIDs := filterIDs(v, "ids", ids)
+	if err := v.result("invalid inventory items query"); err != nil {
+		return nil, err
+	}
+
+	items, err := r.shopifyService.ListInventoryItems(ctx, domain, itemIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelInventoryItems(items), nil
+}
+
+// ShopifyInventoryItem is the resolver for the shopify_inventoryItem field.
+func (r *queryResolver) ShopifyInventoryItem(ctx context.Context, domain string, inventoryItemID string) (*model.InventoryItem, error) {
+	var itemID int64
+	if _, err := fmt.Sscanf(inventoryItemID, "%d", &itemID); err != nil {
+		return nil, invalidIDError("inventoryItemId", err)
+	}
+
+	item, err := r.shopifyService.GetInventoryItem(ctx, domain, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelInventoryItem(item), nil
+}
+
+// ShopifyVariantInventory is the resolver for the shopify_variantInventory field.
+func (r *queryResolver) ShopifyVariantInventory(ctx context.Context, domain string, variantID string) (*model.VariantInventory, error) {
+	var vid int64
+	if _, err := fmt.Sscanf(variantID, "%d", &vid); err != nil {
+		return nil, invalidIDError("variantId", err)
+	}
+
+	inventory, err := r.shopifyService.GetVariantInventory(ctx, domain, vid)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelVariantInventory(inventory), nil
+}
@@ -0,0 +1,75 @@
+package graph
+
+import (
+	"context"
+	"strings"
+
+	"archie-core-shopify-layer/internal/domain"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// shopArguments are the arguments, at the top level or in input, that name the shop a root field acts on
+var shopArguments = []string{"domain", "shopDomain", "shop"}
+
+// KeyScopeGuard rejects root fields outside the scopes of the caller's integration key, and root fields acting on
+// another shop than the one the caller's credential is bound to
+// Introspection fields are always allowed so clients can load the schema
+func KeyScopeGuard(ctx context.Context, next graphql.RootResolver) graphql.Marshaler {
+	field := graphql.GetRootFieldContext(ctx)
+	principal := domain.GetPrincipalFromContext(ctx)
+	if field == nil || principal == nil || strings.HasPrefix(field.Field.Name, "__") {
+		return next(ctx)
+	}
+
+	if !principal.AllowsGraphQLField(field.Field.Name) {
+		return rejectRootField(ctx, "integration key is not allowed to call "+field.Field.Name)
+	}
+	if principal.ShopDomain != "" {
+		for _, shop := range rootFieldShops(ctx, field) {
+			if !strings.EqualFold(shop, principal.ShopDomain) {
+				return rejectRootField(ctx, "credential is bound to shop "+principal.ShopDomain)
+			}
+		}
+	}
+	return next(ctx)
+}
+
+// rejectRootField adds a FORBIDDEN error for the root field and resolves it to null
+func rejectRootField(ctx context.Context, message string) graphql.Marshaler {
+	graphql.AddError(ctx, &gqlerror.Error{
+		Path:       graphql.GetPath(ctx),
+		Message:    message,
+		Extensions: map[string]interface{}{"code": errorCodeForbidden},
+	})
+	return graphql.Null
+}
+
+// rootFieldShops returns the shops a root field names in its arguments or its input, with variables resolved
+func rootFieldShops(ctx context.Context, field *graphql.RootFieldContext) []string {
+	arguments := field.Field.ArgumentMap(graphql.GetOperationContext(ctx).Variables)
+
+	var shops []string
+	collect := func(values map[string]interface{}) {
+		for _, name := range shopArguments {
+			if shop, ok := values[name].(string); ok {
+				shops = append(shops, shop)
+			}
+		}
+	}
+	collect(arguments)
+	if input, ok := arguments["input"].(map[string]interface{}); ok {
+		collect(input)
+	}
+	return shops
+}
+
+// authorizeIntegration checks that the caller may manage an integration
+func (r *Resolver) authorizeIntegration(ctx context.Context, id string) error {
+	integration, err := r.integrationService.GetIntegration(ctx, id)
+	if err != nil {
+		return err
+	}
+	return authorizeTenant(ctx, integration.ProjectID, integration.Environment)
+}
@@ -0,0 +1,481 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"archie-core-shopify-layer/graph/model"
+	"archie-core-shopify-layer/internal/domain"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+)
+
+// metafieldListOptions are the Shopify options of shopify_metafields
+type metafieldListOptions struct {
+	goshopify.ListOptions
+	Namespace string `url:"namespace,omitempty"`
+	Key       string `url:"key,omitempty"`
+}
+
+// toMetafieldListOptions builds the Shopify options of shopify_metafields
+func toMetafieldListOptions(request pageRequest, namespace *string, key *string) *metafieldListOptions {
+	options := &metafieldListOptions{ListOptions: request.options}
+	if request.filtered {
+		options.Namespace = stringValue(namespace)
+		options.Key = stringValue(key)
+	}
+	return options
+}
+
+// metafieldOwnerType converts a metafield owner type enum
+func metafieldOwnerType(ownerType model.MetafieldOwnerType) domain.MetafieldOwnerType {
+	return domain.MetafieldOwnerType(shopifyEnum(string(ownerType)))
+}
+
+// metafieldOwnerFromInput validates the owner of a metafield, the ID is required except for the shop
+func metafieldOwnerFromInput(v *inputValidator, field string, input *model.MetafieldOwnerInput) domain.MetafieldOwner {
+	if input == nil {
+		v.reject(field, "is required")
+		return domain.MetafieldOwner{}
+	}
+	owner := domain.MetafieldOwner{Type: metafieldOwnerType(input.Type)}
+	if owner.Type == domain.MetafieldOwnerShop {
+		return owner
+	}
+	owner.ID = requiredInputID(v, field+".id", stringValue(input.ID))
+	return owner
+}
+
+// shopMetafieldOwner is the owner of the metafields of the shop itself
+var shopMetafieldOwner = domain.MetafieldOwner{Type: domain.MetafieldOwnerShop}
+
+// resourceOwner builds the owner of the metafields of a resource resolved from Shopify
+func resourceOwner(ownerType model.MetafieldOwnerType, id string) (domain.MetafieldOwner, error) {
+	ownerID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return domain.MetafieldOwner{}, fmt.Errorf("invalid %s id %q: %w", metafieldOwnerType(ownerType), id, err)
+	}
+	return domain.MetafieldOwner{Type: metafieldOwnerType(ownerType), ID: ownerID}, nil
+}
+
+// metafieldValueFromInput returns value, or jsonValue encoded as the JSON text Shopify expects, reporting failures on
+// jsonField. Strings are used as they are so text and reference types can be given either way
+func metafieldValueFromInput(v *inputValidator, jsonField string, value *string, jsonValue any) (string, bool) {
+	switch {
+	case value != nil && jsonValue != nil:
+		v.reject(jsonField, "cannot be combined with value")
+	case value != nil:
+		return *value, true
+	case jsonValue != nil:
+		if text, ok := jsonValue.(string); ok {
+			return text, true
+		}
+		encoded, err := json.Marshal(jsonValue)
+		if err != nil {
+			v.reject(jsonField, "must be a JSON value")
+			return "", false
+		}
+		return string(encoded), true
+	}
+	return "", false
+}
+
+// metafieldValueString returns a metafield value as stored by Shopify, which returns numbers and booleans unquoted
+func metafieldValueString(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
+
+// toModelMetafield converts a Shopify metafield
+func toModelMetafield(metafield *goshopify.Metafield) *model.Metafield {
+	value := metafieldValueString(metafield.Value)
+	result := &model.Metafield{
+		ID:          shopifyID(metafield.Id),
+		Namespace:   metafield.Namespace,
+		Key:         metafield.Key,
+		Type:        string(metafield.Type),
+		Value:       value,
+		JSONValue:   domain.DecodeMetafieldValue(string(metafield.Type), value),
+		Description: optionalString(metafield.Description),
+		OwnerID:     optionalID(metafield.OwnerId),
+		CreatedAt:   optionalTime(metafield.CreatedAt),
+		UpdatedAt:   optionalTime(metafield.UpdatedAt),
+	}
+	if metafield.OwnerResource != "" {
+		result.OwnerType = toModelEnum[model.MetafieldOwnerType](metafield.OwnerResource)
+	}
+	return result
+}
+
+// toModelMetafields converts Shopify metafields
+func toModelMetafields(metafields []goshopify.Metafield) []*model.Metafield {
+	result := make([]*model.Metafield, len(metafields))
+	for i := range metafields {
+		result[i] = toModelMetafield(&metafields[i])
+	}
+	return result
+}
+
+// toMetafieldConnection converts a page of Shopify metafields
+func toMetafieldConnection(metafields []goshopify.Metafield, pagination *goshopify.Pagination) *model.MetafieldConnection {
+	nodes := toModelMetafields(metafields)
+	edges := make([]*model.MetafieldEdge, len(metafields))
+	for i := range metafields {
+		edges[i] = &model.MetafieldEdge{Cursor: idCursor(metafields[i].Id), Node: nodes[i]}
+	}
+	return &model.MetafieldConnection{Edges: edges, Nodes: nodes, PageInfo: toModelPageInfo(pagination)}
+}
+
+// toShopifyMetafield validates a metafield input, creates require a namespace, key, type and value
+func toShopifyMetafield(input model.MetafieldInput, create bool) (domain.MetafieldOwner, *goshopify.Metafield, error) {
+	v := &inputValidator{}
+	owner := metafieldOwnerFromInput(v, "owner", input.Owner)
+	metafield := &goshopify.Metafield{
+		Id:          v.id("id", input.ID),
+		Namespace:   stringValue(input.Namespace),
+		Key:         stringValue(input.Key),
+		Type:        goshopify.MetafieldType(stringValue(input.Type)),
+		Description: stringValue(input.Description),
+	}
+
+	value, ok := metafieldValueFromInput(v, "jsonValue", input.Value, input.JSONValue)
+	if !ok && !v.rejected("jsonValue") {
+		v.reject("value", "is required")
+	}
+	metafield.Value = value
+
+	if create {
+		if metafield.Namespace == "" {
+			v.reject("namespace", "is required")
+		}
+		if metafield.Key == "" {
+			v.reject("key", "is required")
+		}
+		if metafield.Type == "" {
+			v.reject("type", "is required")
+		}
+	} else {
+		v.requiredID(metafield.Id)
+		if input.Namespace != nil || input.Key != nil {
+			v.reject("key", "namespace and key cannot be changed")
+		}
+	}
+
+	if err := v.result("invalid metafield input"); err != nil {
+		return owner, nil, err
+	}
+	return owner, metafield, nil
+}
+
+// toModelMetafieldValidations converts the validations of a definition
+func toModelMetafieldValidations(validations []domain.MetafieldValidation) []*model.MetafieldValidation {
+	result := make([]*model.MetafieldValidation, len(validations))
+	for i, validation := range validations {
+		result[i] = &model.MetafieldValidation{Name: validation.Name, Value: validation.Value}
+	}
+	return result
+}
+
+// metafieldValidationsFromInput converts the validations of a definition input
+func metafieldValidationsFromInput(inputs []*model.MetafieldValidationInput) []domain.MetafieldValidation {
+	result := make([]domain.MetafieldValidation, len(inputs))
+	for i, input := range inputs {
+		result[i] = domain.MetafieldValidation{Name: input.Name, Value: input.Value}
+	}
+	return result
+}
+
+// toModelMetafieldDefinition converts a metafield definition
+func toModelMetafieldDefinition(definition *domain.MetafieldDefinition) *model.MetafieldDefinition {
+	result := &model.MetafieldDefinition{
+		ID:          shopifyID(definition.ID),
+		Namespace:   definition.Namespace,
+		Key:         definition.Key,
+		Name:        definition.Name,
+		Description: optionalString(definition.Description),
+		Type:        definition.Type,
+		Validations: toModelMetafieldValidations(definition.Validations),
+		Pinned:      definition.Pinned,
+	}
+	if ownerType := toModelEnum[model.MetafieldOwnerType](string(definition.OwnerType)); ownerType != nil {
+		result.OwnerType = *ownerType
+	}
+	return result
+}
+
+// toModelMetafieldDefinitions converts metafield definitions
+func toModelMetafieldDefinitions(definitions []domain.MetafieldDefinition) []*model.MetafieldDefinition {
+	result := make([]*model.MetafieldDefinition, len(definitions))
+	for i := range definitions {
+		result[i] = toModelMetafieldDefinition(&definitions[i])
+	}
+	return result
+}
+
+// metafieldDefinitionFromInput validates a metafield definition input, creates require a type
+func metafieldDefinitionFromInput(input model.MetafieldDefinitionInput, create bool) (domain.MetafieldDefinition, error) {
+	v := &inputValidator{}
+	definition := domain.MetafieldDefinition{
+		OwnerType:   metafieldOwnerType(input.OwnerType),
+		Namespace:   input.Namespace,
+		Key:         input.Key,
+		Name:        input.Name,
+		Description: stringValue(input.Description),
+		Type:        stringValue(input.Type),
+		Validations: metafieldValidationsFromInput(input.Validations),
+		Pinned:      boolValue(input.Pin),
+	}
+
+	if definition.Name == "" {
+		v.reject("name", "is required")
+	}
+	if create && definition.Type == "" {
+		v.reject("type", "is required")
+	}
+	if !create && input.Type != nil {
+		v.reject("type", "cannot be changed")
+	}
+	if !create && input.Pin != nil {
+		v.reject("pin", "can only be set on create")
+	}
+
+	return definition, v.result("invalid metafield definition input")
+}
+
+// toModelMetaobjectDefinition converts a metaobject definition
+func toModelMetaobjectDefinition(definition *domain.MetaobjectDefinition) *model.MetaobjectDefinition {
+	fields := make([]*model.MetaobjectFieldDefinition, len(definition.Fields))
+	for i, field := range definition.Fields {
+		fields[i] = &model.MetaobjectFieldDefinition{
+			Key:         field.Key,
+			Name:        field.Name,
+			Description: optionalString(field.Description),
+			Type:        field.Type,
+			Required:    field.Required,
+			Validations: toModelMetafieldValidations(field.Validations),
+		}
+	}
+	return &model.MetaobjectDefinition{
+		ID:               shopifyID(definition.ID),
+		Type:             definition.Type,
+		Name:             definition.Name,
+		Description:      optionalString(definition.Description),
+		DisplayNameKey:   optionalString(definition.DisplayNameKey),
+		Fields:           fields,
+		MetaobjectsCount: definition.MetaobjectsCount,
+	}
+}
+
+// toModelMetaobjectDefinitions converts metaobject definitions
+func toModelMetaobjectDefinitions(definitions []domain.MetaobjectDefinition) []*model.MetaobjectDefinition {
+	result := make([]*model.MetaobjectDefinition, len(definitions))
+	for i := range definitions {
+		result[i] = toModelMetaobjectDefinition(&definitions[i])
+	}
+	return result
+}
+
+// metaobjectFieldDefinitionsFromInput validates the fields of a metaobject definition input
+// New fields require a type; on update, the client decides which keys are new
+func metaobjectFieldDefinitionsFromInput(v *inputValidator, inputs []*model.MetaobjectFieldDefinitionInput, create bool) []domain.MetaobjectFieldDefinition {
+	fields := make([]domain.MetaobjectFieldDefinition, len(inputs))
+	keys := make(map[string]bool, len(inputs))
+	for i, input := range inputs {
+		if input.Key == "" {
+			v.reject(fieldPath("fields", i, "key"), "is required")
+		} else if keys[input.Key] {
+			v.reject(fieldPath("fields", i, "key"), "is duplicated")
+		}
+		keys[input.Key] = true
+		if input.Name == "" {
+			v.reject(fieldPath("fields", i, "name"), "is required")
+		}
+		if create && stringValue(input.Type) == "" {
+			v.reject(fieldPath("fields", i, "type"), "is required")
+		}
+		fields[i] = domain.MetaobjectFieldDefinition{
+			Key:         input.Key,
+			Name:        input.Name,
+			Description: stringValue(input.Description),
+			Type:        stringValue(input.Type),
+			Required:    boolValue(input.Required),
+			Validations: metafieldValidationsFromInput(input.Validations),
+		}
+	}
+	return fields
+}
+
+// metaobjectDefinitionFromInput validates a metaobject definition input for a create
+func metaobjectDefinitionFromInput(input model.MetaobjectDefinitionInput) (domain.MetaobjectDefinition, error) {
+	v := &inputValidator{}
+	definition := domain.MetaobjectDefinition{
+		Type:           stringValue(input.Type),
+		Name:           stringValue(input.Name),
+		Description:    stringValue(input.Description),
+		DisplayNameKey: stringValue(input.DisplayNameKey),
+		Fields:         metaobjectFieldDefinitionsFromInput(v, input.Fields, true),
+	}
+
+	if input.ID != nil {
+		v.reject("id", "cannot be set on create")
+	}
+	if definition.Type == "" {
+		v.reject("type", "is required")
+	}
+	if definition.Name == "" {
+		v.reject("name", "is required")
+	}
+	if len(definition.Fields) == 0 {
+		v.reject("fields", "must have at least one field")
+	}
+	if len(input.DeleteFields) > 0 {
+		v.reject("deleteFields", "can only be set on update")
+	}
+
+	return definition, v.result("invalid metaobject definition input")
+}
+
+// toMetaobjectDefinitionUpdate validates a metaobject definition input for an update
+func toMetaobjectDefinitionUpdate(input model.MetaobjectDefinitionInput) (int64, domain.MetaobjectDefinitionUpdate, error) {
+	v := &inputValidator{}
+	id := v.id("id", input.ID)
+	v.requiredID(id)
+	if input.Type != nil {
+		v.reject("type", "cannot be changed")
+	}
+	if input.Name != nil && *input.Name == "" {
+		v.reject("name", "cannot be empty")
+	}
+
+	update := domain.MetaobjectDefinitionUpdate{
+		Name:           input.Name,
+		Description:    input.Description,
+		DisplayNameKey: input.DisplayNameKey,
+		Fields:         metaobjectFieldDefinitionsFromInput(v, input.Fields, false),
+		DeleteFields:   input.DeleteFields,
+	}
+	for i, key := range input.DeleteFields {
+		for _, field := range update.Fields {
+			if field.Key == key {
+				v.reject(fmt.Sprintf("deleteFields[%d]", i), "is also in fields")
+			}
+		}
+	}
+
+	return int64(id), update, v.result("invalid metaobject definition input")
+}
+
+// toModelMetaobjectField converts a field value of a metaobject
+func toModelMetaobjectField(field domain.MetaobjectField) *model.MetaobjectField {
+	result := &model.MetaobjectField{Key: field.Key, Type: field.Type, Value: field.Value}
+	if field.Value != nil {
+		result.JSONValue = domain.DecodeMetafieldValue(field.Type, *field.Value)
+	}
+	return result
+}
+
+// toModelMetaobject converts a metaobject
+func toModelMetaobject(metaobject *domain.Metaobject) *model.Metaobject {
+	fields := make([]*model.MetaobjectField, len(metaobject.Fields))
+	for i, field := range metaobject.Fields {
+		fields[i] = toModelMetaobjectField(field)
+	}
+	return &model.Metaobject{
+		ID:          shopifyID(metaobject.ID),
+		Type:        metaobject.Type,
+		Handle:      metaobject.Handle,
+		DisplayName: optionalString(metaobject.DisplayName),
+		Fields:      fields,
+		UpdatedAt:   optionalTime(metaobject.UpdatedAt),
+	}
+}
+
+// toMetaobjectConnection converts a page of metaobjects, whose cursors are Shopify's GraphQL cursors
+func toMetaobjectConnection(page *domain.MetaobjectPage) *model.MetaobjectConnection {
+	nodes := make([]*model.Metaobject, len(page.Metaobjects))
+	edges := make([]*model.MetaobjectEdge, len(page.Metaobjects))
+	for i := range page.Metaobjects {
+		nodes[i] = toModelMetaobject(&page.Metaobjects[i])
+		edges[i] = &model.MetaobjectEdge{Cursor: page.Cursors[i], Node: nodes[i]}
+	}
+	return &model.MetaobjectConnection{
+		Edges: edges,
+		Nodes: nodes,
+		PageInfo: &model.PageInfo{
+			HasNextPage:     page.HasNextPage,
+			HasPreviousPage: page.HasPreviousPage,
+			StartCursor:     optionalString(page.StartCursor),
+			EndCursor:       optionalString(page.EndCursor),
+		},
+	}
+}
+
+// metaobjectFromInput validates a metaobject input, creates require a type
+func metaobjectFromInput(input model.MetaobjectInput, create bool) (domain.Metaobject, error) {
+	v := &inputValidator{}
+	metaobject := domain.Metaobject{
+		ID:     v.id("id", input.ID),
+		Type:   stringValue(input.Type),
+		Handle: stringValue(input.Handle),
+		Fields: make([]domain.MetaobjectField, len(input.Fields)),
+	}
+
+	if create {
+		if input.ID != nil {
+			v.reject("id", "cannot be set on create")
+		}
+		if metaobject.Type == "" {
+			v.reject("type", "is required")
+		}
+	} else {
+		v.requiredID(metaobject.ID)
+		if input.Type != nil {
+			v.reject("type", "cannot be changed")
+		}
+	}
+	if input.Handle != nil && metaobject.Handle == "" {
+		v.reject("handle", "cannot be empty")
+	}
+
+	for i, field := range input.Fields {
+		if field.Key == "" {
+			v.reject(fieldPath("fields", i, "key"), "is required")
+		}
+		metaobject.Fields[i] = domain.MetaobjectField{Key: field.Key}
+		// A field without a value is cleared
+		if value, ok := metafieldValueFromInput(v, fieldPath("fields", i, "jsonValue"), field.Value, field.JSONValue); ok {
+			metaobject.Fields[i].Value = &value
+		}
+	}
+
+	return metaobject, v.result("invalid metaobject input")
+}
+
+// ownerMetafield resolves the metafield of a namespace and key of a resource
+func (r *Resolver) ownerMetafield(ctx context.Context, shop string, owner domain.MetafieldOwner, namespace string, key string) (*model.Metafield, error) {
+	metafield, err := r.shopifyService.GetMetafieldByKey(ctx, shop, owner, namespace, key)
+	if err != nil || metafield == nil {
+		return nil, err
+	}
+	return toModelMetafield(metafield), nil
+}
+
+// ownerMetafields resolves the metafields of a resource, optionally of a single namespace
+func (r *Resolver) ownerMetafields(ctx context.Context, shop string, owner domain.MetafieldOwner, namespace *string) ([]*model.Metafield, error) {
+	metafields, err := r.shopifyService.ListOwnerMetafields(ctx, shop, owner, stringValue(namespace))
+	if err != nil {
+		return nil, err
+	}
+	return toModelMetafields(metafields), nil
+}
@@ -0,0 +1,396 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.84
+
+import (
+	"archie-core-shopify-layer/graph/generated"
+	"archie-core-shopify-layer/graph/model"
+	"context"
+	"fmt"
+)
+
+// Metafield is the resolver for the metafield field.
+func (r *customerResolver) Metafield(ctx context.Context, obj *model.Customer, namespace string, key string) (*model.Metafield, error) {
+	owner, err := resourceOwner(model.MetafieldOwnerTypeCustomer, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	return r.ownerMetafield(ctx, obj.Shop, owner, namespace, key)
+}
+
+// Metafields is the resolver for the metafields field.
+func (r *customerResolver) Metafields(ctx context.Context, obj *model.Customer, namespace *string) ([]*model.Metafield, error) {
+	owner, err := resourceOwner(model.MetafieldOwnerTypeCustomer, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	return r.ownerMetafields(ctx, obj.Shop, owner, namespace)
+}
+
+// Field is the resolver for the field field.
+func (r *metaobjectResolver) Field(ctx context.Context, obj *model.Metaobject, key string) (*model.MetaobjectField, error) {
+	for _, field := range obj.Fields {
+		if field.Key == key {
+			return field, nil
+		}
+	}
+	return nil, nil
+}
+
+// ShopifyCreateMetafield is the resolver for the shopify_createMetafield field.
+func (r *mutationResolver) ShopifyCreateMetafield(ctx context.Context, input model.MetafieldInput) (*model.Metafield, error) {
+	owner, metafield, err := toShopifyMetafield(input, true)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := r.shopifyService.CreateMetafield(ctx, input.Domain, owner, metafield)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelMetafield(created), nil
+}
+
+// ShopifyUpdateMetafield is the resolver for the shopify_updateMetafield field.
+func (r *mutationResolver) ShopifyUpdateMetafield(ctx context.Context, input model.MetafieldInput) (*model.Metafield, error) {
+	owner, metafield, err := toShopifyMetafield(input, false)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := r.shopifyService.UpdateMetafield(ctx, input.Domain, owner, metafield)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelMetafield(updated), nil
+}
+
+// ShopifyDeleteMetafield is the resolver for the shopify_deleteMetafield field.
+func (r *mutationResolver) ShopifyDeleteMetafield(ctx context.Context, domain string, owner model.MetafieldOwnerInput, metafieldID string) (bool, error) {
+	v := &inputValidator{}
+	metafieldOwner := metafieldOwnerFromInput(v, "owner", &owner)
+	if err := v.result("invalid metafield"); err != nil {
+		return false, err
+	}
+	var mid int64
+	if _, err := fmt.Sscanf(metafieldID, "%d", &mid); err != nil {
+		return false, invalidIDError("metafieldId", err)
+	}
+
+	if err := r.shopifyService.DeleteMetafield(ctx, domain, metafieldOwner, mid); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ShopifyCreateMetafieldDefinition is the resolver for the shopify_createMetafieldDefinition field.
+func (r *mutationResolver) ShopifyCreateMetafieldDefinition(ctx context.Context, input model.MetafieldDefinitionInput) (*model.MetafieldDefinition, error) {
+	definition, err := metafieldDefinitionFromInput(input, true)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := r.shopifyService.CreateMetafieldDefinition(ctx, input.Domain, definition)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelMetafieldDefinition(created), nil
+}
+
+// ShopifyUpdateMetafieldDefinition is the resolver for the shopify_updateMetafieldDefinition field.
+func (r *mutationResolver) ShopifyUpdateMetafieldDefinition(ctx context.Context, input model.MetafieldDefinitionInput) (*model.MetafieldDefinition, error) {
+	definition, err := metafieldDefinitionFromInput(input, false)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := r.shopifyService.UpdateMetafieldDefinition(ctx, input.Domain, definition)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelMetafieldDefinition(updated), nil
+}
+
+// ShopifyDeleteMetafieldDefinition is the resolver for the shopify_deleteMetafieldDefinition field.
+func (r *mutationResolver) ShopifyDeleteMetafieldDefinition(ctx context.Context, domain string, definitionID string, deleteMetafields *bool) (bool, error) {
+	var did int64
+	if _, err := fmt.Sscanf(definitionID, "%d", &did); err != nil {
+		return false, invalidIDError("definitionId", err)
+	}
+
+	if err := r.shopifyService.DeleteMetafieldDefinition(ctx, domain, did, deleteMetafields != nil && *deleteMetafields); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ShopifyCreateMetaobjectDefinition is the resolver for the shopify_createMetaobjectDefinition field.
+func (r *mutationResolver) ShopifyCreateMetaobjectDefinition(ctx context.Context, input model.MetaobjectDefinitionInput) (*model.MetaobjectDefinition, error) {
+	definition, err := metaobjectDefinitionFromInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := r.shopifyService.CreateMetaobjectDefinition(ctx, input.Domain, definition)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelMetaobjectDefinition(created), nil
+}
+
+// ShopifyUpdateMetaobjectDefinition is the resolver for the shopify_updateMetaobjectDefinition field.
+func (r *mutationResolver) ShopifyUpdateMetaobjectDefinition(ctx context.Context, input model.MetaobjectDefinitionInput) (*model.MetaobjectDefinition, error) {
+	id, update, err := toMetaobjectDefinitionUpdate(input)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := r.shopifyService.UpdateMetaobjectDefinition(ctx, input.Domain, id, update)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelMetaobjectDefinition(updated), nil
+}
+
+// ShopifyDeleteMetaobjectDefinition is the resolver for the shopify_deleteMetaobjectDefinition field.
+func (r *mutationResolver) ShopifyDeleteMetaobjectDefinition(ctx context.Context, domain string, definitionID string) (bool, error) {
+	var did int64
+	if _, err := fmt.Sscanf(definitionID, "%d", &did); err != nil {
+		return false, invalidIDError("definitionId", err)
+	}
+
+	if err := r.shopifyService.DeleteMetaobjectDefinition(ctx, domain, did); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ShopifyCreateMetaobject is the resolver for the shopify_createMetaobject field.
+func (r *mutationResolver) ShopifyCreateMetaobject(ctx context.Context, input model.MetaobjectInput) (*model.Metaobject, error) {
+	metaobject, err := metaobjectFromInput(input, true)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := r.shopifyService.CreateMetaobject(ctx, input.Domain, metaobject)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelMetaobject(created), nil
+}
+
+// ShopifyUpdateMetaobject is the resolver for the shopify_updateMetaobject field.
+func (r *mutationResolver) ShopifyUpdateMetaobject(ctx context.Context, input model.MetaobjectInput) (*model.Metaobject, error) {
+	metaobject, err := metaobjectFromInput(input, false)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := r.shopifyService.UpdateMetaobject(ctx, input.Domain, metaobject)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelMetaobject(updated), nil
+}
+
+// ShopifyDeleteMetaobject is the resolver for the shopify_deleteMetaobject field.
+func (r *mutationResolver) ShopifyDeleteMetaobject(ctx context.Context, domain string, metaobjectID string) (bool, error) {
+	var mid int64
+	if _, err := fmt.Sscanf(metaobjectID, "%d", &mid); err != nil {
+		return false, invalidIDError("metaobjectId", err)
+	}
+
+	if err := r.shopifyService.DeleteMetaobject(ctx, domain, mid); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Metafield is the resolver for the metafield field.
+func (r *orderResolver) Metafield(ctx context.Context, obj *model.Order, namespace string, key string) (*model.Metafield, error) {
+	owner, err := resourceOwner(model.MetafieldOwnerTypeOrder, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	return r.ownerMetafield(ctx, obj.Shop, owner, namespace, key)
+}
+
+// Metafields is the resolver for the metafields field.
+func (r *orderResolver) Metafields(ctx context.Context, obj *model.Order, namespace *string) ([]*model.Metafield, error) {
+	owner, err := resourceOwner(model.MetafieldOwnerTypeOrder, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	return r.ownerMetafields(ctx, obj.Shop, owner, namespace)
+}
+
+// Metafield is the resolver for the metafield field.
+func (r *productResolver) Metafield(ctx context.Context, obj *model.Product, namespace string, key string) (*model.Metafield, error) {
+	owner, err := resourceOwner(model.MetafieldOwnerTypeProduct, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	return r.ownerMetafield(ctx, obj.Shop, owner, namespace, key)
+}
+
+// Metafields is the resolver for the metafields field.
+func (r *productResolver) Metafields(ctx context.Context, obj *model.Product, namespace *string) ([]*model.Metafield, error) {
+	owner, err := resourceOwner(model.MetafieldOwnerTypeProduct, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	return r.ownerMetafields(ctx, obj.Shop, owner, namespace)
+}
+
+// ShopifyMetafields is the resolver for the shopify_metafields field.
+func (r *queryResolver) ShopifyMetafields(ctx context.Context, domain string, owner model.MetafieldOwnerInput, namespace *string, key *string, first *int, after *string, before *string) (*model.MetafieldConnection, error) {
+	v := &inputValidator{}
+	metafieldOwner := metafieldOwnerFromInput(v, "owner", &owner)
+	options := toMetafieldListOptions(newPageRequest(v, first, after, before, nil), namespace, key)
+	if err := v.result("invalid metafields query"); err != nil {
+		return nil, err
+	}
+
+	metafields, pagination, err := r.shopifyService.ListMetafields(ctx, domain, metafieldOwner, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return toMetafieldConnection(metafields, pagination), nil
+}
+
+// ShopifyMetafield is the resolver for the shopify_metafield field.
+func (r *queryResolver) ShopifyMetafield(ctx context.Context, domain string, owner model.MetafieldOwnerInput, metafieldID string) (*model.Metafield, error) {
+	v := &inputValidator{}
+	metafieldOwner := metafieldOwnerFromInput(v, "owner", &owner)
+	if err := v.result("invalid metafield query"); err != nil {
+		return nil, err
+	}
+	var mid int64
+	if _, err := fmt.Sscanf(metafieldID, "%d", &mid); err != nil {
+		return nil, invalidIDError("metafieldId", err)
+	}
+
+	metafield, err := r.shopifyService.GetMetafield(ctx, domain, metafieldOwner, mid)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelMetafield(metafield), nil
+}
+
+// ShopifyMetafieldDefinitions is the resolver for the shopify_metafieldDefinitions field.
+func (r *queryResolver) ShopifyMetafieldDefinitions(ctx context.Context, domain string, ownerType model.MetafieldOwnerType, namespace *string) ([]*model.MetafieldDefinition, error) {
+	definitions, err := r.shopifyService.ListMetafieldDefinitions(ctx, domain, metafieldOwnerType(ownerType), stringValue(namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelMetafieldDefinitions(definitions), nil
+}
+
+// ShopifyMetaobjectDefinitions is the resolver for the shopify_metaobjectDefinitions field.
+func (r *queryResolver) ShopifyMetaobjectDefinitions(ctx context.Context, domain string) ([]*model.MetaobjectDefinition, error) {
+	definitions, err := r.shopifyService.ListMetaobjectDefinitions(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelMetaobjectDefinitions(definitions), nil
+}
+
+// ShopifyMetaobjectDefinition is the resolver for the shopify_metaobjectDefinition field.
+func (r *queryResolver) ShopifyMetaobjectDefinition(ctx context.Context, domain string, typeArg string) (*model.MetaobjectDefinition, error) {
+	definition, err := r.shopifyService.GetMetaobjectDefinitionByType(ctx, domain, typeArg)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return toModelMetaobjectDefinition(definition), nil
+}
+
+// ShopifyMetaobjects is the resolver for the shopify_metaobjects field.
+func (r *queryResolver) ShopifyMetaobjects(ctx context.Context, domain string, typeArg string, first *int, after *string, before *string) (*model.MetaobjectConnection, error) {
+	v := &inputValidator{}
+	limit := defaultPageSize
+	if first != nil {
+		if *first < 1 || *first > maxPageSize {
+			v.reject("first", fmt.Sprintf("must be between 1 and %d", maxPageSize))
+		}
+		limit = *first
+	}
+	if after != nil && before != nil {
+		v.reject("before", "cannot be combined with after")
+	}
+	if err := v.result("invalid metaobjects query"); err != nil {
+		return nil, err
+	}
+
+	page, err := r.shopifyService.ListMetaobjects(ctx, domain, typeArg, limit, stringValue(after), stringValue(before))
+	if err != nil {
+		return nil, err
+	}
+
+	return toMetaobjectConnection(page), nil
+}
+
+// ShopifyMetaobject is the resolver for the shopify_metaobject field.
+func (r *queryResolver) ShopifyMetaobject(ctx context.Context, domain string, metaobjectID string) (*model.Metaobject, error) {
+	var mid int64
+	if _, err := fmt.Sscanf(metaobjectID, "%d", &mid); err != nil {
+		return nil, invalidIDError("metaobjectId", err)
+	}
+
+	metaobject, err := r.shopifyService.GetMetaobject(ctx, domain, mid)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelMetaobject(metaobject), nil
+}
+
+// ShopifyMetaobjectByHandle is the resolver for the shopify_metaobjectByHandle field.
+func (r *queryResolver) ShopifyMetaobjectByHandle(ctx context.Context, domain string, typeArg string, handle string) (*model.Metaobject, error) {
+	metaobject, err := r.shopifyService.GetMetaobjectByHandle(ctx, domain, typeArg, handle)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return toModelMetaobject(metaobject), nil
+}
+
+// Metafield is the resolver for the metafield field.
+func (r *shopResolver) Metafield(ctx context.Context, obj *model.Shop, namespace string, key string) (*model.Metafield, error) {
+	return r.ownerMetafield(ctx, obj.Domain, shopMetafieldOwner, namespace, key)
+}
+
+// Metafields is the resolver for the metafields field.
+func (r *shopResolver) Metafields(ctx context.Context, obj *model.Shop, namespace *string) ([]*model.Metafield, error) {
+	return r.ownerMetafields(ctx, obj.Domain, shopMetafieldOwner, namespace)
+}
+
+// Metaobject returns generated.MetaobjectResolver implementation.
+func (r *Resolver) Metaobject() generated.MetaobjectResolver { return &metaobjectResolver{r} }
+
+type metaobjectResolver struct{ *Resolver }
@@ -4,8 +4,173 @@ package model
 
 import (
 	"archie-core-shopify-layer/graph/scalars"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
 )
 
+type AddressInput struct {
+	FirstName    *string `json:"firstName,omitempty"`
+	LastName     *string `json:"lastName,omitempty"`
+	Company      *string `json:"company,omitempty"`
+	Address1     *string `json:"address1,omitempty"`
+	Address2     *string `json:"address2,omitempty"`
+	City         *string `json:"city,omitempty"`
+	Province     *string `json:"province,omitempty"`
+	ProvinceCode *string `json:"provinceCode,omitempty"`
+	Country      *string `json:"country,omitempty"`
+	CountryCode  *string `json:"countryCode,omitempty"`
+	Zip          *string `json:"zip,omitempty"`
+	Phone        *string `json:"phone,omitempty"`
+}
+
+type AdjustInventoryInput struct {
+	Domain          string                    `json:"domain"`
+	InventoryItemID string                    `json:"inventoryItemId"`
+	LocationID      string                    `json:"locationId"`
+	Delta           int                       `json:"delta"`
+	Reason          InventoryAdjustmentReason `json:"reason"`
+	Reference       *string                   `json:"reference,omitempty"`
+}
+
+type APIUsage struct {
+	ProjectID     string         `json:"projectId"`
+	IntegrationID *string        `json:"integrationId,omitempty"`
+	Tier          *RateLimitTier `json:"tier"`
+	Daily         *UsageCounter  `json:"daily"`
+	Monthly       *UsageCounter  `json:"monthly"`
+}
+
+type AppConfigDiff struct {
+	AppHandle                   string `json:"appHandle"`
+	InSource                    bool   `json:"inSource"`
+	InTarget                    bool   `json:"inTarget"`
+	SameAPIKey                  bool   `json:"sameApiKey"`
+	ExpiringOfflineTokensDiffer bool   `json:"expiringOfflineTokensDiffer"`
+}
+
+type BulkMutationInput struct {
+	Domain           string  `json:"domain"`
+	Mutation         string  `json:"mutation"`
+	Variables        []any   `json:"variables,omitempty"`
+	StagedUploadPath *string `json:"stagedUploadPath,omitempty"`
+}
+
+type BulkOperationResultConnection struct {
+	Nodes    []any     `json:"nodes"`
+	PageInfo *PageInfo `json:"pageInfo"`
+}
+
+type CancelOrderInput struct {
+	Domain  string             `json:"domain"`
+	OrderID string             `json:"orderId"`
+	Reason  *OrderCancelReason `json:"reason,omitempty"`
+	Email   *bool              `json:"email,omitempty"`
+	Restock *bool              `json:"restock,omitempty"`
+	Refund  *RefundInput       `json:"refund,omitempty"`
+}
+
+type CaptureTransactionInput struct {
+	Domain          string  `json:"domain"`
+	OrderID         string  `json:"orderId"`
+	AuthorizationID string  `json:"authorizationId"`
+	Amount          *string `json:"amount,omitempty"`
+	Currency        *string `json:"currency,omitempty"`
+}
+
+type CloneEnvironmentInput struct {
+	SourceEnvironment string  `json:"sourceEnvironment"`
+	TargetEnvironment string  `json:"targetEnvironment"`
+	AppHandle         *string `json:"appHandle,omitempty"`
+	APIKey            string  `json:"apiKey"`
+	APISecret         string  `json:"apiSecret"`
+}
+
+type Collect struct {
+	ID           string        `json:"id"`
+	CollectionID string        `json:"collectionId"`
+	ProductID    string        `json:"productId"`
+	Position     *int          `json:"position,omitempty"`
+	SortValue    *string       `json:"sortValue,omitempty"`
+	CreatedAt    *scalars.Time `json:"createdAt,omitempty"`
+	UpdatedAt    *scalars.Time `json:"updatedAt,omitempty"`
+}
+
+type CollectionConnection struct {
+	Edges    []*CollectionEdge `json:"edges"`
+	Nodes    []*Collection     `json:"nodes"`
+	PageInfo *PageInfo         `json:"pageInfo"`
+}
+
+type CollectionEdge struct {
+	Cursor string      `json:"cursor"`
+	Node   *Collection `json:"node"`
+}
+
+type CollectionFilter struct {
+	Ids             []string         `json:"ids,omitempty"`
+	Title           *string          `json:"title,omitempty"`
+	Handle          *string          `json:"handle,omitempty"`
+	ProductID       *string          `json:"productId,omitempty"`
+	PublishedStatus *PublishedStatus `json:"publishedStatus,omitempty"`
+	UpdatedAtMin    *scalars.Time    `json:"updatedAtMin,omitempty"`
+	UpdatedAtMax    *scalars.Time    `json:"updatedAtMax,omitempty"`
+	PublishedAtMin  *scalars.Time    `json:"publishedAtMin,omitempty"`
+	PublishedAtMax  *scalars.Time    `json:"publishedAtMax,omitempty"`
+}
+
+type CollectionImage struct {
+	Src       string        `json:"src"`
+	Alt       *string       `json:"alt,omitempty"`
+	Width     *int          `json:"width,omitempty"`
+	Height    *int          `json:"height,omitempty"`
+	CreatedAt *scalars.Time `json:"createdAt,omitempty"`
+}
+
+type CollectionImageInput struct {
+	Src string  `json:"src"`
+	Alt *string `json:"alt,omitempty"`
+}
+
+type CollectionInput struct {
+	Domain         string                 `json:"domain"`
+	ID             *string                `json:"id,omitempty"`
+	Type           CollectionType         `json:"type"`
+	Title          *string                `json:"title,omitempty"`
+	Handle         *string                `json:"handle,omitempty"`
+	BodyHTML       *string                `json:"bodyHtml,omitempty"`
+	SortOrder      *CollectionSortOrder   `json:"sortOrder,omitempty"`
+	TemplateSuffix *string                `json:"templateSuffix,omitempty"`
+	Published      *bool                  `json:"published,omitempty"`
+	Image          *CollectionImageInput  `json:"image,omitempty"`
+	Rules          []*CollectionRuleInput `json:"rules,omitempty"`
+	Disjunctive    *bool                  `json:"disjunctive,omitempty"`
+	ProductIds     []string               `json:"productIds,omitempty"`
+}
+
+type CollectionPayload struct {
+	Collection *Collection `json:"collection"`
+}
+
+type CollectionProductsInput struct {
+	Domain       string   `json:"domain"`
+	CollectionID string   `json:"collectionId"`
+	ProductIds   []string `json:"productIds"`
+}
+
+type CollectionRule struct {
+	Column    CollectionRuleColumn   `json:"column"`
+	Relation  CollectionRuleRelation `json:"relation"`
+	Condition string                 `json:"condition"`
+}
+
+type CollectionRuleInput struct {
+	Column    CollectionRuleColumn   `json:"column"`
+	Relation  CollectionRuleRelation `json:"relation"`
+	Condition string                 `json:"condition"`
+}
+
 type ConfigureCredentialsInput struct {
 	ProjectID   string `json:"projectId"`
 	Environment string `json:"environment"`
@@ -18,51 +183,442 @@ type ConfigureCredentialsPayload struct {
 }
 
 type ConfigureShopifyInput struct {
-	APIKey        string  `json:"apiKey"`
-	APISecret     string  `json:"apiSecret"`
-	WebhookSecret *string `json:"webhookSecret,omitempty"`
+	APIKey                string  `json:"apiKey"`
+	APISecret             string  `json:"apiSecret"`
+	WebhookSecret         *string `json:"webhookSecret,omitempty"`
+	ExpiringOfflineTokens *bool   `json:"expiringOfflineTokens,omitempty"`
+	AppHandle             *string `json:"appHandle,omitempty"`
 }
 
 type ConfigureShopifyPayload struct {
-	ID          string       `json:"id"`
-	ProjectID   string       `json:"projectId"`
-	Environment string       `json:"environment"`
-	APIKey      string       `json:"apiKey"`
-	WebhookURL  string       `json:"webhookUrl"`
-	CreatedAt   scalars.Time `json:"createdAt"`
-	UpdatedAt   scalars.Time `json:"updatedAt"`
+	ID                    string       `json:"id"`
+	ProjectID             string       `json:"projectId"`
+	Environment           string       `json:"environment"`
+	AppHandle             string       `json:"appHandle"`
+	APIKey                string       `json:"apiKey"`
+	WebhookURL            string       `json:"webhookUrl"`
+	ExpiringOfflineTokens bool         `json:"expiringOfflineTokens"`
+	CreatedAt             scalars.Time `json:"createdAt"`
+	UpdatedAt             scalars.Time `json:"updatedAt"`
+}
+
+type CreateFulfillmentInput struct {
+	Domain                      string                            `json:"domain"`
+	LineItemsByFulfillmentOrder []*FulfillmentOrderLineItemsInput `json:"lineItemsByFulfillmentOrder"`
+	TrackingInfo                *FulfillmentTrackingInput         `json:"trackingInfo,omitempty"`
+	NotifyCustomer              *bool                             `json:"notifyCustomer,omitempty"`
 }
 
 type CreateIntegrationInput struct {
-	ProjectID   string `json:"projectId"`
-	Environment string `json:"environment"`
-	ShopDomain  string `json:"shopDomain"`
+	ProjectID   string        `json:"projectId"`
+	Environment string        `json:"environment"`
+	ShopDomain  string        `json:"shopDomain"`
+	AppHandle   *string       `json:"appHandle,omitempty"`
+	Scopes      []string      `json:"scopes,omitempty"`
+	ExpiresAt   *scalars.Time `json:"expiresAt,omitempty"`
 }
 
 type CreateIntegrationPayload struct {
 	Integration *Integration `json:"integration"`
 }
 
-type Customer struct {
-	ID          string       `json:"id"`
-	Email       *string      `json:"email,omitempty"`
-	FirstName   *string      `json:"firstName,omitempty"`
-	LastName    *string      `json:"lastName,omitempty"`
-	OrdersCount *int         `json:"ordersCount,omitempty"`
-	TotalSpent  *string      `json:"totalSpent,omitempty"`
-	CreatedAt   scalars.Time `json:"createdAt"`
-	UpdatedAt   scalars.Time `json:"updatedAt"`
+type CreateReturnInput struct {
+	Domain         string                 `json:"domain"`
+	OrderID        string                 `json:"orderId"`
+	LineItems      []*ReturnLineItemInput `json:"lineItems"`
+	NotifyCustomer *bool                  `json:"notifyCustomer,omitempty"`
+}
+
+type CustomerAddress struct {
+	ID           string  `json:"id"`
+	CustomerID   *string `json:"customerId,omitempty"`
+	FirstName    *string `json:"firstName,omitempty"`
+	LastName     *string `json:"lastName,omitempty"`
+	Name         *string `json:"name,omitempty"`
+	Company      *string `json:"company,omitempty"`
+	Address1     *string `json:"address1,omitempty"`
+	Address2     *string `json:"address2,omitempty"`
+	City         *string `json:"city,omitempty"`
+	Province     *string `json:"province,omitempty"`
+	ProvinceCode *string `json:"provinceCode,omitempty"`
+	Country      *string `json:"country,omitempty"`
+	CountryCode  *string `json:"countryCode,omitempty"`
+	CountryName  *string `json:"countryName,omitempty"`
+	Zip          *string `json:"zip,omitempty"`
+	Phone        *string `json:"phone,omitempty"`
+	Default      bool    `json:"default"`
+}
+
+type CustomerAddressInput struct {
+	ID           *string `json:"id,omitempty"`
+	FirstName    *string `json:"firstName,omitempty"`
+	LastName     *string `json:"lastName,omitempty"`
+	Company      *string `json:"company,omitempty"`
+	Address1     *string `json:"address1,omitempty"`
+	Address2     *string `json:"address2,omitempty"`
+	City         *string `json:"city,omitempty"`
+	Province     *string `json:"province,omitempty"`
+	ProvinceCode *string `json:"provinceCode,omitempty"`
+	Country      *string `json:"country,omitempty"`
+	CountryCode  *string `json:"countryCode,omitempty"`
+	Zip          *string `json:"zip,omitempty"`
+	Phone        *string `json:"phone,omitempty"`
+	Default      *bool   `json:"default,omitempty"`
+}
+
+type CustomerConnection struct {
+	Edges    []*CustomerEdge `json:"edges"`
+	Nodes    []*Customer     `json:"nodes"`
+	PageInfo *PageInfo       `json:"pageInfo"`
+}
+
+type CustomerEdge struct {
+	Cursor string    `json:"cursor"`
+	Node   *Customer `json:"node"`
+}
+
+type CustomerFilter struct {
+	Ids          []string      `json:"ids,omitempty"`
+	CreatedAtMin *scalars.Time `json:"createdAtMin,omitempty"`
+	CreatedAtMax *scalars.Time `json:"createdAtMax,omitempty"`
+	UpdatedAtMin *scalars.Time `json:"updatedAtMin,omitempty"`
+	UpdatedAtMax *scalars.Time `json:"updatedAtMax,omitempty"`
+}
+
+type CustomerInput struct {
+	Domain                string                      `json:"domain"`
+	ID                    *string                     `json:"id,omitempty"`
+	Email                 *string                     `json:"email,omitempty"`
+	Phone                 *string                     `json:"phone,omitempty"`
+	FirstName             *string                     `json:"firstName,omitempty"`
+	LastName              *string                     `json:"lastName,omitempty"`
+	Note                  *string                     `json:"note,omitempty"`
+	Tags                  []string                    `json:"tags,omitempty"`
+	TaxExempt             *bool                       `json:"taxExempt,omitempty"`
+	VerifiedEmail         *bool                       `json:"verifiedEmail,omitempty"`
+	Addresses             []*CustomerAddressInput     `json:"addresses,omitempty"`
+	EmailMarketingConsent *EmailMarketingConsentInput `json:"emailMarketingConsent,omitempty"`
+	SmsMarketingConsent   *SmsMarketingConsentInput   `json:"smsMarketingConsent,omitempty"`
+	Customer              *string                     `json:"customer,omitempty"`
+}
+
+type CustomerPayload struct {
+	Customer *Customer `json:"customer"`
+}
+
+type DeleteCollectionInput struct {
+	Domain       string         `json:"domain"`
+	CollectionID string         `json:"collectionId"`
+	Type         CollectionType `json:"type"`
+}
+
+type DeleteCustomerInput struct {
+	Domain     string `json:"domain"`
+	CustomerID string `json:"customerId"`
+}
+
+type DeleteProductInput struct {
+	Domain    string `json:"domain"`
+	ProductID string `json:"productId"`
+}
+
+type DiscountCode struct {
+	ID          string        `json:"id"`
+	PriceRuleID string        `json:"priceRuleId"`
+	Code        string        `json:"code"`
+	UsageCount  int           `json:"usageCount"`
+	CreatedAt   *scalars.Time `json:"createdAt,omitempty"`
+	UpdatedAt   *scalars.Time `json:"updatedAt,omitempty"`
+}
+
+type DiscountCodeBatchCode struct {
+	ID         *string                   `json:"id,omitempty"`
+	Code       string                    `json:"code"`
+	UsageCount int                       `json:"usageCount"`
+	Errors     []*DiscountCodeBatchError `json:"errors"`
+}
+
+type DiscountCodeBatchError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type DiscountCodeBatchInput struct {
+	Domain      string   `json:"domain"`
+	PriceRuleID string   `json:"priceRuleId"`
+	Codes       []string `json:"codes"`
+	Wait        *bool    `json:"wait,omitempty"`
+}
+
+type DiscountCodeConnection struct {
+	Edges    []*DiscountCodeEdge `json:"edges"`
+	Nodes    []*DiscountCode     `json:"nodes"`
+	PageInfo *PageInfo           `json:"pageInfo"`
+}
+
+type DiscountCodeCountFilter struct {
+	TimesUsed    *int `json:"timesUsed,omitempty"`
+	TimesUsedMin *int `json:"timesUsedMin,omitempty"`
+	TimesUsedMax *int `json:"timesUsedMax,omitempty"`
+}
+
+type DiscountCodeEdge struct {
+	Cursor string        `json:"cursor"`
+	Node   *DiscountCode `json:"node"`
+}
+
+type DiscountCodeInput struct {
+	Domain      string  `json:"domain"`
+	PriceRuleID string  `json:"priceRuleId"`
+	ID          *string `json:"id,omitempty"`
+	Code        string  `json:"code"`
+}
+
+type DraftOrderAppliedDiscount struct {
+	Title       *string                      `json:"title,omitempty"`
+	Description *string                      `json:"description,omitempty"`
+	Value       string                       `json:"value"`
+	ValueType   *DraftOrderDiscountValueType `json:"valueType,omitempty"`
+	Amount      *string                      `json:"amount,omitempty"`
+}
+
+type DraftOrderAppliedDiscountInput struct {
+	Title       *string                     `json:"title,omitempty"`
+	Description *string                     `json:"description,omitempty"`
+	Value       string                      `json:"value"`
+	ValueType   DraftOrderDiscountValueType `json:"valueType"`
+}
+
+type DraftOrderConnection struct {
+	Edges    []*DraftOrderEdge `json:"edges"`
+	Nodes    []*DraftOrder     `json:"nodes"`
+	PageInfo *PageInfo         `json:"pageInfo"`
+}
+
+type DraftOrderEdge struct {
+	Cursor string      `json:"cursor"`
+	Node   *DraftOrder `json:"node"`
+}
+
+type DraftOrderFilter struct {
+	Ids          []string          `json:"ids,omitempty"`
+	Status       *DraftOrderStatus `json:"status,omitempty"`
+	UpdatedAtMin *scalars.Time     `json:"updatedAtMin,omitempty"`
+	UpdatedAtMax *scalars.Time     `json:"updatedAtMax,omitempty"`
+}
+
+type DraftOrderInput struct {
+	Domain                    string                          `json:"domain"`
+	ID                        *string                         `json:"id,omitempty"`
+	Email                     *string                         `json:"email,omitempty"`
+	Note                      *string                         `json:"note,omitempty"`
+	Tags                      []string                        `json:"tags,omitempty"`
+	CustomerID                *string                         `json:"customerId,omitempty"`
+	UseCustomerDefaultAddress *bool                           `json:"useCustomerDefaultAddress,omitempty"`
+	TaxExempt                 *bool                           `json:"taxExempt,omitempty"`
+	BillingAddress            *AddressInput                   `json:"billingAddress,omitempty"`
+	ShippingAddress           *AddressInput                   `json:"shippingAddress,omitempty"`
+	LineItems                 []*DraftOrderLineItemInput      `json:"lineItems,omitempty"`
+	ShippingLine              *DraftOrderShippingLineInput    `json:"shippingLine,omitempty"`
+	AppliedDiscount           *DraftOrderAppliedDiscountInput `json:"appliedDiscount,omitempty"`
+	NoteAttributes            []*NoteAttributeInput           `json:"noteAttributes,omitempty"`
+}
+
+type DraftOrderInvoice struct {
+	To            *string  `json:"to,omitempty"`
+	From          *string  `json:"from,omitempty"`
+	Subject       *string  `json:"subject,omitempty"`
+	CustomMessage *string  `json:"customMessage,omitempty"`
+	Bcc           []string `json:"bcc"`
+}
+
+type DraftOrderInvoiceInput struct {
+	Domain        string   `json:"domain"`
+	DraftOrderID  string   `json:"draftOrderId"`
+	To            *string  `json:"to,omitempty"`
+	From          *string  `json:"from,omitempty"`
+	Subject       *string  `json:"subject,omitempty"`
+	CustomMessage *string  `json:"customMessage,omitempty"`
+	Bcc           []string `json:"bcc,omitempty"`
+}
+
+type DraftOrderLineItem struct {
+	ProductID        *string                    `json:"productId,omitempty"`
+	VariantID        *string                    `json:"variantId,omitempty"`
+	Title            string                     `json:"title"`
+	VariantTitle     *string                    `json:"variantTitle,omitempty"`
+	Name             *string                    `json:"name,omitempty"`
+	Sku              *string                    `json:"sku,omitempty"`
+	Vendor           *string                    `json:"vendor,omitempty"`
+	Quantity         int                        `json:"quantity"`
+	Price            *string                    `json:"price,omitempty"`
+	Grams            int                        `json:"grams"`
+	Taxable          bool                       `json:"taxable"`
+	RequiresShipping bool                       `json:"requiresShipping"`
+	GiftCard         bool                       `json:"giftCard"`
+	AppliedDiscount  *DraftOrderAppliedDiscount `json:"appliedDiscount,omitempty"`
+	Properties       []*NoteAttribute           `json:"properties"`
+	TaxLines         []*TaxLine                 `json:"taxLines"`
+}
+
+type DraftOrderLineItemInput struct {
+	VariantID        *string                         `json:"variantId,omitempty"`
+	Title            *string                         `json:"title,omitempty"`
+	Price            *string                         `json:"price,omitempty"`
+	Quantity         int                             `json:"quantity"`
+	Sku              *string                         `json:"sku,omitempty"`
+	Grams            *int                            `json:"grams,omitempty"`
+	Taxable          *bool                           `json:"taxable,omitempty"`
+	RequiresShipping *bool                           `json:"requiresShipping,omitempty"`
+	Properties       []*NoteAttributeInput           `json:"properties,omitempty"`
+	AppliedDiscount  *DraftOrderAppliedDiscountInput `json:"appliedDiscount,omitempty"`
+}
+
+type DraftOrderShippingLine struct {
+	Title string  `json:"title"`
+	Code  *string `json:"code,omitempty"`
+	Price *string `json:"price,omitempty"`
+}
+
+type DraftOrderShippingLineInput struct {
+	Title string  `json:"title"`
+	Price string  `json:"price"`
+	Code  *string `json:"code,omitempty"`
+}
+
+type EmailMarketingConsentInput struct {
+	State            MarketingState       `json:"state"`
+	OptInLevel       *MarketingOptInLevel `json:"optInLevel,omitempty"`
+	ConsentUpdatedAt *scalars.Time        `json:"consentUpdatedAt,omitempty"`
+}
+
+type EnvironmentComparison struct {
+	ProjectID string           `json:"projectId"`
+	Source    string           `json:"source"`
+	Target    string           `json:"target"`
+	Apps      []*AppConfigDiff `json:"apps"`
+	Topics    *StringSetDiff   `json:"topics"`
+	Scopes    *StringSetDiff   `json:"scopes"`
+	Shops     *StringSetDiff   `json:"shops"`
+}
+
+type Fulfillment struct {
+	ID              string        `json:"id"`
+	OrderID         string        `json:"orderId"`
+	LocationID      *string       `json:"locationId,omitempty"`
+	Status          *string       `json:"status,omitempty"`
+	Service         *string       `json:"service,omitempty"`
+	ShipmentStatus  *string       `json:"shipmentStatus,omitempty"`
+	TrackingCompany *string       `json:"trackingCompany,omitempty"`
+	TrackingNumbers []string      `json:"trackingNumbers"`
+	TrackingUrls    []string      `json:"trackingUrls"`
+	LineItems       []*LineItem   `json:"lineItems"`
+	CreatedAt       *scalars.Time `json:"createdAt,omitempty"`
+	UpdatedAt       *scalars.Time `json:"updatedAt,omitempty"`
+}
+
+type FulfillmentHold struct {
+	Reason      *FulfillmentHoldReason `json:"reason,omitempty"`
+	ReasonNotes *string                `json:"reasonNotes,omitempty"`
+}
+
+type FulfillmentOrder struct {
+	ID                 string                             `json:"id"`
+	OrderID            string                             `json:"orderId"`
+	AssignedLocationID *string                            `json:"assignedLocationId,omitempty"`
+	AssignedLocation   *FulfillmentOrderLocation          `json:"assignedLocation,omitempty"`
+	Status             *FulfillmentOrderStatus            `json:"status,omitempty"`
+	RequestStatus      *FulfillmentOrderRequestStatus     `json:"requestStatus,omitempty"`
+	Destination        *FulfillmentOrderDestination       `json:"destination,omitempty"`
+	DeliveryMethod     *string                            `json:"deliveryMethod,omitempty"`
+	FulfillAt          *scalars.Time                      `json:"fulfillAt,omitempty"`
+	FulfillBy          *scalars.Time                      `json:"fulfillBy,omitempty"`
+	Holds              []*FulfillmentHold                 `json:"holds"`
+	LineItems          []*FulfillmentOrderLineItem        `json:"lineItems"`
+	MerchantRequests   []*FulfillmentOrderMerchantRequest `json:"merchantRequests"`
+	SupportedActions   []string                           `json:"supportedActions"`
+	CreatedAt          *scalars.Time                      `json:"createdAt,omitempty"`
+	UpdatedAt          *scalars.Time                      `json:"updatedAt,omitempty"`
+}
+
+type FulfillmentOrderDestination struct {
+	FirstName *string `json:"firstName,omitempty"`
+	LastName  *string `json:"lastName,omitempty"`
+	Company   *string `json:"company,omitempty"`
+	Address1  *string `json:"address1,omitempty"`
+	Address2  *string `json:"address2,omitempty"`
+	City      *string `json:"city,omitempty"`
+	Province  *string `json:"province,omitempty"`
+	Zip       *string `json:"zip,omitempty"`
+	Country   *string `json:"country,omitempty"`
+	Email     *string `json:"email,omitempty"`
+	Phone     *string `json:"phone,omitempty"`
+}
+
+type FulfillmentOrderLineItem struct {
+	ID                  string  `json:"id"`
+	LineItemID          string  `json:"lineItemId"`
+	VariantID           *string `json:"variantId,omitempty"`
+	InventoryItemID     *string `json:"inventoryItemId,omitempty"`
+	Quantity            int     `json:"quantity"`
+	FulfillableQuantity int     `json:"fulfillableQuantity"`
 }
 
-type ExchangeTokenInput struct {
-	Shop  string  `json:"shop"`
-	Code  string  `json:"code"`
-	State *string `json:"state,omitempty"` // Optional: used to retrieve redirect_uri from session
+type FulfillmentOrderLineItemInput struct {
+	ID       string `json:"id"`
+	Quantity int    `json:"quantity"`
 }
 
-type ExchangeTokenPayload struct {
-	Shop        *Shop  `json:"shop"`
-	AccessToken string `json:"accessToken"`
+type FulfillmentOrderLineItemsInput struct {
+	FulfillmentOrderID string                           `json:"fulfillmentOrderId"`
+	LineItems          []*FulfillmentOrderLineItemInput `json:"lineItems,omitempty"`
+}
+
+type FulfillmentOrderLocation struct {
+	LocationID  *string `json:"locationId,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	Address1    *string `json:"address1,omitempty"`
+	Address2    *string `json:"address2,omitempty"`
+	City        *string `json:"city,omitempty"`
+	Province    *string `json:"province,omitempty"`
+	Zip         *string `json:"zip,omitempty"`
+	CountryCode *string `json:"countryCode,omitempty"`
+	Phone       *string `json:"phone,omitempty"`
+}
+
+type FulfillmentOrderMerchantRequest struct {
+	Kind    *string `json:"kind,omitempty"`
+	Message *string `json:"message,omitempty"`
+}
+
+type FulfillmentOrderMoveResult struct {
+	OriginalFulfillmentOrder *FulfillmentOrder `json:"originalFulfillmentOrder"`
+	MovedFulfillmentOrder    *FulfillmentOrder `json:"movedFulfillmentOrder"`
+}
+
+type FulfillmentRejectionLineItemInput struct {
+	FulfillmentOrderLineItemID string  `json:"fulfillmentOrderLineItemId"`
+	Message                    *string `json:"message,omitempty"`
+}
+
+type FulfillmentRequestInput struct {
+	Domain             string  `json:"domain"`
+	FulfillmentOrderID string  `json:"fulfillmentOrderId"`
+	Message            *string `json:"message,omitempty"`
+}
+
+type FulfillmentTrackingInput struct {
+	Company *string `json:"company,omitempty"`
+	Number  *string `json:"number,omitempty"`
+	URL     *string `json:"url,omitempty"`
+}
+
+type HoldFulfillmentOrderInput struct {
+	Domain             string                `json:"domain"`
+	FulfillmentOrderID string                `json:"fulfillmentOrderId"`
+	Reason             FulfillmentHoldReason `json:"reason"`
+	ReasonNotes        *string               `json:"reasonNotes,omitempty"`
+	NotifyMerchant     *bool                 `json:"notifyMerchant,omitempty"`
 }
 
 type InstallAppInput struct {
@@ -72,30 +628,89 @@ type InstallAppInput struct {
 	RedirectURI *string  `json:"redirectUri,omitempty"`
 	APIKey      *string  `json:"apiKey,omitempty"`
 	APISecret   *string  `json:"apiSecret,omitempty"`
+	AppHandle   *string  `json:"appHandle,omitempty"`
 }
 
 type InstallAppPayload struct {
 	AuthURL string `json:"authUrl"`
 }
 
-type SaveShopInput struct {
-	Domain      string   `json:"domain"`
-	AccessToken string   `json:"accessToken"`
-	Scopes      []string `json:"scopes"`
+type InstallStateCount struct {
+	State InstallState `json:"state"`
+	Count int          `json:"count"`
 }
 
-type SaveShopPayload struct {
-	Shop *Shop `json:"shop"`
+type InstallTransition struct {
+	From   *InstallState `json:"from,omitempty"`
+	To     InstallState  `json:"to"`
+	Reason string        `json:"reason"`
+	At     scalars.Time  `json:"at"`
+}
+
+type Installation struct {
+	ID              string               `json:"id"`
+	ProjectID       string               `json:"projectId"`
+	Environment     string               `json:"environment"`
+	AppHandle       string               `json:"appHandle"`
+	ShopDomain      string               `json:"shopDomain"`
+	State           InstallState         `json:"state"`
+	RequestedScopes []string             `json:"requestedScopes"`
+	GrantedScopes   []string             `json:"grantedScopes"`
+	MissingScopes   []string             `json:"missingScopes"`
+	History         []*InstallTransition `json:"history"`
+	InstalledAt     *scalars.Time        `json:"installedAt,omitempty"`
+	UninstalledAt   *scalars.Time        `json:"uninstalledAt,omitempty"`
+	CreatedAt       scalars.Time         `json:"createdAt"`
+	UpdatedAt       scalars.Time         `json:"updatedAt"`
 }
 
 type Integration struct {
-	ID          string       `json:"id"`
-	Key         string       `json:"key"`
-	ProjectID   string       `json:"projectId"`
-	Environment string       `json:"environment"`
-	ShopDomain  string       `json:"shopDomain"`
-	CreatedAt   scalars.Time `json:"createdAt"`
-	UpdatedAt   scalars.Time `json:"updatedAt"`
+	ID          string            `json:"id"`
+	Key         *string           `json:"key,omitempty"`
+	KeyPrefix   string            `json:"keyPrefix"`
+	ProjectID   string            `json:"projectId"`
+	Environment string            `json:"environment"`
+	ShopDomain  string            `json:"shopDomain"`
+	AppHandle   string            `json:"appHandle"`
+	Scopes      []string          `json:"scopes"`
+	Status      IntegrationStatus `json:"status"`
+	ExpiresAt   *scalars.Time     `json:"expiresAt,omitempty"`
+	LastUsedAt  *scalars.Time     `json:"lastUsedAt,omitempty"`
+	RevokedAt   *scalars.Time     `json:"revokedAt,omitempty"`
+	RotatedToID *string           `json:"rotatedToId,omitempty"`
+	CreatedAt   scalars.Time      `json:"createdAt"`
+	UpdatedAt   scalars.Time      `json:"updatedAt"`
+}
+
+type InventoryAdjustment struct {
+	InventoryLevel *InventoryLevel           `json:"inventoryLevel"`
+	Delta          int                       `json:"delta"`
+	Reason         InventoryAdjustmentReason `json:"reason"`
+	Reference      *string                   `json:"reference,omitempty"`
+}
+
+type InventoryItem struct {
+	ID                           string        `json:"id"`
+	Sku                          *string       `json:"sku,omitempty"`
+	Cost                         *string       `json:"cost,omitempty"`
+	Tracked                      bool          `json:"tracked"`
+	CountryCodeOfOrigin          *string       `json:"countryCodeOfOrigin,omitempty"`
+	ProvinceCodeOfOrigin         *string       `json:"provinceCodeOfOrigin,omitempty"`
+	HarmonizedSystemCode         *string       `json:"harmonizedSystemCode,omitempty"`
+	CountryHarmonizedSystemCodes []string      `json:"countryHarmonizedSystemCodes"`
+	CreatedAt                    *scalars.Time `json:"createdAt,omitempty"`
+	UpdatedAt                    *scalars.Time `json:"updatedAt,omitempty"`
+}
+
+type InventoryItemInput struct {
+	Domain               string  `json:"domain"`
+	ID                   string  `json:"id"`
+	Sku                  *string `json:"sku,omitempty"`
+	Cost                 *string `json:"cost,omitempty"`
+	Tracked              *bool   `json:"tracked,omitempty"`
+	CountryCodeOfOrigin  *string `json:"countryCodeOfOrigin,omitempty"`
+	ProvinceCodeOfOrigin *string `json:"provinceCodeOfOrigin,omitempty"`
+	HarmonizedSystemCode *string `json:"harmonizedSystemCode,omitempty"`
 }
 
 type InventoryLevel struct {
@@ -105,81 +720,3451 @@ type InventoryLevel struct {
 	UpdatedAt       scalars.Time `json:"updatedAt"`
 }
 
+type InventoryLevelConnection struct {
+	Nodes    []*InventoryLevel `json:"nodes"`
+	PageInfo *PageInfo         `json:"pageInfo"`
+}
+
+type InventoryLevelFilter struct {
+	InventoryItemIds []string      `json:"inventoryItemIds,omitempty"`
+	LocationIds      []string      `json:"locationIds,omitempty"`
+	UpdatedAtMin     *scalars.Time `json:"updatedAtMin,omitempty"`
+}
+
+type InventoryLevelLocationInput struct {
+	Domain              string `json:"domain"`
+	InventoryItemID     string `json:"inventoryItemId"`
+	LocationID          string `json:"locationId"`
+	RelocateIfNecessary *bool  `json:"relocateIfNecessary,omitempty"`
+}
+
+type LineItem struct {
+	ID                  string           `json:"id"`
+	ProductID           *string          `json:"productId,omitempty"`
+	VariantID           *string          `json:"variantId,omitempty"`
+	Title               string           `json:"title"`
+	VariantTitle        *string          `json:"variantTitle,omitempty"`
+	Name                *string          `json:"name,omitempty"`
+	Sku                 *string          `json:"sku,omitempty"`
+	Vendor              *string          `json:"vendor,omitempty"`
+	Quantity            int              `json:"quantity"`
+	CurrentQuantity     int              `json:"currentQuantity"`
+	FulfillableQuantity int              `json:"fulfillableQuantity"`
+	FulfillmentStatus   *string          `json:"fulfillmentStatus,omitempty"`
+	Price               *string          `json:"price,omitempty"`
+	TotalDiscount       *string          `json:"totalDiscount,omitempty"`
+	Grams               int              `json:"grams"`
+	Taxable             bool             `json:"taxable"`
+	RequiresShipping    bool             `json:"requiresShipping"`
+	GiftCard            bool             `json:"giftCard"`
+	Properties          []*NoteAttribute `json:"properties"`
+	TaxLines            []*TaxLine       `json:"taxLines"`
+}
+
+type Location struct {
+	ID           string        `json:"id"`
+	Name         string        `json:"name"`
+	Active       bool          `json:"active"`
+	Legacy       bool          `json:"legacy"`
+	Address1     *string       `json:"address1,omitempty"`
+	Address2     *string       `json:"address2,omitempty"`
+	City         *string       `json:"city,omitempty"`
+	Province     *string       `json:"province,omitempty"`
+	ProvinceCode *string       `json:"provinceCode,omitempty"`
+	Country      *string       `json:"country,omitempty"`
+	CountryCode  *string       `json:"countryCode,omitempty"`
+	Zip          *string       `json:"zip,omitempty"`
+	Phone        *string       `json:"phone,omitempty"`
+	CreatedAt    *scalars.Time `json:"createdAt,omitempty"`
+	UpdatedAt    *scalars.Time `json:"updatedAt,omitempty"`
+}
+
+type LocationInventoryLevel struct {
+	Location  *Location     `json:"location"`
+	Stocked   bool          `json:"stocked"`
+	Available *int          `json:"available,omitempty"`
+	UpdatedAt *scalars.Time `json:"updatedAt,omitempty"`
+}
+
+type MailingAddress struct {
+	FirstName    *string  `json:"firstName,omitempty"`
+	LastName     *string  `json:"lastName,omitempty"`
+	Name         *string  `json:"name,omitempty"`
+	Company      *string  `json:"company,omitempty"`
+	Address1     *string  `json:"address1,omitempty"`
+	Address2     *string  `json:"address2,omitempty"`
+	City         *string  `json:"city,omitempty"`
+	Province     *string  `json:"province,omitempty"`
+	ProvinceCode *string  `json:"provinceCode,omitempty"`
+	Country      *string  `json:"country,omitempty"`
+	CountryCode  *string  `json:"countryCode,omitempty"`
+	Zip          *string  `json:"zip,omitempty"`
+	Phone        *string  `json:"phone,omitempty"`
+	Latitude     *float64 `json:"latitude,omitempty"`
+	Longitude    *float64 `json:"longitude,omitempty"`
+}
+
+type MarketingConsent struct {
+	State                *MarketingState      `json:"state,omitempty"`
+	OptInLevel           *MarketingOptInLevel `json:"optInLevel,omitempty"`
+	ConsentUpdatedAt     *scalars.Time        `json:"consentUpdatedAt,omitempty"`
+	ConsentCollectedFrom *string              `json:"consentCollectedFrom,omitempty"`
+}
+
+type Metafield struct {
+	ID          string              `json:"id"`
+	Namespace   string              `json:"namespace"`
+	Key         string              `json:"key"`
+	Type        string              `json:"type"`
+	Value       string              `json:"value"`
+	JSONValue   any                 `json:"jsonValue,omitempty"`
+	Description *string             `json:"description,omitempty"`
+	OwnerType   *MetafieldOwnerType `json:"ownerType,omitempty"`
+	OwnerID     *string             `json:"ownerId,omitempty"`
+	CreatedAt   *scalars.Time       `json:"createdAt,omitempty"`
+	UpdatedAt   *scalars.Time       `json:"updatedAt,omitempty"`
+}
+
+type MetafieldConnection struct {
+	Edges    []*MetafieldEdge `json:"edges"`
+	Nodes    []*Metafield     `json:"nodes"`
+	PageInfo *PageInfo        `json:"pageInfo"`
+}
+
+type MetafieldDefinition struct {
+	ID          string                 `json:"id"`
+	OwnerType   MetafieldOwnerType     `json:"ownerType"`
+	Namespace   string                 `json:"namespace"`
+	Key         string                 `json:"key"`
+	Name        string                 `json:"name"`
+	Description *string                `json:"description,omitempty"`
+	Type        string                 `json:"type"`
+	Validations []*MetafieldValidation `json:"validations"`
+	Pinned      bool                   `json:"pinned"`
+}
+
+type MetafieldDefinitionInput struct {
+	Domain      string                      `json:"domain"`
+	OwnerType   MetafieldOwnerType          `json:"ownerType"`
+	Namespace   string                      `json:"namespace"`
+	Key         string                      `json:"key"`
+	Name        string                      `json:"name"`
+	Description *string                     `json:"description,omitempty"`
+	Type        *string                     `json:"type,omitempty"`
+	Validations []*MetafieldValidationInput `json:"validations,omitempty"`
+	Pin         *bool                       `json:"pin,omitempty"`
+}
+
+type MetafieldEdge struct {
+	Cursor string     `json:"cursor"`
+	Node   *Metafield `json:"node"`
+}
+
+type MetafieldInput struct {
+	Domain      string               `json:"domain"`
+	Owner       *MetafieldOwnerInput `json:"owner"`
+	ID          *string              `json:"id,omitempty"`
+	Namespace   *string              `json:"namespace,omitempty"`
+	Key         *string              `json:"key,omitempty"`
+	Type        *string              `json:"type,omitempty"`
+	Value       *string              `json:"value,omitempty"`
+	JSONValue   any                  `json:"jsonValue,omitempty"`
+	Description *string              `json:"description,omitempty"`
+}
+
+type MetafieldOwnerInput struct {
+	Type MetafieldOwnerType `json:"type"`
+	ID   *string            `json:"id,omitempty"`
+}
+
+type MetafieldValidation struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type MetafieldValidationInput struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type Metaobject struct {
+	ID          string             `json:"id"`
+	Type        string             `json:"type"`
+	Handle      string             `json:"handle"`
+	DisplayName *string            `json:"displayName,omitempty"`
+	Fields      []*MetaobjectField `json:"fields"`
+	Field       *MetaobjectField   `json:"field,omitempty"`
+	UpdatedAt   *scalars.Time      `json:"updatedAt,omitempty"`
+}
+
+type MetaobjectConnection struct {
+	Edges    []*MetaobjectEdge `json:"edges"`
+	Nodes    []*Metaobject     `json:"nodes"`
+	PageInfo *PageInfo         `json:"pageInfo"`
+}
+
+type MetaobjectDefinition struct {
+	ID               string                       `json:"id"`
+	Type             string                       `json:"type"`
+	Name             string                       `json:"name"`
+	Description      *string                      `json:"description,omitempty"`
+	DisplayNameKey   *string                      `json:"displayNameKey,omitempty"`
+	Fields           []*MetaobjectFieldDefinition `json:"fields"`
+	MetaobjectsCount int                          `json:"metaobjectsCount"`
+}
+
+type MetaobjectDefinitionInput struct {
+	Domain         string                            `json:"domain"`
+	ID             *string                           `json:"id,omitempty"`
+	Type           *string                           `json:"type,omitempty"`
+	Name           *string                           `json:"name,omitempty"`
+	Description    *string                           `json:"description,omitempty"`
+	DisplayNameKey *string                           `json:"displayNameKey,omitempty"`
+	Fields         []*MetaobjectFieldDefinitionInput `json:"fields,omitempty"`
+	DeleteFields   []string                          `json:"deleteFields,omitempty"`
+}
+
+type MetaobjectEdge struct {
+	Cursor string      `json:"cursor"`
+	Node   *Metaobject `json:"node"`
+}
+
+type MetaobjectField struct {
+	Key       string  `json:"key"`
+	Type      string  `json:"type"`
+	Value     *string `json:"value,omitempty"`
+	JSONValue any     `json:"jsonValue,omitempty"`
+}
+
+type MetaobjectFieldDefinition struct {
+	Key         string                 `json:"key"`
+	Name        string                 `json:"name"`
+	Description *string                `json:"description,omitempty"`
+	Type        string                 `json:"type"`
+	Required    bool                   `json:"required"`
+	Validations []*MetafieldValidation `json:"validations"`
+}
+
+type MetaobjectFieldDefinitionInput struct {
+	Key         string                      `json:"key"`
+	Name        string                      `json:"name"`
+	Description *string                     `json:"description,omitempty"`
+	Type        *string                     `json:"type,omitempty"`
+	Required    *bool                       `json:"required,omitempty"`
+	Validations []*MetafieldValidationInput `json:"validations,omitempty"`
+}
+
+type MetaobjectFieldInput struct {
+	Key       string  `json:"key"`
+	Value     *string `json:"value,omitempty"`
+	JSONValue any     `json:"jsonValue,omitempty"`
+}
+
+type MetaobjectInput struct {
+	Domain string                  `json:"domain"`
+	ID     *string                 `json:"id,omitempty"`
+	Type   *string                 `json:"type,omitempty"`
+	Handle *string                 `json:"handle,omitempty"`
+	Fields []*MetaobjectFieldInput `json:"fields"`
+}
+
+type MoveFulfillmentOrderInput struct {
+	Domain             string                           `json:"domain"`
+	FulfillmentOrderID string                           `json:"fulfillmentOrderId"`
+	NewLocationID      string                           `json:"newLocationId"`
+	LineItems          []*FulfillmentOrderLineItemInput `json:"lineItems,omitempty"`
+}
+
 type Mutation struct {
 }
 
-type Order struct {
-	ID                string       `json:"id"`
-	OrderNumber       int          `json:"orderNumber"`
-	Email             *string      `json:"email,omitempty"`
-	TotalPrice        string       `json:"totalPrice"`
-	FinancialStatus   *string      `json:"financialStatus,omitempty"`
-	FulfillmentStatus *string      `json:"fulfillmentStatus,omitempty"`
-	CreatedAt         scalars.Time `json:"createdAt"`
-	UpdatedAt         scalars.Time `json:"updatedAt"`
+type NoteAttribute struct {
+	Name  string  `json:"name"`
+	Value *string `json:"value,omitempty"`
 }
 
-type Product struct {
-	ID          string       `json:"id"`
-	Title       string       `json:"title"`
-	Handle      *string      `json:"handle,omitempty"`
-	Vendor      *string      `json:"vendor,omitempty"`
-	ProductType *string      `json:"productType,omitempty"`
-	CreatedAt   scalars.Time `json:"createdAt"`
-	UpdatedAt   scalars.Time `json:"updatedAt"`
+type NoteAttributeInput struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
 }
 
-type Query struct {
+type OrderConnection struct {
+	Edges    []*OrderEdge `json:"edges"`
+	Nodes    []*Order     `json:"nodes"`
+	PageInfo *PageInfo    `json:"pageInfo"`
 }
 
-type Shop struct {
-	ID        string       `json:"id"`
-	Domain    string       `json:"domain"`
-	Scopes    []string     `json:"scopes"`
-	CreatedAt scalars.Time `json:"createdAt"`
-	UpdatedAt scalars.Time `json:"updatedAt"`
+type OrderDiscountCode struct {
+	Code   string  `json:"code"`
+	Amount *string `json:"amount,omitempty"`
+	Type   *string `json:"type,omitempty"`
 }
 
-type ShopifyConfig struct {
-	ID          string       `json:"id"`
-	ProjectID   string       `json:"projectId"`
-	Environment string       `json:"environment"`
-	APIKey      string       `json:"apiKey"`
-	WebhookURL  string       `json:"webhookUrl"`
-	CreatedAt   scalars.Time `json:"createdAt"`
-	UpdatedAt   scalars.Time `json:"updatedAt"`
+type OrderEdge struct {
+	Cursor string `json:"cursor"`
+	Node   *Order `json:"node"`
 }
 
-type ShopifyCredentials struct {
-	ID          string       `json:"id"`
-	ProjectID   string       `json:"projectId"`
-	Environment string       `json:"environment"`
-	APIKey      string       `json:"apiKey"`
-	CreatedAt   scalars.Time `json:"createdAt"`
-	UpdatedAt   scalars.Time `json:"updatedAt"`
+type OrderFilter struct {
+	Ids               []string                `json:"ids,omitempty"`
+	Status            *OrderStatus            `json:"status,omitempty"`
+	FinancialStatus   *OrderFinancialFilter   `json:"financialStatus,omitempty"`
+	FulfillmentStatus *OrderFulfillmentFilter `json:"fulfillmentStatus,omitempty"`
+	CreatedAtMin      *scalars.Time           `json:"createdAtMin,omitempty"`
+	CreatedAtMax      *scalars.Time           `json:"createdAtMax,omitempty"`
+	UpdatedAtMin      *scalars.Time           `json:"updatedAtMin,omitempty"`
+	UpdatedAtMax      *scalars.Time           `json:"updatedAtMax,omitempty"`
+	ProcessedAtMin    *scalars.Time           `json:"processedAtMin,omitempty"`
+	ProcessedAtMax    *scalars.Time           `json:"processedAtMax,omitempty"`
 }
 
-type Subscription struct {
+type OrderInput struct {
+	Domain                 string                   `json:"domain"`
+	ID                     *string                  `json:"id,omitempty"`
+	Email                  *string                  `json:"email,omitempty"`
+	Phone                  *string                  `json:"phone,omitempty"`
+	Note                   *string                  `json:"note,omitempty"`
+	Tags                   []string                 `json:"tags,omitempty"`
+	Currency               *string                  `json:"currency,omitempty"`
+	FinancialStatus        *OrderFinancialStatus    `json:"financialStatus,omitempty"`
+	InventoryBehaviour     *InventoryBehaviour      `json:"inventoryBehaviour,omitempty"`
+	SendReceipt            *bool                    `json:"sendReceipt,omitempty"`
+	SendFulfillmentReceipt *bool                    `json:"sendFulfillmentReceipt,omitempty"`
+	Test                   *bool                    `json:"test,omitempty"`
+	CustomerID             *string                  `json:"customerId,omitempty"`
+	LineItems              []*OrderLineItemInput    `json:"lineItems,omitempty"`
+	BillingAddress         *AddressInput            `json:"billingAddress,omitempty"`
+	ShippingAddress        *AddressInput            `json:"shippingAddress,omitempty"`
+	Transactions           []*OrderTransactionInput `json:"transactions,omitempty"`
+	NoteAttributes         []*NoteAttributeInput    `json:"noteAttributes,omitempty"`
+	Order                  *string                  `json:"order,omitempty"`
 }
 
-type WebhookEvent struct {
-	ID        string       `json:"id"`
-	Topic     string       `json:"topic"`
-	Shop      string       `json:"shop"`
-	Verified  bool         `json:"verified"`
-	CreatedAt scalars.Time `json:"createdAt"`
+type OrderLineItemInput struct {
+	VariantID        *string               `json:"variantId,omitempty"`
+	Title            *string               `json:"title,omitempty"`
+	Price            *string               `json:"price,omitempty"`
+	Quantity         int                   `json:"quantity"`
+	Sku              *string               `json:"sku,omitempty"`
+	Grams            *int                  `json:"grams,omitempty"`
+	Taxable          *bool                 `json:"taxable,omitempty"`
+	RequiresShipping *bool                 `json:"requiresShipping,omitempty"`
+	Properties       []*NoteAttributeInput `json:"properties,omitempty"`
 }
 
-type WebhookEventFilter struct {
-	Topics []string `json:"topics,omitempty"`
-	Shop   *string  `json:"shop,omitempty"`
+type OrderPayload struct {
+	Order *Order `json:"order"`
 }
 
-type WebhookEventPayload struct {
-	ID        string       `json:"id"`
-	Topic     string       `json:"topic"`
-	Shop      string       `json:"shop"`
-	Verified  bool         `json:"verified"`
-	Payload   string       `json:"payload"`
-	CreatedAt scalars.Time `json:"createdAt"`
+type OrderReturn struct {
+	ID            string            `json:"id"`
+	OrderID       string            `json:"orderId"`
+	Name          string            `json:"name"`
+	Status        *ReturnStatus     `json:"status,omitempty"`
+	TotalQuantity int               `json:"totalQuantity"`
+	LineItems     []*ReturnLineItem `json:"lineItems"`
+}
+
+type OrderTransactionInput struct {
+	Kind          TransactionKind    `json:"kind"`
+	Status        *TransactionStatus `json:"status,omitempty"`
+	Amount        string             `json:"amount"`
+	Gateway       *string            `json:"gateway,omitempty"`
+	Currency      *string            `json:"currency,omitempty"`
+	Authorization *string            `json:"authorization,omitempty"`
+}
+
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor,omitempty"`
+	EndCursor       *string `json:"endCursor,omitempty"`
+}
+
+type PriceRuleConnection struct {
+	Edges    []*PriceRuleEdge `json:"edges"`
+	Nodes    []*PriceRule     `json:"nodes"`
+	PageInfo *PageInfo        `json:"pageInfo"`
+}
+
+type PriceRuleEdge struct {
+	Cursor string     `json:"cursor"`
+	Node   *PriceRule `json:"node"`
+}
+
+type PriceRuleEntitlements struct {
+	ProductIds    []string `json:"productIds"`
+	VariantIds    []string `json:"variantIds"`
+	CollectionIds []string `json:"collectionIds"`
+	CountryIds    []string `json:"countryIds"`
+}
+
+type PriceRuleEntitlementsInput struct {
+	ProductIds    []string `json:"productIds,omitempty"`
+	VariantIds    []string `json:"variantIds,omitempty"`
+	CollectionIds []string `json:"collectionIds,omitempty"`
+	CountryIds    []string `json:"countryIds,omitempty"`
+}
+
+type PriceRuleFilter struct {
+	CreatedAtMin *scalars.Time `json:"createdAtMin,omitempty"`
+	CreatedAtMax *scalars.Time `json:"createdAtMax,omitempty"`
+	UpdatedAtMin *scalars.Time `json:"updatedAtMin,omitempty"`
+	UpdatedAtMax *scalars.Time `json:"updatedAtMax,omitempty"`
+	StartsAtMin  *scalars.Time `json:"startsAtMin,omitempty"`
+	StartsAtMax  *scalars.Time `json:"startsAtMax,omitempty"`
+	EndsAtMin    *scalars.Time `json:"endsAtMin,omitempty"`
+	EndsAtMax    *scalars.Time `json:"endsAtMax,omitempty"`
+	TimesUsed    *int          `json:"timesUsed,omitempty"`
+}
+
+type PriceRuleInput struct {
+	Domain            string                       `json:"domain"`
+	ID                *string                      `json:"id,omitempty"`
+	Title             *string                      `json:"title,omitempty"`
+	ValueType         *PriceRuleValueType          `json:"valueType,omitempty"`
+	Value             *string                      `json:"value,omitempty"`
+	TargetType        *PriceRuleTargetType         `json:"targetType,omitempty"`
+	TargetSelection   *PriceRuleTargetSelection    `json:"targetSelection,omitempty"`
+	AllocationMethod  *PriceRuleAllocationMethod   `json:"allocationMethod,omitempty"`
+	AllocationLimit   *int                         `json:"allocationLimit,omitempty"`
+	CustomerSelection *PriceRuleCustomerSelection  `json:"customerSelection,omitempty"`
+	OncePerCustomer   *bool                        `json:"oncePerCustomer,omitempty"`
+	UsageLimit        *int                         `json:"usageLimit,omitempty"`
+	StartsAt          *scalars.Time                `json:"startsAt,omitempty"`
+	EndsAt            *scalars.Time                `json:"endsAt,omitempty"`
+	Entitlements      *PriceRuleEntitlementsInput  `json:"entitlements,omitempty"`
+	Prerequisites     *PriceRulePrerequisitesInput `json:"prerequisites,omitempty"`
+}
+
+type PriceRulePrerequisites struct {
+	ProductIds                 []string                `json:"productIds"`
+	VariantIds                 []string                `json:"variantIds"`
+	CollectionIds              []string                `json:"collectionIds"`
+	CustomerIds                []string                `json:"customerIds"`
+	SavedSearchIds             []string                `json:"savedSearchIds"`
+	SubtotalMin                *string                 `json:"subtotalMin,omitempty"`
+	QuantityMin                *int                    `json:"quantityMin,omitempty"`
+	ShippingPriceMax           *string                 `json:"shippingPriceMax,omitempty"`
+	ToEntitlementQuantityRatio *PriceRuleQuantityRatio `json:"toEntitlementQuantityRatio,omitempty"`
+}
+
+type PriceRulePrerequisitesInput struct {
+	ProductIds                 []string                     `json:"productIds,omitempty"`
+	VariantIds                 []string                     `json:"variantIds,omitempty"`
+	CollectionIds              []string                     `json:"collectionIds,omitempty"`
+	CustomerIds                []string                     `json:"customerIds,omitempty"`
+	SavedSearchIds             []string                     `json:"savedSearchIds,omitempty"`
+	SubtotalMin                *string                      `json:"subtotalMin,omitempty"`
+	QuantityMin                *int                         `json:"quantityMin,omitempty"`
+	ShippingPriceMax           *string                      `json:"shippingPriceMax,omitempty"`
+	ToEntitlementQuantityRatio *PriceRuleQuantityRatioInput `json:"toEntitlementQuantityRatio,omitempty"`
+}
+
+type PriceRuleQuantityRatio struct {
+	PrerequisiteQuantity int `json:"prerequisiteQuantity"`
+	EntitledQuantity     int `json:"entitledQuantity"`
+}
+
+type PriceRuleQuantityRatioInput struct {
+	PrerequisiteQuantity int `json:"prerequisiteQuantity"`
+	EntitledQuantity     int `json:"entitledQuantity"`
+}
+
+type ProductConnection struct {
+	Edges    []*ProductEdge `json:"edges"`
+	Nodes    []*Product     `json:"nodes"`
+	PageInfo *PageInfo      `json:"pageInfo"`
+}
+
+type ProductEdge struct {
+	Cursor string   `json:"cursor"`
+	Node   *Product `json:"node"`
+}
+
+type ProductFilter struct {
+	Ids            []string        `json:"ids,omitempty"`
+	Status         []ProductStatus `json:"status,omitempty"`
+	Vendor         *string         `json:"vendor,omitempty"`
+	ProductType    *string         `json:"productType,omitempty"`
+	Handle         *string         `json:"handle,omitempty"`
+	Title          *string         `json:"title,omitempty"`
+	CollectionID   *string         `json:"collectionId,omitempty"`
+	CreatedAtMin   *scalars.Time   `json:"createdAtMin,omitempty"`
+	CreatedAtMax   *scalars.Time   `json:"createdAtMax,omitempty"`
+	UpdatedAtMin   *scalars.Time   `json:"updatedAtMin,omitempty"`
+	UpdatedAtMax   *scalars.Time   `json:"updatedAtMax,omitempty"`
+	PublishedAtMin *scalars.Time   `json:"publishedAtMin,omitempty"`
+	PublishedAtMax *scalars.Time   `json:"publishedAtMax,omitempty"`
+}
+
+type ProductImage struct {
+	ID         string        `json:"id"`
+	ProductID  string        `json:"productId"`
+	Position   int           `json:"position"`
+	Src        string        `json:"src"`
+	Alt        *string       `json:"alt,omitempty"`
+	Width      int           `json:"width"`
+	Height     int           `json:"height"`
+	VariantIds []string      `json:"variantIds"`
+	CreatedAt  *scalars.Time `json:"createdAt,omitempty"`
+	UpdatedAt  *scalars.Time `json:"updatedAt,omitempty"`
+}
+
+type ProductImageInput struct {
+	ID         *string `json:"id,omitempty"`
+	Src        *string `json:"src,omitempty"`
+	Attachment *string `json:"attachment,omitempty"`
+	Filename   *string `json:"filename,omitempty"`
+	Alt        *string `json:"alt,omitempty"`
+	Position   *int    `json:"position,omitempty"`
+}
+
+type ProductInput struct {
+	Domain      string                 `json:"domain"`
+	ID          *string                `json:"id,omitempty"`
+	Title       *string                `json:"title,omitempty"`
+	BodyHTML    *string                `json:"bodyHtml,omitempty"`
+	Vendor      *string                `json:"vendor,omitempty"`
+	ProductType *string                `json:"productType,omitempty"`
+	Handle      *string                `json:"handle,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
+	Status      *ProductStatus         `json:"status,omitempty"`
+	Options     []*ProductOptionInput  `json:"options,omitempty"`
+	Variants    []*ProductVariantInput `json:"variants,omitempty"`
+	Images      []*ProductImageInput   `json:"images,omitempty"`
+	Product     *string                `json:"product,omitempty"`
+}
+
+type ProductOption struct {
+	ID        string   `json:"id"`
+	ProductID string   `json:"productId"`
+	Name      string   `json:"name"`
+	Position  int      `json:"position"`
+	Values    []string `json:"values"`
+}
+
+type ProductOptionInput struct {
+	ID     *string  `json:"id,omitempty"`
+	Name   string   `json:"name"`
+	Values []string `json:"values,omitempty"`
+}
+
+type ProductPayload struct {
+	Product *Product `json:"product"`
+}
+
+type ProductVariantInput struct {
+	ID                  *string          `json:"id,omitempty"`
+	Title               *string          `json:"title,omitempty"`
+	Sku                 *string          `json:"sku,omitempty"`
+	Barcode             *string          `json:"barcode,omitempty"`
+	Price               *string          `json:"price,omitempty"`
+	CompareAtPrice      *string          `json:"compareAtPrice,omitempty"`
+	Option1             *string          `json:"option1,omitempty"`
+	Option2             *string          `json:"option2,omitempty"`
+	Option3             *string          `json:"option3,omitempty"`
+	Position            *int             `json:"position,omitempty"`
+	InventoryPolicy     *InventoryPolicy `json:"inventoryPolicy,omitempty"`
+	InventoryManagement *string          `json:"inventoryManagement,omitempty"`
+	Taxable             *bool            `json:"taxable,omitempty"`
+	RequiresShipping    *bool            `json:"requiresShipping,omitempty"`
+	Weight              *float64         `json:"weight,omitempty"`
+	WeightUnit          *WeightUnit      `json:"weightUnit,omitempty"`
+}
+
+type ProjectEnvironment struct {
+	Name          string               `json:"name"`
+	Apps          []*ShopifyConfig     `json:"apps"`
+	Installations []*InstallStateCount `json:"installations"`
+	ActiveShops   int                  `json:"activeShops"`
+}
+
+type Query struct {
+}
+
+type RateLimitTier struct {
+	Name                 string `json:"name"`
+	RequestsPerMinute    *int   `json:"requestsPerMinute,omitempty"`
+	KeyRequestsPerMinute *int   `json:"keyRequestsPerMinute,omitempty"`
+	DailyQuota           *int   `json:"dailyQuota,omitempty"`
+	MonthlyQuota         *int   `json:"monthlyQuota,omitempty"`
+}
+
+type Refund struct {
+	ID              string            `json:"id"`
+	OrderID         string            `json:"orderId"`
+	Note            *string           `json:"note,omitempty"`
+	Restock         bool              `json:"restock"`
+	RefundLineItems []*RefundLineItem `json:"refundLineItems"`
+	Transactions    []*Transaction    `json:"transactions"`
+	CreatedAt       *scalars.Time     `json:"createdAt,omitempty"`
+}
+
+type RefundCalculation struct {
+	Currency     *string                       `json:"currency,omitempty"`
+	Total        string                        `json:"total"`
+	Shipping     *RefundShippingCalculation    `json:"shipping"`
+	LineItems    []*RefundLineItemCalculation  `json:"lineItems"`
+	Transactions []*SuggestedRefundTransaction `json:"transactions"`
+}
+
+type RefundInput struct {
+	Note         *string                   `json:"note,omitempty"`
+	Notify       *bool                     `json:"notify,omitempty"`
+	Currency     *string                   `json:"currency,omitempty"`
+	Shipping     *RefundShippingInput      `json:"shipping,omitempty"`
+	LineItems    []*RefundLineItemInput    `json:"lineItems,omitempty"`
+	Transactions []*RefundTransactionInput `json:"transactions,omitempty"`
+}
+
+type RefundLineItem struct {
+	ID         string  `json:"id"`
+	LineItemID string  `json:"lineItemId"`
+	Quantity   int     `json:"quantity"`
+	Subtotal   *string `json:"subtotal,omitempty"`
+	TotalTax   *string `json:"totalTax,omitempty"`
+}
+
+type RefundLineItemCalculation struct {
+	LineItemID      string       `json:"lineItemId"`
+	Quantity        int          `json:"quantity"`
+	RestockType     *RestockType `json:"restockType,omitempty"`
+	LocationID      *string      `json:"locationId,omitempty"`
+	Price           *string      `json:"price,omitempty"`
+	DiscountedPrice *string      `json:"discountedPrice,omitempty"`
+	Subtotal        *string      `json:"subtotal,omitempty"`
+	TotalTax        *string      `json:"totalTax,omitempty"`
+}
+
+type RefundLineItemInput struct {
+	LineItemID  string       `json:"lineItemId"`
+	Quantity    int          `json:"quantity"`
+	RestockType *RestockType `json:"restockType,omitempty"`
+	LocationID  *string      `json:"locationId,omitempty"`
+}
+
+type RefundShippingCalculation struct {
+	Amount            *string `json:"amount,omitempty"`
+	Tax               *string `json:"tax,omitempty"`
+	MaximumRefundable *string `json:"maximumRefundable,omitempty"`
+}
+
+type RefundShippingInput struct {
+	FullRefund *bool   `json:"fullRefund,omitempty"`
+	Amount     *string `json:"amount,omitempty"`
+}
+
+type RefundTransactionInput struct {
+	ParentID string `json:"parentId"`
+	Amount   string `json:"amount"`
+	Gateway  string `json:"gateway"`
+}
+
+type RejectFulfillmentRequestInput struct {
+	Domain             string                               `json:"domain"`
+	FulfillmentOrderID string                               `json:"fulfillmentOrderId"`
+	Reason             *FulfillmentRejectionReason          `json:"reason,omitempty"`
+	Message            *string                              `json:"message,omitempty"`
+	LineItems          []*FulfillmentRejectionLineItemInput `json:"lineItems,omitempty"`
+}
+
+type ReturnLineItem struct {
+	ID                    string        `json:"id"`
+	FulfillmentLineItemID *string       `json:"fulfillmentLineItemId,omitempty"`
+	LineItemID            *string       `json:"lineItemId,omitempty"`
+	Quantity              int           `json:"quantity"`
+	Reason                *ReturnReason `json:"reason,omitempty"`
+	ReasonNote            *string       `json:"reasonNote,omitempty"`
+}
+
+type ReturnLineItemInput struct {
+	LineItemID string       `json:"lineItemId"`
+	Quantity   int          `json:"quantity"`
+	Reason     ReturnReason `json:"reason"`
+	ReasonNote *string      `json:"reasonNote,omitempty"`
+}
+
+type SaveShopInput struct {
+	Domain                string   `json:"domain"`
+	AccessToken           string   `json:"accessToken"`
+	Scopes                []string `json:"scopes"`
+	ExpiresIn             *int     `json:"expiresIn,omitempty"`
+	RefreshToken          *string  `json:"refreshToken,omitempty"`
+	RefreshTokenExpiresIn *int     `json:"refreshTokenExpiresIn,omitempty"`
+}
+
+type SaveShopPayload struct {
+	Shop *Shop `json:"shop"`
+}
+
+type SetInventoryLevelInput struct {
+	Domain                string `json:"domain"`
+	InventoryItemID       string `json:"inventoryItemId"`
+	LocationID            string `json:"locationId"`
+	Available             int    `json:"available"`
+	DisconnectIfNecessary *bool  `json:"disconnectIfNecessary,omitempty"`
+}
+
+type ShippingLine struct {
+	ID                string     `json:"id"`
+	Title             string     `json:"title"`
+	Code              *string    `json:"code,omitempty"`
+	Source            *string    `json:"source,omitempty"`
+	Price             *string    `json:"price,omitempty"`
+	DiscountedPrice   *string    `json:"discountedPrice,omitempty"`
+	CarrierIdentifier *string    `json:"carrierIdentifier,omitempty"`
+	TaxLines          []*TaxLine `json:"taxLines"`
+}
+
+type Shop struct {
+	ID                    string        `json:"id"`
+	ProjectID             string        `json:"projectId"`
+	Environment           string        `json:"environment"`
+	Domain                string        `json:"domain"`
+	Scopes                []string      `json:"scopes"`
+	AppHandle             string        `json:"appHandle"`
+	TokenStatus           TokenStatus   `json:"tokenStatus"`
+	AccessTokenExpiresAt  *scalars.Time `json:"accessTokenExpiresAt,omitempty"`
+	RefreshTokenExpiresAt *scalars.Time `json:"refreshTokenExpiresAt,omitempty"`
+	TokenRefreshedAt      *scalars.Time `json:"tokenRefreshedAt,omitempty"`
+	TokenRefreshError     *string       `json:"tokenRefreshError,omitempty"`
+	CreatedAt             scalars.Time  `json:"createdAt"`
+	UpdatedAt             scalars.Time  `json:"updatedAt"`
+	Metafield             *Metafield    `json:"metafield,omitempty"`
+	Metafields            []*Metafield  `json:"metafields"`
+}
+
+type ShopifyConfig struct {
+	ID                    string       `json:"id"`
+	ProjectID             string       `json:"projectId"`
+	Environment           string       `json:"environment"`
+	AppHandle             string       `json:"appHandle"`
+	APIKey                string       `json:"apiKey"`
+	WebhookURL            string       `json:"webhookUrl"`
+	ExpiringOfflineTokens bool         `json:"expiringOfflineTokens"`
+	CreatedAt             scalars.Time `json:"createdAt"`
+	UpdatedAt             scalars.Time `json:"updatedAt"`
+}
+
+type ShopifyCredentials struct {
+	ID          string       `json:"id"`
+	ProjectID   string       `json:"projectId"`
+	Environment string       `json:"environment"`
+	APIKey      string       `json:"apiKey"`
+	CreatedAt   scalars.Time `json:"createdAt"`
+	UpdatedAt   scalars.Time `json:"updatedAt"`
+}
+
+type SmsMarketingConsentInput struct {
+	State                MarketingState       `json:"state"`
+	OptInLevel           *MarketingOptInLevel `json:"optInLevel,omitempty"`
+	ConsentUpdatedAt     *scalars.Time        `json:"consentUpdatedAt,omitempty"`
+	ConsentCollectedFrom *string              `json:"consentCollectedFrom,omitempty"`
+}
+
+type StagedUploadParameter struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type StagedUploadTarget struct {
+	URL              string                   `json:"url"`
+	ResourceURL      *string                  `json:"resourceUrl,omitempty"`
+	StagedUploadPath string                   `json:"stagedUploadPath"`
+	Parameters       []*StagedUploadParameter `json:"parameters"`
+}
+
+type StorefrontToken struct {
+	ID           string        `json:"id"`
+	Title        string        `json:"title"`
+	AccessToken  *string       `json:"accessToken,omitempty"`
+	AccessScopes []string      `json:"accessScopes"`
+	CreatedBy    *string       `json:"createdBy,omitempty"`
+	CreatedAt    *scalars.Time `json:"createdAt,omitempty"`
+}
+
+type StringSetDiff struct {
+	OnlyInSource []string `json:"onlyInSource"`
+	OnlyInTarget []string `json:"onlyInTarget"`
+	Common       []string `json:"common"`
+}
+
+type Subscription struct {
+}
+
+type SuggestedRefundTransaction struct {
+	ParentID          string  `json:"parentId"`
+	Amount            *string `json:"amount,omitempty"`
+	MaximumRefundable *string `json:"maximumRefundable,omitempty"`
+	Kind              *string `json:"kind,omitempty"`
+	Gateway           *string `json:"gateway,omitempty"`
+}
+
+type TaxLine struct {
+	Title string  `json:"title"`
+	Price *string `json:"price,omitempty"`
+	Rate  *string `json:"rate,omitempty"`
+}
+
+type Transaction struct {
+	ID            string             `json:"id"`
+	OrderID       string             `json:"orderId"`
+	ParentID      *string            `json:"parentId,omitempty"`
+	Kind          *TransactionKind   `json:"kind,omitempty"`
+	Status        *TransactionStatus `json:"status,omitempty"`
+	Amount        *string            `json:"amount,omitempty"`
+	Currency      *string            `json:"currency,omitempty"`
+	Gateway       *string            `json:"gateway,omitempty"`
+	Authorization *string            `json:"authorization,omitempty"`
+	Message       *string            `json:"message,omitempty"`
+	ErrorCode     *string            `json:"errorCode,omitempty"`
+	Test          bool               `json:"test"`
+	CreatedAt     *scalars.Time      `json:"createdAt,omitempty"`
+}
+
+type UpdateFulfillmentTrackingInput struct {
+	Domain         string                    `json:"domain"`
+	FulfillmentID  string                    `json:"fulfillmentId"`
+	TrackingInfo   *FulfillmentTrackingInput `json:"trackingInfo"`
+	NotifyCustomer *bool                     `json:"notifyCustomer,omitempty"`
+}
+
+type UsageCounter struct {
+	Period    UsagePeriod  `json:"period"`
+	Used      int          `json:"used"`
+	Limit     *int         `json:"limit,omitempty"`
+	Remaining *int         `json:"remaining,omitempty"`
+	ResetsAt  scalars.Time `json:"resetsAt"`
+}
+
+type VariantInventory struct {
+	VariantID      string                    `json:"variantId"`
+	ProductID      string                    `json:"productId"`
+	Title          string                    `json:"title"`
+	Sku            *string                   `json:"sku,omitempty"`
+	InventoryItem  *InventoryItem            `json:"inventoryItem,omitempty"`
+	Levels         []*LocationInventoryLevel `json:"levels"`
+	TotalAvailable int                       `json:"totalAvailable"`
+}
+
+type WebhookEvent struct {
+	ID        string       `json:"id"`
+	Topic     string       `json:"topic"`
+	Shop      string       `json:"shop"`
+	Verified  bool         `json:"verified"`
+	CreatedAt scalars.Time `json:"createdAt"`
+}
+
+type WebhookEventFilter struct {
+	Topics []string `json:"topics,omitempty"`
+	Shop   *string  `json:"shop,omitempty"`
+}
+
+type WebhookEventPayload struct {
+	ID        string       `json:"id"`
+	Topic     string       `json:"topic"`
+	Shop      string       `json:"shop"`
+	Verified  bool         `json:"verified"`
+	Payload   string       `json:"payload"`
+	CreatedAt scalars.Time `json:"createdAt"`
+}
+
+type BulkOperationStatus string
+
+const (
+	BulkOperationStatusCreated   BulkOperationStatus = "CREATED"
+	BulkOperationStatusRunning   BulkOperationStatus = "RUNNING"
+	BulkOperationStatusCompleted BulkOperationStatus = "COMPLETED"
+	BulkOperationStatusCanceling BulkOperationStatus = "CANCELING"
+	BulkOperationStatusCanceled  BulkOperationStatus = "CANCELED"
+	BulkOperationStatusFailed    BulkOperationStatus = "FAILED"
+	BulkOperationStatusExpired   BulkOperationStatus = "EXPIRED"
+)
+
+var AllBulkOperationStatus = []BulkOperationStatus{
+	BulkOperationStatusCreated,
+	BulkOperationStatusRunning,
+	BulkOperationStatusCompleted,
+	BulkOperationStatusCanceling,
+	BulkOperationStatusCanceled,
+	BulkOperationStatusFailed,
+	BulkOperationStatusExpired,
+}
+
+func (e BulkOperationStatus) IsValid() bool {
+	switch e {
+	case BulkOperationStatusCreated, BulkOperationStatusRunning, BulkOperationStatusCompleted, BulkOperationStatusCanceling, BulkOperationStatusCanceled, BulkOperationStatusFailed, BulkOperationStatusExpired:
+		return true
+	}
+	return false
+}
+
+func (e BulkOperationStatus) String() string {
+	return string(e)
+}
+
+func (e *BulkOperationStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = BulkOperationStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid BulkOperationStatus", str)
+	}
+	return nil
+}
+
+func (e BulkOperationStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *BulkOperationStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e BulkOperationStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type BulkOperationType string
+
+const (
+	BulkOperationTypeQuery    BulkOperationType = "QUERY"
+	BulkOperationTypeMutation BulkOperationType = "MUTATION"
+)
+
+var AllBulkOperationType = []BulkOperationType{
+	BulkOperationTypeQuery,
+	BulkOperationTypeMutation,
+}
+
+func (e BulkOperationType) IsValid() bool {
+	switch e {
+	case BulkOperationTypeQuery, BulkOperationTypeMutation:
+		return true
+	}
+	return false
+}
+
+func (e BulkOperationType) String() string {
+	return string(e)
+}
+
+func (e *BulkOperationType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = BulkOperationType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid BulkOperationType", str)
+	}
+	return nil
+}
+
+func (e BulkOperationType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *BulkOperationType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e BulkOperationType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type CollectionRuleColumn string
+
+const (
+	CollectionRuleColumnTitle                 CollectionRuleColumn = "TITLE"
+	CollectionRuleColumnType                  CollectionRuleColumn = "TYPE"
+	CollectionRuleColumnVendor                CollectionRuleColumn = "VENDOR"
+	CollectionRuleColumnTag                   CollectionRuleColumn = "TAG"
+	CollectionRuleColumnVariantTitle          CollectionRuleColumn = "VARIANT_TITLE"
+	CollectionRuleColumnVariantPrice          CollectionRuleColumn = "VARIANT_PRICE"
+	CollectionRuleColumnVariantCompareAtPrice CollectionRuleColumn = "VARIANT_COMPARE_AT_PRICE"
+	CollectionRuleColumnVariantWeight         CollectionRuleColumn = "VARIANT_WEIGHT"
+	CollectionRuleColumnVariantInventory      CollectionRuleColumn = "VARIANT_INVENTORY"
+	CollectionRuleColumnIsPriceReduced        CollectionRuleColumn = "IS_PRICE_REDUCED"
+	CollectionRuleColumnProductTaxonomyNodeID CollectionRuleColumn = "PRODUCT_TAXONOMY_NODE_ID"
+)
+
+var AllCollectionRuleColumn = []CollectionRuleColumn{
+	CollectionRuleColumnTitle,
+	CollectionRuleColumnType,
+	CollectionRuleColumnVendor,
+	CollectionRuleColumnTag,
+	CollectionRuleColumnVariantTitle,
+	CollectionRuleColumnVariantPrice,
+	CollectionRuleColumnVariantCompareAtPrice,
+	CollectionRuleColumnVariantWeight,
+	CollectionRuleColumnVariantInventory,
+	CollectionRuleColumnIsPriceReduced,
+	CollectionRuleColumnProductTaxonomyNodeID,
+}
+
+func (e CollectionRuleColumn) IsValid() bool {
+	switch e {
+	case CollectionRuleColumnTitle, CollectionRuleColumnType, CollectionRuleColumnVendor, CollectionRuleColumnTag, CollectionRuleColumnVariantTitle, CollectionRuleColumnVariantPrice, CollectionRuleColumnVariantCompareAtPrice, CollectionRuleColumnVariantWeight, CollectionRuleColumnVariantInventory, CollectionRuleColumnIsPriceReduced, CollectionRuleColumnProductTaxonomyNodeID:
+		return true
+	}
+	return false
+}
+
+func (e CollectionRuleColumn) String() string {
+	return string(e)
+}
+
+func (e *CollectionRuleColumn) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CollectionRuleColumn(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CollectionRuleColumn", str)
+	}
+	return nil
+}
+
+func (e CollectionRuleColumn) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *CollectionRuleColumn) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e CollectionRuleColumn) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type CollectionRuleRelation string
+
+const (
+	CollectionRuleRelationEquals      CollectionRuleRelation = "EQUALS"
+	CollectionRuleRelationNotEquals   CollectionRuleRelation = "NOT_EQUALS"
+	CollectionRuleRelationGreaterThan CollectionRuleRelation = "GREATER_THAN"
+	CollectionRuleRelationLessThan    CollectionRuleRelation = "LESS_THAN"
+	CollectionRuleRelationStartsWith  CollectionRuleRelation = "STARTS_WITH"
+	CollectionRuleRelationEndsWith    CollectionRuleRelation = "ENDS_WITH"
+	CollectionRuleRelationContains    CollectionRuleRelation = "CONTAINS"
+	CollectionRuleRelationNotContains CollectionRuleRelation = "NOT_CONTAINS"
+	CollectionRuleRelationIsSet       CollectionRuleRelation = "IS_SET"
+	CollectionRuleRelationIsNotSet    CollectionRuleRelation = "IS_NOT_SET"
+)
+
+var AllCollectionRuleRelation = []CollectionRuleRelation{
+	CollectionRuleRelationEquals,
+	CollectionRuleRelationNotEquals,
+	CollectionRuleRelationGreaterThan,
+	CollectionRuleRelationLessThan,
+	CollectionRuleRelationStartsWith,
+	CollectionRuleRelationEndsWith,
+	CollectionRuleRelationContains,
+	CollectionRuleRelationNotContains,
+	CollectionRuleRelationIsSet,
+	CollectionRuleRelationIsNotSet,
+}
+
+func (e CollectionRuleRelation) IsValid() bool {
+	switch e {
+	case CollectionRuleRelationEquals, CollectionRuleRelationNotEquals, CollectionRuleRelationGreaterThan, CollectionRuleRelationLessThan, CollectionRuleRelationStartsWith, CollectionRuleRelationEndsWith, CollectionRuleRelationContains, CollectionRuleRelationNotContains, CollectionRuleRelationIsSet, CollectionRuleRelationIsNotSet:
+		return true
+	}
+	return false
+}
+
+func (e CollectionRuleRelation) String() string {
+	return string(e)
+}
+
+func (e *CollectionRuleRelation) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CollectionRuleRelation(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CollectionRuleRelation", str)
+	}
+	return nil
+}
+
+func (e CollectionRuleRelation) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *CollectionRuleRelation) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e CollectionRuleRelation) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type CollectionSortOrder string
+
+const (
+	CollectionSortOrderAlphaAsc    CollectionSortOrder = "ALPHA_ASC"
+	CollectionSortOrderAlphaDesc   CollectionSortOrder = "ALPHA_DESC"
+	CollectionSortOrderBestSelling CollectionSortOrder = "BEST_SELLING"
+	CollectionSortOrderCreated     CollectionSortOrder = "CREATED"
+	CollectionSortOrderCreatedDesc CollectionSortOrder = "CREATED_DESC"
+	CollectionSortOrderManual      CollectionSortOrder = "MANUAL"
+	CollectionSortOrderPriceAsc    CollectionSortOrder = "PRICE_ASC"
+	CollectionSortOrderPriceDesc   CollectionSortOrder = "PRICE_DESC"
+)
+
+var AllCollectionSortOrder = []CollectionSortOrder{
+	CollectionSortOrderAlphaAsc,
+	CollectionSortOrderAlphaDesc,
+	CollectionSortOrderBestSelling,
+	CollectionSortOrderCreated,
+	CollectionSortOrderCreatedDesc,
+	CollectionSortOrderManual,
+	CollectionSortOrderPriceAsc,
+	CollectionSortOrderPriceDesc,
+}
+
+func (e CollectionSortOrder) IsValid() bool {
+	switch e {
+	case CollectionSortOrderAlphaAsc, CollectionSortOrderAlphaDesc, CollectionSortOrderBestSelling, CollectionSortOrderCreated, CollectionSortOrderCreatedDesc, CollectionSortOrderManual, CollectionSortOrderPriceAsc, CollectionSortOrderPriceDesc:
+		return true
+	}
+	return false
+}
+
+func (e CollectionSortOrder) String() string {
+	return string(e)
+}
+
+func (e *CollectionSortOrder) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CollectionSortOrder(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CollectionSortOrder", str)
+	}
+	return nil
+}
+
+func (e CollectionSortOrder) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *CollectionSortOrder) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e CollectionSortOrder) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type CollectionType string
+
+const (
+	CollectionTypeCustom CollectionType = "CUSTOM"
+	CollectionTypeSmart  CollectionType = "SMART"
+)
+
+var AllCollectionType = []CollectionType{
+	CollectionTypeCustom,
+	CollectionTypeSmart,
+}
+
+func (e CollectionType) IsValid() bool {
+	switch e {
+	case CollectionTypeCustom, CollectionTypeSmart:
+		return true
+	}
+	return false
+}
+
+func (e CollectionType) String() string {
+	return string(e)
+}
+
+func (e *CollectionType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CollectionType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CollectionType", str)
+	}
+	return nil
+}
+
+func (e CollectionType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *CollectionType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e CollectionType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type DiscountCodeBatchStatus string
+
+const (
+	DiscountCodeBatchStatusQueued    DiscountCodeBatchStatus = "QUEUED"
+	DiscountCodeBatchStatusRunning   DiscountCodeBatchStatus = "RUNNING"
+	DiscountCodeBatchStatusCompleted DiscountCodeBatchStatus = "COMPLETED"
+)
+
+var AllDiscountCodeBatchStatus = []DiscountCodeBatchStatus{
+	DiscountCodeBatchStatusQueued,
+	DiscountCodeBatchStatusRunning,
+	DiscountCodeBatchStatusCompleted,
+}
+
+func (e DiscountCodeBatchStatus) IsValid() bool {
+	switch e {
+	case DiscountCodeBatchStatusQueued, DiscountCodeBatchStatusRunning, DiscountCodeBatchStatusCompleted:
+		return true
+	}
+	return false
+}
+
+func (e DiscountCodeBatchStatus) String() string {
+	return string(e)
+}
+
+func (e *DiscountCodeBatchStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DiscountCodeBatchStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DiscountCodeBatchStatus", str)
+	}
+	return nil
+}
+
+func (e DiscountCodeBatchStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *DiscountCodeBatchStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e DiscountCodeBatchStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type DraftOrderDiscountValueType string
+
+const (
+	DraftOrderDiscountValueTypeFixedAmount DraftOrderDiscountValueType = "FIXED_AMOUNT"
+	DraftOrderDiscountValueTypePercentage  DraftOrderDiscountValueType = "PERCENTAGE"
+)
+
+var AllDraftOrderDiscountValueType = []DraftOrderDiscountValueType{
+	DraftOrderDiscountValueTypeFixedAmount,
+	DraftOrderDiscountValueTypePercentage,
+}
+
+func (e DraftOrderDiscountValueType) IsValid() bool {
+	switch e {
+	case DraftOrderDiscountValueTypeFixedAmount, DraftOrderDiscountValueTypePercentage:
+		return true
+	}
+	return false
+}
+
+func (e DraftOrderDiscountValueType) String() string {
+	return string(e)
+}
+
+func (e *DraftOrderDiscountValueType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DraftOrderDiscountValueType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DraftOrderDiscountValueType", str)
+	}
+	return nil
+}
+
+func (e DraftOrderDiscountValueType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *DraftOrderDiscountValueType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e DraftOrderDiscountValueType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type DraftOrderStatus string
+
+const (
+	DraftOrderStatusOpen        DraftOrderStatus = "OPEN"
+	DraftOrderStatusInvoiceSent DraftOrderStatus = "INVOICE_SENT"
+	DraftOrderStatusCompleted   DraftOrderStatus = "COMPLETED"
+)
+
+var AllDraftOrderStatus = []DraftOrderStatus{
+	DraftOrderStatusOpen,
+	DraftOrderStatusInvoiceSent,
+	DraftOrderStatusCompleted,
+}
+
+func (e DraftOrderStatus) IsValid() bool {
+	switch e {
+	case DraftOrderStatusOpen, DraftOrderStatusInvoiceSent, DraftOrderStatusCompleted:
+		return true
+	}
+	return false
+}
+
+func (e DraftOrderStatus) String() string {
+	return string(e)
+}
+
+func (e *DraftOrderStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DraftOrderStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DraftOrderStatus", str)
+	}
+	return nil
+}
+
+func (e DraftOrderStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *DraftOrderStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e DraftOrderStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type FulfillmentHoldReason string
+
+const (
+	FulfillmentHoldReasonAwaitingPayment     FulfillmentHoldReason = "AWAITING_PAYMENT"
+	FulfillmentHoldReasonHighRiskOfFraud     FulfillmentHoldReason = "HIGH_RISK_OF_FRAUD"
+	FulfillmentHoldReasonIncorrectAddress    FulfillmentHoldReason = "INCORRECT_ADDRESS"
+	FulfillmentHoldReasonInventoryOutOfStock FulfillmentHoldReason = "INVENTORY_OUT_OF_STOCK"
+	FulfillmentHoldReasonOther               FulfillmentHoldReason = "OTHER"
+)
+
+var AllFulfillmentHoldReason = []FulfillmentHoldReason{
+	FulfillmentHoldReasonAwaitingPayment,
+	FulfillmentHoldReasonHighRiskOfFraud,
+	FulfillmentHoldReasonIncorrectAddress,
+	FulfillmentHoldReasonInventoryOutOfStock,
+	FulfillmentHoldReasonOther,
+}
+
+func (e FulfillmentHoldReason) IsValid() bool {
+	switch e {
+	case FulfillmentHoldReasonAwaitingPayment, FulfillmentHoldReasonHighRiskOfFraud, FulfillmentHoldReasonIncorrectAddress, FulfillmentHoldReasonInventoryOutOfStock, FulfillmentHoldReasonOther:
+		return true
+	}
+	return false
+}
+
+func (e FulfillmentHoldReason) String() string {
+	return string(e)
+}
+
+func (e *FulfillmentHoldReason) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FulfillmentHoldReason(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FulfillmentHoldReason", str)
+	}
+	return nil
+}
+
+func (e FulfillmentHoldReason) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *FulfillmentHoldReason) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e FulfillmentHoldReason) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type FulfillmentOrderAssignmentStatus string
+
+const (
+	FulfillmentOrderAssignmentStatusCancellationRequested FulfillmentOrderAssignmentStatus = "CANCELLATION_REQUESTED"
+	FulfillmentOrderAssignmentStatusFulfillmentRequested  FulfillmentOrderAssignmentStatus = "FULFILLMENT_REQUESTED"
+	FulfillmentOrderAssignmentStatusFulfillmentAccepted   FulfillmentOrderAssignmentStatus = "FULFILLMENT_ACCEPTED"
+)
+
+var AllFulfillmentOrderAssignmentStatus = []FulfillmentOrderAssignmentStatus{
+	FulfillmentOrderAssignmentStatusCancellationRequested,
+	FulfillmentOrderAssignmentStatusFulfillmentRequested,
+	FulfillmentOrderAssignmentStatusFulfillmentAccepted,
+}
+
+func (e FulfillmentOrderAssignmentStatus) IsValid() bool {
+	switch e {
+	case FulfillmentOrderAssignmentStatusCancellationRequested, FulfillmentOrderAssignmentStatusFulfillmentRequested, FulfillmentOrderAssignmentStatusFulfillmentAccepted:
+		return true
+	}
+	return false
+}
+
+func (e FulfillmentOrderAssignmentStatus) String() string {
+	return string(e)
+}
+
+func (e *FulfillmentOrderAssignmentStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FulfillmentOrderAssignmentStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FulfillmentOrderAssignmentStatus", str)
+	}
+	return nil
+}
+
+func (e FulfillmentOrderAssignmentStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *FulfillmentOrderAssignmentStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e FulfillmentOrderAssignmentStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type FulfillmentOrderRequestStatus string
+
+const (
+	FulfillmentOrderRequestStatusUnsubmitted           FulfillmentOrderRequestStatus = "UNSUBMITTED"
+	FulfillmentOrderRequestStatusSubmitted             FulfillmentOrderRequestStatus = "SUBMITTED"
+	FulfillmentOrderRequestStatusAccepted              FulfillmentOrderRequestStatus = "ACCEPTED"
+	FulfillmentOrderRequestStatusRejected              FulfillmentOrderRequestStatus = "REJECTED"
+	FulfillmentOrderRequestStatusCancellationRequested FulfillmentOrderRequestStatus = "CANCELLATION_REQUESTED"
+	FulfillmentOrderRequestStatusCancellationAccepted  FulfillmentOrderRequestStatus = "CANCELLATION_ACCEPTED"
+	FulfillmentOrderRequestStatusCancellationRejected  FulfillmentOrderRequestStatus = "CANCELLATION_REJECTED"
+	FulfillmentOrderRequestStatusClosed                FulfillmentOrderRequestStatus = "CLOSED"
+)
+
+var AllFulfillmentOrderRequestStatus = []FulfillmentOrderRequestStatus{
+	FulfillmentOrderRequestStatusUnsubmitted,
+	FulfillmentOrderRequestStatusSubmitted,
+	FulfillmentOrderRequestStatusAccepted,
+	FulfillmentOrderRequestStatusRejected,
+	FulfillmentOrderRequestStatusCancellationRequested,
+	FulfillmentOrderRequestStatusCancellationAccepted,
+	FulfillmentOrderRequestStatusCancellationRejected,
+	FulfillmentOrderRequestStatusClosed,
+}
+
+func (e FulfillmentOrderRequestStatus) IsValid() bool {
+	switch e {
+	case FulfillmentOrderRequestStatusUnsubmitted, FulfillmentOrderRequestStatusSubmitted, FulfillmentOrderRequestStatusAccepted, FulfillmentOrderRequestStatusRejected, FulfillmentOrderRequestStatusCancellationRequested, FulfillmentOrderRequestStatusCancellationAccepted, FulfillmentOrderRequestStatusCancellationRejected, FulfillmentOrderRequestStatusClosed:
+		return true
+	}
+	return false
+}
+
+func (e FulfillmentOrderRequestStatus) String() string {
+	return string(e)
+}
+
+func (e *FulfillmentOrderRequestStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FulfillmentOrderRequestStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FulfillmentOrderRequestStatus", str)
+	}
+	return nil
+}
+
+func (e FulfillmentOrderRequestStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *FulfillmentOrderRequestStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e FulfillmentOrderRequestStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type FulfillmentOrderStatus string
+
+const (
+	FulfillmentOrderStatusOpen       FulfillmentOrderStatus = "OPEN"
+	FulfillmentOrderStatusInProgress FulfillmentOrderStatus = "IN_PROGRESS"
+	FulfillmentOrderStatusScheduled  FulfillmentOrderStatus = "SCHEDULED"
+	FulfillmentOrderStatusOnHold     FulfillmentOrderStatus = "ON_HOLD"
+	FulfillmentOrderStatusIncomplete FulfillmentOrderStatus = "INCOMPLETE"
+	FulfillmentOrderStatusClosed     FulfillmentOrderStatus = "CLOSED"
+	FulfillmentOrderStatusCancelled  FulfillmentOrderStatus = "CANCELLED"
+)
+
+var AllFulfillmentOrderStatus = []FulfillmentOrderStatus{
+	FulfillmentOrderStatusOpen,
+	FulfillmentOrderStatusInProgress,
+	FulfillmentOrderStatusScheduled,
+	FulfillmentOrderStatusOnHold,
+	FulfillmentOrderStatusIncomplete,
+	FulfillmentOrderStatusClosed,
+	FulfillmentOrderStatusCancelled,
+}
+
+func (e FulfillmentOrderStatus) IsValid() bool {
+	switch e {
+	case FulfillmentOrderStatusOpen, FulfillmentOrderStatusInProgress, FulfillmentOrderStatusScheduled, FulfillmentOrderStatusOnHold, FulfillmentOrderStatusIncomplete, FulfillmentOrderStatusClosed, FulfillmentOrderStatusCancelled:
+		return true
+	}
+	return false
+}
+
+func (e FulfillmentOrderStatus) String() string {
+	return string(e)
+}
+
+func (e *FulfillmentOrderStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FulfillmentOrderStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FulfillmentOrderStatus", str)
+	}
+	return nil
+}
+
+func (e FulfillmentOrderStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *FulfillmentOrderStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e FulfillmentOrderStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type FulfillmentRejectionReason string
+
+const (
+	FulfillmentRejectionReasonIncorrectAddress         FulfillmentRejectionReason = "INCORRECT_ADDRESS"
+	FulfillmentRejectionReasonInventoryOutOfStock      FulfillmentRejectionReason = "INVENTORY_OUT_OF_STOCK"
+	FulfillmentRejectionReasonIneligibleProduct        FulfillmentRejectionReason = "INELIGIBLE_PRODUCT"
+	FulfillmentRejectionReasonUndeliverableDestination FulfillmentRejectionReason = "UNDELIVERABLE_DESTINATION"
+	FulfillmentRejectionReasonOther                    FulfillmentRejectionReason = "OTHER"
+)
+
+var AllFulfillmentRejectionReason = []FulfillmentRejectionReason{
+	FulfillmentRejectionReasonIncorrectAddress,
+	FulfillmentRejectionReasonInventoryOutOfStock,
+	FulfillmentRejectionReasonIneligibleProduct,
+	FulfillmentRejectionReasonUndeliverableDestination,
+	FulfillmentRejectionReasonOther,
+}
+
+func (e FulfillmentRejectionReason) IsValid() bool {
+	switch e {
+	case FulfillmentRejectionReasonIncorrectAddress, FulfillmentRejectionReasonInventoryOutOfStock, FulfillmentRejectionReasonIneligibleProduct, FulfillmentRejectionReasonUndeliverableDestination, FulfillmentRejectionReasonOther:
+		return true
+	}
+	return false
+}
+
+func (e FulfillmentRejectionReason) String() string {
+	return string(e)
+}
+
+func (e *FulfillmentRejectionReason) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FulfillmentRejectionReason(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FulfillmentRejectionReason", str)
+	}
+	return nil
+}
+
+func (e FulfillmentRejectionReason) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *FulfillmentRejectionReason) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e FulfillmentRejectionReason) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type InstallState string
+
+const (
+	InstallStatePendingOauth   InstallState = "PENDING_OAUTH"
+	InstallStateInstalled      InstallState = "INSTALLED"
+	InstallStateScopesOutdated InstallState = "SCOPES_OUTDATED"
+	InstallStateUninstalled    InstallState = "UNINSTALLED"
+	InstallStateReinstalled    InstallState = "REINSTALLED"
+)
+
+var AllInstallState = []InstallState{
+	InstallStatePendingOauth,
+	InstallStateInstalled,
+	InstallStateScopesOutdated,
+	InstallStateUninstalled,
+	InstallStateReinstalled,
+}
+
+func (e InstallState) IsValid() bool {
+	switch e {
+	case InstallStatePendingOauth, InstallStateInstalled, InstallStateScopesOutdated, InstallStateUninstalled, InstallStateReinstalled:
+		return true
+	}
+	return false
+}
+
+func (e InstallState) String() string {
+	return string(e)
+}
+
+func (e *InstallState) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = InstallState(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid InstallState", str)
+	}
+	return nil
+}
+
+func (e InstallState) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *InstallState) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e InstallState) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type IntegrationStatus string
+
+const (
+	IntegrationStatusActive  IntegrationStatus = "ACTIVE"
+	IntegrationStatusExpired IntegrationStatus = "EXPIRED"
+	IntegrationStatusRevoked IntegrationStatus = "REVOKED"
+)
+
+var AllIntegrationStatus = []IntegrationStatus{
+	IntegrationStatusActive,
+	IntegrationStatusExpired,
+	IntegrationStatusRevoked,
+}
+
+func (e IntegrationStatus) IsValid() bool {
+	switch e {
+	case IntegrationStatusActive, IntegrationStatusExpired, IntegrationStatusRevoked:
+		return true
+	}
+	return false
+}
+
+func (e IntegrationStatus) String() string {
+	return string(e)
+}
+
+func (e *IntegrationStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = IntegrationStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid IntegrationStatus", str)
+	}
+	return nil
+}
+
+func (e IntegrationStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *IntegrationStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e IntegrationStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type InventoryAdjustmentReason string
+
+const (
+	InventoryAdjustmentReasonCorrection          InventoryAdjustmentReason = "CORRECTION"
+	InventoryAdjustmentReasonCycleCountAvailable InventoryAdjustmentReason = "CYCLE_COUNT_AVAILABLE"
+	InventoryAdjustmentReasonDamaged             InventoryAdjustmentReason = "DAMAGED"
+	InventoryAdjustmentReasonPromotion           InventoryAdjustmentReason = "PROMOTION"
+	InventoryAdjustmentReasonQualityControl      InventoryAdjustmentReason = "QUALITY_CONTROL"
+	InventoryAdjustmentReasonReceived            InventoryAdjustmentReason = "RECEIVED"
+	InventoryAdjustmentReasonRestock             InventoryAdjustmentReason = "RESTOCK"
+	InventoryAdjustmentReasonSafetyStock         InventoryAdjustmentReason = "SAFETY_STOCK"
+	InventoryAdjustmentReasonShrinkage           InventoryAdjustmentReason = "SHRINKAGE"
+	InventoryAdjustmentReasonOther               InventoryAdjustmentReason = "OTHER"
+)
+
+var AllInventoryAdjustmentReason = []InventoryAdjustmentReason{
+	InventoryAdjustmentReasonCorrection,
+	InventoryAdjustmentReasonCycleCountAvailable,
+	InventoryAdjustmentReasonDamaged,
+	InventoryAdjustmentReasonPromotion,
+	InventoryAdjustmentReasonQualityControl,
+	InventoryAdjustmentReasonReceived,
+	InventoryAdjustmentReasonRestock,
+	InventoryAdjustmentReasonSafetyStock,
+	InventoryAdjustmentReasonShrinkage,
+	InventoryAdjustmentReasonOther,
+}
+
+func (e InventoryAdjustmentReason) IsValid() bool {
+	switch e {
+	case InventoryAdjustmentReasonCorrection, InventoryAdjustmentReasonCycleCountAvailable, InventoryAdjustmentReasonDamaged, InventoryAdjustmentReasonPromotion, InventoryAdjustmentReasonQualityControl, InventoryAdjustmentReasonReceived, InventoryAdjustmentReasonRestock, InventoryAdjustmentReasonSafetyStock, InventoryAdjustmentReasonShrinkage, InventoryAdjustmentReasonOther:
+		return true
+	}
+	return false
+}
+
+func (e InventoryAdjustmentReason) String() string {
+	return string(e)
+}
+
+func (e *InventoryAdjustmentReason) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = InventoryAdjustmentReason(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid InventoryAdjustmentReason", str)
+	}
+	return nil
+}
+
+func (e InventoryAdjustmentReason) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *InventoryAdjustmentReason) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e InventoryAdjustmentReason) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type InventoryBehaviour string
+
+const (
+	InventoryBehaviourBypass                  InventoryBehaviour = "BYPASS"
+	InventoryBehaviourDecrementIgnoringPolicy InventoryBehaviour = "DECREMENT_IGNORING_POLICY"
+	InventoryBehaviourDecrementObeyingPolicy  InventoryBehaviour = "DECREMENT_OBEYING_POLICY"
+)
+
+var AllInventoryBehaviour = []InventoryBehaviour{
+	InventoryBehaviourBypass,
+	InventoryBehaviourDecrementIgnoringPolicy,
+	InventoryBehaviourDecrementObeyingPolicy,
+}
+
+func (e InventoryBehaviour) IsValid() bool {
+	switch e {
+	case InventoryBehaviourBypass, InventoryBehaviourDecrementIgnoringPolicy, InventoryBehaviourDecrementObeyingPolicy:
+		return true
+	}
+	return false
+}
+
+func (e InventoryBehaviour) String() string {
+	return string(e)
+}
+
+func (e *InventoryBehaviour) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = InventoryBehaviour(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid InventoryBehaviour", str)
+	}
+	return nil
+}
+
+func (e InventoryBehaviour) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *InventoryBehaviour) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e InventoryBehaviour) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type InventoryPolicy string
+
+const (
+	InventoryPolicyDeny     InventoryPolicy = "DENY"
+	InventoryPolicyContinue InventoryPolicy = "CONTINUE"
+)
+
+var AllInventoryPolicy = []InventoryPolicy{
+	InventoryPolicyDeny,
+	InventoryPolicyContinue,
+}
+
+func (e InventoryPolicy) IsValid() bool {
+	switch e {
+	case InventoryPolicyDeny, InventoryPolicyContinue:
+		return true
+	}
+	return false
+}
+
+func (e InventoryPolicy) String() string {
+	return string(e)
+}
+
+func (e *InventoryPolicy) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = InventoryPolicy(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid InventoryPolicy", str)
+	}
+	return nil
+}
+
+func (e InventoryPolicy) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *InventoryPolicy) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e InventoryPolicy) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type MarketingOptInLevel string
+
+const (
+	MarketingOptInLevelSingleOptIn    MarketingOptInLevel = "SINGLE_OPT_IN"
+	MarketingOptInLevelConfirmedOptIn MarketingOptInLevel = "CONFIRMED_OPT_IN"
+	MarketingOptInLevelUnknown        MarketingOptInLevel = "UNKNOWN"
+)
+
+var AllMarketingOptInLevel = []MarketingOptInLevel{
+	MarketingOptInLevelSingleOptIn,
+	MarketingOptInLevelConfirmedOptIn,
+	MarketingOptInLevelUnknown,
+}
+
+func (e MarketingOptInLevel) IsValid() bool {
+	switch e {
+	case MarketingOptInLevelSingleOptIn, MarketingOptInLevelConfirmedOptIn, MarketingOptInLevelUnknown:
+		return true
+	}
+	return false
+}
+
+func (e MarketingOptInLevel) String() string {
+	return string(e)
+}
+
+func (e *MarketingOptInLevel) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MarketingOptInLevel(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MarketingOptInLevel", str)
+	}
+	return nil
+}
+
+func (e MarketingOptInLevel) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *MarketingOptInLevel) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e MarketingOptInLevel) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type MarketingState string
+
+const (
+	MarketingStateSubscribed    MarketingState = "SUBSCRIBED"
+	MarketingStateNotSubscribed MarketingState = "NOT_SUBSCRIBED"
+	MarketingStatePending       MarketingState = "PENDING"
+	MarketingStateUnsubscribed  MarketingState = "UNSUBSCRIBED"
+	MarketingStateRedacted      MarketingState = "REDACTED"
+	MarketingStateInvalid       MarketingState = "INVALID"
+)
+
+var AllMarketingState = []MarketingState{
+	MarketingStateSubscribed,
+	MarketingStateNotSubscribed,
+	MarketingStatePending,
+	MarketingStateUnsubscribed,
+	MarketingStateRedacted,
+	MarketingStateInvalid,
+}
+
+func (e MarketingState) IsValid() bool {
+	switch e {
+	case MarketingStateSubscribed, MarketingStateNotSubscribed, MarketingStatePending, MarketingStateUnsubscribed, MarketingStateRedacted, MarketingStateInvalid:
+		return true
+	}
+	return false
+}
+
+func (e MarketingState) String() string {
+	return string(e)
+}
+
+func (e *MarketingState) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MarketingState(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MarketingState", str)
+	}
+	return nil
+}
+
+func (e MarketingState) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *MarketingState) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e MarketingState) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type MetafieldOwnerType string
+
+const (
+	MetafieldOwnerTypeProduct    MetafieldOwnerType = "PRODUCT"
+	MetafieldOwnerTypeVariant    MetafieldOwnerType = "VARIANT"
+	MetafieldOwnerTypeCollection MetafieldOwnerType = "COLLECTION"
+	MetafieldOwnerTypeCustomer   MetafieldOwnerType = "CUSTOMER"
+	MetafieldOwnerTypeOrder      MetafieldOwnerType = "ORDER"
+	MetafieldOwnerTypeDraftOrder MetafieldOwnerType = "DRAFT_ORDER"
+	MetafieldOwnerTypeShop       MetafieldOwnerType = "SHOP"
+)
+
+var AllMetafieldOwnerType = []MetafieldOwnerType{
+	MetafieldOwnerTypeProduct,
+	MetafieldOwnerTypeVariant,
+	MetafieldOwnerTypeCollection,
+	MetafieldOwnerTypeCustomer,
+	MetafieldOwnerTypeOrder,
+	MetafieldOwnerTypeDraftOrder,
+	MetafieldOwnerTypeShop,
+}
+
+func (e MetafieldOwnerType) IsValid() bool {
+	switch e {
+	case MetafieldOwnerTypeProduct, MetafieldOwnerTypeVariant, MetafieldOwnerTypeCollection, MetafieldOwnerTypeCustomer, MetafieldOwnerTypeOrder, MetafieldOwnerTypeDraftOrder, MetafieldOwnerTypeShop:
+		return true
+	}
+	return false
+}
+
+func (e MetafieldOwnerType) String() string {
+	return string(e)
+}
+
+func (e *MetafieldOwnerType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MetafieldOwnerType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MetafieldOwnerType", str)
+	}
+	return nil
+}
+
+func (e MetafieldOwnerType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *MetafieldOwnerType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e MetafieldOwnerType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type OrderCancelReason string
+
+const (
+	OrderCancelReasonCustomer  OrderCancelReason = "CUSTOMER"
+	OrderCancelReasonFraud     OrderCancelReason = "FRAUD"
+	OrderCancelReasonInventory OrderCancelReason = "INVENTORY"
+	OrderCancelReasonDeclined  OrderCancelReason = "DECLINED"
+	OrderCancelReasonStaff     OrderCancelReason = "STAFF"
+	OrderCancelReasonOther     OrderCancelReason = "OTHER"
+)
+
+var AllOrderCancelReason = []OrderCancelReason{
+	OrderCancelReasonCustomer,
+	OrderCancelReasonFraud,
+	OrderCancelReasonInventory,
+	OrderCancelReasonDeclined,
+	OrderCancelReasonStaff,
+	OrderCancelReasonOther,
+}
+
+func (e OrderCancelReason) IsValid() bool {
+	switch e {
+	case OrderCancelReasonCustomer, OrderCancelReasonFraud, OrderCancelReasonInventory, OrderCancelReasonDeclined, OrderCancelReasonStaff, OrderCancelReasonOther:
+		return true
+	}
+	return false
+}
+
+func (e OrderCancelReason) String() string {
+	return string(e)
+}
+
+func (e *OrderCancelReason) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OrderCancelReason(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrderCancelReason", str)
+	}
+	return nil
+}
+
+func (e OrderCancelReason) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *OrderCancelReason) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e OrderCancelReason) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type OrderFinancialFilter string
+
+const (
+	OrderFinancialFilterAuthorized        OrderFinancialFilter = "AUTHORIZED"
+	OrderFinancialFilterPending           OrderFinancialFilter = "PENDING"
+	OrderFinancialFilterPaid              OrderFinancialFilter = "PAID"
+	OrderFinancialFilterPartiallyPaid     OrderFinancialFilter = "PARTIALLY_PAID"
+	OrderFinancialFilterRefunded          OrderFinancialFilter = "REFUNDED"
+	OrderFinancialFilterVoided            OrderFinancialFilter = "VOIDED"
+	OrderFinancialFilterPartiallyRefunded OrderFinancialFilter = "PARTIALLY_REFUNDED"
+	OrderFinancialFilterUnpaid            OrderFinancialFilter = "UNPAID"
+	OrderFinancialFilterAny               OrderFinancialFilter = "ANY"
+)
+
+var AllOrderFinancialFilter = []OrderFinancialFilter{
+	OrderFinancialFilterAuthorized,
+	OrderFinancialFilterPending,
+	OrderFinancialFilterPaid,
+	OrderFinancialFilterPartiallyPaid,
+	OrderFinancialFilterRefunded,
+	OrderFinancialFilterVoided,
+	OrderFinancialFilterPartiallyRefunded,
+	OrderFinancialFilterUnpaid,
+	OrderFinancialFilterAny,
+}
+
+func (e OrderFinancialFilter) IsValid() bool {
+	switch e {
+	case OrderFinancialFilterAuthorized, OrderFinancialFilterPending, OrderFinancialFilterPaid, OrderFinancialFilterPartiallyPaid, OrderFinancialFilterRefunded, OrderFinancialFilterVoided, OrderFinancialFilterPartiallyRefunded, OrderFinancialFilterUnpaid, OrderFinancialFilterAny:
+		return true
+	}
+	return false
+}
+
+func (e OrderFinancialFilter) String() string {
+	return string(e)
+}
+
+func (e *OrderFinancialFilter) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OrderFinancialFilter(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrderFinancialFilter", str)
+	}
+	return nil
+}
+
+func (e OrderFinancialFilter) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *OrderFinancialFilter) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e OrderFinancialFilter) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type OrderFinancialStatus string
+
+const (
+	OrderFinancialStatusPending           OrderFinancialStatus = "PENDING"
+	OrderFinancialStatusAuthorized        OrderFinancialStatus = "AUTHORIZED"
+	OrderFinancialStatusPartiallyPaid     OrderFinancialStatus = "PARTIALLY_PAID"
+	OrderFinancialStatusPaid              OrderFinancialStatus = "PAID"
+	OrderFinancialStatusPartiallyRefunded OrderFinancialStatus = "PARTIALLY_REFUNDED"
+	OrderFinancialStatusRefunded          OrderFinancialStatus = "REFUNDED"
+	OrderFinancialStatusVoided            OrderFinancialStatus = "VOIDED"
+)
+
+var AllOrderFinancialStatus = []OrderFinancialStatus{
+	OrderFinancialStatusPending,
+	OrderFinancialStatusAuthorized,
+	OrderFinancialStatusPartiallyPaid,
+	OrderFinancialStatusPaid,
+	OrderFinancialStatusPartiallyRefunded,
+	OrderFinancialStatusRefunded,
+	OrderFinancialStatusVoided,
+}
+
+func (e OrderFinancialStatus) IsValid() bool {
+	switch e {
+	case OrderFinancialStatusPending, OrderFinancialStatusAuthorized, OrderFinancialStatusPartiallyPaid, OrderFinancialStatusPaid, OrderFinancialStatusPartiallyRefunded, OrderFinancialStatusRefunded, OrderFinancialStatusVoided:
+		return true
+	}
+	return false
+}
+
+func (e OrderFinancialStatus) String() string {
+	return string(e)
+}
+
+func (e *OrderFinancialStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OrderFinancialStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrderFinancialStatus", str)
+	}
+	return nil
+}
+
+func (e OrderFinancialStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *OrderFinancialStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e OrderFinancialStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type OrderFulfillmentFilter string
+
+const (
+	OrderFulfillmentFilterShipped     OrderFulfillmentFilter = "SHIPPED"
+	OrderFulfillmentFilterPartial     OrderFulfillmentFilter = "PARTIAL"
+	OrderFulfillmentFilterUnshipped   OrderFulfillmentFilter = "UNSHIPPED"
+	OrderFulfillmentFilterUnfulfilled OrderFulfillmentFilter = "UNFULFILLED"
+	OrderFulfillmentFilterAny         OrderFulfillmentFilter = "ANY"
+)
+
+var AllOrderFulfillmentFilter = []OrderFulfillmentFilter{
+	OrderFulfillmentFilterShipped,
+	OrderFulfillmentFilterPartial,
+	OrderFulfillmentFilterUnshipped,
+	OrderFulfillmentFilterUnfulfilled,
+	OrderFulfillmentFilterAny,
+}
+
+func (e OrderFulfillmentFilter) IsValid() bool {
+	switch e {
+	case OrderFulfillmentFilterShipped, OrderFulfillmentFilterPartial, OrderFulfillmentFilterUnshipped, OrderFulfillmentFilterUnfulfilled, OrderFulfillmentFilterAny:
+		return true
+	}
+	return false
+}
+
+func (e OrderFulfillmentFilter) String() string {
+	return string(e)
+}
+
+func (e *OrderFulfillmentFilter) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OrderFulfillmentFilter(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrderFulfillmentFilter", str)
+	}
+	return nil
+}
+
+func (e OrderFulfillmentFilter) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *OrderFulfillmentFilter) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e OrderFulfillmentFilter) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type OrderStatus string
+
+const (
+	OrderStatusOpen      OrderStatus = "OPEN"
+	OrderStatusClosed    OrderStatus = "CLOSED"
+	OrderStatusCancelled OrderStatus = "CANCELLED"
+	OrderStatusAny       OrderStatus = "ANY"
+)
+
+var AllOrderStatus = []OrderStatus{
+	OrderStatusOpen,
+	OrderStatusClosed,
+	OrderStatusCancelled,
+	OrderStatusAny,
+}
+
+func (e OrderStatus) IsValid() bool {
+	switch e {
+	case OrderStatusOpen, OrderStatusClosed, OrderStatusCancelled, OrderStatusAny:
+		return true
+	}
+	return false
+}
+
+func (e OrderStatus) String() string {
+	return string(e)
+}
+
+func (e *OrderStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OrderStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrderStatus", str)
+	}
+	return nil
+}
+
+func (e OrderStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *OrderStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e OrderStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type PriceRuleAllocationMethod string
+
+const (
+	PriceRuleAllocationMethodEach   PriceRuleAllocationMethod = "EACH"
+	PriceRuleAllocationMethodAcross PriceRuleAllocationMethod = "ACROSS"
+)
+
+var AllPriceRuleAllocationMethod = []PriceRuleAllocationMethod{
+	PriceRuleAllocationMethodEach,
+	PriceRuleAllocationMethodAcross,
+}
+
+func (e PriceRuleAllocationMethod) IsValid() bool {
+	switch e {
+	case PriceRuleAllocationMethodEach, PriceRuleAllocationMethodAcross:
+		return true
+	}
+	return false
+}
+
+func (e PriceRuleAllocationMethod) String() string {
+	return string(e)
+}
+
+func (e *PriceRuleAllocationMethod) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PriceRuleAllocationMethod(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PriceRuleAllocationMethod", str)
+	}
+	return nil
+}
+
+func (e PriceRuleAllocationMethod) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PriceRuleAllocationMethod) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PriceRuleAllocationMethod) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type PriceRuleCustomerSelection string
+
+const (
+	PriceRuleCustomerSelectionAll          PriceRuleCustomerSelection = "ALL"
+	PriceRuleCustomerSelectionPrerequisite PriceRuleCustomerSelection = "PREREQUISITE"
+)
+
+var AllPriceRuleCustomerSelection = []PriceRuleCustomerSelection{
+	PriceRuleCustomerSelectionAll,
+	PriceRuleCustomerSelectionPrerequisite,
+}
+
+func (e PriceRuleCustomerSelection) IsValid() bool {
+	switch e {
+	case PriceRuleCustomerSelectionAll, PriceRuleCustomerSelectionPrerequisite:
+		return true
+	}
+	return false
+}
+
+func (e PriceRuleCustomerSelection) String() string {
+	return string(e)
+}
+
+func (e *PriceRuleCustomerSelection) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PriceRuleCustomerSelection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PriceRuleCustomerSelection", str)
+	}
+	return nil
+}
+
+func (e PriceRuleCustomerSelection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PriceRuleCustomerSelection) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PriceRuleCustomerSelection) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type PriceRuleTargetSelection string
+
+const (
+	PriceRuleTargetSelectionAll      PriceRuleTargetSelection = "ALL"
+	PriceRuleTargetSelectionEntitled PriceRuleTargetSelection = "ENTITLED"
+)
+
+var AllPriceRuleTargetSelection = []PriceRuleTargetSelection{
+	PriceRuleTargetSelectionAll,
+	PriceRuleTargetSelectionEntitled,
+}
+
+func (e PriceRuleTargetSelection) IsValid() bool {
+	switch e {
+	case PriceRuleTargetSelectionAll, PriceRuleTargetSelectionEntitled:
+		return true
+	}
+	return false
+}
+
+func (e PriceRuleTargetSelection) String() string {
+	return string(e)
+}
+
+func (e *PriceRuleTargetSelection) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PriceRuleTargetSelection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PriceRuleTargetSelection", str)
+	}
+	return nil
+}
+
+func (e PriceRuleTargetSelection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PriceRuleTargetSelection) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PriceRuleTargetSelection) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type PriceRuleTargetType string
+
+const (
+	PriceRuleTargetTypeLineItem     PriceRuleTargetType = "LINE_ITEM"
+	PriceRuleTargetTypeShippingLine PriceRuleTargetType = "SHIPPING_LINE"
+)
+
+var AllPriceRuleTargetType = []PriceRuleTargetType{
+	PriceRuleTargetTypeLineItem,
+	PriceRuleTargetTypeShippingLine,
+}
+
+func (e PriceRuleTargetType) IsValid() bool {
+	switch e {
+	case PriceRuleTargetTypeLineItem, PriceRuleTargetTypeShippingLine:
+		return true
+	}
+	return false
+}
+
+func (e PriceRuleTargetType) String() string {
+	return string(e)
+}
+
+func (e *PriceRuleTargetType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PriceRuleTargetType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PriceRuleTargetType", str)
+	}
+	return nil
+}
+
+func (e PriceRuleTargetType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PriceRuleTargetType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PriceRuleTargetType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type PriceRuleValueType string
+
+const (
+	PriceRuleValueTypeFixedAmount PriceRuleValueType = "FIXED_AMOUNT"
+	PriceRuleValueTypePercentage  PriceRuleValueType = "PERCENTAGE"
+)
+
+var AllPriceRuleValueType = []PriceRuleValueType{
+	PriceRuleValueTypeFixedAmount,
+	PriceRuleValueTypePercentage,
+}
+
+func (e PriceRuleValueType) IsValid() bool {
+	switch e {
+	case PriceRuleValueTypeFixedAmount, PriceRuleValueTypePercentage:
+		return true
+	}
+	return false
+}
+
+func (e PriceRuleValueType) String() string {
+	return string(e)
+}
+
+func (e *PriceRuleValueType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PriceRuleValueType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PriceRuleValueType", str)
+	}
+	return nil
+}
+
+func (e PriceRuleValueType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PriceRuleValueType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PriceRuleValueType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ProductStatus string
+
+const (
+	ProductStatusActive   ProductStatus = "ACTIVE"
+	ProductStatusArchived ProductStatus = "ARCHIVED"
+	ProductStatusDraft    ProductStatus = "DRAFT"
+)
+
+var AllProductStatus = []ProductStatus{
+	ProductStatusActive,
+	ProductStatusArchived,
+	ProductStatusDraft,
+}
+
+func (e ProductStatus) IsValid() bool {
+	switch e {
+	case ProductStatusActive, ProductStatusArchived, ProductStatusDraft:
+		return true
+	}
+	return false
+}
+
+func (e ProductStatus) String() string {
+	return string(e)
+}
+
+func (e *ProductStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ProductStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ProductStatus", str)
+	}
+	return nil
+}
+
+func (e ProductStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ProductStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ProductStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type PublishedStatus string
+
+const (
+	PublishedStatusPublished   PublishedStatus = "PUBLISHED"
+	PublishedStatusUnpublished PublishedStatus = "UNPUBLISHED"
+	PublishedStatusAny         PublishedStatus = "ANY"
+)
+
+var AllPublishedStatus = []PublishedStatus{
+	PublishedStatusPublished,
+	PublishedStatusUnpublished,
+	PublishedStatusAny,
+}
+
+func (e PublishedStatus) IsValid() bool {
+	switch e {
+	case PublishedStatusPublished, PublishedStatusUnpublished, PublishedStatusAny:
+		return true
+	}
+	return false
+}
+
+func (e PublishedStatus) String() string {
+	return string(e)
+}
+
+func (e *PublishedStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PublishedStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PublishedStatus", str)
+	}
+	return nil
+}
+
+func (e PublishedStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PublishedStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PublishedStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type RestockType string
+
+const (
+	RestockTypeNoRestock RestockType = "NO_RESTOCK"
+	RestockTypeCancel    RestockType = "CANCEL"
+	RestockTypeReturn    RestockType = "RETURN"
+)
+
+var AllRestockType = []RestockType{
+	RestockTypeNoRestock,
+	RestockTypeCancel,
+	RestockTypeReturn,
+}
+
+func (e RestockType) IsValid() bool {
+	switch e {
+	case RestockTypeNoRestock, RestockTypeCancel, RestockTypeReturn:
+		return true
+	}
+	return false
+}
+
+func (e RestockType) String() string {
+	return string(e)
+}
+
+func (e *RestockType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = RestockType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid RestockType", str)
+	}
+	return nil
+}
+
+func (e RestockType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *RestockType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e RestockType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ReturnDeclineReason string
+
+const (
+	ReturnDeclineReasonReturnPeriodEnded ReturnDeclineReason = "RETURN_PERIOD_ENDED"
+	ReturnDeclineReasonFinalSale         ReturnDeclineReason = "FINAL_SALE"
+	ReturnDeclineReasonOther             ReturnDeclineReason = "OTHER"
+)
+
+var AllReturnDeclineReason = []ReturnDeclineReason{
+	ReturnDeclineReasonReturnPeriodEnded,
+	ReturnDeclineReasonFinalSale,
+	ReturnDeclineReasonOther,
+}
+
+func (e ReturnDeclineReason) IsValid() bool {
+	switch e {
+	case ReturnDeclineReasonReturnPeriodEnded, ReturnDeclineReasonFinalSale, ReturnDeclineReasonOther:
+		return true
+	}
+	return false
+}
+
+func (e ReturnDeclineReason) String() string {
+	return string(e)
+}
+
+func (e *ReturnDeclineReason) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ReturnDeclineReason(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReturnDeclineReason", str)
+	}
+	return nil
+}
+
+func (e ReturnDeclineReason) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ReturnDeclineReason) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ReturnDeclineReason) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ReturnReason string
+
+const (
+	ReturnReasonColor          ReturnReason = "COLOR"
+	ReturnReasonDefective      ReturnReason = "DEFECTIVE"
+	ReturnReasonNotAsDescribed ReturnReason = "NOT_AS_DESCRIBED"
+	ReturnReasonSizeTooLarge   ReturnReason = "SIZE_TOO_LARGE"
+	ReturnReasonSizeTooSmall   ReturnReason = "SIZE_TOO_SMALL"
+	ReturnReasonStyle          ReturnReason = "STYLE"
+	ReturnReasonUnwanted       ReturnReason = "UNWANTED"
+	ReturnReasonWrongItem      ReturnReason = "WRONG_ITEM"
+	ReturnReasonOther          ReturnReason = "OTHER"
+	ReturnReasonUnknown        ReturnReason = "UNKNOWN"
+)
+
+var AllReturnReason = []ReturnReason{
+	ReturnReasonColor,
+	ReturnReasonDefective,
+	ReturnReasonNotAsDescribed,
+	ReturnReasonSizeTooLarge,
+	ReturnReasonSizeTooSmall,
+	ReturnReasonStyle,
+	ReturnReasonUnwanted,
+	ReturnReasonWrongItem,
+	ReturnReasonOther,
+	ReturnReasonUnknown,
+}
+
+func (e ReturnReason) IsValid() bool {
+	switch e {
+	case ReturnReasonColor, ReturnReasonDefective, ReturnReasonNotAsDescribed, ReturnReasonSizeTooLarge, ReturnReasonSizeTooSmall, ReturnReasonStyle, ReturnReasonUnwanted, ReturnReasonWrongItem, ReturnReasonOther, ReturnReasonUnknown:
+		return true
+	}
+	return false
+}
+
+func (e ReturnReason) String() string {
+	return string(e)
+}
+
+func (e *ReturnReason) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ReturnReason(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReturnReason", str)
+	}
+	return nil
+}
+
+func (e ReturnReason) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ReturnReason) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ReturnReason) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ReturnStatus string
+
+const (
+	ReturnStatusRequested ReturnStatus = "REQUESTED"
+	ReturnStatusOpen      ReturnStatus = "OPEN"
+	ReturnStatusClosed    ReturnStatus = "CLOSED"
+	ReturnStatusDeclined  ReturnStatus = "DECLINED"
+	ReturnStatusCanceled  ReturnStatus = "CANCELED"
+)
+
+var AllReturnStatus = []ReturnStatus{
+	ReturnStatusRequested,
+	ReturnStatusOpen,
+	ReturnStatusClosed,
+	ReturnStatusDeclined,
+	ReturnStatusCanceled,
+}
+
+func (e ReturnStatus) IsValid() bool {
+	switch e {
+	case ReturnStatusRequested, ReturnStatusOpen, ReturnStatusClosed, ReturnStatusDeclined, ReturnStatusCanceled:
+		return true
+	}
+	return false
+}
+
+func (e ReturnStatus) String() string {
+	return string(e)
+}
+
+func (e *ReturnStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ReturnStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReturnStatus", str)
+	}
+	return nil
+}
+
+func (e ReturnStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ReturnStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ReturnStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type TokenStatus string
+
+const (
+	TokenStatusNonExpiring   TokenStatus = "NON_EXPIRING"
+	TokenStatusActive        TokenStatus = "ACTIVE"
+	TokenStatusExpiring      TokenStatus = "EXPIRING"
+	TokenStatusExpired       TokenStatus = "EXPIRED"
+	TokenStatusRefreshFailed TokenStatus = "REFRESH_FAILED"
+)
+
+var AllTokenStatus = []TokenStatus{
+	TokenStatusNonExpiring,
+	TokenStatusActive,
+	TokenStatusExpiring,
+	TokenStatusExpired,
+	TokenStatusRefreshFailed,
+}
+
+func (e TokenStatus) IsValid() bool {
+	switch e {
+	case TokenStatusNonExpiring, TokenStatusActive, TokenStatusExpiring, TokenStatusExpired, TokenStatusRefreshFailed:
+		return true
+	}
+	return false
+}
+
+func (e TokenStatus) String() string {
+	return string(e)
+}
+
+func (e *TokenStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TokenStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TokenStatus", str)
+	}
+	return nil
+}
+
+func (e TokenStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *TokenStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e TokenStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type TransactionKind string
+
+const (
+	TransactionKindAuthorization TransactionKind = "AUTHORIZATION"
+	TransactionKindCapture       TransactionKind = "CAPTURE"
+	TransactionKindSale          TransactionKind = "SALE"
+	TransactionKindVoid          TransactionKind = "VOID"
+	TransactionKindRefund        TransactionKind = "REFUND"
+)
+
+var AllTransactionKind = []TransactionKind{
+	TransactionKindAuthorization,
+	TransactionKindCapture,
+	TransactionKindSale,
+	TransactionKindVoid,
+	TransactionKindRefund,
+}
+
+func (e TransactionKind) IsValid() bool {
+	switch e {
+	case TransactionKindAuthorization, TransactionKindCapture, TransactionKindSale, TransactionKindVoid, TransactionKindRefund:
+		return true
+	}
+	return false
+}
+
+func (e TransactionKind) String() string {
+	return string(e)
+}
+
+func (e *TransactionKind) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TransactionKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TransactionKind", str)
+	}
+	return nil
+}
+
+func (e TransactionKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *TransactionKind) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e TransactionKind) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type TransactionStatus string
+
+const (
+	TransactionStatusPending TransactionStatus = "PENDING"
+	TransactionStatusFailure TransactionStatus = "FAILURE"
+	TransactionStatusSuccess TransactionStatus = "SUCCESS"
+	TransactionStatusError   TransactionStatus = "ERROR"
+)
+
+var AllTransactionStatus = []TransactionStatus{
+	TransactionStatusPending,
+	TransactionStatusFailure,
+	TransactionStatusSuccess,
+	TransactionStatusError,
+}
+
+func (e TransactionStatus) IsValid() bool {
+	switch e {
+	case TransactionStatusPending, TransactionStatusFailure, TransactionStatusSuccess, TransactionStatusError:
+		return true
+	}
+	return false
+}
+
+func (e TransactionStatus) String() string {
+	return string(e)
+}
+
+func (e *TransactionStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TransactionStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TransactionStatus", str)
+	}
+	return nil
+}
+
+func (e TransactionStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *TransactionStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e TransactionStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type UsagePeriod string
+
+const (
+	UsagePeriodDay   UsagePeriod = "DAY"
+	UsagePeriodMonth UsagePeriod = "MONTH"
+)
+
+var AllUsagePeriod = []UsagePeriod{
+	UsagePeriodDay,
+	UsagePeriodMonth,
+}
+
+func (e UsagePeriod) IsValid() bool {
+	switch e {
+	case UsagePeriodDay, UsagePeriodMonth:
+		return true
+	}
+	return false
+}
+
+func (e UsagePeriod) String() string {
+	return string(e)
+}
+
+func (e *UsagePeriod) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = UsagePeriod(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid UsagePeriod", str)
+	}
+	return nil
+}
+
+func (e UsagePeriod) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *UsagePeriod) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e UsagePeriod) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type WeightUnit string
+
+const (
+	WeightUnitG  WeightUnit = "G"
+	WeightUnitKg WeightUnit = "KG"
+	WeightUnitOz WeightUnit = "OZ"
+	WeightUnitLb WeightUnit = "LB"
+)
+
+var AllWeightUnit = []WeightUnit{
+	WeightUnitG,
+	WeightUnitKg,
+	WeightUnitOz,
+	WeightUnitLb,
+}
+
+func (e WeightUnit) IsValid() bool {
+	switch e {
+	case WeightUnitG, WeightUnitKg, WeightUnitOz, WeightUnitLb:
+		return true
+	}
+	return false
+}
+
+func (e WeightUnit) String() string {
+	return string(e)
+}
+
+func (e *WeightUnit) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WeightUnit(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WeightUnit", str)
+	}
+	return nil
+}
+
+func (e WeightUnit) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *WeightUnit) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e WeightUnit) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
 }
@@ -0,0 +1,239 @@
+package model
+
+import (
+	"archie-core-shopify-layer/graph/scalars"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+)
+
+// Product is a Shopify product
+// Nested fields are resolved from Source when they are selected
+type Product struct {
+	ID                string         `json:"id"`
+	Title             string         `json:"title"`
+	BodyHTML          *string        `json:"bodyHtml,omitempty"`
+	Handle            *string        `json:"handle,omitempty"`
+	Vendor            *string        `json:"vendor,omitempty"`
+	ProductType       *string        `json:"productType,omitempty"`
+	Status            *ProductStatus `json:"status,omitempty"`
+	Tags              []string       `json:"tags"`
+	PublishedAt       *scalars.Time  `json:"publishedAt,omitempty"`
+	PublishedScope    *string        `json:"publishedScope,omitempty"`
+	TemplateSuffix    *string        `json:"templateSuffix,omitempty"`
+	AdminGraphqlAPIID *string        `json:"adminGraphqlApiId,omitempty"`
+	CreatedAt         scalars.Time   `json:"createdAt"`
+	UpdatedAt         scalars.Time   `json:"updatedAt"`
+
+	Shop   string             `json:"-"` // Shop domain, for nested fields fetched from Shopify
+	Source *goshopify.Product `json:"-"`
+}
+
+// ProductVariant is a variant of a Shopify product
+// Inventory levels are fetched from Shopify when they are selected
+type ProductVariant struct {
+	ID                  string           `json:"id"`
+	ProductID           string           `json:"productId"`
+	Title               string           `json:"title"`
+	Sku                 *string          `json:"sku,omitempty"`
+	Barcode             *string          `json:"barcode,omitempty"`
+	Position            int              `json:"position"`
+	Price               *string          `json:"price,omitempty"`
+	CompareAtPrice      *string          `json:"compareAtPrice,omitempty"`
+	Option1             *string          `json:"option1,omitempty"`
+	Option2             *string          `json:"option2,omitempty"`
+	Option3             *string          `json:"option3,omitempty"`
+	InventoryPolicy     *InventoryPolicy `json:"inventoryPolicy,omitempty"`
+	InventoryManagement *string          `json:"inventoryManagement,omitempty"`
+	InventoryItemID     *string          `json:"inventoryItemId,omitempty"`
+	InventoryQuantity   int              `json:"inventoryQuantity"`
+	FulfillmentService  *string          `json:"fulfillmentService,omitempty"`
+	Taxable             bool             `json:"taxable"`
+	TaxCode             *string          `json:"taxCode,omitempty"`
+	RequiresShipping    bool             `json:"requiresShipping"`
+	Grams               int              `json:"grams"`
+	Weight              *float64         `json:"weight,omitempty"`
+	WeightUnit          *WeightUnit      `json:"weightUnit,omitempty"`
+	ImageID             *string          `json:"imageId,omitempty"`
+	CreatedAt           *scalars.Time    `json:"createdAt,omitempty"`
+	UpdatedAt           *scalars.Time    `json:"updatedAt,omitempty"`
+
+	Shop   string             `json:"-"`
+	Source *goshopify.Variant `json:"-"`
+}
+
+// Order is a Shopify order
+// Nested fields are resolved from Source when they are selected
+type Order struct {
+	ID                  string        `json:"id"`
+	Name                *string       `json:"name,omitempty"`
+	OrderNumber         int           `json:"orderNumber"`
+	Email               *string       `json:"email,omitempty"`
+	Phone               *string       `json:"phone,omitempty"`
+	Note                *string       `json:"note,omitempty"`
+	Tags                []string      `json:"tags"`
+	Currency            *string       `json:"currency,omitempty"`
+	PresentmentCurrency *string       `json:"presentmentCurrency,omitempty"`
+	TotalPrice          string        `json:"totalPrice"`
+	SubtotalPrice       *string       `json:"subtotalPrice,omitempty"`
+	TotalTax            *string       `json:"totalTax,omitempty"`
+	TotalDiscounts      *string       `json:"totalDiscounts,omitempty"`
+	TotalLineItemsPrice *string       `json:"totalLineItemsPrice,omitempty"`
+	TotalWeight         int           `json:"totalWeight"`
+	TaxesIncluded       bool          `json:"taxesIncluded"`
+	FinancialStatus     *string       `json:"financialStatus,omitempty"`
+	FulfillmentStatus   *string       `json:"fulfillmentStatus,omitempty"`
+	CancelReason        *string       `json:"cancelReason,omitempty"`
+	Test                bool          `json:"test"`
+	Confirmed           bool          `json:"confirmed"`
+	SourceName          *string       `json:"sourceName,omitempty"`
+	OrderStatusURL      *string       `json:"orderStatusUrl,omitempty"`
+	ProcessedAt         *scalars.Time `json:"processedAt,omitempty"`
+	CancelledAt         *scalars.Time `json:"cancelledAt,omitempty"`
+	ClosedAt            *scalars.Time `json:"closedAt,omitempty"`
+	CreatedAt           scalars.Time  `json:"createdAt"`
+	UpdatedAt           scalars.Time  `json:"updatedAt"`
+
+	Shop   string           `json:"-"`
+	Source *goshopify.Order `json:"-"`
+}
+
+// Customer is a Shopify customer
+// Nested fields are resolved from Source when they are selected
+type Customer struct {
+	ID            string       `json:"id"`
+	Email         *string      `json:"email,omitempty"`
+	Phone         *string      `json:"phone,omitempty"`
+	FirstName     *string      `json:"firstName,omitempty"`
+	LastName      *string      `json:"lastName,omitempty"`
+	State         *string      `json:"state,omitempty"`
+	Note          *string      `json:"note,omitempty"`
+	VerifiedEmail bool         `json:"verifiedEmail"`
+	TaxExempt     bool         `json:"taxExempt"`
+	OrdersCount   *int         `json:"ordersCount,omitempty"`
+	TotalSpent    *string      `json:"totalSpent,omitempty"`
+	Currency      *string      `json:"currency,omitempty"`
+	Tags          []string     `json:"tags"`
+	LastOrderID   *string      `json:"lastOrderId,omitempty"`
+	LastOrderName *string      `json:"lastOrderName,omitempty"`
+	CreatedAt     scalars.Time `json:"createdAt"`
+	UpdatedAt     scalars.Time `json:"updatedAt"`
+
+	Shop   string              `json:"-"`
+	Source *goshopify.Customer `json:"-"`
+}
+
+// Collection is a Shopify custom or smart collection
+// Products are fetched from Shopify when they are selected
+type Collection struct {
+	ID             string               `json:"id"`
+	Type           CollectionType       `json:"type"`
+	Title          string               `json:"title"`
+	Handle         *string              `json:"handle,omitempty"`
+	BodyHTML       *string              `json:"bodyHtml,omitempty"`
+	SortOrder      *CollectionSortOrder `json:"sortOrder,omitempty"`
+	TemplateSuffix *string              `json:"templateSuffix,omitempty"`
+	Published      bool                 `json:"published"`
+	PublishedAt    *scalars.Time        `json:"publishedAt,omitempty"`
+	PublishedScope *string              `json:"publishedScope,omitempty"`
+	Image          *CollectionImage     `json:"image,omitempty"`
+	Rules          []*CollectionRule    `json:"rules"`
+	Disjunctive    bool                 `json:"disjunctive"`
+	UpdatedAt      *scalars.Time        `json:"updatedAt,omitempty"`
+
+	Shop string `json:"-"`
+}
+
+// PriceRule is a Shopify price rule
+// Discount codes are fetched from Shopify when they are selected
+type PriceRule struct {
+	ID                string                      `json:"id"`
+	Title             string                      `json:"title"`
+	ValueType         *PriceRuleValueType         `json:"valueType,omitempty"`
+	Value             *string                     `json:"value,omitempty"`
+	TargetType        *PriceRuleTargetType        `json:"targetType,omitempty"`
+	TargetSelection   *PriceRuleTargetSelection   `json:"targetSelection,omitempty"`
+	AllocationMethod  *PriceRuleAllocationMethod  `json:"allocationMethod,omitempty"`
+	AllocationLimit   *int                        `json:"allocationLimit,omitempty"`
+	CustomerSelection *PriceRuleCustomerSelection `json:"customerSelection,omitempty"`
+	OncePerCustomer   bool                        `json:"oncePerCustomer"`
+	UsageLimit        *int                        `json:"usageLimit,omitempty"`
+	StartsAt          *scalars.Time               `json:"startsAt,omitempty"`
+	EndsAt            *scalars.Time               `json:"endsAt,omitempty"`
+	Entitlements      *PriceRuleEntitlements      `json:"entitlements"`
+	Prerequisites     *PriceRulePrerequisites     `json:"prerequisites"`
+	CreatedAt         *scalars.Time               `json:"createdAt,omitempty"`
+	UpdatedAt         *scalars.Time               `json:"updatedAt,omitempty"`
+
+	Shop string `json:"-"`
+}
+
+// DiscountCodeBatch is a Shopify discount code batch
+// Its codes are fetched from Shopify when they are selected
+type DiscountCodeBatch struct {
+	ID            string                  `json:"id"`
+	PriceRuleID   string                  `json:"priceRuleId"`
+	Status        DiscountCodeBatchStatus `json:"status"`
+	CodesCount    int                     `json:"codesCount"`
+	ImportedCount int                     `json:"importedCount"`
+	FailedCount   int                     `json:"failedCount"`
+	Logs          []string                `json:"logs"`
+	StartedAt     *scalars.Time           `json:"startedAt,omitempty"`
+	CompletedAt   *scalars.Time           `json:"completedAt,omitempty"`
+	CreatedAt     *scalars.Time           `json:"createdAt,omitempty"`
+	UpdatedAt     *scalars.Time           `json:"updatedAt,omitempty"`
+
+	Shop string `json:"-"`
+}
+
+// DraftOrder is a Shopify draft order
+// The order it was completed into is fetched from Shopify when it is selected
+type DraftOrder struct {
+	ID              string                     `json:"id"`
+	Name            *string                    `json:"name,omitempty"`
+	Status          *DraftOrderStatus          `json:"status,omitempty"`
+	Email           *string                    `json:"email,omitempty"`
+	Note            *string                    `json:"note,omitempty"`
+	Tags            []string                   `json:"tags"`
+	Currency        *string                    `json:"currency,omitempty"`
+	TaxesIncluded   bool                       `json:"taxesIncluded"`
+	TaxExempt       bool                       `json:"taxExempt"`
+	SubtotalPrice   *string                    `json:"subtotalPrice,omitempty"`
+	TotalTax        *string                    `json:"totalTax,omitempty"`
+	TotalPrice      *string                    `json:"totalPrice,omitempty"`
+	InvoiceURL      *string                    `json:"invoiceUrl,omitempty"`
+	InvoiceSentAt   *scalars.Time              `json:"invoiceSentAt,omitempty"`
+	Customer        *Customer                  `json:"customer,omitempty"`
+	BillingAddress  *MailingAddress            `json:"billingAddress,omitempty"`
+	ShippingAddress *MailingAddress            `json:"shippingAddress,omitempty"`
+	LineItems       []*DraftOrderLineItem      `json:"lineItems"`
+	ShippingLine    *DraftOrderShippingLine    `json:"shippingLine,omitempty"`
+	AppliedDiscount *DraftOrderAppliedDiscount `json:"appliedDiscount,omitempty"`
+	TaxLines        []*TaxLine                 `json:"taxLines"`
+	NoteAttributes  []*NoteAttribute           `json:"noteAttributes"`
+	OrderID         *string                    `json:"orderId,omitempty"`
+	CompletedAt     *scalars.Time              `json:"completedAt,omitempty"`
+	CreatedAt       *scalars.Time              `json:"createdAt,omitempty"`
+	UpdatedAt       *scalars.Time              `json:"updatedAt,omitempty"`
+
+	Shop string `json:"-"`
+}
+
+// BulkOperation is a Shopify bulk query or mutation
+// results is resolved from the results file of the operation when selected
+type BulkOperation struct {
+	ID              string              `json:"id"`
+	Type            BulkOperationType   `json:"type"`
+	Status          BulkOperationStatus `json:"status"`
+	Query           string              `json:"query"`
+	ErrorCode       *string             `json:"errorCode,omitempty"`
+	ObjectCount     int                 `json:"objectCount"`
+	RootObjectCount int                 `json:"rootObjectCount"`
+	FileSize        int                 `json:"fileSize"`
+	URL             *string             `json:"url,omitempty"`
+	PartialDataURL  *string             `json:"partialDataUrl,omitempty"`
+	StartedBy       *string             `json:"startedBy,omitempty"`
+	CreatedAt       *scalars.Time       `json:"createdAt,omitempty"`
+	CompletedAt     *scalars.Time       `json:"completedAt,omitempty"`
+
+	Shop string `json:"-"`
+}
@@ -0,0 +1,41 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"archie-core-shopify-layer/internal/domain"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// maxOnDemandFetches bounds the Shopify requests that nested fields fetched on demand make per operation
+// Each item of a list selecting such a field costs one request, larger lists must use the root fields instead
+const maxOnDemandFetches = 25
+
+// onDemandFetchesKey is the context key for the on-demand fetches made by an operation
+type onDemandFetchesKey struct{}
+
+// OnDemandFetchBudget counts the on-demand fetches of each operation
+func OnDemandFetchBudget(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	var fetches int32
+	return next(context.WithValue(ctx, onDemandFetchesKey{}, &fetches))
+}
+
+// authorizeOnDemandFetch checks a nested field that fetches from Shopify: integration keys must be allowed the root
+// field serving the same data, and the operation must not have used up its on-demand fetches
+func authorizeOnDemandFetch(ctx context.Context, rootField string) error {
+	if principal := domain.GetPrincipalFromContext(ctx); principal != nil && !principal.AllowsGraphQLField(rootField) {
+		return &gqlerror.Error{
+			Path:       graphql.GetPath(ctx),
+			Message:    "integration key is not allowed to call " + rootField,
+			Extensions: map[string]interface{}{"code": errorCodeForbidden},
+		}
+	}
+	if fetches, ok := ctx.Value(onDemandFetchesKey{}).(*int32); ok && atomic.AddInt32(fetches, 1) > maxOnDemandFetches {
+		return domain.NewValidationError(fmt.Sprintf("at most %d on-demand fields can be resolved per operation, query %s instead", maxOnDemandFetches, rootField), nil)
+	}
+	return nil
+}
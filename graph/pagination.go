@@ -0,0 +1,290 @@
+package graph
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"archie-core-shopify-layer/graph/model"
+	"archie-core-shopify-layer/graph/scalars"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+)
+
+// Shopify REST page sizes
+const (
+	defaultPageSize = 50
+	maxPageSize     = 250
+)
+
+// Cursor kinds: a Shopify page_info token, or the ID of a record to resume after
+const (
+	pageInfoCursor = "page_info"
+	sinceIDCursor  = "since_id"
+)
+
+// idOrder sorts first pages by ID, so that the since_id cursors of their edges resume right after the record
+const idOrder = "id asc"
+
+// pageRequest holds the Shopify list options built from the paging arguments of a list query
+type pageRequest struct {
+	options  goshopify.ListOptions
+	filtered bool // Filters may be applied, false when paging with a page_info cursor
+}
+
+// encodeCursor builds an opaque cursor
+func encodeCursor(kind string, value string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(kind + ":" + value))
+}
+
+// decodeCursor reads a cursor built by encodeCursor
+func decodeCursor(cursor string) (string, string, bool) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", "", false
+	}
+	kind, value, ok := strings.Cut(string(decoded), ":")
+	if !ok || value == "" || (kind != pageInfoCursor && kind != sinceIDCursor) {
+		return "", "", false
+	}
+	return kind, value, true
+}
+
+// newPageRequest validates the paging arguments of a list query
+func newPageRequest(v *inputValidator, first *int, after *string, before *string, fields []string) pageRequest {
+	request := pageRequest{
+		options:  goshopify.ListOptions{Limit: defaultPageSize},
+		filtered: true,
+	}
+
+	if first != nil {
+		if *first < 1 || *first > maxPageSize {
+			v.reject("first", fmt.Sprintf("must be between 1 and %d", maxPageSize))
+		}
+		request.options.Limit = *first
+	}
+
+	if len(fields) > 0 {
+		projection := []string{"id"}
+		for _, field := range fields {
+			if field = strings.TrimSpace(field); field != "" && field != "id" {
+				projection = append(projection, field)
+			}
+		}
+		request.options.Fields = strings.Join(projection, ",")
+	}
+
+	if after != nil && before != nil {
+		v.reject("before", "cannot be combined with after")
+		return request
+	}
+
+	if after != nil {
+		kind, value, ok := decodeCursor(*after)
+		switch {
+		case !ok:
+			v.reject("after", "is not a valid cursor")
+		case kind == pageInfoCursor:
+			request.options.PageInfo = value
+			request.filtered = false
+		default:
+			id, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				v.reject("after", "is not a valid cursor")
+			}
+			request.options.SinceId = &id
+		}
+	}
+
+	if before != nil {
+		kind, value, ok := decodeCursor(*before)
+		if !ok || kind != pageInfoCursor {
+			v.reject("before", "must be a pageInfo.startCursor")
+		}
+		request.options.PageInfo = value
+		request.filtered = false
+	}
+
+	// Page_info cursors keep the order of the first page, and Shopify refuses order alongside them
+	if request.options.PageInfo == "" {
+		request.options.Order = idOrder
+	}
+
+	return request
+}
+
+// toModelPageInfo converts Shopify's Link header pagination to a page info
+func toModelPageInfo(pagination *goshopify.Pagination) *model.PageInfo {
+	result := &model.PageInfo{}
+	if pagination == nil {
+		return result
+	}
+	if next := pagination.NextPageOptions; next != nil && next.PageInfo != "" {
+		cursor := encodeCursor(pageInfoCursor, next.PageInfo)
+		result.HasNextPage = true
+		result.EndCursor = &cursor
+	}
+	if previous := pagination.PreviousPageOptions; previous != nil && previous.PageInfo != "" {
+		cursor := encodeCursor(pageInfoCursor, previous.PageInfo)
+		result.HasPreviousPage = true
+		result.StartCursor = &cursor
+	}
+	return result
+}
+
+// idCursor builds the cursor resuming after a record
+func idCursor(id uint64) string {
+	return encodeCursor(sinceIDCursor, strconv.FormatUint(id, 10))
+}
+
+// filterIDs parses the ID list of a filter
+func filterIDs(v *inputValidator, field string, ids []string) []uint64 {
+	result := make([]uint64, 0, len(ids))
+	for i, value := range ids {
+		path := fmt.Sprintf("%s[%d]", field, i)
+		if id := v.id(path, &value); id != 0 {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// filterTime converts an optional filter time, the zero time when unset
+func filterTime(value *scalars.Time) time.Time {
+	if value == nil {
+		return time.Time{}
+	}
+	return time.Time(*value)
+}
+
+// productListOptions builds the Shopify options of shopify_products
+func productListOptions(v *inputValidator, request pageRequest, filter *model.ProductFilter) *goshopify.ProductListOptions {
+	options := &goshopify.ProductListOptions{ListOptions: request.options}
+	if filter == nil || !request.filtered {
+		return options
+	}
+
+	options.Ids = filterIDs(v, "filter.ids", filter.Ids)
+	for _, status := range filter.Status {
+		options.Status = append(options.Status, goshopify.ProductStatus(shopifyEnum(string(status))))
+	}
+	options.Vendor = stringValue(filter.Vendor)
+	options.ProductType = stringValue(filter.ProductType)
+	options.Handle = stringValue(filter.Handle)
+	options.Title = stringValue(filter.Title)
+	options.CollectionId = v.id("filter.collectionId", filter.CollectionID)
+	options.CreatedAtMin = filterTime(filter.CreatedAtMin)
+	options.CreatedAtMax = filterTime(filter.CreatedAtMax)
+	options.UpdatedAtMin = filterTime(filter.UpdatedAtMin)
+	options.UpdatedAtMax = filterTime(filter.UpdatedAtMax)
+	options.PublishedAtMin = filterTime(filter.PublishedAtMin)
+	options.PublishedAtMax = filterTime(filter.PublishedAtMax)
+	return options
+}
+
+// orderListOptions builds the Shopify options of shopify_orders
+func orderListOptions(v *inputValidator, request pageRequest, filter *model.OrderFilter) *goshopify.OrderListOptions {
+	options := &goshopify.OrderListOptions{ListOptions: request.options}
+	if filter == nil || !request.filtered {
+		return options
+	}
+
+	options.Ids = filterIDs(v, "filter.ids", filter.Ids)
+	if filter.Status != nil {
+		options.Status = goshopify.OrderStatus(shopifyEnum(string(*filter.Status)))
+	}
+	if filter.FinancialStatus != nil {
+		options.FinancialStatus = goshopify.OrderFinancialStatus(shopifyEnum(string(*filter.FinancialStatus)))
+	}
+	if filter.FulfillmentStatus != nil {
+		options.FulfillmentStatus = goshopify.OrderFulfillmentStatus(shopifyEnum(string(*filter.FulfillmentStatus)))
+	}
+	options.CreatedAtMin = filterTime(filter.CreatedAtMin)
+	options.CreatedAtMax = filterTime(filter.CreatedAtMax)
+	options.UpdatedAtMin = filterTime(filter.UpdatedAtMin)
+	options.UpdatedAtMax = filterTime(filter.UpdatedAtMax)
+	options.ProcessedAtMin = filterTime(filter.ProcessedAtMin)
+	options.ProcessedAtMax = filterTime(filter.ProcessedAtMax)
+	return options
+}
+
+// customerListOptions builds the Shopify options of shopify_customers
+func customerListOptions(v *inputValidator, request pageRequest, filter *model.CustomerFilter) *goshopify.ListOptions {
+	options := request.options
+	if filter == nil || !request.filtered {
+		return &options
+	}
+
+	options.Ids = filterIDs(v, "filter.ids", filter.Ids)
+	options.CreatedAtMin = filterTime(filter.CreatedAtMin)
+	options.CreatedAtMax = filterTime(filter.CreatedAtMax)
+	options.UpdatedAtMin = filterTime(filter.UpdatedAtMin)
+	options.UpdatedAtMax = filterTime(filter.UpdatedAtMax)
+	return &options
+}
+
+// customerSearchOptions are the Shopify options of shopify_searchCustomers
+type customerSearchOptions struct {
+	goshopify.ListOptions
+	Query string `url:"query,omitempty"`
+}
+
+// inventoryLevelListOptions are the Shopify options of shopify_inventoryLevels
+type inventoryLevelListOptions struct {
+	PageInfo         string    `url:"page_info,omitempty"`
+	Limit            int       `url:"limit,omitempty"`
+	InventoryItemIds []uint64  `url:"inventory_item_ids,omitempty,comma"`
+	LocationIds      []uint64  `url:"location_ids,omitempty,comma"`
+	UpdatedAtMin     time.Time `url:"updated_at_min,omitempty"`
+}
+
+// toInventoryLevelListOptions builds the Shopify options of shopify_inventoryLevels
+func toInventoryLevelListOptions(v *inputValidator, request pageRequest, filter model.InventoryLevelFilter) *inventoryLevelListOptions {
+	options := &inventoryLevelListOptions{PageInfo: request.options.PageInfo, Limit: request.options.Limit}
+	if request.options.SinceId != nil {
+		v.reject("after", "must be a pageInfo.endCursor")
+	}
+	if !request.filtered {
+		return options
+	}
+
+	options.InventoryItemIds = filterIDs(v, "filter.inventoryItemIds", filter.InventoryItemIds)
+	options.LocationIds = filterIDs(v, "filter.locationIds", filter.LocationIds)
+	options.UpdatedAtMin = filterTime(filter.UpdatedAtMin)
+	if len(filter.InventoryItemIds) == 0 && len(filter.LocationIds) == 0 {
+		v.reject("filter", "must set inventoryItemIds or locationIds")
+	}
+	return options
+}
+
+// toProductConnection converts a page of Shopify products
+func toProductConnection(shop string, products []goshopify.Product, pagination *goshopify.Pagination) *model.ProductConnection {
+	nodes := toModelProducts(shop, products)
+	edges := make([]*model.ProductEdge, len(nodes))
+	for i, node := range nodes {
+		edges[i] = &model.ProductEdge{Cursor: idCursor(products[i].Id), Node: node}
+	}
+	return &model.ProductConnection{Edges: edges, Nodes: nodes, PageInfo: toModelPageInfo(pagination)}
+}
+
+// toOrderConnection converts a page of Shopify orders
+func toOrderConnection(shop string, orders []goshopify.Order, pagination *goshopify.Pagination) *model.OrderConnection {
+	nodes := toModelOrders(shop, orders)
+	edges := make([]*model.OrderEdge, len(nodes))
+	for i, node := range nodes {
+		edges[i] = &model.OrderEdge{Cursor: idCursor(orders[i].Id), Node: node}
+	}
+	return &model.OrderConnection{Edges: edges, Nodes: nodes, PageInfo: toModelPageInfo(pagination)}
+}
+
+// toCustomerConnection converts a page of Shopify customers
+func toCustomerConnection(shop string, customers []goshopify.Customer, pagination *goshopify.Pagination) *model.CustomerConnection {
+	nodes := toModelCustomers(shop, customers)
+	edges := make([]*model.CustomerEdge, len(nodes))
+	for i, node := range nodes {
+		edges[i] = &model.CustomerEdge{Cursor: idCursor(customers[i].Id), Node: node}
+	}
+	return &model.CustomerConnection{Edges: edges, Nodes: nodes, PageInfo: toModelPageInfo(pagination)}
+}
@@ -0,0 +1,53 @@
+package graph
+
+import (
+	"context"
+
+	"archie-core-shopify-layer/internal/domain"
+	"archie-core-shopify-layer/internal/infrastructure/auth"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/rs/zerolog"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// Error codes set in the extensions of authorization errors
+const (
+	errorCodeUnauthenticated = "UNAUTHENTICATED"
+	errorCodeForbidden       = "FORBIDDEN"
+)
+
+// HasPermissionDirective implements @hasPermission, refusing fields the principal has no permission for
+// Denials are audit-logged and returned with a FORBIDDEN code and the missing permission
+func HasPermissionDirective(logger zerolog.Logger) func(ctx context.Context, obj interface{}, next graphql.Resolver, permission string) (interface{}, error) {
+	return func(ctx context.Context, obj interface{}, next graphql.Resolver, permission string) (interface{}, error) {
+		required := domain.Permission(permission)
+		operation := ""
+		if field := graphql.GetFieldContext(ctx); field != nil {
+			operation = field.Field.Name
+		}
+
+		principal := domain.GetPrincipalFromContext(ctx)
+		if principal == nil {
+			auth.AuditPermissionDenied(logger, nil, operation, required)
+			return nil, authorizationError(ctx, errorCodeUnauthenticated, "authentication required", required)
+		}
+		if !principal.HasPermission(required) {
+			auth.AuditPermissionDenied(logger, principal, operation, required)
+			return nil, authorizationError(ctx, errorCodeForbidden, "missing permission "+permission, required)
+		}
+		return next(ctx)
+	}
+}
+
+// authorizationError builds a GraphQL error with a machine-readable code and the required permission
+func authorizationError(ctx context.Context, code string, message string, permission domain.Permission) *gqlerror.Error {
+	return &gqlerror.Error{
+		Path:    graphql.GetPath(ctx),
+		Message: message,
+		Extensions: map[string]interface{}{
+			"code":       code,
+			"permission": string(permission),
+		},
+	}
+}
@@ -0,0 +1,239 @@
+package graph
+
+import (
+	"strings"
+
+	"archie-core-shopify-layer/graph/model"
+	"archie-core-shopify-layer/internal/domain"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+	"github.com/shopspring/decimal"
+)
+
+// toRefundRequest validates a refund input, field is the name of the input in the query
+func toRefundRequest(v *inputValidator, field string, input *model.RefundInput) domain.RefundRequest {
+	prefix := field + "."
+	request := domain.RefundRequest{
+		Currency: strings.ToUpper(strings.TrimSpace(stringValue(input.Currency))),
+		Note:     strings.TrimSpace(stringValue(input.Note)),
+		Notify:   boolValue(input.Notify),
+	}
+
+	if shipping := input.Shipping; shipping != nil {
+		amount := v.amount(prefix+"shipping.amount", shipping.Amount)
+		v.nonNegative(prefix+"shipping.amount", amount)
+		if boolValue(shipping.FullRefund) && amount != nil {
+			v.reject(prefix+"shipping", "must set either fullRefund or amount")
+		}
+		request.Shipping = &domain.RefundShippingRequest{FullRefund: boolValue(shipping.FullRefund), Amount: amount}
+	}
+
+	for i, item := range input.LineItems {
+		lineItem := domain.RefundLineItemRequest{
+			LineItemID:  requiredInputID(v, fieldPath(prefix+"lineItems", i, "lineItemId"), item.LineItemID),
+			Quantity:    item.Quantity,
+			RestockType: domain.RestockTypeNoRestock,
+			LocationID:  v.id(fieldPath(prefix+"lineItems", i, "locationId"), item.LocationID),
+		}
+		if item.Quantity <= 0 {
+			v.reject(fieldPath(prefix+"lineItems", i, "quantity"), "must be positive")
+		}
+		if item.RestockType != nil {
+			lineItem.RestockType = domain.RestockType(shopifyEnum(string(*item.RestockType)))
+		}
+		if lineItem.RestockType == domain.RestockTypeNoRestock && lineItem.LocationID != 0 {
+			v.reject(fieldPath(prefix+"lineItems", i, "locationId"), "must not be set when the line item is not restocked")
+		}
+		request.LineItems = append(request.LineItems, lineItem)
+	}
+
+	for i, transaction := range input.Transactions {
+		amountField := fieldPath(prefix+"transactions", i, "amount")
+		amount := v.amount(amountField, &transaction.Amount)
+		if amount != nil && !amount.IsPositive() {
+			v.reject(amountField, "must be positive")
+		}
+		if strings.TrimSpace(transaction.Gateway) == "" {
+			v.reject(fieldPath(prefix+"transactions", i, "gateway"), "is required")
+		}
+		refund := domain.RefundTransactionRequest{
+			ParentID: requiredInputID(v, fieldPath(prefix+"transactions", i, "parentId"), transaction.ParentID),
+			Kind:     "refund",
+			Gateway:  strings.TrimSpace(transaction.Gateway),
+		}
+		if amount != nil {
+			refund.Amount = *amount
+		}
+		request.Transactions = append(request.Transactions, refund)
+	}
+
+	if request.Shipping == nil && len(request.LineItems) == 0 && len(request.Transactions) == 0 {
+		v.reject(field, "must refund shipping, line items or transactions")
+	}
+	return request
+}
+
+// toOrderCancellation validates the options of an order cancellation
+func toOrderCancellation(input model.CancelOrderInput) (int64, domain.OrderCancellation, error) {
+	v := &inputValidator{}
+	orderID := requiredInputID(v, "orderId", input.OrderID)
+	cancellation := domain.OrderCancellation{
+		Email:   boolValue(input.Email),
+		Restock: boolValue(input.Restock),
+	}
+	if input.Reason != nil {
+		cancellation.Reason = domain.OrderCancelReason(shopifyEnum(string(*input.Reason)))
+	}
+	if input.Refund != nil {
+		refund := toRefundRequest(v, "refund", input.Refund)
+		cancellation.Refund = &refund
+	}
+	if err := v.result("invalid order cancellation input"); err != nil {
+		return 0, cancellation, err
+	}
+	return int64(orderID), cancellation, nil
+}
+
+// toModelRefund converts a Shopify refund
+func toModelRefund(refund *goshopify.Refund) *model.Refund {
+	return toModelRefunds([]goshopify.Refund{*refund})[0]
+}
+
+// toModelRefundCalculation converts a refund calculation, totalling its suggested transactions
+func toModelRefundCalculation(calculation *domain.RefundCalculation) *model.RefundCalculation {
+	total := decimal.Zero
+	transactions := make([]*model.SuggestedRefundTransaction, len(calculation.Transactions))
+	for i, transaction := range calculation.Transactions {
+		if transaction.Amount != nil {
+			total = total.Add(*transaction.Amount)
+		}
+		transactions[i] = &model.SuggestedRefundTransaction{
+			ParentID:          shopifyID(transaction.ParentID),
+			Amount:            decimalString(transaction.Amount),
+			MaximumRefundable: decimalString(transaction.MaximumRefundable),
+			Kind:              optionalString(transaction.Kind),
+			Gateway:           optionalString(transaction.Gateway),
+		}
+	}
+
+	lineItems := make([]*model.RefundLineItemCalculation, len(calculation.LineItems))
+	for i, item := range calculation.LineItems {
+		lineItems[i] = &model.RefundLineItemCalculation{
+			LineItemID:      shopifyID(item.LineItemID),
+			Quantity:        item.Quantity,
+			RestockType:     toModelEnum[model.RestockType](string(item.RestockType)),
+			LocationID:      optionalID(item.LocationID),
+			Price:           decimalString(item.Price),
+			DiscountedPrice: decimalString(item.DiscountedPrice),
+			Subtotal:        decimalString(item.Subtotal),
+			TotalTax:        decimalString(item.TotalTax),
+		}
+	}
+
+	return &model.RefundCalculation{
+		Currency: optionalString(calculation.Currency),
+		Total:    total.StringFixed(2),
+		Shipping: &model.RefundShippingCalculation{
+			Amount:            decimalString(calculation.Shipping.Amount),
+			Tax:               decimalString(calculation.Shipping.Tax),
+			MaximumRefundable: decimalString(calculation.Shipping.MaximumRefundable),
+		},
+		LineItems:    lineItems,
+		Transactions: transactions,
+	}
+}
+
+// toModelOrderReturn converts an order return
+func toModelOrderReturn(orderReturn *domain.OrderReturn) *model.OrderReturn {
+	lineItems := make([]*model.ReturnLineItem, len(orderReturn.LineItems))
+	for i, item := range orderReturn.LineItems {
+		lineItems[i] = &model.ReturnLineItem{
+			ID:                    shopifyID(item.ID),
+			FulfillmentLineItemID: optionalID(item.FulfillmentLineItemID),
+			LineItemID:            optionalID(item.LineItemID),
+			Quantity:              item.Quantity,
+			Reason:                toModelEnum[model.ReturnReason](string(item.Reason)),
+			ReasonNote:            optionalString(item.ReasonNote),
+		}
+	}
+	return &model.OrderReturn{
+		ID:            shopifyID(orderReturn.ID),
+		OrderID:       shopifyID(orderReturn.OrderID),
+		Name:          orderReturn.Name,
+		Status:        toModelEnum[model.ReturnStatus](string(orderReturn.Status)),
+		TotalQuantity: orderReturn.TotalQuantity,
+		LineItems:     lineItems,
+	}
+}
+
+// toModelOrderReturns converts order returns
+func toModelOrderReturns(returns []domain.OrderReturn) []*model.OrderReturn {
+	result := make([]*model.OrderReturn, len(returns))
+	for i := range returns {
+		result[i] = toModelOrderReturn(&returns[i])
+	}
+	return result
+}
+
+// toReturnRequest validates a return input
+func toReturnRequest(input model.CreateReturnInput) (domain.ReturnRequest, error) {
+	v := &inputValidator{}
+	request := domain.ReturnRequest{
+		OrderID:        requiredInputID(v, "orderId", input.OrderID),
+		NotifyCustomer: boolValue(input.NotifyCustomer),
+	}
+	if len(input.LineItems) == 0 {
+		v.reject("lineItems", "must have at least one line item")
+	}
+	for i, item := range input.LineItems {
+		if item.Quantity <= 0 {
+			v.reject(fieldPath("lineItems", i, "quantity"), "must be positive")
+		}
+		request.LineItems = append(request.LineItems, domain.ReturnLineItemRequest{
+			LineItemID: requiredInputID(v, fieldPath("lineItems", i, "lineItemId"), item.LineItemID),
+			Quantity:   item.Quantity,
+			Reason:     domain.ReturnReason(shopifyEnum(string(item.Reason))),
+			ReasonNote: strings.TrimSpace(stringValue(item.ReasonNote)),
+		})
+	}
+	if err := v.result("invalid return input"); err != nil {
+		return request, err
+	}
+	return request, nil
+}
+
+// transactionCapture is a validated capture of an authorized payment
+type transactionCapture struct {
+	orderID         int64
+	authorizationID int64
+	amount          *decimal.Decimal
+	currency        string
+}
+
+// toTransactionCapture validates a capture input
+func toTransactionCapture(input model.CaptureTransactionInput) (transactionCapture, error) {
+	v := &inputValidator{}
+	capture := transactionCapture{
+		orderID:         int64(requiredInputID(v, "orderId", input.OrderID)),
+		authorizationID: int64(requiredInputID(v, "authorizationId", input.AuthorizationID)),
+		amount:          v.amount("amount", input.Amount),
+		currency:        strings.ToUpper(strings.TrimSpace(stringValue(input.Currency))),
+	}
+	if capture.amount != nil && !capture.amount.IsPositive() {
+		v.reject("amount", "must be positive")
+	}
+	if err := v.result("invalid capture input"); err != nil {
+		return capture, err
+	}
+	return capture, nil
+}
+
+// toModelTransaction converts a Shopify order transaction
+func toModelTransaction(transaction *goshopify.Transaction) *model.Transaction {
+	return toModelTransactions([]goshopify.Transaction{*transaction})[0]
+}
+
+// returnDeclineReason converts the reason a return request is declined
+func returnDeclineReason(reason model.ReturnDeclineReason) domain.ReturnDeclineReason {
+	return domain.ReturnDeclineReason(shopifyEnum(string(reason)))
+}
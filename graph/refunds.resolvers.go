@@ -0,0 +1,208 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.84
+
+import (
+	"archie-core-shopify-layer/graph/model"
+	"context"
+	"fmt"
+)
+
+// ShopifyCreateRefund is the resolver for the shopify_createRefund field.
+func (r *mutationResolver) ShopifyCreateRefund(ctx context.Context, domain string, orderID string, refund model.RefundInput) (*model.Refund, error) {
+	var oid int64
+	if _, err := fmt.Sscanf(orderID, "%d", &oid); err != nil {
+		return nil, invalidIDError("orderId", err)
+	}
+
+	v := &inputValidator{}
+	request := toRefundRequest(v, "refund", &refund)
+	if err := v.result("invalid refund input"); err != nil {
+		return nil, err
+	}
+
+	created, err := r.shopifyService.CreateRefund(ctx, domain, oid, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelRefund(created), nil
+}
+
+// ShopifyCaptureTransaction is the resolver for the shopify_captureTransaction field.
+func (r *mutationResolver) ShopifyCaptureTransaction(ctx context.Context, input model.CaptureTransactionInput) (*model.Transaction, error) {
+	capture, err := toTransactionCapture(input)
+	if err != nil {
+		return nil, err
+	}
+
+	captured, err := r.shopifyService.CaptureTransaction(ctx, input.Domain, capture.orderID, capture.authorizationID, capture.amount, capture.currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelTransaction(captured), nil
+}
+
+// ShopifyCreateReturn is the resolver for the shopify_createReturn field.
+func (r *mutationResolver) ShopifyCreateReturn(ctx context.Context, input model.CreateReturnInput) (*model.OrderReturn, error) {
+	request, err := toReturnRequest(input)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := r.shopifyService.CreateReturn(ctx, input.Domain, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelOrderReturn(created), nil
+}
+
+// ShopifyApproveReturnRequest is the resolver for the shopify_approveReturnRequest field.
+func (r *mutationResolver) ShopifyApproveReturnRequest(ctx context.Context, domain string, returnID string) (*model.OrderReturn, error) {
+	var rid int64
+	if _, err := fmt.Sscanf(returnID, "%d", &rid); err != nil {
+		return nil, invalidIDError("returnId", err)
+	}
+
+	orderReturn, err := r.shopifyService.ApproveReturnRequest(ctx, domain, rid)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelOrderReturn(orderReturn), nil
+}
+
+// ShopifyDeclineReturnRequest is the resolver for the shopify_declineReturnRequest field.
+func (r *mutationResolver) ShopifyDeclineReturnRequest(ctx context.Context, domain string, returnID string, reason model.ReturnDeclineReason) (*model.OrderReturn, error) {
+	var rid int64
+	if _, err := fmt.Sscanf(returnID, "%d", &rid); err != nil {
+		return nil, invalidIDError("returnId", err)
+	}
+
+	orderReturn, err := r.shopifyService.DeclineReturnRequest(ctx, domain, rid, returnDeclineReason(reason))
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelOrderReturn(orderReturn), nil
+}
+
+// ShopifyCloseReturn is the resolver for the shopify_closeReturn field.
+func (r *mutationResolver) ShopifyCloseReturn(ctx context.Context, domain string, returnID string) (*model.OrderReturn, error) {
+	var rid int64
+	if _, err := fmt.Sscanf(returnID, "%d", &rid); err != nil {
+		return nil, invalidIDError("returnId", err)
+	}
+
+	orderReturn, err := r.shopifyService.CloseReturn(ctx, domain, rid)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelOrderReturn(orderReturn), nil
+}
+
+// ShopifyCancelReturn is the resolver for the shopify_cancelReturn field.
+func (r *mutationResolver) ShopifyCancelReturn(ctx context.Context, domain string, returnID string) (*model.OrderReturn, error) {
+	var rid int64
+	if _, err := fmt.Sscanf(returnID, "%d", &rid); err != nil {
+		return nil, invalidIDError("returnId", err)
+	}
+
+	orderReturn, err := r.shopifyService.CancelReturn(ctx, domain, rid)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelOrderReturn(orderReturn), nil
+}
+
+// ShopifyOrderTransactions is the resolver for the shopify_orderTransactions field.
+func (r *queryResolver) ShopifyOrderTransactions(ctx context.Context, domain string, orderID string) ([]*model.Transaction, error) {
+	var oid int64
+	if _, err := fmt.Sscanf(orderID, "%d", &oid); err != nil {
+		return nil, invalidIDError("orderId", err)
+	}
+
+	transactions, err := r.shopifyService.GetOrderTransactions(ctx, domain, oid)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelTransactions(transactions), nil
+}
+
+// ShopifyOrderRefunds is the resolver for the shopify_orderRefunds field.
+func (r *queryResolver) ShopifyOrderRefunds(ctx context.Context, domain string, orderID string) ([]*model.Refund, error) {
+	var oid int64
+	if _, err := fmt.Sscanf(orderID, "%d", &oid); err != nil {
+		return nil, invalidIDError("orderId", err)
+	}
+
+	refunds, err := r.shopifyService.ListOrderRefunds(ctx, domain, oid)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelRefunds(refunds), nil
+}
+
+// ShopifyRefund is the resolver for the shopify_refund field.
+func (r *queryResolver) ShopifyRefund(ctx context.Context, domain string, orderID string, refundID string) (*model.Refund, error) {
+	var oid int64
+	if _, err := fmt.Sscanf(orderID, "%d", &oid); err != nil {
+		return nil, invalidIDError("orderId", err)
+	}
+	var rid int64
+	if _, err := fmt.Sscanf(refundID, "%d", &rid); err != nil {
+		return nil, invalidIDError("refundId", err)
+	}
+
+	refund, err := r.shopifyService.GetRefund(ctx, domain, oid, rid)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelRefund(refund), nil
+}
+
+// ShopifyCalculateRefund is the resolver for the shopify_calculateRefund field.
+func (r *queryResolver) ShopifyCalculateRefund(ctx context.Context, domain string, orderID string, refund model.RefundInput) (*model.RefundCalculation, error) {
+	var oid int64
+	if _, err := fmt.Sscanf(orderID, "%d", &oid); err != nil {
+		return nil, invalidIDError("orderId", err)
+	}
+
+	v := &inputValidator{}
+	request := toRefundRequest(v, "refund", &refund)
+	if err := v.result("invalid refund input"); err != nil {
+		return nil, err
+	}
+
+	calculation, err := r.shopifyService.CalculateRefund(ctx, domain, oid, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelRefundCalculation(calculation), nil
+}
+
+// ShopifyOrderReturns is the resolver for the shopify_orderReturns field.
+func (r *queryResolver) ShopifyOrderReturns(ctx context.Context, domain string, orderID string) ([]*model.OrderReturn, error) {
+	var oid int64
+	if _, err := fmt.Sscanf(orderID, "%d", &oid); err != nil {
+		return nil, invalidIDError("orderId", err)
+	}
+
+	returns, err := r.shopifyService.ListOrderReturns(ctx, domain, oid)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelOrderReturns(returns), nil
+}
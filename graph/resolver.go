@@ -3,7 +3,7 @@ package graph
 import (
 	"archie-core-shopify-layer/internal/application"
 	"archie-core-shopify-layer/internal/infrastructure/pubsub"
-	"archie-core-shopify-layer/internal/infrastructure/repository"
+	"archie-core-shopify-layer/internal/ports"
 )
 
 // This file will not be regenerated automatically.
@@ -11,11 +11,16 @@ import (
 // It serves as dependency injection for your app, add any dependencies you require here.
 
 type Resolver struct {
-	shopifyService     *application.ShopifyService
-	credentialsService *application.CredentialsService
-	webhookPubSub      *pubsub.WebhookPubSub
-	sessionRepo        *repository.SessionRepository
-	integrationService *application.IntegrationService
+	shopifyService       *application.ShopifyService
+	credentialsService   *application.CredentialsService
+	webhookPubSub        *pubsub.WebhookPubSub
+	sessionRepo          ports.SessionRepository
+	integrationService   *application.IntegrationService
+	installationService  *application.InstallationService
+	rateLimitService     *application.RateLimitService
+	environmentService   *application.EnvironmentService
+	bulkOperationService *application.BulkOperationService
+	storefrontService    *application.StorefrontService
 }
 
 // NewResolver creates a new GraphQL resolver
@@ -23,14 +28,24 @@ func NewResolver(
 	shopifyService *application.ShopifyService,
 	credentialsService *application.CredentialsService,
 	webhookPubSub *pubsub.WebhookPubSub,
-	sessionRepo *repository.SessionRepository,
+	sessionRepo ports.SessionRepository,
 	integrationService *application.IntegrationService,
+	installationService *application.InstallationService,
+	rateLimitService *application.RateLimitService,
+	environmentService *application.EnvironmentService,
+	bulkOperationService *application.BulkOperationService,
+	storefrontService *application.StorefrontService,
 ) *Resolver {
 	return &Resolver{
-		shopifyService:     shopifyService,
-		credentialsService: credentialsService,
-		webhookPubSub:      webhookPubSub,
-		sessionRepo:        sessionRepo,
-		integrationService: integrationService,
+		shopifyService:       shopifyService,
+		credentialsService:   credentialsService,
+		webhookPubSub:        webhookPubSub,
+		sessionRepo:          sessionRepo,
+		integrationService:   integrationService,
+		installationService:  installationService,
+		rateLimitService:     rateLimitService,
+		environmentService:   environmentService,
+		bulkOperationService: bulkOperationService,
+		storefrontService:    storefrontService,
 	}
 }
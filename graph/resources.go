@@ -0,0 +1,493 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"archie-core-shopify-layer/graph/model"
+	"archie-core-shopify-layer/graph/scalars"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+	"github.com/shopspring/decimal"
+)
+
+// Converters from go-shopify resources to GraphQL models
+// Shopify omits empty values, so empty strings and zero IDs become null
+
+// toModelProduct converts a Shopify product, keeping the source for nested fields
+func toModelProduct(shop string, product *goshopify.Product) *model.Product {
+	return &model.Product{
+		ID:                shopifyID(product.Id),
+		Title:             product.Title,
+		BodyHTML:          optionalString(product.BodyHTML),
+		Handle:            optionalString(product.Handle),
+		Vendor:            optionalString(product.Vendor),
+		ProductType:       optionalString(product.ProductType),
+		Status:            toModelEnum[model.ProductStatus](string(product.Status)),
+		Tags:              splitTags(product.Tags),
+		PublishedAt:       optionalTime(product.PublishedAt),
+		PublishedScope:    optionalString(product.PublishedScope),
+		TemplateSuffix:    optionalString(product.TemplateSuffix),
+		AdminGraphqlAPIID: optionalString(product.AdminGraphqlApiId),
+		CreatedAt:         shopifyTime(product.CreatedAt),
+		UpdatedAt:         shopifyTime(product.UpdatedAt),
+		Shop:              shop,
+		Source:            product,
+	}
+}
+
+// toModelProducts converts a list of Shopify products
+func toModelProducts(shop string, products []goshopify.Product) []*model.Product {
+	result := make([]*model.Product, len(products))
+	for i := range products {
+		result[i] = toModelProduct(shop, &products[i])
+	}
+	return result
+}
+
+// toModelVariant converts a Shopify product variant, keeping the source for inventory levels
+func toModelVariant(shop string, variant *goshopify.Variant) *model.ProductVariant {
+	result := &model.ProductVariant{
+		ID:                  shopifyID(variant.Id),
+		ProductID:           shopifyID(variant.ProductId),
+		Title:               variant.Title,
+		Sku:                 optionalString(variant.Sku),
+		Barcode:             optionalString(variant.Barcode),
+		Position:            variant.Position,
+		Price:               decimalString(variant.Price),
+		CompareAtPrice:      decimalString(variant.CompareAtPrice),
+		Option1:             optionalString(variant.Option1),
+		Option2:             optionalString(variant.Option2),
+		Option3:             optionalString(variant.Option3),
+		InventoryPolicy:     toModelEnum[model.InventoryPolicy](string(variant.InventoryPolicy)),
+		InventoryManagement: optionalString(variant.InventoryManagement),
+		InventoryItemID:     optionalID(variant.InventoryItemId),
+		InventoryQuantity:   variant.InventoryQuantity,
+		FulfillmentService:  optionalString(variant.FulfillmentService),
+		Taxable:             variant.Taxable,
+		TaxCode:             optionalString(variant.TaxCode),
+		RequiresShipping:    variant.RequireShipping,
+		Grams:               variant.Grams,
+		WeightUnit:          toModelEnum[model.WeightUnit](variant.WeightUnit),
+		ImageID:             optionalID(variant.ImageId),
+		CreatedAt:           optionalTime(variant.CreatedAt),
+		UpdatedAt:           optionalTime(variant.UpdatedAt),
+		Shop:                shop,
+		Source:              variant,
+	}
+	if variant.Weight != nil {
+		weight, _ := variant.Weight.Float64()
+		result.Weight = &weight
+	}
+	return result
+}
+
+// toModelProductImage converts a Shopify product image
+func toModelProductImage(image *goshopify.Image) *model.ProductImage {
+	variantIDs := make([]string, len(image.VariantIds))
+	for i, id := range image.VariantIds {
+		variantIDs[i] = shopifyID(id)
+	}
+	return &model.ProductImage{
+		ID:         shopifyID(image.Id),
+		ProductID:  shopifyID(image.ProductId),
+		Position:   image.Position,
+		Src:        image.Src,
+		Alt:        optionalString(image.Alt),
+		Width:      image.Width,
+		Height:     image.Height,
+		VariantIds: variantIDs,
+		CreatedAt:  optionalTime(image.CreatedAt),
+		UpdatedAt:  optionalTime(image.UpdatedAt),
+	}
+}
+
+// toModelOrder converts a Shopify order, keeping the source for nested fields
+func toModelOrder(shop string, order *goshopify.Order) *model.Order {
+	totalPrice := ""
+	if order.TotalPrice != nil {
+		totalPrice = order.TotalPrice.String()
+	}
+	return &model.Order{
+		ID:                  shopifyID(order.Id),
+		Name:                optionalString(order.Name),
+		OrderNumber:         order.OrderNumber,
+		Email:               optionalString(order.Email),
+		Phone:               optionalString(order.Phone),
+		Note:                optionalString(order.Note),
+		Tags:                splitTags(order.Tags),
+		Currency:            optionalString(order.Currency),
+		PresentmentCurrency: optionalString(order.PresentmentCurrency),
+		TotalPrice:          totalPrice,
+		SubtotalPrice:       decimalString(order.SubtotalPrice),
+		TotalTax:            decimalString(order.TotalTax),
+		TotalDiscounts:      decimalString(order.TotalDiscounts),
+		TotalLineItemsPrice: decimalString(order.TotalLineItemsPrice),
+		TotalWeight:         order.TotalWeight,
+		TaxesIncluded:       order.TaxesIncluded,
+		FinancialStatus:     optionalString(string(order.FinancialStatus)),
+		FulfillmentStatus:   optionalString(string(order.FulfillmentStatus)),
+		CancelReason:        optionalString(string(order.CancelReason)),
+		Test:                order.Test,
+		Confirmed:           order.Confirmed,
+		SourceName:          optionalString(order.SourceName),
+		OrderStatusURL:      optionalString(order.OrderStatusUrl),
+		ProcessedAt:         optionalTime(order.ProcessedAt),
+		CancelledAt:         optionalTime(order.CancelledAt),
+		ClosedAt:            optionalTime(order.ClosedAt),
+		CreatedAt:           shopifyTime(order.CreatedAt),
+		UpdatedAt:           shopifyTime(order.UpdatedAt),
+		Shop:                shop,
+		Source:              order,
+	}
+}
+
+// toModelOrders converts a list of Shopify orders
+func toModelOrders(shop string, orders []goshopify.Order) []*model.Order {
+	result := make([]*model.Order, len(orders))
+	for i := range orders {
+		result[i] = toModelOrder(shop, &orders[i])
+	}
+	return result
+}
+
+// toModelMailingAddress converts a Shopify order address, nil when absent
+func toModelMailingAddress(address *goshopify.Address) *model.MailingAddress {
+	if address == nil {
+		return nil
+	}
+	result := &model.MailingAddress{
+		FirstName:    optionalString(address.FirstName),
+		LastName:     optionalString(address.LastName),
+		Name:         optionalString(address.Name),
+		Company:      optionalString(address.Company),
+		Address1:     optionalString(address.Address1),
+		Address2:     optionalString(address.Address2),
+		City:         optionalString(address.City),
+		Province:     optionalString(address.Province),
+		ProvinceCode: optionalString(address.ProvinceCode),
+		Country:      optionalString(address.Country),
+		CountryCode:  optionalString(address.CountryCode),
+		Zip:          optionalString(address.Zip),
+		Phone:        optionalString(address.Phone),
+	}
+	if address.Latitude != 0 || address.Longitude != 0 {
+		result.Latitude = &address.Latitude
+		result.Longitude = &address.Longitude
+	}
+	return result
+}
+
+// toModelLineItems converts Shopify order line items
+func toModelLineItems(items []goshopify.LineItem) []*model.LineItem {
+	result := make([]*model.LineItem, len(items))
+	for i, item := range items {
+		result[i] = &model.LineItem{
+			ID:                  shopifyID(item.Id),
+			ProductID:           optionalID(item.ProductId),
+			VariantID:           optionalID(item.VariantId),
+			Title:               item.Title,
+			VariantTitle:        optionalString(item.VariantTitle),
+			Name:                optionalString(item.Name),
+			Sku:                 optionalString(item.SKU),
+			Vendor:              optionalString(item.Vendor),
+			Quantity:            item.Quantity,
+			CurrentQuantity:     item.CurrentQuantity,
+			FulfillableQuantity: item.FulfillableQuantity,
+			FulfillmentStatus:   optionalString(string(item.FulfillmentStatus)),
+			Price:               decimalString(item.Price),
+			TotalDiscount:       decimalString(item.TotalDiscount),
+			Grams:               item.Grams,
+			Taxable:             item.Taxable,
+			RequiresShipping:    item.RequiresShipping,
+			GiftCard:            item.GiftCard,
+			Properties:          toModelNoteAttributes(item.Properties),
+			TaxLines:            toModelTaxLines(item.TaxLines),
+		}
+	}
+	return result
+}
+
+// toModelShippingLines converts Shopify order shipping lines
+func toModelShippingLines(lines []goshopify.ShippingLines) []*model.ShippingLine {
+	result := make([]*model.ShippingLine, len(lines))
+	for i, line := range lines {
+		result[i] = &model.ShippingLine{
+			ID:                shopifyID(line.Id),
+			Title:             line.Title,
+			Code:              optionalString(line.Code),
+			Source:            optionalString(line.Source),
+			Price:             decimalString(line.Price),
+			DiscountedPrice:   decimalString(line.DiscountedPrice),
+			CarrierIdentifier: optionalString(line.CarrierIdentifier),
+			TaxLines:          toModelTaxLines(line.TaxLines),
+		}
+	}
+	return result
+}
+
+// toModelDiscountCodes converts the discount codes applied to a Shopify order
+func toModelDiscountCodes(codes []goshopify.DiscountCode) []*model.OrderDiscountCode {
+	result := make([]*model.OrderDiscountCode, len(codes))
+	for i, code := range codes {
+		result[i] = &model.OrderDiscountCode{
+			Code:   code.Code,
+			Amount: decimalString(code.Amount),
+			Type:   optionalString(code.Type),
+		}
+	}
+	return result
+}
+
+// toModelTaxLines converts Shopify tax lines
+func toModelTaxLines(lines []goshopify.TaxLine) []*model.TaxLine {
+	result := make([]*model.TaxLine, len(lines))
+	for i, line := range lines {
+		result[i] = &model.TaxLine{
+			Title: line.Title,
+			Price: decimalString(line.Price),
+			Rate:  decimalString(line.Rate),
+		}
+	}
+	return result
+}
+
+// toModelNoteAttributes converts Shopify note attributes, formatting non-string values
+func toModelNoteAttributes(attributes []goshopify.NoteAttribute) []*model.NoteAttribute {
+	result := make([]*model.NoteAttribute, len(attributes))
+	for i, attribute := range attributes {
+		result[i] = &model.NoteAttribute{Name: attribute.Name}
+		if attribute.Value != nil {
+			value := fmt.Sprint(attribute.Value)
+			result[i].Value = &value
+		}
+	}
+	return result
+}
+
+// toModelFulfillments converts Shopify order fulfillments
+func toModelFulfillments(fulfillments []goshopify.Fulfillment) []*model.Fulfillment {
+	result := make([]*model.Fulfillment, len(fulfillments))
+	for i := range fulfillments {
+		result[i] = toModelFulfillment(&fulfillments[i])
+	}
+	return result
+}
+
+// toModelFulfillment converts a Shopify fulfillment
+func toModelFulfillment(fulfillment *goshopify.Fulfillment) *model.Fulfillment {
+	return &model.Fulfillment{
+		ID:              shopifyID(fulfillment.Id),
+		OrderID:         shopifyID(fulfillment.OrderId),
+		LocationID:      optionalID(fulfillment.LocationId),
+		Status:          optionalString(fulfillment.Status),
+		Service:         optionalString(fulfillment.Service),
+		ShipmentStatus:  optionalString(fulfillment.ShipmentStatus),
+		TrackingCompany: optionalString(fulfillment.TrackingCompany),
+		TrackingNumbers: nonNilStrings(fulfillment.TrackingNumbers),
+		TrackingUrls:    nonNilStrings(fulfillment.TrackingUrls),
+		LineItems:       toModelLineItems(fulfillment.LineItems),
+		CreatedAt:       optionalTime(fulfillment.CreatedAt),
+		UpdatedAt:       optionalTime(fulfillment.UpdatedAt),
+	}
+}
+
+// toModelRefunds converts Shopify order refunds
+func toModelRefunds(refunds []goshopify.Refund) []*model.Refund {
+	result := make([]*model.Refund, len(refunds))
+	for i, refund := range refunds {
+		lineItems := make([]*model.RefundLineItem, len(refund.RefundLineItems))
+		for j, item := range refund.RefundLineItems {
+			lineItems[j] = &model.RefundLineItem{
+				ID:         shopifyID(item.Id),
+				LineItemID: shopifyID(item.LineItemId),
+				Quantity:   item.Quantity,
+				Subtotal:   decimalString(item.Subtotal),
+				TotalTax:   decimalString(item.TotalTax),
+			}
+		}
+		result[i] = &model.Refund{
+			ID:              shopifyID(refund.Id),
+			OrderID:         shopifyID(refund.OrderId),
+			Note:            optionalString(refund.Note),
+			Restock:         refund.Restock,
+			RefundLineItems: lineItems,
+			Transactions:    toModelTransactions(refund.Transactions),
+			CreatedAt:       optionalTime(refund.CreatedAt),
+		}
+	}
+	return result
+}
+
+// toModelTransactions converts Shopify order transactions
+func toModelTransactions(transactions []goshopify.Transaction) []*model.Transaction {
+	result := make([]*model.Transaction, len(transactions))
+	for i, transaction := range transactions {
+		result[i] = &model.Transaction{
+			ID:            shopifyID(transaction.Id),
+			OrderID:       shopifyID(transaction.OrderId),
+			Kind:          toModelEnum[model.TransactionKind](transaction.Kind),
+			Status:        toModelEnum[model.TransactionStatus](transaction.Status),
+			Amount:        decimalString(transaction.Amount),
+			Currency:      optionalString(transaction.Currency),
+			Gateway:       optionalString(transaction.Gateway),
+			Authorization: optionalString(transaction.Authorization),
+			Message:       optionalString(transaction.Message),
+			ErrorCode:     optionalString(transaction.ErrorCode),
+			Test:          transaction.Test,
+			CreatedAt:     optionalTime(transaction.CreatedAt),
+		}
+		if transaction.ParentId != nil {
+			parentID := fmt.Sprintf("%d", *transaction.ParentId)
+			result[i].ParentID = &parentID
+		}
+	}
+	return result
+}
+
+// toModelCustomer converts a Shopify customer, keeping the source for nested fields
+func toModelCustomer(shop string, customer *goshopify.Customer) *model.Customer {
+	ordersCount := customer.OrdersCount
+	return &model.Customer{
+		ID:            shopifyID(customer.Id),
+		Email:         optionalString(customer.Email),
+		Phone:         optionalString(customer.Phone),
+		FirstName:     optionalString(customer.FirstName),
+		LastName:      optionalString(customer.LastName),
+		State:         optionalString(customer.State),
+		Note:          optionalString(customer.Note),
+		VerifiedEmail: customer.VerifiedEmail,
+		TaxExempt:     customer.TaxExempt,
+		OrdersCount:   &ordersCount,
+		TotalSpent:    decimalString(customer.TotalSpent),
+		Currency:      optionalString(customer.Currency),
+		Tags:          splitTags(customer.Tags),
+		LastOrderID:   optionalID(customer.LastOrderId),
+		LastOrderName: optionalString(customer.LastOrderName),
+		CreatedAt:     shopifyTime(customer.CreatedAt),
+		UpdatedAt:     shopifyTime(customer.UpdatedAt),
+		Shop:          shop,
+		Source:        customer,
+	}
+}
+
+// toModelCustomers converts a list of Shopify customers
+func toModelCustomers(shop string, customers []goshopify.Customer) []*model.Customer {
+	result := make([]*model.Customer, len(customers))
+	for i := range customers {
+		result[i] = toModelCustomer(shop, &customers[i])
+	}
+	return result
+}
+
+// toModelCustomerAddress converts a Shopify customer address, nil when absent
+func toModelCustomerAddress(address *goshopify.CustomerAddress) *model.CustomerAddress {
+	if address == nil {
+		return nil
+	}
+	return &model.CustomerAddress{
+		ID:           shopifyID(address.Id),
+		CustomerID:   optionalID(address.CustomerId),
+		FirstName:    optionalString(address.FirstName),
+		LastName:     optionalString(address.LastName),
+		Name:         optionalString(address.Name),
+		Company:      optionalString(address.Company),
+		Address1:     optionalString(address.Address1),
+		Address2:     optionalString(address.Address2),
+		City:         optionalString(address.City),
+		Province:     optionalString(address.Province),
+		ProvinceCode: optionalString(address.ProvinceCode),
+		Country:      optionalString(address.Country),
+		CountryCode:  optionalString(address.CountryCode),
+		CountryName:  optionalString(address.CountryName),
+		Zip:          optionalString(address.Zip),
+		Phone:        optionalString(address.Phone),
+		Default:      address.Default,
+	}
+}
+
+// toModelInventoryLevels converts Shopify inventory levels
+func toModelInventoryLevels(levels []goshopify.InventoryLevel) []*model.InventoryLevel {
+	result := make([]*model.InventoryLevel, len(levels))
+	for i, level := range levels {
+		available := level.Available
+		result[i] = &model.InventoryLevel{
+			InventoryItemID: shopifyID(level.InventoryItemId),
+			LocationID:      shopifyID(level.LocationId),
+			Available:       &available,
+			UpdatedAt:       shopifyTime(level.UpdatedAt),
+		}
+	}
+	return result
+}
+
+// toModelEnum converts a lowercase Shopify value to a GraphQL enum, nil when empty or unknown
+func toModelEnum[T interface {
+	~string
+	IsValid() bool
+}](value string) *T {
+	result := T(strings.ToUpper(value))
+	if !result.IsValid() {
+		return nil
+	}
+	return &result
+}
+
+// shopifyID formats a Shopify numeric ID
+func shopifyID(id uint64) string {
+	return fmt.Sprintf("%d", id)
+}
+
+// optionalID formats a Shopify numeric ID, nil when unset
+func optionalID(id uint64) *string {
+	if id == 0 {
+		return nil
+	}
+	value := shopifyID(id)
+	return &value
+}
+
+// optionalString returns nil for an empty string
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// decimalString formats a Shopify amount, nil when absent
+func decimalString(value *decimal.Decimal) *string {
+	if value == nil {
+		return nil
+	}
+	formatted := value.String()
+	return &formatted
+}
+
+// shopifyTime converts a Shopify timestamp, the zero time when absent
+func shopifyTime(value *time.Time) scalars.Time {
+	if value == nil {
+		return scalars.Time{}
+	}
+	return scalars.Time(*value)
+}
+
+// splitTags splits Shopify's comma separated tags
+func splitTags(tags string) []string {
+	result := []string{}
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+// nonNilStrings returns an empty list for nil
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
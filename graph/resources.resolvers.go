@@ -0,0 +1,210 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.84
+
+import (
+	"archie-core-shopify-layer/graph/generated"
+	"archie-core-shopify-layer/graph/model"
+	"context"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+)
+
+// EmailMarketingConsent is the resolver for the emailMarketingConsent field.
+func (r *customerResolver) EmailMarketingConsent(ctx context.Context, obj *model.Customer) (*model.MarketingConsent, error) {
+	consent := obj.Source.EmailMarketingConsent
+	if consent == nil {
+		return nil, nil
+	}
+	return &model.MarketingConsent{
+		State:            toModelEnum[model.MarketingState](consent.State),
+		OptInLevel:       toModelEnum[model.MarketingOptInLevel](consent.OptInLevel),
+		ConsentUpdatedAt: optionalTime(consent.ConsentUpdatedAt),
+	}, nil
+}
+
+// SmsMarketingConsent is the resolver for the smsMarketingConsent field.
+func (r *customerResolver) SmsMarketingConsent(ctx context.Context, obj *model.Customer) (*model.MarketingConsent, error) {
+	consent := obj.Source.SMSMarketingConsent
+	if consent == nil {
+		return nil, nil
+	}
+	return &model.MarketingConsent{
+		State:                toModelEnum[model.MarketingState](consent.State),
+		OptInLevel:           toModelEnum[model.MarketingOptInLevel](consent.OptInLevel),
+		ConsentUpdatedAt:     optionalTime(consent.ConsentUpdatedAt),
+		ConsentCollectedFrom: optionalString(consent.ConsentCollectedFrom),
+	}, nil
+}
+
+// DefaultAddress is the resolver for the defaultAddress field.
+func (r *customerResolver) DefaultAddress(ctx context.Context, obj *model.Customer) (*model.CustomerAddress, error) {
+	return toModelCustomerAddress(obj.Source.DefaultAddress), nil
+}
+
+// Addresses is the resolver for the addresses field.
+func (r *customerResolver) Addresses(ctx context.Context, obj *model.Customer) ([]*model.CustomerAddress, error) {
+	result := make([]*model.CustomerAddress, 0, len(obj.Source.Addresses))
+	for _, address := range obj.Source.Addresses {
+		if address != nil {
+			result = append(result, toModelCustomerAddress(address))
+		}
+	}
+	return result, nil
+}
+
+// Orders is the resolver for the orders field.
+func (r *customerResolver) Orders(ctx context.Context, obj *model.Customer) ([]*model.Order, error) {
+	if err := authorizeOnDemandFetch(ctx, "shopify_orders"); err != nil {
+		return nil, err
+	}
+	orders, err := r.shopifyService.GetCustomerOrders(ctx, obj.Shop, int64(obj.Source.Id))
+	if err != nil {
+		return nil, err
+	}
+	return toModelOrders(obj.Shop, orders), nil
+}
+
+// Customer is the resolver for the customer field.
+func (r *orderResolver) Customer(ctx context.Context, obj *model.Order) (*model.Customer, error) {
+	if obj.Source.Customer == nil || obj.Source.Customer.Id == 0 {
+		return nil, nil
+	}
+	return toModelCustomer(obj.Shop, obj.Source.Customer), nil
+}
+
+// BillingAddress is the resolver for the billingAddress field.
+func (r *orderResolver) BillingAddress(ctx context.Context, obj *model.Order) (*model.MailingAddress, error) {
+	return toModelMailingAddress(obj.Source.BillingAddress), nil
+}
+
+// ShippingAddress is the resolver for the shippingAddress field.
+func (r *orderResolver) ShippingAddress(ctx context.Context, obj *model.Order) (*model.MailingAddress, error) {
+	return toModelMailingAddress(obj.Source.ShippingAddress), nil
+}
+
+// LineItems is the resolver for the lineItems field.
+func (r *orderResolver) LineItems(ctx context.Context, obj *model.Order) ([]*model.LineItem, error) {
+	return toModelLineItems(obj.Source.LineItems), nil
+}
+
+// ShippingLines is the resolver for the shippingLines field.
+func (r *orderResolver) ShippingLines(ctx context.Context, obj *model.Order) ([]*model.ShippingLine, error) {
+	return toModelShippingLines(obj.Source.ShippingLines), nil
+}
+
+// DiscountCodes is the resolver for the discountCodes field.
+func (r *orderResolver) DiscountCodes(ctx context.Context, obj *model.Order) ([]*model.OrderDiscountCode, error) {
+	return toModelDiscountCodes(obj.Source.DiscountCodes), nil
+}
+
+// TaxLines is the resolver for the taxLines field.
+func (r *orderResolver) TaxLines(ctx context.Context, obj *model.Order) ([]*model.TaxLine, error) {
+	return toModelTaxLines(obj.Source.TaxLines), nil
+}
+
+// NoteAttributes is the resolver for the noteAttributes field.
+func (r *orderResolver) NoteAttributes(ctx context.Context, obj *model.Order) ([]*model.NoteAttribute, error) {
+	return toModelNoteAttributes(obj.Source.NoteAttributes), nil
+}
+
+// Fulfillments is the resolver for the fulfillments field.
+func (r *orderResolver) Fulfillments(ctx context.Context, obj *model.Order) ([]*model.Fulfillment, error) {
+	return toModelFulfillments(obj.Source.Fulfillments), nil
+}
+
+// Refunds is the resolver for the refunds field.
+func (r *orderResolver) Refunds(ctx context.Context, obj *model.Order) ([]*model.Refund, error) {
+	return toModelRefunds(obj.Source.Refunds), nil
+}
+
+// Transactions is the resolver for the transactions field.
+func (r *orderResolver) Transactions(ctx context.Context, obj *model.Order) ([]*model.Transaction, error) {
+	if err := authorizeOnDemandFetch(ctx, "shopify_orderTransactions"); err != nil {
+		return nil, err
+	}
+	transactions, err := r.shopifyService.GetOrderTransactions(ctx, obj.Shop, int64(obj.Source.Id))
+	if err != nil {
+		return nil, err
+	}
+	return toModelTransactions(transactions), nil
+}
+
+// Options is the resolver for the options field.
+func (r *productResolver) Options(ctx context.Context, obj *model.Product) ([]*model.ProductOption, error) {
+	result := make([]*model.ProductOption, len(obj.Source.Options))
+	for i, option := range obj.Source.Options {
+		result[i] = &model.ProductOption{
+			ID:        shopifyID(option.Id),
+			ProductID: shopifyID(option.ProductId),
+			Name:      option.Name,
+			Position:  option.Position,
+			Values:    nonNilStrings(option.Values),
+		}
+	}
+	return result, nil
+}
+
+// Variants is the resolver for the variants field.
+func (r *productResolver) Variants(ctx context.Context, obj *model.Product) ([]*model.ProductVariant, error) {
+	result := make([]*model.ProductVariant, len(obj.Source.Variants))
+	for i := range obj.Source.Variants {
+		result[i] = toModelVariant(obj.Shop, &obj.Source.Variants[i])
+	}
+	return result, nil
+}
+
+// Images is the resolver for the images field.
+func (r *productResolver) Images(ctx context.Context, obj *model.Product) ([]*model.ProductImage, error) {
+	result := make([]*model.ProductImage, len(obj.Source.Images))
+	for i := range obj.Source.Images {
+		result[i] = toModelProductImage(&obj.Source.Images[i])
+	}
+	return result, nil
+}
+
+// Image is the resolver for the image field.
+func (r *productResolver) Image(ctx context.Context, obj *model.Product) (*model.ProductImage, error) {
+	if obj.Source.Image.Id == 0 {
+		return nil, nil
+	}
+	return toModelProductImage(&obj.Source.Image), nil
+}
+
+// InventoryLevels is the resolver for the inventoryLevels field.
+func (r *productVariantResolver) InventoryLevels(ctx context.Context, obj *model.ProductVariant) ([]*model.InventoryLevel, error) {
+	if obj.Source.InventoryItemId == 0 {
+		return []*model.InventoryLevel{}, nil
+	}
+	if err := authorizeOnDemandFetch(ctx, "shopify_inventoryLevels"); err != nil {
+		return nil, err
+	}
+	options := goshopify.InventoryLevelListOptions{InventoryItemIds: []uint64{obj.Source.InventoryItemId}, Limit: 250}
+	levels, _, err := r.shopifyService.GetInventoryLevels(ctx, obj.Shop, options)
+	if err != nil {
+		return nil, err
+	}
+	return toModelInventoryLevels(levels), nil
+}
+
+// Customer returns generated.CustomerResolver implementation.
+func (r *Resolver) Customer() generated.CustomerResolver { return &customerResolver{r} }
+
+// Order returns generated.OrderResolver implementation.
+func (r *Resolver) Order() generated.OrderResolver { return &orderResolver{r} }
+
+// Product returns generated.ProductResolver implementation.
+func (r *Resolver) Product() generated.ProductResolver { return &productResolver{r} }
+
+// ProductVariant returns generated.ProductVariantResolver implementation.
+func (r *Resolver) ProductVariant() generated.ProductVariantResolver {
+	return &productVariantResolver{r}
+}
+
+type customerResolver struct{ *Resolver }
+type orderResolver struct{ *Resolver }
+type productResolver struct{ *Resolver }
+type productVariantResolver struct{ *Resolver }
@@ -11,23 +11,21 @@ import (
 	"archie-core-shopify-layer/graph/scalars"
 	"archie-core-shopify-layer/internal/application"
 	"archie-core-shopify-layer/internal/domain"
-	"archie-core-shopify-layer/internal/infrastructure/pubsub"
 	"context"
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
-
-	goshopify "github.com/bold-commerce/go-shopify/v4"
 )
 
 // ConfigureShopify is the resolver for the configureShopify field.
 func (r *mutationResolver) ConfigureShopify(ctx context.Context, input model.ConfigureShopifyInput) (*model.ConfigureShopifyPayload, error) {
 	tenantID := getTenantID(ctx)
 	if tenantID == "" {
-		return nil, fmt.Errorf("tenant ID not found in context")
+		return nil, domain.NewValidationError("tenant ID not found in context", nil)
 	}
 
 	configInput := &application.ConfigureShopifyInput{
@@ -38,6 +36,10 @@ func (r *mutationResolver) ConfigureShopify(ctx context.Context, input model.Con
 	if input.WebhookSecret != nil {
 		configInput.WebhookSecret = *input.WebhookSecret
 	}
+	configInput.ExpiringOfflineTokens = input.ExpiringOfflineTokens
+	if input.AppHandle != nil {
+		configInput.AppHandle = *input.AppHandle
+	}
 
 	config, err := r.credentialsService.ConfigureShopify(ctx, tenantID, configInput)
 	if err != nil {
@@ -45,13 +47,15 @@ func (r *mutationResolver) ConfigureShopify(ctx context.Context, input model.Con
 	}
 
 	return &model.ConfigureShopifyPayload{
-		ID:          config.ID,
-		ProjectID:   config.ProjectID,
-		Environment: config.Environment,
-		APIKey:      config.APIKey,
-		WebhookURL:  config.WebhookURL,
-		CreatedAt:   scalars.Time(config.CreatedAt),
-		UpdatedAt:   scalars.Time(config.UpdatedAt),
+		ID:                    config.ID,
+		ProjectID:             config.ProjectID,
+		Environment:           config.Environment,
+		AppHandle:             config.AppHandle,
+		APIKey:                config.APIKey,
+		WebhookURL:            config.WebhookURL,
+		ExpiringOfflineTokens: config.ExpiringOfflineTokens,
+		CreatedAt:             scalars.Time(config.CreatedAt),
+		UpdatedAt:             scalars.Time(config.UpdatedAt),
 	}, nil
 }
 
@@ -67,11 +71,20 @@ func (r *mutationResolver) ShopifyInstallApp(ctx context.Context, input model.In
 		environment = domain.DefaultEnvironment
 	}
 
+	// Resolve the app being installed, defaulting to the app selected by the request
+	if input.AppHandle != nil && *input.AppHandle != "" {
+		ctx = domain.WithAppHandle(ctx, *input.AppHandle)
+	}
+	appHandle := domain.NormalizeAppHandle(domain.GetAppHandleFromContext(ctx))
+	if config, err := r.shopifyService.GetConfig(ctx, ""); err == nil {
+		appHandle = config.AppHandle
+	}
+
 	// Generate random state for CSRF protection and encode projectId/environment
 	// Format: base64(json({random: "...", projectId: "...", environment: "..."}))
 	stateBytes := make([]byte, 16)
 	if _, err := rand.Read(stateBytes); err != nil {
-		return nil, fmt.Errorf("failed to generate state: %w", err)
+		return nil, domain.NewInternalError("failed to generate state", err)
 	}
 	randomPart := hex.EncodeToString(stateBytes)
 
@@ -83,7 +96,7 @@ func (r *mutationResolver) ShopifyInstallApp(ctx context.Context, input model.In
 	}
 	stateJSON, err := json.Marshal(stateData)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal state: %w", err)
+		return nil, domain.NewInternalError("failed to marshal state", err)
 	}
 	state := base64.URLEncoding.EncodeToString(stateJSON)
 
@@ -117,13 +130,14 @@ func (r *mutationResolver) ShopifyInstallApp(ctx context.Context, input model.In
 		Scopes:      input.Scopes,
 		ProjectID:   projectID,
 		Environment: environment,
+		AppHandle:   appHandle,
 		ReturnURL:   *returnURL,
 		RedirectURI: redirectURI, // Store redirect URI for token exchange
 		ExpiresAt:   time.Now().Add(10 * time.Minute),
 	}
 
 	if err := r.sessionRepo.CreateSession(ctx, session); err != nil {
-		return nil, fmt.Errorf("failed to create session: %w", err)
+		return nil, domain.NewInternalError("failed to create session", err)
 	}
 
 	// Generate auth URL with state
@@ -139,6 +153,11 @@ func (r *mutationResolver) ShopifyInstallApp(ctx context.Context, input model.In
 		return nil, err
 	}
 
+	// Track the pending install (failures must not block the OAuth flow)
+	if _, err := r.installationService.StartInstall(ctx, domain.NewShopKey(projectID, environment, appHandle, input.Shop), input.Scopes); err != nil {
+		fmt.Printf("⚠️ [ShopifyInstallApp] Failed to record pending installation: %v\n", err)
+	}
+
 	return &model.InstallAppPayload{
 		AuthURL: authURL,
 	}, nil
@@ -147,19 +166,31 @@ func (r *mutationResolver) ShopifyInstallApp(ctx context.Context, input model.In
 // ShopifySaveShop is the resolver for the shopify_saveShop field.
 func (r *mutationResolver) ShopifySaveShop(ctx context.Context, input model.SaveShopInput) (*model.SaveShopPayload, error) {
 	// Use ShopifyService to save shop (it handles encryption internally)
-	domainShop, err := r.shopifyService.SaveShop(ctx, input.Domain, input.AccessToken, input.Scopes)
+	token := &domain.Token{AccessToken: input.AccessToken}
+	if input.ExpiresIn != nil {
+		token.ExpiresIn = *input.ExpiresIn
+	}
+	if input.RefreshToken != nil {
+		token.RefreshToken = *input.RefreshToken
+	}
+	if input.RefreshTokenExpiresIn != nil {
+		token.RefreshTokenExpiresIn = *input.RefreshTokenExpiresIn
+	}
+
+	domainShop, err := r.shopifyService.SaveShop(ctx, input.Domain, token, input.Scopes)
 	if err != nil {
 		return nil, err
 	}
 
+	// Saving the shop completes the OAuth install started by shopify_installApp
+	if getTenantID(ctx) != "" {
+		if _, err := r.installationService.CompleteInstall(ctx, domainShop.Key(), input.Scopes, "shop_saved"); err != nil {
+			fmt.Printf("⚠️ [ShopifySaveShop] Failed to record completed installation: %v\n", err)
+		}
+	}
+
 	return &model.SaveShopPayload{
-		Shop: &model.Shop{
-			ID:        domainShop.ID,
-			Domain:    domainShop.Domain,
-			Scopes:    domainShop.Scopes,
-			CreatedAt: scalars.Time(domainShop.CreatedAt),
-			UpdatedAt: scalars.Time(domainShop.UpdatedAt),
-		},
+		Shop: toModelShop(domainShop),
 	}, nil
 }
 
@@ -199,6 +230,10 @@ func (r *mutationResolver) ShopifyConfigureCredentials(ctx context.Context, inpu
 
 // ShopifyDeleteCredentials is the resolver for the shopify_deleteCredentials field.
 func (r *mutationResolver) ShopifyDeleteCredentials(ctx context.Context, projectID string, environment string) (bool, error) {
+	if err := authorizeTenant(ctx, projectID, environment); err != nil {
+		return false, err
+	}
+
 	// Add projectID and environment to context if not already present
 	if projectID != "" {
 		ctx = domain.WithProjectID(ctx, projectID)
@@ -217,31 +252,38 @@ func (r *mutationResolver) ShopifyDeleteCredentials(ctx context.Context, project
 
 // CreateIntegration is the resolver for the createIntegration field.
 func (r *mutationResolver) CreateIntegration(ctx context.Context, input model.CreateIntegrationInput) (*model.CreateIntegrationPayload, error) {
+	if err := authorizeTenant(ctx, input.ProjectID, input.Environment); err != nil {
+		return nil, err
+	}
+
 	integration, err := r.integrationService.CreateIntegration(ctx, application.CreateIntegrationInput{
 		ProjectID:   input.ProjectID,
 		Environment: input.Environment,
 		ShopDomain:  input.ShopDomain,
+		AppHandle:   stringValue(input.AppHandle),
+		Scopes:      input.Scopes,
+		ExpiresAt:   domainTime(input.ExpiresAt),
 	})
 	if err != nil {
 		return nil, err
 	}
 
 	return &model.CreateIntegrationPayload{
-		Integration: &model.Integration{
-			ID:          integration.ID,
-			Key:         integration.Key,
-			ProjectID:   integration.ProjectID,
-			Environment: integration.Environment,
-			ShopDomain:  integration.ShopDomain,
-			CreatedAt:   scalars.Time(integration.CreatedAt),
-			UpdatedAt:   scalars.Time(integration.UpdatedAt),
-		},
+		Integration: toModelIntegration(integration),
 	}, nil
 }
 
 // DeleteIntegration is the resolver for the deleteIntegration field.
 func (r *mutationResolver) DeleteIntegration(ctx context.Context, key string) (bool, error) {
-	err := r.integrationService.DeleteIntegration(ctx, key)
+	integration, err := r.integrationService.GetIntegrationByKey(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	if err := authorizeTenant(ctx, integration.ProjectID, integration.Environment); err != nil {
+		return false, err
+	}
+
+	err = r.integrationService.DeleteIntegration(ctx, key)
 	if err != nil {
 		return false, err
 	}
@@ -250,65 +292,39 @@ func (r *mutationResolver) DeleteIntegration(ctx context.Context, key string) (b
 
 // ShopifyCreateProduct is the resolver for the shopify_createProduct field.
 func (r *mutationResolver) ShopifyCreateProduct(ctx context.Context, input model.ProductInput) (*model.ProductPayload, error) {
-	var product goshopify.Product
-	if err := json.Unmarshal([]byte(input.Product), &product); err != nil {
-		return nil, fmt.Errorf("failed to parse product JSON: %w", err)
+	product, variantSettings, err := toShopifyProduct(input, false)
+	if err != nil {
+		return nil, err
 	}
 
-	created, err := r.shopifyService.CreateProduct(ctx, input.Domain, &product)
+	created, err := r.shopifyService.CreateProduct(ctx, input.Domain, product, variantSettings)
 	if err != nil {
 		return nil, err
 	}
 
-	createdAt, _ := time.Parse(time.RFC3339, created.CreatedAt.String())
-	updatedAt, _ := time.Parse(time.RFC3339, created.UpdatedAt.String())
-
-	return &model.ProductPayload{
-		Product: &model.Product{
-			ID:          fmt.Sprintf("%d", created.Id),
-			Title:       created.Title,
-			Handle:      &created.Handle,
-			Vendor:      &created.Vendor,
-			ProductType: &created.ProductType,
-			CreatedAt:   scalars.Time(createdAt),
-			UpdatedAt:   scalars.Time(updatedAt),
-		},
-	}, nil
+	return &model.ProductPayload{Product: toModelProduct(input.Domain, created)}, nil
 }
 
 // ShopifyUpdateProduct is the resolver for the shopify_updateProduct field.
 func (r *mutationResolver) ShopifyUpdateProduct(ctx context.Context, input model.ProductInput) (*model.ProductPayload, error) {
-	var product goshopify.Product
-	if err := json.Unmarshal([]byte(input.Product), &product); err != nil {
-		return nil, fmt.Errorf("failed to parse product JSON: %w", err)
+	product, variantSettings, err := toShopifyProduct(input, true)
+	if err != nil {
+		return nil, err
 	}
 
-	updated, err := r.shopifyService.UpdateProduct(ctx, input.Domain, &product)
+	updated, err := r.shopifyService.UpdateProduct(ctx, input.Domain, product, variantSettings)
 	if err != nil {
 		return nil, err
 	}
 
-	createdAt, _ := time.Parse(time.RFC3339, updated.CreatedAt.String())
-	updatedAt, _ := time.Parse(time.RFC3339, updated.UpdatedAt.String())
-
-	return &model.ProductPayload{
-		Product: &model.Product{
-			ID:          fmt.Sprintf("%d", updated.Id),
-			Title:       updated.Title,
-			Handle:      &updated.Handle,
-			Vendor:      &updated.Vendor,
-			ProductType: &updated.ProductType,
-			CreatedAt:   scalars.Time(createdAt),
-			UpdatedAt:   scalars.Time(updatedAt),
-		},
-	}, nil
+	return &model.ProductPayload{Product: toModelProduct(input.Domain, updated)}, nil
 }
 
 // ShopifyDeleteProduct is the resolver for the shopify_deleteProduct field.
 func (r *mutationResolver) ShopifyDeleteProduct(ctx context.Context, input model.DeleteProductInput) (bool, error) {
 	var productID int64
 	if _, err := fmt.Sscanf(input.ProductID, "%d", &productID); err != nil {
-		return false, fmt.Errorf("invalid product ID format: %w", err)
+		return false, invalidIDError("productId", err)
 	}
 
 	err := r.shopifyService.DeleteProduct(ctx, input.Domain, productID)
@@ -321,187 +337,84 @@ func (r *mutationResolver) ShopifyDeleteProduct(ctx context.Context, input model
 
 // ShopifyCreateOrder is the resolver for the shopify_createOrder field.
 func (r *mutationResolver) ShopifyCreateOrder(ctx context.Context, input model.OrderInput) (*model.OrderPayload, error) {
-	var order goshopify.Order
-	if err := json.Unmarshal([]byte(input.Order), &order); err != nil {
-		return nil, fmt.Errorf("failed to parse order JSON: %w", err)
-	}
-
-	created, err := r.shopifyService.CreateOrder(ctx, input.Domain, &order)
+	order, err := toShopifyOrder(input, false)
 	if err != nil {
 		return nil, err
 	}
 
-	createdAt, _ := time.Parse(time.RFC3339, created.CreatedAt.String())
-	updatedAt, _ := time.Parse(time.RFC3339, created.UpdatedAt.String())
-
-	totalPrice := ""
-	if created.TotalPrice != nil {
-		totalPrice = created.TotalPrice.String()
+	created, err := r.shopifyService.CreateOrder(ctx, input.Domain, order)
+	if err != nil {
+		return nil, err
 	}
-	financialStatus := string(created.FinancialStatus)
-	fulfillmentStatus := string(created.FulfillmentStatus)
 
-	return &model.OrderPayload{
-		Order: &model.Order{
-			ID:                fmt.Sprintf("%d", created.Id),
-			OrderNumber:       int(created.OrderNumber),
-			Email:             &created.Email,
-			TotalPrice:        totalPrice,
-			FinancialStatus:   &financialStatus,
-			FulfillmentStatus: &fulfillmentStatus,
-			CreatedAt:         scalars.Time(createdAt),
-			UpdatedAt:         scalars.Time(updatedAt),
-		},
-	}, nil
+	return &model.OrderPayload{Order: toModelOrder(input.Domain, created)}, nil
 }
 
 // ShopifyUpdateOrder is the resolver for the shopify_updateOrder field.
 func (r *mutationResolver) ShopifyUpdateOrder(ctx context.Context, input model.OrderInput) (*model.OrderPayload, error) {
-	var order goshopify.Order
-	if err := json.Unmarshal([]byte(input.Order), &order); err != nil {
-		return nil, fmt.Errorf("failed to parse order JSON: %w", err)
-	}
-
-	updated, err := r.shopifyService.UpdateOrder(ctx, input.Domain, &order)
+	order, err := toShopifyOrder(input, true)
 	if err != nil {
 		return nil, err
 	}
 
-	createdAt, _ := time.Parse(time.RFC3339, updated.CreatedAt.String())
-	updatedAt, _ := time.Parse(time.RFC3339, updated.UpdatedAt.String())
-
-	totalPrice := ""
-	if updated.TotalPrice != nil {
-		totalPrice = updated.TotalPrice.String()
+	updated, err := r.shopifyService.UpdateOrder(ctx, input.Domain, order)
+	if err != nil {
+		return nil, err
 	}
-	financialStatus := string(updated.FinancialStatus)
-	fulfillmentStatus := string(updated.FulfillmentStatus)
 
-	return &model.OrderPayload{
-		Order: &model.Order{
-			ID:                fmt.Sprintf("%d", updated.Id),
-			OrderNumber:       int(updated.OrderNumber),
-			Email:             &updated.Email,
-			TotalPrice:        totalPrice,
-			FinancialStatus:   &financialStatus,
-			FulfillmentStatus: &fulfillmentStatus,
-			CreatedAt:         scalars.Time(createdAt),
-			UpdatedAt:         scalars.Time(updatedAt),
-		},
-	}, nil
+	return &model.OrderPayload{Order: toModelOrder(input.Domain, updated)}, nil
 }
 
 // ShopifyCancelOrder is the resolver for the shopify_cancelOrder field.
 func (r *mutationResolver) ShopifyCancelOrder(ctx context.Context, input model.CancelOrderInput) (*model.OrderPayload, error) {
-	var orderID int64
-	if _, err := fmt.Sscanf(input.OrderID, "%d", &orderID); err != nil {
-		return nil, fmt.Errorf("invalid order ID format: %w", err)
-	}
-
-	canceled, err := r.shopifyService.CancelOrder(ctx, input.Domain, orderID)
+	orderID, cancellation, err := toOrderCancellation(input)
 	if err != nil {
 		return nil, err
 	}
 
-	createdAt, _ := time.Parse(time.RFC3339, canceled.CreatedAt.String())
-	updatedAt, _ := time.Parse(time.RFC3339, canceled.UpdatedAt.String())
-
-	totalPrice := ""
-	if canceled.TotalPrice != nil {
-		totalPrice = canceled.TotalPrice.String()
+	canceled, err := r.shopifyService.CancelOrder(ctx, input.Domain, orderID, cancellation)
+	if err != nil {
+		return nil, err
 	}
-	financialStatus := string(canceled.FinancialStatus)
-	fulfillmentStatus := string(canceled.FulfillmentStatus)
 
-	return &model.OrderPayload{
-		Order: &model.Order{
-			ID:                fmt.Sprintf("%d", canceled.Id),
-			OrderNumber:       int(canceled.OrderNumber),
-			Email:             &canceled.Email,
-			TotalPrice:        totalPrice,
-			FinancialStatus:   &financialStatus,
-			FulfillmentStatus: &fulfillmentStatus,
-			CreatedAt:         scalars.Time(createdAt),
-			UpdatedAt:         scalars.Time(updatedAt),
-		},
-	}, nil
+	return &model.OrderPayload{Order: toModelOrder(input.Domain, canceled)}, nil
 }
 
 // ShopifyCreateCustomer is the resolver for the shopify_createCustomer field.
 func (r *mutationResolver) ShopifyCreateCustomer(ctx context.Context, input model.CustomerInput) (*model.CustomerPayload, error) {
-	var customer goshopify.Customer
-	if err := json.Unmarshal([]byte(input.Customer), &customer); err != nil {
-		return nil, fmt.Errorf("failed to parse customer JSON: %w", err)
-	}
-
-	created, err := r.shopifyService.CreateCustomer(ctx, input.Domain, &customer)
+	customer, err := toShopifyCustomer(input, false)
 	if err != nil {
 		return nil, err
 	}
 
-	createdAt, _ := time.Parse(time.RFC3339, created.CreatedAt.String())
-	updatedAt, _ := time.Parse(time.RFC3339, created.UpdatedAt.String())
-
-	ordersCount := int(created.OrdersCount)
-	totalSpent := ""
-	if created.TotalSpent != nil {
-		totalSpent = created.TotalSpent.String()
+	created, err := r.shopifyService.CreateCustomer(ctx, input.Domain, customer)
+	if err != nil {
+		return nil, err
 	}
 
-	return &model.CustomerPayload{
-		Customer: &model.Customer{
-			ID:          fmt.Sprintf("%d", created.Id),
-			Email:       &created.Email,
-			FirstName:   &created.FirstName,
-			LastName:    &created.LastName,
-			OrdersCount: &ordersCount,
-			TotalSpent:  &totalSpent,
-			CreatedAt:   scalars.Time(createdAt),
-			UpdatedAt:   scalars.Time(updatedAt),
-		},
-	}, nil
+	return &model.CustomerPayload{Customer: toModelCustomer(input.Domain, created)}, nil
 }
 
 // ShopifyUpdateCustomer is the resolver for the shopify_updateCustomer field.
 func (r *mutationResolver) ShopifyUpdateCustomer(ctx context.Context, input model.CustomerInput) (*model.CustomerPayload, error) {
-	var customer goshopify.Customer
-	if err := json.Unmarshal([]byte(input.Customer), &customer); err != nil {
-		return nil, fmt.Errorf("failed to parse customer JSON: %w", err)
-	}
-
-	updated, err := r.shopifyService.UpdateCustomer(ctx, input.Domain, &customer)
+	customer, err := toShopifyCustomer(input, true)
 	if err != nil {
 		return nil, err
 	}
 
-	createdAt, _ := time.Parse(time.RFC3339, updated.CreatedAt.String())
-	updatedAt, _ := time.Parse(time.RFC3339, updated.UpdatedAt.String())
-
-	ordersCount := int(updated.OrdersCount)
-	totalSpent := ""
-	if updated.TotalSpent != nil {
-		totalSpent = updated.TotalSpent.String()
+	updated, err := r.shopifyService.UpdateCustomer(ctx, input.Domain, customer)
+	if err != nil {
+		return nil, err
 	}
 
-	return &model.CustomerPayload{
-		Customer: &model.Customer{
-			ID:          fmt.Sprintf("%d", updated.Id),
-			Email:       &updated.Email,
-			FirstName:   &updated.FirstName,
-			LastName:    &updated.LastName,
-			OrdersCount: &ordersCount,
-			TotalSpent:  &totalSpent,
-			CreatedAt:   scalars.Time(createdAt),
-			UpdatedAt:   scalars.Time(updatedAt),
-		},
-	}, nil
+	return &model.CustomerPayload{Customer: toModelCustomer(input.Domain, updated)}, nil
 }
 
 // ShopifyDeleteCustomer is the resolver for the shopify_deleteCustomer field.
 func (r *mutationResolver) ShopifyDeleteCustomer(ctx context.Context, input model.DeleteCustomerInput) (bool, error) {
 	var customerID int64
 	if _, err := fmt.Sscanf(input.CustomerID, "%d", &customerID); err != nil {
-		return false, fmt.Errorf("invalid customer ID format: %w", err)
+		return false, invalidIDError("customerId", err)
 	}
 
 	err := r.shopifyService.DeleteCustomer(ctx, input.Domain, customerID)
@@ -523,13 +436,7 @@ func (r *queryResolver) ShopifyShop(ctx context.Context, domain string) (*model.
 		return nil, nil
 	}
 
-	return &model.Shop{
-		ID:        shop.ID,
-		Domain:    shop.Domain,
-		Scopes:    shop.Scopes,
-		CreatedAt: scalars.Time(shop.CreatedAt),
-		UpdatedAt: scalars.Time(shop.UpdatedAt),
-	}, nil
+	return toModelShop(shop), nil
 }
 
 // ShopifyShops is the resolver for the shopify_shops field.
@@ -539,44 +446,33 @@ func (r *queryResolver) ShopifyShops(ctx context.Context) ([]*model.Shop, error)
 		return nil, err
 	}
 
-	result := make([]*model.Shop, len(shops))
-	for i, shop := range shops {
-		result[i] = &model.Shop{
-			ID:        shop.ID,
-			Domain:    shop.Domain,
-			Scopes:    shop.Scopes,
-			CreatedAt: scalars.Time(shop.CreatedAt),
-			UpdatedAt: scalars.Time(shop.UpdatedAt),
+	// A credential bound to one shop only sees that shop
+	principal := domain.GetPrincipalFromContext(ctx)
+	result := make([]*model.Shop, 0, len(shops))
+	for _, shop := range shops {
+		if principal != nil && principal.ShopDomain != "" && !strings.EqualFold(shop.Domain, principal.ShopDomain) {
+			continue
 		}
+		result = append(result, toModelShop(shop))
 	}
 
 	return result, nil
 }
 
 // ShopifyProducts is the resolver for the shopify_products field.
-func (r *queryResolver) ShopifyProducts(ctx context.Context, domain string) ([]*model.Product, error) {
-	products, err := r.shopifyService.GetProducts(ctx, domain)
-	if err != nil {
+func (r *queryResolver) ShopifyProducts(ctx context.Context, domain string, first *int, after *string, before *string, filter *model.ProductFilter, fields []string) (*model.ProductConnection, error) {
+	v := &inputValidator{}
+	options := productListOptions(v, newPageRequest(v, first, after, before, fields), filter)
+	if err := v.result("invalid products query"); err != nil {
 		return nil, err
 	}
 
-	result := make([]*model.Product, len(products))
-	for i, p := range products {
-		createdAt, _ := time.Parse(time.RFC3339, p.CreatedAt.String())
-		updatedAt, _ := time.Parse(time.RFC3339, p.UpdatedAt.String())
-
-		result[i] = &model.Product{
-			ID:          fmt.Sprintf("%d", p.Id),
-			Title:       p.Title,
-			Handle:      &p.Handle,
-			Vendor:      &p.Vendor,
-			ProductType: &p.ProductType,
-			CreatedAt:   scalars.Time(createdAt),
-			UpdatedAt:   scalars.Time(updatedAt),
-		}
+	products, pagination, err := r.shopifyService.GetProducts(ctx, domain, options)
+	if err != nil {
+		return nil, err
 	}
 
-	return result, nil
+	return toProductConnection(domain, products, pagination), nil
 }
 
 // ShopifyProduct is the resolver for the shopify_product field.
@@ -584,7 +480,7 @@ func (r *queryResolver) ShopifyProduct(ctx context.Context, domain string, produ
 	// Parse product ID
 	var pid int64
 	if _, err := fmt.Sscanf(productID, "%d", &pid); err != nil {
-		return nil, fmt.Errorf("invalid product ID format: %w", err)
+		return nil, invalidIDError("productId", err)
 	}
 
 	product, err := r.shopifyService.GetProduct(ctx, domain, pid)
@@ -592,53 +488,23 @@ func (r *queryResolver) ShopifyProduct(ctx context.Context, domain string, produ
 		return nil, err
 	}
 
-	createdAt, _ := time.Parse(time.RFC3339, product.CreatedAt.String())
-	updatedAt, _ := time.Parse(time.RFC3339, product.UpdatedAt.String())
-
-	return &model.Product{
-		ID:          fmt.Sprintf("%d", product.Id),
-		Title:       product.Title,
-		Handle:      &product.Handle,
-		Vendor:      &product.Vendor,
-		ProductType: &product.ProductType,
-		CreatedAt:   scalars.Time(createdAt),
-		UpdatedAt:   scalars.Time(updatedAt),
-	}, nil
+	return toModelProduct(domain, product), nil
 }
 
 // ShopifyOrders is the resolver for the shopify_orders field.
-func (r *queryResolver) ShopifyOrders(ctx context.Context, domain string) ([]*model.Order, error) {
-	orders, err := r.shopifyService.GetOrders(ctx, domain)
-	if err != nil {
+func (r *queryResolver) ShopifyOrders(ctx context.Context, domain string, first *int, after *string, before *string, filter *model.OrderFilter, fields []string) (*model.OrderConnection, error) {
+	v := &inputValidator{}
+	options := orderListOptions(v, newPageRequest(v, first, after, before, fields), filter)
+	if err := v.result("invalid orders query"); err != nil {
 		return nil, err
 	}
 
-	result := make([]*model.Order, len(orders))
-	for i, o := range orders {
-		createdAt, _ := time.Parse(time.RFC3339, o.CreatedAt.String())
-		updatedAt, _ := time.Parse(time.RFC3339, o.UpdatedAt.String())
-
-		// Convert types
-		totalPrice := ""
-		if o.TotalPrice != nil {
-			totalPrice = o.TotalPrice.String()
-		}
-		financialStatus := string(o.FinancialStatus)
-		fulfillmentStatus := string(o.FulfillmentStatus)
-
-		result[i] = &model.Order{
-			ID:                fmt.Sprintf("%d", o.Id),
-			OrderNumber:       int(o.OrderNumber),
-			Email:             &o.Email,
-			TotalPrice:        totalPrice,
-			FinancialStatus:   &financialStatus,
-			FulfillmentStatus: &fulfillmentStatus,
-			CreatedAt:         scalars.Time(createdAt),
-			UpdatedAt:         scalars.Time(updatedAt),
-		}
+	orders, pagination, err := r.shopifyService.GetOrders(ctx, domain, options)
+	if err != nil {
+		return nil, err
 	}
 
-	return result, nil
+	return toOrderConnection(domain, orders, pagination), nil
 }
 
 // ShopifyOrder is the resolver for the shopify_order field.
@@ -646,7 +512,7 @@ func (r *queryResolver) ShopifyOrder(ctx context.Context, domain string, orderID
 	// Parse order ID
 	var oid int64
 	if _, err := fmt.Sscanf(orderID, "%d", &oid); err != nil {
-		return nil, fmt.Errorf("invalid order ID format: %w", err)
+		return nil, invalidIDError("orderId", err)
 	}
 
 	order, err := r.shopifyService.GetOrder(ctx, domain, oid)
@@ -654,60 +520,23 @@ func (r *queryResolver) ShopifyOrder(ctx context.Context, domain string, orderID
 		return nil, err
 	}
 
-	createdAt, _ := time.Parse(time.RFC3339, order.CreatedAt.String())
-	updatedAt, _ := time.Parse(time.RFC3339, order.UpdatedAt.String())
-
-	// Convert types
-	totalPrice := ""
-	if order.TotalPrice != nil {
-		totalPrice = order.TotalPrice.String()
-	}
-	financialStatus := string(order.FinancialStatus)
-	fulfillmentStatus := string(order.FulfillmentStatus)
-
-	return &model.Order{
-		ID:                fmt.Sprintf("%d", order.Id),
-		OrderNumber:       int(order.OrderNumber),
-		Email:             &order.Email,
-		TotalPrice:        totalPrice,
-		FinancialStatus:   &financialStatus,
-		FulfillmentStatus: &fulfillmentStatus,
-		CreatedAt:         scalars.Time(createdAt),
-		UpdatedAt:         scalars.Time(updatedAt),
-	}, nil
+	return toModelOrder(domain, order), nil
 }
 
 // ShopifyCustomers is the resolver for the shopify_customers field.
-func (r *queryResolver) ShopifyCustomers(ctx context.Context, domain string) ([]*model.Customer, error) {
-	customers, err := r.shopifyService.GetCustomers(ctx, domain)
-	if err != nil {
+func (r *queryResolver) ShopifyCustomers(ctx context.Context, domain string, first *int, after *string, before *string, filter *model.CustomerFilter, fields []string) (*model.CustomerConnection, error) {
+	v := &inputValidator{}
+	options := customerListOptions(v, newPageRequest(v, first, after, before, fields), filter)
+	if err := v.result("invalid customers query"); err != nil {
 		return nil, err
 	}
 
-	result := make([]*model.Customer, len(customers))
-	for i, c := range customers {
-		createdAt, _ := time.Parse(time.RFC3339, c.CreatedAt.String())
-		updatedAt, _ := time.Parse(time.RFC3339, c.UpdatedAt.String())
-
-		ordersCount := int(c.OrdersCount)
-		totalSpent := ""
-		if c.TotalSpent != nil {
-			totalSpent = c.TotalSpent.String()
-		}
-
-		result[i] = &model.Customer{
-			ID:          fmt.Sprintf("%d", c.Id),
-			Email:       &c.Email,
-			FirstName:   &c.FirstName,
-			LastName:    &c.LastName,
-			OrdersCount: &ordersCount,
-			TotalSpent:  &totalSpent,
-			CreatedAt:   scalars.Time(createdAt),
-			UpdatedAt:   scalars.Time(updatedAt),
-		}
+	customers, pagination, err := r.shopifyService.GetCustomers(ctx, domain, options)
+	if err != nil {
+		return nil, err
 	}
 
-	return result, nil
+	return toCustomerConnection(domain, customers, pagination), nil
 }
 
 // ShopifyCustomer is the resolver for the shopify_customer field.
@@ -715,7 +544,7 @@ func (r *queryResolver) ShopifyCustomer(ctx context.Context, domain string, cust
 	// Parse customer ID
 	var cid int64
 	if _, err := fmt.Sscanf(customerID, "%d", &cid); err != nil {
-		return nil, fmt.Errorf("invalid customer ID format: %w", err)
+		return nil, invalidIDError("customerId", err)
 	}
 
 	customer, err := r.shopifyService.GetCustomer(ctx, domain, cid)
@@ -723,88 +552,55 @@ func (r *queryResolver) ShopifyCustomer(ctx context.Context, domain string, cust
 		return nil, err
 	}
 
-	createdAt, _ := time.Parse(time.RFC3339, customer.CreatedAt.String())
-	updatedAt, _ := time.Parse(time.RFC3339, customer.UpdatedAt.String())
-
-	ordersCount := int(customer.OrdersCount)
-	totalSpent := ""
-	if customer.TotalSpent != nil {
-		totalSpent = customer.TotalSpent.String()
-	}
-
-	return &model.Customer{
-		ID:          fmt.Sprintf("%d", customer.Id),
-		Email:       &customer.Email,
-		FirstName:   &customer.FirstName,
-		LastName:    &customer.LastName,
-		OrdersCount: &ordersCount,
-		TotalSpent:  &totalSpent,
-		CreatedAt:   scalars.Time(createdAt),
-		UpdatedAt:   scalars.Time(updatedAt),
-	}, nil
+	return toModelCustomer(domain, customer), nil
 }
 
 // ShopifySearchCustomers is the resolver for the shopify_searchCustomers field.
-func (r *queryResolver) ShopifySearchCustomers(ctx context.Context, domain string, query string) ([]*model.Customer, error) {
-	customers, err := r.shopifyService.SearchCustomers(ctx, domain, query)
-	if err != nil {
+func (r *queryResolver) ShopifySearchCustomers(ctx context.Context, domain string, query string, first *int, after *string, before *string, fields []string) (*model.CustomerConnection, error) {
+	v := &inputValidator{}
+	request := newPageRequest(v, first, after, before, fields)
+	if err := v.result("invalid customer search"); err != nil {
 		return nil, err
 	}
 
-	result := make([]*model.Customer, len(customers))
-	for i, c := range customers {
-		createdAt, _ := time.Parse(time.RFC3339, c.CreatedAt.String())
-		updatedAt, _ := time.Parse(time.RFC3339, c.UpdatedAt.String())
-
-		ordersCount := int(c.OrdersCount)
-		totalSpent := ""
-		if c.TotalSpent != nil {
-			totalSpent = c.TotalSpent.String()
-		}
+	// The query is kept by page cursors, Shopify rejects it alongside page_info
+	options := &customerSearchOptions{ListOptions: request.options}
+	if request.filtered {
+		options.Query = query
+	}
 
-		result[i] = &model.Customer{
-			ID:          fmt.Sprintf("%d", c.Id),
-			Email:       &c.Email,
-			FirstName:   &c.FirstName,
-			LastName:    &c.LastName,
-			OrdersCount: &ordersCount,
-			TotalSpent:  &totalSpent,
-			CreatedAt:   scalars.Time(createdAt),
-			UpdatedAt:   scalars.Time(updatedAt),
-		}
+	customers, pagination, err := r.shopifyService.SearchCustomers(ctx, domain, options)
+	if err != nil {
+		return nil, err
 	}
 
-	return result, nil
+	return toCustomerConnection(domain, customers, pagination), nil
 }
 
 // ShopifyInventoryLevels is the resolver for the shopify_inventoryLevels field.
-func (r *queryResolver) ShopifyInventoryLevels(ctx context.Context, domain string) ([]*model.InventoryLevel, error) {
-	levels, err := r.shopifyService.GetInventoryLevels(ctx, domain)
-	if err != nil {
+func (r *queryResolver) ShopifyInventoryLevels(ctx context.Context, domain string, filter model.InventoryLevelFilter, first *int, after *string, before *string) (*model.InventoryLevelConnection, error) {
+	v := &inputValidator{}
+	options := toInventoryLevelListOptions(v, newPageRequest(v, first, after, before, nil), filter)
+	if err := v.result("invalid inventory levels query"); err != nil {
 		return nil, err
 	}
 
-	result := make([]*model.InventoryLevel, len(levels))
-	for i, l := range levels {
-		updatedAt, _ := time.Parse(time.RFC3339, l.UpdatedAt.String())
-		available := int(l.Available)
-
-		result[i] = &model.InventoryLevel{
-			InventoryItemID: fmt.Sprintf("%d", l.InventoryItemId),
-			LocationID:      fmt.Sprintf("%d", l.LocationId),
-			Available:       &available,
-			UpdatedAt:       scalars.Time(updatedAt),
-		}
+	levels, pagination, err := r.shopifyService.GetInventoryLevels(ctx, domain, options)
+	if err != nil {
+		return nil, err
 	}
 
-	return result, nil
+	return &model.InventoryLevelConnection{
+		Nodes:    toModelInventoryLevels(levels),
+		PageInfo: toModelPageInfo(pagination),
+	}, nil
 }
 
 // ShopifyGetConfig is the resolver for the shopify_getConfig field.
 func (r *queryResolver) ShopifyGetConfig(ctx context.Context) (*model.ShopifyConfig, error) {
 	tenantID := getTenantID(ctx)
 	if tenantID == "" {
-		return nil, fmt.Errorf("tenant ID not found in context")
+		return nil, domain.NewValidationError("tenant ID not found in context", nil)
 	}
 
 	config, err := r.credentialsService.GetConfig(ctx, tenantID)
@@ -812,15 +608,26 @@ func (r *queryResolver) ShopifyGetConfig(ctx context.Context) (*model.ShopifyCon
 		return nil, err
 	}
 
-	return &model.ShopifyConfig{
-		ID:          config.ID,
-		ProjectID:   config.ProjectID,
-		Environment: config.Environment,
-		APIKey:      config.APIKey,
-		WebhookURL:  config.WebhookURL,
-		CreatedAt:   scalars.Time(config.CreatedAt),
-		UpdatedAt:   scalars.Time(config.UpdatedAt),
-	}, nil
+	return toModelShopifyConfig(config), nil
+}
+
+// ShopifyApps is the resolver for the shopify_apps field.
+func (r *queryResolver) ShopifyApps(ctx context.Context) ([]*model.ShopifyConfig, error) {
+	tenantID := getTenantID(ctx)
+	if tenantID == "" {
+		return nil, domain.NewValidationError("tenant ID not found in context", nil)
+	}
+
+	configs, err := r.credentialsService.ListApps(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.ShopifyConfig, len(configs))
+	for i, config := range configs {
+		result[i] = toModelShopifyConfig(config)
+	}
+	return result, nil
 }
 
 // ShopifyGetCredentials is the resolver for the shopify_getCredentials field (deprecated).
@@ -829,6 +636,9 @@ func (r *queryResolver) ShopifyGetCredentials(ctx context.Context, projectID str
 	if tenantID == "" {
 		tenantID = projectID // Fallback
 	}
+	if err := authorizeTenant(ctx, tenantID, environment); err != nil {
+		return nil, err
+	}
 
 	creds, err := r.credentialsService.GetCredentials(ctx, tenantID, environment)
 	if err != nil {
@@ -855,31 +665,19 @@ func (r *queryResolver) GetIntegrationByKey(ctx context.Context, key string) (*m
 	if err != nil {
 		return nil, err
 	}
+	if err := authorizeTenant(ctx, integration.ProjectID, integration.Environment); err != nil {
+		return nil, err
+	}
 
-	return &model.Integration{
-		ID:          integration.ID,
-		Key:         integration.Key,
-		ProjectID:   integration.ProjectID,
-		Environment: integration.Environment,
-		ShopDomain:  integration.ShopDomain,
-		CreatedAt:   scalars.Time(integration.CreatedAt),
-		UpdatedAt:   scalars.Time(integration.UpdatedAt),
-	}, nil
+	return toModelIntegration(integration), nil
 }
 
 // WebhookEvents is the resolver for the webhookEvents field.
 func (r *subscriptionResolver) WebhookEvents(ctx context.Context, filter *model.WebhookEventFilter) (<-chan *model.WebhookEventPayload, error) {
-	// Convert GraphQL filter to pubsub filter
-	var pubsubFilter *pubsub.WebhookEventFilter
-	if filter != nil {
-		shop := ""
-		if filter.Shop != nil {
-			shop = *filter.Shop
-		}
-		pubsubFilter = &pubsub.WebhookEventFilter{
-			Topics: filter.Topics,
-			Shop:   shop,
-		}
+	// Convert GraphQL filter to pubsub filter, always limited to the caller's tenant and bound shop
+	pubsubFilter, err := webhookEventFilter(ctx, filter)
+	if err != nil {
+		return nil, err
 	}
 
 	// Subscribe to webhook events
@@ -929,9 +727,13 @@ func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResol
 // Query returns generated.QueryResolver implementation.
 func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
 
+// Shop returns generated.ShopResolver implementation.
+func (r *Resolver) Shop() generated.ShopResolver { return &shopResolver{r} }
+
 // Subscription returns generated.SubscriptionResolver implementation.
 func (r *Resolver) Subscription() generated.SubscriptionResolver { return &subscriptionResolver{r} }
 
 type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type shopResolver struct{ *Resolver }
 type subscriptionResolver struct{ *Resolver }
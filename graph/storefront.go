@@ -0,0 +1,28 @@
+package graph
+
+import (
+	"strconv"
+
+	"archie-core-shopify-layer/graph/model"
+	"archie-core-shopify-layer/internal/domain"
+)
+
+// toModelStorefrontToken converts a domain Storefront token to its GraphQL model
+func toModelStorefrontToken(token *domain.StorefrontToken) *model.StorefrontToken {
+	result := &model.StorefrontToken{
+		ID:           strconv.FormatUint(token.ID, 10),
+		Title:        token.Title,
+		AccessScopes: token.AccessScopes,
+		CreatedAt:    optionalTime(token.CreatedAt),
+	}
+	if result.AccessScopes == nil {
+		result.AccessScopes = []string{}
+	}
+	if token.AccessToken != "" {
+		result.AccessToken = &token.AccessToken
+	}
+	if token.CreatedBy != "" {
+		result.CreatedBy = &token.CreatedBy
+	}
+	return result
+}
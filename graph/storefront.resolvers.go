@@ -0,0 +1,50 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.84
+
+import (
+	"archie-core-shopify-layer/graph/model"
+	"context"
+	"strconv"
+)
+
+// ShopifyCreateStorefrontToken is the resolver for the shopify_createStorefrontToken field.
+func (r *mutationResolver) ShopifyCreateStorefrontToken(ctx context.Context, domain string, title *string) (*model.StorefrontToken, error) {
+	token, err := r.storefrontService.CreateStorefrontToken(ctx, domain, stringValue(title))
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelStorefrontToken(token), nil
+}
+
+// ShopifyDeleteStorefrontToken is the resolver for the shopify_deleteStorefrontToken field.
+func (r *mutationResolver) ShopifyDeleteStorefrontToken(ctx context.Context, domain string, id string) (bool, error) {
+	tokenID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return false, invalidIDError("id", err)
+	}
+
+	if err := r.storefrontService.DeleteStorefrontToken(ctx, domain, tokenID); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ShopifyStorefrontTokens is the resolver for the shopify_storefrontTokens field.
+func (r *queryResolver) ShopifyStorefrontTokens(ctx context.Context, domain string) ([]*model.StorefrontToken, error) {
+	tokens, err := r.storefrontService.ListStorefrontTokens(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.StorefrontToken, 0, len(tokens))
+	for _, token := range tokens {
+		result = append(result, toModelStorefrontToken(token))
+	}
+	return result, nil
+}
@@ -0,0 +1,38 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.84
+
+import (
+	"archie-core-shopify-layer/graph/model"
+	"archie-core-shopify-layer/internal/domain"
+	"context"
+)
+
+// ShopifyUsage is the resolver for the shopify_usage field.
+func (r *queryResolver) ShopifyUsage(ctx context.Context, integrationID *string) (*model.APIUsage, error) {
+	tenantID := getTenantID(ctx)
+	if tenantID == "" {
+		return nil, domain.NewValidationError("project ID is required", nil)
+	}
+
+	id := stringValue(integrationID)
+	if id != "" {
+		integration, err := r.integrationService.GetIntegration(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if integration.ProjectID != tenantID {
+			return nil, domain.NewNotFoundError("integration")
+		}
+	}
+
+	usage, err := r.rateLimitService.GetUsage(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelAPIUsage(usage), nil
+}
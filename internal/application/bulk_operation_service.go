@@ -0,0 +1,344 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"archie-core-shopify-layer/internal/domain"
+	"archie-core-shopify-layer/internal/ports"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	// bulkOperationPollInterval is how often WaitForBulkOperation checks an operation
+	bulkOperationPollInterval = 2 * time.Second
+	// bulkMutationVariablesFile names the staged upload of bulk mutation variables
+	bulkMutationVariablesFile = "bulk_mutation_variables.jsonl"
+	// maxBulkMutationVariablesSize is the largest JSONL file Shopify accepts for a bulk mutation
+	maxBulkMutationVariablesSize = 100 << 20
+)
+
+// errStopBulkResults stops streaming the results of a bulk operation once a page is complete
+var errStopBulkResults = errors.New("stop streaming bulk operation results")
+
+// BulkOperationService runs Shopify bulk queries and mutations and tracks their progress per shop
+// Operations are refreshed from Shopify when polled and when the bulk_operations/finish webhook arrives
+type BulkOperationService struct {
+	repo           ports.BulkOperationRepository
+	shopifyService *ShopifyService
+	files          ports.ShopifyBulkFileClient
+	logger         zerolog.Logger
+}
+
+// NewBulkOperationService creates a new bulk operation service
+func NewBulkOperationService(
+	repo ports.BulkOperationRepository,
+	shopifyService *ShopifyService,
+	files ports.ShopifyBulkFileClient,
+	logger zerolog.Logger,
+) *BulkOperationService {
+	return &BulkOperationService{
+		repo:           repo,
+		shopifyService: shopifyService,
+		files:          files,
+		logger:         logger,
+	}
+}
+
+// RunBulkQuery starts a bulk query, Shopify runs one bulk query per shop at a time
+func (s *BulkOperationService) RunBulkQuery(ctx context.Context, shopDomain string, query string) (*domain.BulkOperation, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, domain.NewValidationError("invalid bulk query", nil).WithFieldError("query", "is required")
+	}
+
+	client, accessToken, err := s.shopifyService.graphQLShopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	operation, err := client.RunBulkQuery(ctx, shopDomain, accessToken, query)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Msg("Failed to run bulk query")
+		return nil, fmt.Errorf("failed to run bulk query: %w", err)
+	}
+
+	return s.track(ctx, shopDomain, operation, "")
+}
+
+// CreateBulkMutationUpload reserves a staged upload for the JSONL variables of a bulk mutation
+// Callers POST the file to the target themselves, then pass its Path() to RunBulkMutation
+func (s *BulkOperationService) CreateBulkMutationUpload(ctx context.Context, shopDomain string, filename string) (*domain.StagedUploadTarget, error) {
+	if filename == "" {
+		filename = bulkMutationVariablesFile
+	}
+
+	client, accessToken, err := s.shopifyService.graphQLShopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := client.CreateBulkMutationUpload(ctx, shopDomain, accessToken, filename)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Msg("Failed to create bulk mutation upload")
+		return nil, fmt.Errorf("failed to create bulk mutation upload: %w", err)
+	}
+
+	return target, nil
+}
+
+// RunBulkMutation starts a bulk mutation over the variables of a staged upload, one line per call
+func (s *BulkOperationService) RunBulkMutation(ctx context.Context, shopDomain string, mutation string, stagedUploadPath string) (*domain.BulkOperation, error) {
+	v := domain.NewValidationError("invalid bulk mutation", nil)
+	if strings.TrimSpace(mutation) == "" {
+		v = v.WithFieldError("mutation", "is required")
+	}
+	if stagedUploadPath == "" {
+		v = v.WithFieldError("stagedUploadPath", "is required")
+	}
+	if len(v.Fields) > 0 {
+		return nil, v
+	}
+
+	client, accessToken, err := s.shopifyService.graphQLShopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	operation, err := client.RunBulkMutation(ctx, shopDomain, accessToken, mutation, stagedUploadPath)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Msg("Failed to run bulk mutation")
+		return nil, fmt.Errorf("failed to run bulk mutation: %w", err)
+	}
+
+	return s.track(ctx, shopDomain, operation, stagedUploadPath)
+}
+
+// RunBulkMutationWithVariables uploads the variables of each call as JSONL, then starts the bulk mutation
+func (s *BulkOperationService) RunBulkMutationWithVariables(ctx context.Context, shopDomain string, mutation string, variables []map[string]interface{}) (*domain.BulkOperation, error) {
+	if len(variables) == 0 {
+		return nil, domain.NewValidationError("invalid bulk mutation", nil).WithFieldError("variables", "must not be empty")
+	}
+
+	var content bytes.Buffer
+	encoder := json.NewEncoder(&content) // One object per line
+	for i, callVariables := range variables {
+		if err := encoder.Encode(callVariables); err != nil {
+			return nil, domain.NewValidationError("invalid bulk mutation", err).WithFieldError(fmt.Sprintf("variables.%d", i), "cannot be encoded as JSON")
+		}
+	}
+	if content.Len() > maxBulkMutationVariablesSize {
+		return nil, domain.NewValidationError("invalid bulk mutation", nil).WithFieldError("variables", "exceed Shopify's 100MB limit")
+	}
+
+	target, err := s.CreateBulkMutationUpload(ctx, shopDomain, bulkMutationVariablesFile)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.files.Upload(ctx, *target, bulkMutationVariablesFile, &content); err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Msg("Failed to upload bulk mutation variables")
+		return nil, fmt.Errorf("failed to upload bulk mutation variables: %w", err)
+	}
+
+	return s.RunBulkMutation(ctx, shopDomain, mutation, target.Path())
+}
+
+// GetBulkOperation retrieves a bulk operation of a shop, refreshed from Shopify until it is done
+// Operations started outside this service are tracked from their first lookup
+func (s *BulkOperationService) GetBulkOperation(ctx context.Context, shopDomain string, operationID uint64) (*domain.BulkOperation, error) {
+	stored, err := s.repo.Get(ctx, s.shopifyService.ShopKey(ctx, shopDomain), operationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bulk operation: %w", err)
+	}
+	if stored != nil && stored.Status.Done() {
+		return stored, nil
+	}
+	return s.refresh(ctx, shopDomain, operationID, stored)
+}
+
+// WaitForBulkOperation polls a bulk operation until it is done or timeout elapses
+// It returns the last status seen, which is not done when the timeout elapsed first
+func (s *BulkOperationService) WaitForBulkOperation(ctx context.Context, shopDomain string, operationID uint64, timeout time.Duration) (*domain.BulkOperation, error) {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(bulkOperationPollInterval)
+	defer ticker.Stop()
+
+	for {
+		operation, err := s.GetBulkOperation(ctx, shopDomain, operationID)
+		if err != nil || operation.Status.Done() {
+			return operation, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-deadline.C:
+			return operation, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// ListBulkOperations retrieves the most recent bulk operations of a shop as last recorded
+func (s *BulkOperationService) ListBulkOperations(ctx context.Context, shopDomain string, limit int) ([]*domain.BulkOperation, error) {
+	operations, err := s.repo.List(ctx, s.shopifyService.ShopKey(ctx, shopDomain), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bulk operations: %w", err)
+	}
+	return operations, nil
+}
+
+// CancelBulkOperation asks Shopify to stop a running bulk operation, it moves to CANCELING then CANCELED
+func (s *BulkOperationService) CancelBulkOperation(ctx context.Context, shopDomain string, operationID uint64) (*domain.BulkOperation, error) {
+	client, accessToken, err := s.shopifyService.graphQLShopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	operation, err := client.CancelBulkOperation(ctx, shopDomain, accessToken, operationID)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Uint64("operationID", operationID).Msg("Failed to cancel bulk operation")
+		return nil, fmt.Errorf("failed to cancel bulk operation: %w", err)
+	}
+
+	stored, err := s.repo.Get(ctx, s.shopifyService.ShopKey(ctx, shopDomain), operationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bulk operation: %w", err)
+	}
+	return s.save(ctx, shopDomain, operation, stored)
+}
+
+// HandleBulkOperationFinish records the final state of an operation reported by the bulk_operations/finish webhook
+// The payload has no result URL, so the operation is fetched from Shopify
+func (s *BulkOperationService) HandleBulkOperationFinish(ctx context.Context, shopDomain string, operationGID string) (*domain.BulkOperation, error) {
+	operationID, err := strconv.ParseUint(operationGID[strings.LastIndex(operationGID, "/")+1:], 10, 64)
+	if err != nil {
+		return nil, domain.NewValidationError(fmt.Sprintf("invalid bulk operation ID %q", operationGID), err)
+	}
+
+	stored, err := s.repo.Get(ctx, s.shopifyService.ShopKey(ctx, shopDomain), operationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bulk operation: %w", err)
+	}
+	operation, err := s.refresh(ctx, shopDomain, operationID, stored)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().
+		Str("domain", shopDomain).
+		Uint64("operationID", operationID).
+		Str("status", string(operation.Status)).
+		Int64("objectCount", operation.ObjectCount).
+		Msg("Bulk operation finished")
+	return operation, nil
+}
+
+// StreamBulkOperationResults calls handle for each line of the results of a completed operation
+// Failed operations stream their partial data; running ones are rejected
+func (s *BulkOperationService) StreamBulkOperationResults(ctx context.Context, shopDomain string, operationID uint64, handle func(line json.RawMessage) error) error {
+	operation, err := s.GetBulkOperation(ctx, shopDomain, operationID)
+	if err != nil {
+		return err
+	}
+
+	url := operation.ResultURL()
+	switch {
+	case !operation.Status.Done():
+		return domain.NewValidationError(fmt.Sprintf("bulk operation %d is %s, results are available once it completes", operationID, operation.Status), nil)
+	case url == "" && operation.Status == domain.BulkOperationStatusCompleted:
+		return nil // Completed without matching objects
+	case url == "":
+		return domain.NewNotFoundError(fmt.Sprintf("results of bulk operation %d", operationID))
+	}
+
+	if err := s.files.StreamJSONL(ctx, url, handle); err != nil {
+		if errors.Is(err, errStopBulkResults) {
+			return err
+		}
+		s.logger.Error().Err(err).Str("domain", shopDomain).Uint64("operationID", operationID).Msg("Failed to stream bulk operation results")
+		return fmt.Errorf("failed to stream bulk operation results: %w", err)
+	}
+	return nil
+}
+
+// GetBulkOperationResults reads limit lines of the results starting at line offset, and whether more follow
+// Each page streams the file from its start, so large exports are better consumed with StreamBulkOperationResults
+func (s *BulkOperationService) GetBulkOperationResults(ctx context.Context, shopDomain string, operationID uint64, offset int, limit int) ([]json.RawMessage, bool, error) {
+	lines := make([]json.RawMessage, 0, limit)
+	hasMore := false
+	skipped := 0
+	err := s.StreamBulkOperationResults(ctx, shopDomain, operationID, func(line json.RawMessage) error {
+		switch {
+		case skipped < offset:
+			skipped++
+			return nil
+		case len(lines) == limit:
+			hasMore = true
+			return errStopBulkResults
+		}
+		lines = append(lines, line)
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStopBulkResults) {
+		return nil, false, err
+	}
+	return lines, hasMore, nil
+}
+
+// refresh fetches an operation from Shopify and records it
+func (s *BulkOperationService) refresh(ctx context.Context, shopDomain string, operationID uint64, stored *domain.BulkOperation) (*domain.BulkOperation, error) {
+	client, accessToken, err := s.shopifyService.graphQLShopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	operation, err := client.GetBulkOperation(ctx, shopDomain, accessToken, operationID)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Uint64("operationID", operationID).Msg("Failed to get bulk operation")
+		return nil, fmt.Errorf("failed to get bulk operation: %w", err)
+	}
+
+	return s.save(ctx, shopDomain, operation, stored)
+}
+
+// track records an operation that was just started, with the caller that started it
+func (s *BulkOperationService) track(ctx context.Context, shopDomain string, operation *domain.BulkOperation, stagedUploadPath string) (*domain.BulkOperation, error) {
+	operation.StagedUploadPath = stagedUploadPath
+	if principal := domain.GetPrincipalFromContext(ctx); principal != nil {
+		operation.StartedBy = string(principal.Type) + ":" + principal.Subject
+	}
+
+	s.logger.Info().
+		Str("domain", shopDomain).
+		Uint64("operationID", operation.ID).
+		Str("type", string(operation.Type)).
+		Str("startedBy", operation.StartedBy).
+		Msg("Started bulk operation")
+	return s.save(ctx, shopDomain, operation, nil)
+}
+
+// save stores the Shopify state of an operation under the shop of the context, keeping what only we know
+func (s *BulkOperationService) save(ctx context.Context, shopDomain string, operation *domain.BulkOperation, stored *domain.BulkOperation) (*domain.BulkOperation, error) {
+	key := s.shopifyService.ShopKey(ctx, shopDomain)
+	operation.ProjectID = key.ProjectID
+	operation.Environment = key.Environment
+	operation.AppHandle = key.AppHandle
+	operation.ShopDomain = key.Domain
+	if stored != nil {
+		operation.StagedUploadPath = stored.StagedUploadPath
+		operation.StartedBy = stored.StartedBy
+	}
+
+	if err := s.repo.Save(ctx, operation); err != nil {
+		return nil, fmt.Errorf("failed to save bulk operation: %w", err)
+	}
+	return operation, nil
+}
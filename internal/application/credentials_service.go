@@ -38,6 +38,10 @@ type ConfigureShopifyInput struct {
 	APIKey        string
 	APISecret     string
 	WebhookSecret string
+	// ExpiringOfflineTokens opts the project in to expiring offline tokens (nil keeps the current setting)
+	ExpiringOfflineTokens *bool
+	// AppHandle selects which app of the environment is configured (empty uses the app handle in context)
+	AppHandle string
 }
 
 // ConfigureShopify configures Shopify for a project and environment
@@ -60,8 +64,16 @@ func (s *CredentialsService) ConfigureShopify(ctx context.Context, tenantID stri
 		return nil, fmt.Errorf("failed to encrypt API secret: %w", err)
 	}
 
+	// Resolve the app being configured
+	appHandle := input.AppHandle
+	if appHandle == "" {
+		appHandle = domain.GetAppHandleFromContext(ctx)
+	}
+	appHandle = domain.NormalizeAppHandle(appHandle)
+	ctx = domain.WithAppHandle(ctx, appHandle)
+
 	// Generate webhook URL
-	webhookURL := fmt.Sprintf("%s/webhooks/shopify/%s/%s", s.webhookBaseURL, projectID, environment)
+	webhookURL := buildWebhookURL(s.webhookBaseURL, projectID, environment, appHandle)
 
 	// Check if config exists
 	existing, err := s.configRepo.GetByTenantID(ctx, projectID)
@@ -73,6 +85,7 @@ func (s *CredentialsService) ConfigureShopify(ctx context.Context, tenantID stri
 	config, err := domain.NewShopifyConfig(
 		projectID,
 		environment,
+		appHandle,
 		encryptedSecret,
 		input.APIKey,
 		input.WebhookSecret,
@@ -82,6 +95,12 @@ func (s *CredentialsService) ConfigureShopify(ctx context.Context, tenantID stri
 		return nil, fmt.Errorf("failed to create ShopifyConfig: %w", err)
 	}
 
+	if input.ExpiringOfflineTokens != nil {
+		config.ExpiringOfflineTokens = *input.ExpiringOfflineTokens
+	} else if existing != nil {
+		config.ExpiringOfflineTokens = existing.ExpiringOfflineTokens
+	}
+
 	if existing != nil {
 		// Update existing
 		config.ID = existing.ID
@@ -99,10 +118,28 @@ func (s *CredentialsService) ConfigureShopify(ctx context.Context, tenantID stri
 		}
 	}
 
-	s.logger.Info().Str("projectId", projectID).Str("environment", environment).Msg("Shopify configuration saved successfully")
+	s.logger.Info().Str("projectId", projectID).Str("environment", environment).Str("appHandle", appHandle).Msg("Shopify configuration saved successfully")
 	return config, nil
 }
 
+// buildWebhookURL builds the webhook URL of an app
+// The default app keeps the original URL so existing subscriptions stay valid
+func buildWebhookURL(baseURL, projectID, environment, appHandle string) string {
+	if domain.NormalizeAppHandle(appHandle) == domain.DefaultAppHandle {
+		return fmt.Sprintf("%s/webhooks/shopify/%s/%s", baseURL, projectID, environment)
+	}
+	return fmt.Sprintf("%s/webhooks/shopify/%s/%s/%s", baseURL, projectID, environment, appHandle)
+}
+
+// ListApps retrieves the configuration of every app in a project environment
+func (s *CredentialsService) ListApps(ctx context.Context, tenantID string) ([]*domain.ShopifyConfig, error) {
+	configs, err := s.configRepo.ListByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Shopify apps: %w", err)
+	}
+	return configs, nil
+}
+
 // GetConfig retrieves the Shopify configuration for a project and environment
 func (s *CredentialsService) GetConfig(ctx context.Context, tenantID string) (*domain.ShopifyConfig, error) {
 	// Extract projectID and environment from context (type-safe)
@@ -174,7 +211,8 @@ func (s *CredentialsService) DeleteConfig(ctx context.Context, tenantID string)
 		return fmt.Errorf("shopify not configured for project %s and environment %s", projectID, environment)
 	}
 
-	// Delete configuration
+	// Delete the configuration of the app that was resolved
+	ctx = domain.WithAppHandle(ctx, config.AppHandle)
 	if err := s.configRepo.Delete(ctx, projectID); err != nil {
 		s.logger.Error().Err(err).Str("projectId", projectID).Str("environment", environment).Msg("Failed to delete Shopify configuration")
 		return fmt.Errorf("failed to delete Shopify configuration: %w", err)
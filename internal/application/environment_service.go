@@ -0,0 +1,281 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"archie-core-shopify-layer/internal/domain"
+	"archie-core-shopify-layer/internal/ports"
+
+	"github.com/rs/zerolog"
+)
+
+// EnvironmentService lists, clones and compares the environments of a project
+type EnvironmentService struct {
+	configRepo              ports.ShopifyConfigRepository
+	installationRepo        ports.InstallationRepository
+	webhookSubscriptionRepo ports.WebhookSubscriptionRepository
+	encryptionSvc           ports.EncryptionService
+	logger                  zerolog.Logger
+	webhookBaseURL          string
+}
+
+// NewEnvironmentService creates a new environment service
+func NewEnvironmentService(
+	configRepo ports.ShopifyConfigRepository,
+	installationRepo ports.InstallationRepository,
+	webhookSubscriptionRepo ports.WebhookSubscriptionRepository,
+	encryptionService ports.EncryptionService,
+	logger zerolog.Logger,
+	webhookBaseURL string,
+) *EnvironmentService {
+	return &EnvironmentService{
+		configRepo:              configRepo,
+		installationRepo:        installationRepo,
+		webhookSubscriptionRepo: webhookSubscriptionRepo,
+		encryptionSvc:           encryptionService,
+		logger:                  logger,
+		webhookBaseURL:          webhookBaseURL,
+	}
+}
+
+// ListEnvironments retrieves the environments of a project, whether they have an app configured or installations
+func (s *EnvironmentService) ListEnvironments(ctx context.Context, projectID string) ([]*domain.EnvironmentSummary, error) {
+	configs, err := s.configRepo.ListByProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Shopify configs: %w", err)
+	}
+	installedEnvironments, err := s.installationRepo.ListEnvironments(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := map[string]*domain.EnvironmentSummary{}
+	summary := func(name string) *domain.EnvironmentSummary {
+		if summaries[name] == nil {
+			summaries[name] = &domain.EnvironmentSummary{
+				Name:          name,
+				Apps:          []*domain.ShopifyConfig{},
+				InstallCounts: map[domain.InstallState]int{},
+			}
+		}
+		return summaries[name]
+	}
+	for _, config := range configs {
+		env := summary(config.Environment)
+		env.Apps = append(env.Apps, config)
+	}
+	for _, name := range installedEnvironments {
+		summary(name)
+	}
+
+	result := make([]*domain.EnvironmentSummary, 0, len(summaries))
+	for _, env := range summaries {
+		installations, err := s.installationRepo.List(ctx, projectID, env.Name, nil)
+		if err != nil {
+			return nil, err
+		}
+		// A shop with several apps installed counts once
+		activeShops := map[string]bool{}
+		for _, installation := range installations {
+			env.InstallCounts[installation.State]++
+			if installation.IsActive() {
+				activeShops[installation.ShopDomain] = true
+			}
+		}
+		env.ActiveShops = len(activeShops)
+		result = append(result, env)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+// CloneEnvironmentInput represents input for cloning an app configuration into another environment
+type CloneEnvironmentInput struct {
+	ProjectID         string
+	SourceEnvironment string
+	TargetEnvironment string
+	AppHandle         string // Empty selects the default app
+	APIKey            string // Required, API keys identify a single app and cannot be shared
+	APISecret         string // Required, secrets are never copied between environments
+}
+
+// CloneEnvironment copies an app configuration into a new environment
+// Secrets are not copied: the API secret is provided by the caller and a new webhook secret is generated
+func (s *EnvironmentService) CloneEnvironment(ctx context.Context, input CloneEnvironmentInput) (*domain.ShopifyConfig, error) {
+	if input.TargetEnvironment == "" || input.TargetEnvironment == input.SourceEnvironment {
+		return nil, fmt.Errorf("target environment must differ from the source environment")
+	}
+	if err := authorizeEnvironments(ctx, input.SourceEnvironment, input.TargetEnvironment); err != nil {
+		return nil, err
+	}
+	if input.APIKey == "" {
+		return nil, fmt.Errorf("apiKey is required, each environment needs its own Shopify app")
+	}
+	if input.APISecret == "" {
+		return nil, fmt.Errorf("apiSecret is required, secrets are not copied between environments")
+	}
+	appHandle := domain.NormalizeAppHandle(input.AppHandle)
+
+	sourceCtx := domain.WithAppHandle(domain.WithEnvironment(domain.WithProjectID(ctx, input.ProjectID), input.SourceEnvironment), appHandle)
+	source, err := s.configRepo.GetByTenantID(sourceCtx, input.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if source == nil {
+		return nil, fmt.Errorf("app %s is not configured in environment %s", appHandle, input.SourceEnvironment)
+	}
+
+	encryptedSecret, err := s.encryptionSvc.Encrypt(input.APISecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt API secret: %w", err)
+	}
+	webhookSecret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := domain.NewShopifyConfig(
+		input.ProjectID,
+		input.TargetEnvironment,
+		appHandle,
+		encryptedSecret,
+		input.APIKey,
+		webhookSecret,
+		buildWebhookURL(s.webhookBaseURL, input.ProjectID, input.TargetEnvironment, appHandle),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ShopifyConfig: %w", err)
+	}
+	config.ExpiringOfflineTokens = source.ExpiringOfflineTokens
+
+	// Create fails when the app is already configured in the target environment
+	if err := s.configRepo.Create(ctx, config); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().
+		Str("projectId", input.ProjectID).
+		Str("sourceEnvironment", input.SourceEnvironment).
+		Str("targetEnvironment", input.TargetEnvironment).
+		Str("appHandle", appHandle).
+		Msg("Cloned Shopify configuration into a new environment")
+	return config, nil
+}
+
+// CompareEnvironments compares the apps, webhook topics, requested scopes and installed shops of two environments
+func (s *EnvironmentService) CompareEnvironments(ctx context.Context, projectID string, source string, target string) (*domain.EnvironmentComparison, error) {
+	if err := authorizeEnvironments(ctx, source, target); err != nil {
+		return nil, err
+	}
+
+	configs, err := s.configRepo.ListByProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Shopify configs: %w", err)
+	}
+
+	sourceApps := map[string]*domain.ShopifyConfig{}
+	targetApps := map[string]*domain.ShopifyConfig{}
+	handles := []string{}
+	for _, config := range configs {
+		var apps map[string]*domain.ShopifyConfig
+		switch config.Environment {
+		case source:
+			apps = sourceApps
+		case target:
+			apps = targetApps
+		default:
+			continue
+		}
+		if sourceApps[config.AppHandle] == nil && targetApps[config.AppHandle] == nil {
+			handles = append(handles, config.AppHandle)
+		}
+		apps[config.AppHandle] = config
+	}
+	sort.Strings(handles)
+
+	comparison := &domain.EnvironmentComparison{
+		ProjectID: projectID,
+		Source:    source,
+		Target:    target,
+		Apps:      make([]domain.AppConfigDiff, 0, len(handles)),
+	}
+	for _, handle := range handles {
+		sourceApp, targetApp := sourceApps[handle], targetApps[handle]
+		diff := domain.AppConfigDiff{AppHandle: handle, InSource: sourceApp != nil, InTarget: targetApp != nil}
+		if sourceApp != nil && targetApp != nil {
+			diff.SameAPIKey = sourceApp.APIKey == targetApp.APIKey
+			diff.ExpiringOfflineTokensDiffer = sourceApp.ExpiringOfflineTokens != targetApp.ExpiringOfflineTokens
+		}
+		comparison.Apps = append(comparison.Apps, diff)
+	}
+
+	sourceTopics, sourceScopes, sourceShops, err := s.environmentState(ctx, projectID, source)
+	if err != nil {
+		return nil, err
+	}
+	targetTopics, targetScopes, targetShops, err := s.environmentState(ctx, projectID, target)
+	if err != nil {
+		return nil, err
+	}
+	comparison.Topics = domain.DiffStrings(sourceTopics, targetTopics)
+	comparison.Scopes = domain.DiffStrings(sourceScopes, targetScopes)
+	comparison.Shops = domain.DiffStrings(sourceShops, targetShops)
+
+	return comparison, nil
+}
+
+// authorizeEnvironments checks that the caller may act on every environment, see Principal.AllowsEnvironment
+func authorizeEnvironments(ctx context.Context, environments ...string) error {
+	principal := domain.GetPrincipalFromContext(ctx)
+	if principal == nil {
+		return nil
+	}
+	for _, environment := range environments {
+		if !principal.AllowsEnvironment(environment) {
+			return domain.NewUnauthorizedError(fmt.Sprintf("not authorized for environment %s", environment))
+		}
+	}
+	return nil
+}
+
+// environmentState returns the subscribed webhook topics, requested scopes and installed shops of an environment
+func (s *EnvironmentService) environmentState(ctx context.Context, projectID string, environment string) ([]string, []string, []string, error) {
+	subscriptions, err := s.webhookSubscriptionRepo.ListEnvironmentWebhookSubscriptions(ctx, projectID, environment)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	topics := make([]string, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		topics = append(topics, subscription.Topic)
+	}
+
+	installations, err := s.installationRepo.List(ctx, projectID, environment, nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	scopes := []string{}
+	shops := []string{}
+	for _, installation := range installations {
+		if !installation.IsActive() {
+			continue
+		}
+		scopes = append(scopes, installation.RequestedScopes...)
+		shops = append(shops, installation.ShopDomain)
+	}
+
+	return topics, scopes, shops, nil
+}
+
+// generateWebhookSecret generates a random webhook secret for a new configuration
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,146 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"archie-core-shopify-layer/internal/domain"
+	"archie-core-shopify-layer/internal/ports"
+
+	"github.com/rs/zerolog"
+)
+
+// InstallationService tracks the installation state machine of each shop per project/environment and app
+type InstallationService struct {
+	installationRepo ports.InstallationRepository
+	logger           zerolog.Logger
+}
+
+// NewInstallationService creates a new installation service
+func NewInstallationService(
+	installationRepo ports.InstallationRepository,
+	logger zerolog.Logger,
+) *InstallationService {
+	return &InstallationService{
+		installationRepo: installationRepo,
+		logger:           logger,
+	}
+}
+
+// StartInstall records that an OAuth install of an app was initiated for the shop
+func (s *InstallationService) StartInstall(ctx context.Context, key domain.ShopKey, requestedScopes []string) (*domain.Installation, error) {
+	installation, err := s.getOrNew(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	installation.RequestedScopes = requestedScopes
+	return s.transition(ctx, installation, domain.InstallStatePendingOAuth, "oauth_started")
+}
+
+// CompleteInstall records a completed OAuth or token exchange with the scopes that were granted
+// The shop lands in INSTALLED, REINSTALLED (after an uninstall) or SCOPES_OUTDATED (scopes missing)
+func (s *InstallationService) CompleteInstall(ctx context.Context, key domain.ShopKey, grantedScopes []string, reason string) (*domain.Installation, error) {
+	installation, err := s.getOrNew(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	installation.GrantedScopes = grantedScopes
+	if len(installation.RequestedScopes) == 0 {
+		// Installs that skipped OAuth initiation (e.g. token exchange) request what they were granted
+		installation.RequestedScopes = grantedScopes
+	}
+
+	return s.transition(ctx, installation, installation.CompletedState(), reason)
+}
+
+// UpdateGrantedScopes records a change of granted scopes (app/scopes_update webhook)
+func (s *InstallationService) UpdateGrantedScopes(ctx context.Context, key domain.ShopKey, grantedScopes []string) (*domain.Installation, error) {
+	installation, err := s.installationRepo.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get installation: %w", err)
+	}
+	if installation == nil || !installation.IsActive() {
+		// Scope changes only matter for active installs
+		return installation, nil
+	}
+
+	installation.GrantedScopes = grantedScopes
+	return s.transition(ctx, installation, installation.CompletedState(), "scopes_updated")
+}
+
+// MarkUninstalled records that an app was uninstalled from the shop, other apps of the environment stay installed
+func (s *InstallationService) MarkUninstalled(ctx context.Context, key domain.ShopKey) (*domain.Installation, error) {
+	installation, err := s.installationRepo.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get installation: %w", err)
+	}
+	if installation == nil {
+		s.logger.Warn().
+			Str("projectID", key.ProjectID).
+			Str("environment", key.Environment).
+			Str("appHandle", key.AppHandle).
+			Str("shopDomain", key.Domain).
+			Msg("Uninstall received for shop without installation record")
+		return nil, nil
+	}
+
+	installation.GrantedScopes = []string{}
+	return s.transition(ctx, installation, domain.InstallStateUninstalled, "app_uninstalled")
+}
+
+// GetInstallation retrieves the installation of an app in a shop
+func (s *InstallationService) GetInstallation(ctx context.Context, key domain.ShopKey) (*domain.Installation, error) {
+	installation, err := s.installationRepo.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get installation: %w", err)
+	}
+	return installation, nil
+}
+
+// ListInstallations retrieves the installations of a project/environment, optionally filtered by state
+func (s *InstallationService) ListInstallations(ctx context.Context, projectID, environment string, state *domain.InstallState) ([]*domain.Installation, error) {
+	installations, err := s.installationRepo.List(ctx, projectID, environment, state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list installations: %w", err)
+	}
+	return installations, nil
+}
+
+// getOrNew loads an installation or creates a new one that has not entered any state yet
+func (s *InstallationService) getOrNew(ctx context.Context, key domain.ShopKey) (*domain.Installation, error) {
+	installation, err := s.installationRepo.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get installation: %w", err)
+	}
+	if installation == nil {
+		installation = domain.NewInstallation(key)
+	}
+	return installation, nil
+}
+
+// transition applies a state change and persists the installation
+func (s *InstallationService) transition(ctx context.Context, installation *domain.Installation, to domain.InstallState, reason string) (*domain.Installation, error) {
+	from := installation.State
+	if err := installation.Transition(to, reason, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.installationRepo.Save(ctx, installation); err != nil {
+		return nil, fmt.Errorf("failed to save installation: %w", err)
+	}
+
+	s.logger.Info().
+		Str("projectID", installation.ProjectID).
+		Str("environment", installation.Environment).
+		Str("appHandle", installation.AppHandle).
+		Str("shopDomain", installation.ShopDomain).
+		Str("from", string(from)).
+		Str("to", string(to)).
+		Str("reason", reason).
+		Msg("Installation state changed")
+
+	return installation, nil
+}
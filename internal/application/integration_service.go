@@ -2,8 +2,6 @@ package application
 
 import (
 	"context"
-	"crypto/rand"
-	"encoding/hex"
 	"fmt"
 	"time"
 
@@ -13,6 +11,9 @@ import (
 	"github.com/rs/zerolog"
 )
 
+// integrationLastUsedResolution limits last-used writes to one per key per interval
+const integrationLastUsedResolution = time.Minute
+
 // IntegrationService handles integration key management
 type IntegrationService struct {
 	integrationRepo ports.IntegrationRepository
@@ -35,66 +36,191 @@ type CreateIntegrationInput struct {
 	ProjectID   string
 	Environment string
 	ShopDomain  string
+	AppHandle   string     // Empty selects the default app
+	Scopes      []string   // Allowed GraphQL fields and REST paths, nil keeps the scopes of a replaced key
+	ExpiresAt   *time.Time // Optional expiry
 }
 
-// CreateIntegration creates a new integration key for a project/environment/shop combination
+// CreateIntegration issues an integration key for a project/environment/shop combination
+// The returned integration holds the plaintext key, which is not stored and cannot be retrieved again
+// An existing key of the same shop is rotated, keeping it valid for DefaultKeyRotationOverlap
 func (s *IntegrationService) CreateIntegration(ctx context.Context, input CreateIntegrationInput) (*domain.Integration, error) {
+	if err := domain.ValidateKeyScopes(input.Scopes); err != nil {
+		return nil, err
+	}
+	appHandle := domain.NormalizeAppHandle(input.AppHandle)
+
 	// Check if integration already exists
-	existing, err := s.integrationRepo.GetByProjectAndShop(ctx, input.ProjectID, input.Environment, input.ShopDomain)
+	existing, err := s.integrationRepo.GetByProjectAndShop(ctx, input.ProjectID, input.Environment, input.ShopDomain, appHandle)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check existing integration: %w", err)
 	}
 
-	if existing != nil {
-		// Return existing integration
+	if existing != nil && existing.IsActive(time.Now()) {
 		s.logger.Info().
 			Str("projectID", input.ProjectID).
 			Str("environment", input.Environment).
 			Str("shopDomain", input.ShopDomain).
-			Str("key", existing.Key).
-			Msg("Integration already exists, returning existing key")
-		return existing, nil
-	}
-
-	// Generate unique integration key (32 bytes = 64 hex characters)
-	keyBytes := make([]byte, 32)
-	if _, err := rand.Read(keyBytes); err != nil {
-		return nil, fmt.Errorf("failed to generate integration key: %w", err)
+			Str("keyPrefix", existing.KeyPrefix).
+			Msg("Integration already exists, rotating its key")
+		return s.RotateIntegration(ctx, RotateIntegrationInput{
+			ID:        existing.ID,
+			Overlap:   domain.DefaultKeyRotationOverlap,
+			Scopes:    input.Scopes,
+			ExpiresAt: input.ExpiresAt,
+		})
 	}
-	key := hex.EncodeToString(keyBytes)
 
-	// Create integration
 	integration := &domain.Integration{
-		Key:         key,
 		ProjectID:   input.ProjectID,
 		Environment: input.Environment,
 		ShopDomain:  input.ShopDomain,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		AppHandle:   appHandle,
+		Scopes:      input.Scopes,
+		ExpiresAt:   input.ExpiresAt,
 	}
-
-	if err := s.integrationRepo.Create(ctx, integration); err != nil {
-		s.logger.Error().Err(err).Msg("Failed to create integration")
-		return nil, fmt.Errorf("failed to create integration: %w", err)
+	if err := s.issueKey(ctx, integration); err != nil {
+		return nil, err
 	}
 
 	s.logger.Info().
 		Str("projectID", input.ProjectID).
 		Str("environment", input.Environment).
 		Str("shopDomain", input.ShopDomain).
-		Str("key", key).
+		Str("appHandle", appHandle).
+		Str("keyPrefix", integration.KeyPrefix).
 		Msg("Created new integration")
 
 	return integration, nil
 }
 
-// GetIntegrationByKey retrieves an integration by its key
+// RotateIntegrationInput represents input for rotating an integration key
+type RotateIntegrationInput struct {
+	ID        string
+	Overlap   time.Duration // How long the old key keeps working, 0 revokes it immediately
+	Scopes    []string      // nil keeps the scopes of the old key
+	ExpiresAt *time.Time    // Expiry of the new key
+}
+
+// RotateIntegration issues a new key for an integration and retires the old one after the overlap window
+// The returned integration holds the new plaintext key
+func (s *IntegrationService) RotateIntegration(ctx context.Context, input RotateIntegrationInput) (*domain.Integration, error) {
+	if err := domain.ValidateKeyScopes(input.Scopes); err != nil {
+		return nil, err
+	}
+	if input.Overlap < 0 {
+		return nil, fmt.Errorf("rotation overlap cannot be negative")
+	}
+
+	old, err := s.getIntegration(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if !old.IsActive(now) {
+		return nil, fmt.Errorf("cannot rotate a %s integration key", old.Status(now))
+	}
+
+	scopes := old.Scopes
+	if input.Scopes != nil {
+		scopes = input.Scopes
+	}
+	rotated := &domain.Integration{
+		ProjectID:   old.ProjectID,
+		Environment: old.Environment,
+		ShopDomain:  old.ShopDomain,
+		AppHandle:   old.AppHandle,
+		Scopes:      scopes,
+		ExpiresAt:   input.ExpiresAt,
+	}
+	if err := s.issueKey(ctx, rotated); err != nil {
+		return nil, err
+	}
+
+	// Retire the old key at the end of the overlap, unless it already expires earlier
+	old.RotatedToID = rotated.ID
+	if input.Overlap == 0 {
+		old.RevokedAt = &now
+	} else if retireAt := now.Add(input.Overlap); old.ExpiresAt == nil || retireAt.Before(*old.ExpiresAt) {
+		old.ExpiresAt = &retireAt
+	}
+	if err := s.integrationRepo.Update(ctx, old); err != nil {
+		return nil, fmt.Errorf("failed to retire rotated integration key: %w", err)
+	}
+
+	s.logger.Info().
+		Str("projectID", old.ProjectID).
+		Str("environment", old.Environment).
+		Str("oldKeyPrefix", old.KeyPrefix).
+		Str("newKeyPrefix", rotated.KeyPrefix).
+		Dur("overlap", input.Overlap).
+		Msg("Rotated integration key")
+
+	return rotated, nil
+}
+
+// RevokeIntegration revokes an integration key immediately
+func (s *IntegrationService) RevokeIntegration(ctx context.Context, id string) (*domain.Integration, error) {
+	integration, err := s.getIntegration(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if integration.RevokedAt != nil {
+		return integration, nil
+	}
+
+	now := time.Now()
+	integration.RevokedAt = &now
+	if err := s.integrationRepo.Update(ctx, integration); err != nil {
+		return nil, fmt.Errorf("failed to revoke integration: %w", err)
+	}
+
+	s.logger.Info().Str("keyPrefix", integration.KeyPrefix).Msg("Revoked integration key")
+	return integration, nil
+}
+
+// AuthenticateKey resolves the integration of a key and checks it can authenticate requests
+// Keys stored in plaintext before hashing are upgraded on first use
+func (s *IntegrationService) AuthenticateKey(ctx context.Context, key string) (*domain.Integration, error) {
+	integration, err := s.GetIntegrationByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if status := integration.Status(now); status != domain.IntegrationStatusActive {
+		return nil, fmt.Errorf("integration key is %s", status)
+	}
+
+	if integration.LastUsedAt == nil || now.Sub(*integration.LastUsedAt) >= integrationLastUsedResolution {
+		if err := s.integrationRepo.TouchLastUsed(ctx, integration.ID, now); err != nil {
+			// Authentication still succeeds, last use is informational
+			s.logger.Warn().Err(err).Str("keyPrefix", integration.KeyPrefix).Msg("Failed to record integration key use")
+		}
+		integration.LastUsedAt = &now
+	}
+
+	return integration, nil
+}
+
+// GetIntegrationByKey retrieves an integration by its key, whatever its status
 func (s *IntegrationService) GetIntegrationByKey(ctx context.Context, key string) (*domain.Integration, error) {
-	integration, err := s.integrationRepo.GetByKey(ctx, key)
+	keyHash := domain.HashIntegrationKey(key)
+	integration, err := s.integrationRepo.GetByKeyHash(ctx, keyHash)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get integration: %w", err)
 	}
 
+	if integration == nil {
+		integration, err = s.integrationRepo.UpgradeLegacyKey(ctx, key, keyHash, domain.IntegrationKeyVisiblePrefix(key))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get integration: %w", err)
+		}
+		if integration != nil {
+			s.logger.Info().Str("keyPrefix", integration.KeyPrefix).Msg("Upgraded plaintext integration key to a hash")
+		}
+	}
+
 	if integration == nil {
 		return nil, fmt.Errorf("integration not found")
 	}
@@ -102,14 +228,62 @@ func (s *IntegrationService) GetIntegrationByKey(ctx context.Context, key string
 	return integration, nil
 }
 
+// GetIntegration retrieves an integration by its ID
+func (s *IntegrationService) GetIntegration(ctx context.Context, id string) (*domain.Integration, error) {
+	return s.getIntegration(ctx, id)
+}
+
+// ListIntegrations retrieves the integrations of a project environment
+func (s *IntegrationService) ListIntegrations(ctx context.Context, projectID string, environment string) ([]*domain.Integration, error) {
+	integrations, err := s.integrationRepo.List(ctx, projectID, environment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list integrations: %w", err)
+	}
+	return integrations, nil
+}
+
 // DeleteIntegration deletes an integration by key
 func (s *IntegrationService) DeleteIntegration(ctx context.Context, key string) error {
-	err := s.integrationRepo.Delete(ctx, key)
+	integration, err := s.GetIntegrationByKey(ctx, key)
 	if err != nil {
+		return err
+	}
+
+	if err := s.integrationRepo.Delete(ctx, integration.ID); err != nil {
 		return fmt.Errorf("failed to delete integration: %w", err)
 	}
 
-	s.logger.Info().Str("key", key).Msg("Deleted integration")
+	s.logger.Info().Str("keyPrefix", integration.KeyPrefix).Msg("Deleted integration")
+	return nil
+}
+
+// issueKey generates a key for a new integration and stores its hash
+func (s *IntegrationService) issueKey(ctx context.Context, integration *domain.Integration) error {
+	key, prefix, hash, err := domain.GenerateIntegrationKey()
+	if err != nil {
+		return err
+	}
+	integration.Key = key
+	integration.KeyPrefix = prefix
+	integration.KeyHash = hash
+	integration.CreatedAt = time.Now()
+	integration.UpdatedAt = integration.CreatedAt
+
+	if err := s.integrationRepo.Create(ctx, integration); err != nil {
+		s.logger.Error().Err(err).Msg("Failed to create integration")
+		return fmt.Errorf("failed to create integration: %w", err)
+	}
 	return nil
 }
 
+// getIntegration retrieves an integration by ID, failing when it does not exist
+func (s *IntegrationService) getIntegration(ctx context.Context, id string) (*domain.Integration, error) {
+	integration, err := s.integrationRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get integration: %w", err)
+	}
+	if integration == nil {
+		return nil, fmt.Errorf("integration not found")
+	}
+	return integration, nil
+}
@@ -0,0 +1,246 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"archie-core-shopify-layer/internal/domain"
+	"archie-core-shopify-layer/internal/ports"
+
+	"github.com/rs/zerolog"
+)
+
+// rateLimitWindow is the fixed window of per-minute limits
+const rateLimitWindow = time.Minute
+
+// RateLimitDecision is the outcome of checking a request against the limits of its project
+type RateLimitDecision struct {
+	Allowed    bool
+	Limit      int           // Per-minute limit that applied, 0 when unlimited
+	Remaining  int           // Requests left in the current window
+	ResetAt    time.Time     // End of the current window
+	RetryAfter time.Duration // Set when the request is refused
+	Reason     string        // Which limit refused the request
+}
+
+// rateLimitCheck is a per-minute limit applied to a usage subject
+type rateLimitCheck struct {
+	subject string
+	limit   int
+	reason  string
+}
+
+// RateLimitService enforces per-project and per-integration-key limits on inbound requests and accounts usage
+type RateLimitService struct {
+	usageRepo    ports.UsageRepository
+	tiers        map[string]domain.RateLimitTier
+	projectTiers map[string]string // project ID -> tier name
+	defaultTier  string
+	logger       zerolog.Logger
+	now          func() time.Time
+}
+
+// NewRateLimitService creates a new rate limit service
+func NewRateLimitService(
+	usageRepo ports.UsageRepository,
+	tiers map[string]domain.RateLimitTier,
+	projectTiers map[string]string,
+	defaultTier string,
+	logger zerolog.Logger,
+) (*RateLimitService, error) {
+	if _, ok := tiers[defaultTier]; !ok {
+		return nil, fmt.Errorf("unknown default rate limit tier: %s", defaultTier)
+	}
+	for projectID, tier := range projectTiers {
+		if _, ok := tiers[tier]; !ok {
+			return nil, fmt.Errorf("unknown rate limit tier %s for project %s", tier, projectID)
+		}
+	}
+
+	return &RateLimitService{
+		usageRepo:    usageRepo,
+		tiers:        tiers,
+		projectTiers: projectTiers,
+		defaultTier:  defaultTier,
+		logger:       logger,
+		now:          time.Now,
+	}, nil
+}
+
+// TierForProject returns the rate limit tier of a project
+func (s *RateLimitService) TierForProject(projectID string) domain.RateLimitTier {
+	if name, ok := s.projectTiers[projectID]; ok {
+		return s.tiers[name]
+	}
+	return s.tiers[s.defaultTier]
+}
+
+// Allow counts a request of a principal and checks it against its per-minute limits and quotas
+func (s *RateLimitService) Allow(ctx context.Context, principal *domain.Principal) (*RateLimitDecision, error) {
+	now := s.now().UTC()
+	tier := s.TierForProject(principal.ProjectID)
+	resetAt := now.Truncate(rateLimitWindow).Add(rateLimitWindow)
+	decision := &RateLimitDecision{Allowed: true, ResetAt: resetAt}
+
+	subjects := []string{domain.UsageSubjectForProject(principal.ProjectID)}
+	if principal.Type == domain.PrincipalTypeIntegrationKey {
+		subjects = append(subjects, domain.UsageSubjectForIntegration(principal.Subject))
+	}
+
+	window := strconv.FormatInt(now.Unix()/int64(rateLimitWindow/time.Second), 10)
+	limits := []rateLimitCheck{{subjects[0], tier.RequestsPerMinute, "project rate limit exceeded"}}
+	if len(subjects) > 1 {
+		limits = append(limits, rateLimitCheck{subjects[1], tier.KeyRequestsPerMinute, "integration key rate limit exceeded"})
+	}
+
+	for _, l := range limits {
+		if l.limit <= 0 {
+			continue
+		}
+		count, err := s.usageRepo.IncrementWindow(ctx, l.subject+":"+window, 2*rateLimitWindow)
+		if err != nil {
+			return nil, err
+		}
+		remaining := l.limit - int(count)
+		if remaining < 0 {
+			remaining = 0
+		}
+		// Report the tightest limit
+		if decision.Limit == 0 || remaining < decision.Remaining {
+			decision.Limit = l.limit
+			decision.Remaining = remaining
+		}
+		if count > int64(l.limit) {
+			return s.refuse(decision, resetAt.Sub(now), l.reason), nil
+		}
+	}
+
+	// Quotas are counted first and then compared, like the windows above, so concurrent requests cannot overshoot them
+	// A request refused by a quota still counts, as one refused by a window does
+	daily, monthly, err := s.usageRepo.IncrementUsage(ctx, subjects[0], now)
+	if err != nil {
+		if tier.DailyQuota > 0 || tier.MonthlyQuota > 0 {
+			return nil, err
+		}
+		// Without quotas the request is still served, usage accounting is best effort
+		s.logger.Warn().Err(err).Str("subject", subjects[0]).Msg("Failed to record API usage")
+	}
+	if tier.MonthlyQuota > 0 && monthly > tier.MonthlyQuota {
+		return s.refuse(decision, domain.NextUsageMonth(now).Sub(now), "monthly quota exceeded"), nil
+	}
+	if tier.DailyQuota > 0 && daily > tier.DailyQuota {
+		return s.refuse(decision, domain.NextUsageDay(now).Sub(now), "daily quota exceeded"), nil
+	}
+
+	for _, subject := range subjects[1:] {
+		if _, _, err := s.usageRepo.IncrementUsage(ctx, subject, now); err != nil {
+			// The request is still served, usage accounting is best effort
+			s.logger.Warn().Err(err).Str("subject", subject).Msg("Failed to record API usage")
+		}
+	}
+
+	return decision, nil
+}
+
+// refuse marks a decision as refused
+func (s *RateLimitService) refuse(decision *RateLimitDecision, retryAfter time.Duration, reason string) *RateLimitDecision {
+	decision.Allowed = false
+	decision.Remaining = 0
+	decision.RetryAfter = retryAfter
+	decision.Reason = reason
+	return decision
+}
+
+// GetUsage returns the daily and monthly usage of a project, or of one of its integration keys when integrationID is set
+func (s *RateLimitService) GetUsage(ctx context.Context, projectID string, integrationID string) (*domain.APIUsage, error) {
+	now := s.now().UTC()
+	tier := s.TierForProject(projectID)
+
+	subject := domain.UsageSubjectForProject(projectID)
+	if integrationID != "" {
+		subject = domain.UsageSubjectForIntegration(integrationID)
+	}
+	daily, monthly, err := s.usageRepo.GetUsage(ctx, subject, now)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &domain.APIUsage{
+		ProjectID:     projectID,
+		IntegrationID: integrationID,
+		Tier:          tier,
+		Daily: domain.UsageCounter{
+			Period:   domain.UsagePeriodDay,
+			Used:     daily,
+			ResetsAt: domain.NextUsageDay(now),
+		},
+		Monthly: domain.UsageCounter{
+			Period:   domain.UsagePeriodMonth,
+			Used:     monthly,
+			ResetsAt: domain.NextUsageMonth(now),
+		},
+	}
+	// Quotas apply to projects, keys only report their share
+	if integrationID == "" {
+		usage.Daily.Limit = tier.DailyQuota
+		usage.Monthly.Limit = tier.MonthlyQuota
+	}
+	return usage, nil
+}
+
+// ParseRateLimitTiers parses custom tiers "name:perMinute:keyPerMinute:daily:monthly" separated by commas,
+// as set in RATE_LIMIT_TIERS, and merges them over the built-in tiers
+func ParseRateLimitTiers(value string) (map[string]domain.RateLimitTier, error) {
+	tiers := make(map[string]domain.RateLimitTier, len(domain.DefaultRateLimitTiers))
+	for name, tier := range domain.DefaultRateLimitTiers {
+		tiers[name] = tier
+	}
+
+	for i, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.Split(entry, ":")
+		if len(parts) != 5 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid rate limit tier entry %d, expected name:perMinute:keyPerMinute:daily:monthly", i+1)
+		}
+
+		var numbers [4]int64
+		for j, part := range parts[1:] {
+			n, err := strconv.ParseInt(part, 10, 64)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid rate limit tier entry %d: %q is not a non-negative number", i+1, part)
+			}
+			numbers[j] = n
+		}
+		tiers[parts[0]] = domain.RateLimitTier{
+			Name:                 parts[0],
+			RequestsPerMinute:    int(numbers[0]),
+			KeyRequestsPerMinute: int(numbers[1]),
+			DailyQuota:           numbers[2],
+			MonthlyQuota:         numbers[3],
+		}
+	}
+	return tiers, nil
+}
+
+// ParseProjectTiers parses "projectId:tier" pairs separated by commas, as set in RATE_LIMIT_PROJECT_TIERS
+func ParseProjectTiers(value string) (map[string]string, error) {
+	projectTiers := map[string]string{}
+	for i, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		projectID, tier, ok := strings.Cut(pair, ":")
+		if !ok || projectID == "" || tier == "" {
+			return nil, fmt.Errorf("invalid project tier entry %d, expected projectId:tier", i+1)
+		}
+		projectTiers[projectID] = tier
+	}
+	return projectTiers, nil
+}
@@ -0,0 +1,70 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"archie-core-shopify-layer/internal/ports"
+
+	"github.com/rs/zerolog"
+)
+
+// DefaultSessionCleanupInterval is how often expired OAuth sessions are purged
+const DefaultSessionCleanupInterval = 15 * time.Minute
+
+// SessionCleanupScheduler periodically removes expired OAuth sessions
+// It complements the MongoDB TTL index, whose monitor only runs about once a minute and can lag under load
+type SessionCleanupScheduler struct {
+	sessionRepo ports.SessionRepository
+	interval    time.Duration
+	logger      zerolog.Logger
+}
+
+// NewSessionCleanupScheduler creates a new session cleanup scheduler
+func NewSessionCleanupScheduler(
+	sessionRepo ports.SessionRepository,
+	interval time.Duration,
+	logger zerolog.Logger,
+) *SessionCleanupScheduler {
+	if interval <= 0 {
+		interval = DefaultSessionCleanupInterval
+	}
+	return &SessionCleanupScheduler{
+		sessionRepo: sessionRepo,
+		interval:    interval,
+		logger:      logger,
+	}
+}
+
+// Start runs the cleanup loop in the background until ctx is cancelled
+func (s *SessionCleanupScheduler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.logger.Info().Dur("interval", s.interval).Msg("Session cleanup scheduler started")
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info().Msg("Session cleanup scheduler stopped")
+				return
+			case <-ticker.C:
+				s.runOnce(ctx)
+			}
+		}
+	}()
+}
+
+// runOnce purges expired sessions once
+func (s *SessionCleanupScheduler) runOnce(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if err := s.sessionRepo.CleanupExpiredSessions(ctx); err != nil {
+		s.logger.Error().Err(err).Msg("Failed to cleanup expired sessions")
+		return
+	}
+
+	s.logger.Debug().Msg("Expired sessions cleaned up")
+}
@@ -0,0 +1,243 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+)
+
+// collectListOptions filters collects by collection and product
+type collectListOptions struct {
+	CollectionID uint64 `url:"collection_id,omitempty"`
+	ProductID    uint64 `url:"product_id,omitempty"`
+	Limit        int    `url:"limit,omitempty"`
+}
+
+// ListCustomCollections retrieves a page of custom collections for a shop
+func (s *ShopifyService) ListCustomCollections(ctx context.Context, domain string, options interface{}) ([]goshopify.CustomCollection, *goshopify.Pagination, error) {
+	client, accessToken, err := s.shopClient(ctx, domain)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	collections, pagination, err := client.ListCustomCollections(ctx, domain, accessToken, options)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", domain).Msg("Failed to list custom collections")
+		return nil, nil, fmt.Errorf("failed to list custom collections: %w", err)
+	}
+
+	return collections, pagination, nil
+}
+
+// ListSmartCollections retrieves a page of smart collections for a shop
+func (s *ShopifyService) ListSmartCollections(ctx context.Context, domain string, options interface{}) ([]goshopify.SmartCollection, *goshopify.Pagination, error) {
+	client, accessToken, err := s.shopClient(ctx, domain)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	collections, pagination, err := client.ListSmartCollections(ctx, domain, accessToken, options)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", domain).Msg("Failed to list smart collections")
+		return nil, nil, fmt.Errorf("failed to list smart collections: %w", err)
+	}
+
+	return collections, pagination, nil
+}
+
+// GetCustomCollection retrieves a custom collection by ID
+func (s *ShopifyService) GetCustomCollection(ctx context.Context, domain string, collectionID int64) (*goshopify.CustomCollection, error) {
+	client, accessToken, err := s.shopClient(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	collection, err := client.GetCustomCollection(ctx, domain, accessToken, collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custom collection: %w", err)
+	}
+
+	return collection, nil
+}
+
+// GetSmartCollection retrieves a smart collection by ID
+func (s *ShopifyService) GetSmartCollection(ctx context.Context, domain string, collectionID int64) (*goshopify.SmartCollection, error) {
+	client, accessToken, err := s.shopClient(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	collection, err := client.GetSmartCollection(ctx, domain, accessToken, collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get smart collection: %w", err)
+	}
+
+	return collection, nil
+}
+
+// CreateCustomCollection creates a custom collection and adds products to it
+func (s *ShopifyService) CreateCustomCollection(ctx context.Context, domain string, collection *goshopify.CustomCollection, productIDs []int64) (*goshopify.CustomCollection, error) {
+	client, accessToken, err := s.shopClient(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := client.CreateCustomCollection(ctx, domain, accessToken, collection)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", domain).Msg("Failed to create custom collection")
+		return nil, fmt.Errorf("failed to create custom collection: %w", err)
+	}
+
+	if _, err := s.AddProductsToCollection(ctx, domain, int64(created.Id), productIDs); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// UpdateCustomCollection updates a custom collection
+func (s *ShopifyService) UpdateCustomCollection(ctx context.Context, domain string, collection *goshopify.CustomCollection) (*goshopify.CustomCollection, error) {
+	client, accessToken, err := s.shopClient(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := client.UpdateCustomCollection(ctx, domain, accessToken, collection)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", domain).Uint64("collectionID", collection.Id).Msg("Failed to update custom collection")
+		return nil, fmt.Errorf("failed to update custom collection: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteCustomCollection deletes a custom collection
+func (s *ShopifyService) DeleteCustomCollection(ctx context.Context, domain string, collectionID int64) error {
+	client, accessToken, err := s.shopClient(ctx, domain)
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeleteCustomCollection(ctx, domain, accessToken, collectionID); err != nil {
+		s.logger.Error().Err(err).Str("domain", domain).Int64("collectionID", collectionID).Msg("Failed to delete custom collection")
+		return fmt.Errorf("failed to delete custom collection: %w", err)
+	}
+
+	return nil
+}
+
+// CreateSmartCollection creates a smart collection
+func (s *ShopifyService) CreateSmartCollection(ctx context.Context, domain string, collection *goshopify.SmartCollection) (*goshopify.SmartCollection, error) {
+	client, accessToken, err := s.shopClient(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := client.CreateSmartCollection(ctx, domain, accessToken, collection)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", domain).Msg("Failed to create smart collection")
+		return nil, fmt.Errorf("failed to create smart collection: %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateSmartCollection updates a smart collection
+func (s *ShopifyService) UpdateSmartCollection(ctx context.Context, domain string, collection *goshopify.SmartCollection) (*goshopify.SmartCollection, error) {
+	client, accessToken, err := s.shopClient(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := client.UpdateSmartCollection(ctx, domain, accessToken, collection)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", domain).Uint64("collectionID", collection.Id).Msg("Failed to update smart collection")
+		return nil, fmt.Errorf("failed to update smart collection: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteSmartCollection deletes a smart collection
+func (s *ShopifyService) DeleteSmartCollection(ctx context.Context, domain string, collectionID int64) error {
+	client, accessToken, err := s.shopClient(ctx, domain)
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeleteSmartCollection(ctx, domain, accessToken, collectionID); err != nil {
+		s.logger.Error().Err(err).Str("domain", domain).Int64("collectionID", collectionID).Msg("Failed to delete smart collection")
+		return fmt.Errorf("failed to delete smart collection: %w", err)
+	}
+
+	return nil
+}
+
+// GetCollectionProducts retrieves a page of the products of a collection, in the collection's sort order
+func (s *ShopifyService) GetCollectionProducts(ctx context.Context, domain string, collectionID int64, options interface{}) ([]goshopify.Product, *goshopify.Pagination, error) {
+	client, accessToken, err := s.shopClient(ctx, domain)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	products, pagination, err := client.ListCollectionProducts(ctx, domain, accessToken, collectionID, options)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", domain).Int64("collectionID", collectionID).Msg("Failed to get collection products")
+		return nil, nil, fmt.Errorf("failed to get collection products: %w", err)
+	}
+
+	return products, pagination, nil
+}
+
+// AddProductsToCollection adds products to a custom collection, one collect per product
+func (s *ShopifyService) AddProductsToCollection(ctx context.Context, domain string, collectionID int64, productIDs []int64) ([]goshopify.Collect, error) {
+	if len(productIDs) == 0 {
+		return []goshopify.Collect{}, nil
+	}
+
+	client, accessToken, err := s.shopClient(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	collects := make([]goshopify.Collect, 0, len(productIDs))
+	for _, productID := range productIDs {
+		collect := &goshopify.Collect{CollectionId: uint64(collectionID), ProductId: uint64(productID)}
+		created, err := client.CreateCollect(ctx, domain, accessToken, collect)
+		if err != nil {
+			s.logger.Error().Err(err).Str("domain", domain).Int64("collectionID", collectionID).Int64("productID", productID).Msg("Failed to add product to collection")
+			return nil, fmt.Errorf("failed to add product %d to collection: %w", productID, err)
+		}
+		collects = append(collects, *created)
+	}
+
+	return collects, nil
+}
+
+// RemoveProductsFromCollection removes products from a custom collection and returns how many were removed
+// Products that are not in the collection are skipped
+func (s *ShopifyService) RemoveProductsFromCollection(ctx context.Context, domain string, collectionID int64, productIDs []int64) (int, error) {
+	client, accessToken, err := s.shopClient(ctx, domain)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, productID := range productIDs {
+		options := collectListOptions{CollectionID: uint64(collectionID), ProductID: uint64(productID), Limit: 1}
+		collects, err := client.ListCollects(ctx, domain, accessToken, options)
+		if err != nil {
+			return removed, fmt.Errorf("failed to find product %d in collection: %w", productID, err)
+		}
+		for _, collect := range collects {
+			if err := client.DeleteCollect(ctx, domain, accessToken, int64(collect.Id)); err != nil {
+				s.logger.Error().Err(err).Str("domain", domain).Int64("collectionID", collectionID).Int64("productID", productID).Msg("Failed to remove product from collection")
+				return removed, fmt.Errorf("failed to remove product %d from collection: %w", productID, err)
+			}
+			removed++
+		}
+	}
+
+	return removed, nil
+}
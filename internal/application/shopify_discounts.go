@@ -0,0 +1,261 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"archie-core-shopify-layer/internal/domain"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+)
+
+// discountCodeBatchPollInterval is how often WaitForDiscountCodeBatch checks a batch
+const discountCodeBatchPollInterval = time.Second
+
+// ListPriceRules retrieves a page of price rules for a shop
+func (s *ShopifyService) ListPriceRules(ctx context.Context, shopDomain string, options interface{}) ([]goshopify.PriceRule, *goshopify.Pagination, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	priceRules, pagination, err := client.ListPriceRules(ctx, shopDomain, accessToken, options)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Msg("Failed to list price rules")
+		return nil, nil, fmt.Errorf("failed to list price rules: %w", err)
+	}
+
+	return priceRules, pagination, nil
+}
+
+// GetPriceRule retrieves a price rule by ID
+func (s *ShopifyService) GetPriceRule(ctx context.Context, shopDomain string, priceRuleID int64) (*goshopify.PriceRule, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	priceRule, err := client.GetPriceRule(ctx, shopDomain, accessToken, priceRuleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price rule: %w", err)
+	}
+
+	return priceRule, nil
+}
+
+// CreatePriceRule creates a price rule
+func (s *ShopifyService) CreatePriceRule(ctx context.Context, shopDomain string, priceRule *goshopify.PriceRule) (*goshopify.PriceRule, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := client.CreatePriceRule(ctx, shopDomain, accessToken, priceRule)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Msg("Failed to create price rule")
+		return nil, fmt.Errorf("failed to create price rule: %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdatePriceRule updates a price rule
+func (s *ShopifyService) UpdatePriceRule(ctx context.Context, shopDomain string, priceRule *goshopify.PriceRule) (*goshopify.PriceRule, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := client.UpdatePriceRule(ctx, shopDomain, accessToken, priceRule)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Uint64("priceRuleID", priceRule.Id).Msg("Failed to update price rule")
+		return nil, fmt.Errorf("failed to update price rule: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeletePriceRule deletes a price rule and its discount codes
+func (s *ShopifyService) DeletePriceRule(ctx context.Context, shopDomain string, priceRuleID int64) error {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeletePriceRule(ctx, shopDomain, accessToken, priceRuleID); err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Int64("priceRuleID", priceRuleID).Msg("Failed to delete price rule")
+		return fmt.Errorf("failed to delete price rule: %w", err)
+	}
+
+	return nil
+}
+
+// ListDiscountCodes retrieves a page of the discount codes of a price rule
+func (s *ShopifyService) ListDiscountCodes(ctx context.Context, shopDomain string, priceRuleID int64, options interface{}) ([]goshopify.PriceRuleDiscountCode, *goshopify.Pagination, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	codes, pagination, err := client.ListDiscountCodes(ctx, shopDomain, accessToken, priceRuleID, options)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Int64("priceRuleID", priceRuleID).Msg("Failed to list discount codes")
+		return nil, nil, fmt.Errorf("failed to list discount codes: %w", err)
+	}
+
+	return codes, pagination, nil
+}
+
+// CreateDiscountCode creates a discount code for a price rule
+func (s *ShopifyService) CreateDiscountCode(ctx context.Context, shopDomain string, priceRuleID int64, code string) (*goshopify.PriceRuleDiscountCode, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := client.CreateDiscountCode(ctx, shopDomain, accessToken, priceRuleID, &goshopify.PriceRuleDiscountCode{Code: code})
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Int64("priceRuleID", priceRuleID).Msg("Failed to create discount code")
+		return nil, fmt.Errorf("failed to create discount code: %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateDiscountCode changes the code of a discount code
+func (s *ShopifyService) UpdateDiscountCode(ctx context.Context, shopDomain string, priceRuleID int64, discountCode *goshopify.PriceRuleDiscountCode) (*goshopify.PriceRuleDiscountCode, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := client.UpdateDiscountCode(ctx, shopDomain, accessToken, priceRuleID, discountCode)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Uint64("discountCodeID", discountCode.Id).Msg("Failed to update discount code")
+		return nil, fmt.Errorf("failed to update discount code: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteDiscountCode deletes a discount code
+func (s *ShopifyService) DeleteDiscountCode(ctx context.Context, shopDomain string, priceRuleID int64, discountCodeID int64) error {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeleteDiscountCode(ctx, shopDomain, accessToken, priceRuleID, discountCodeID); err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Int64("discountCodeID", discountCodeID).Msg("Failed to delete discount code")
+		return fmt.Errorf("failed to delete discount code: %w", err)
+	}
+
+	return nil
+}
+
+// LookupDiscountCode finds a discount code by its code
+func (s *ShopifyService) LookupDiscountCode(ctx context.Context, shopDomain string, code string) (*goshopify.PriceRuleDiscountCode, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	discountCode, err := client.LookupDiscountCode(ctx, shopDomain, accessToken, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up discount code: %w", err)
+	}
+
+	return discountCode, nil
+}
+
+// CountDiscountCodes counts the discount codes of a shop, filtered by usage
+func (s *ShopifyService) CountDiscountCodes(ctx context.Context, shopDomain string, options interface{}) (int, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := client.CountDiscountCodes(ctx, shopDomain, accessToken, options)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count discount codes: %w", err)
+	}
+
+	return count, nil
+}
+
+// CreateDiscountCodeBatch starts a Shopify job creating up to 100 discount codes for a price rule
+func (s *ShopifyService) CreateDiscountCodeBatch(ctx context.Context, shopDomain string, priceRuleID int64, codes []string) (*domain.DiscountCodeBatch, error) {
+	if len(codes) == 0 || len(codes) > domain.MaxDiscountCodeBatchSize {
+		return nil, domain.NewValidationError(fmt.Sprintf("a discount code batch must have between 1 and %d codes", domain.MaxDiscountCodeBatchSize), nil).
+			WithFieldError("codes", fmt.Sprintf("must have between 1 and %d codes", domain.MaxDiscountCodeBatchSize))
+	}
+
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	batch, err := client.CreateDiscountCodeBatch(ctx, shopDomain, accessToken, priceRuleID, codes)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Int64("priceRuleID", priceRuleID).Msg("Failed to create discount code batch")
+		return nil, fmt.Errorf("failed to create discount code batch: %w", err)
+	}
+
+	s.logger.Info().Str("domain", shopDomain).Int64("priceRuleID", priceRuleID).Uint64("batchID", batch.ID).Int("codes", len(codes)).Msg("Discount code batch created")
+	return batch, nil
+}
+
+// GetDiscountCodeBatch retrieves the status of a discount code batch
+func (s *ShopifyService) GetDiscountCodeBatch(ctx context.Context, shopDomain string, priceRuleID int64, batchID int64) (*domain.DiscountCodeBatch, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	batch, err := client.GetDiscountCodeBatch(ctx, shopDomain, accessToken, priceRuleID, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get discount code batch: %w", err)
+	}
+
+	return batch, nil
+}
+
+// WaitForDiscountCodeBatch polls a discount code batch until Shopify completes it or timeout elapses
+// It returns the last status seen, which is not done when the timeout elapsed first
+func (s *ShopifyService) WaitForDiscountCodeBatch(ctx context.Context, shopDomain string, priceRuleID int64, batchID int64, timeout time.Duration) (*domain.DiscountCodeBatch, error) {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(discountCodeBatchPollInterval)
+	defer ticker.Stop()
+
+	for {
+		batch, err := s.GetDiscountCodeBatch(ctx, shopDomain, priceRuleID, batchID)
+		if err != nil || batch.Done() {
+			return batch, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-deadline.C:
+			return batch, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetDiscountCodeBatchCodes retrieves the codes of a discount code batch and why any of them failed
+func (s *ShopifyService) GetDiscountCodeBatchCodes(ctx context.Context, shopDomain string, priceRuleID int64, batchID int64) ([]domain.DiscountCodeBatchCode, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := client.ListDiscountCodeBatchCodes(ctx, shopDomain, accessToken, priceRuleID, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get discount code batch codes: %w", err)
+	}
+
+	return codes, nil
+}
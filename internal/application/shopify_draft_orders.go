@@ -0,0 +1,150 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+)
+
+// ListDraftOrders retrieves a page of draft orders for a shop
+func (s *ShopifyService) ListDraftOrders(ctx context.Context, shopDomain string, options interface{}) ([]goshopify.DraftOrder, *goshopify.Pagination, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	draftOrders, pagination, err := client.ListDraftOrders(ctx, shopDomain, accessToken, options)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Msg("Failed to list draft orders")
+		return nil, nil, fmt.Errorf("failed to list draft orders: %w", err)
+	}
+
+	return draftOrders, pagination, nil
+}
+
+// GetDraftOrder retrieves a draft order by ID
+func (s *ShopifyService) GetDraftOrder(ctx context.Context, shopDomain string, draftOrderID int64) (*goshopify.DraftOrder, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	draftOrder, err := client.GetDraftOrder(ctx, shopDomain, accessToken, draftOrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get draft order: %w", err)
+	}
+
+	return draftOrder, nil
+}
+
+// CreateDraftOrder creates a draft order, Shopify computes its taxes, discounts and totals
+func (s *ShopifyService) CreateDraftOrder(ctx context.Context, shopDomain string, draftOrder *goshopify.DraftOrder) (*goshopify.DraftOrder, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := client.CreateDraftOrder(ctx, shopDomain, accessToken, draftOrder)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Msg("Failed to create draft order")
+		return nil, fmt.Errorf("failed to create draft order: %w", err)
+	}
+
+	s.logger.Info().Str("domain", shopDomain).Uint64("draftOrderID", created.Id).Int("lineItems", len(created.LineItems)).Msg("Draft order created")
+	return created, nil
+}
+
+// UpdateDraftOrder updates a draft order
+// The applied discount and shipping line of draftOrder replace the current ones, nil removes them
+func (s *ShopifyService) UpdateDraftOrder(ctx context.Context, shopDomain string, draftOrder *goshopify.DraftOrder) (*goshopify.DraftOrder, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := client.UpdateDraftOrder(ctx, shopDomain, accessToken, draftOrder)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Uint64("draftOrderID", draftOrder.Id).Msg("Failed to update draft order")
+		return nil, fmt.Errorf("failed to update draft order: %w", err)
+	}
+
+	return updated, nil
+}
+
+// ApplyDraftOrderDiscount sets the order-level discount of a draft order, nil removes it
+func (s *ShopifyService) ApplyDraftOrderDiscount(ctx context.Context, shopDomain string, draftOrderID int64, discount *goshopify.AppliedDiscount) (*goshopify.DraftOrder, error) {
+	current, err := s.GetDraftOrder(ctx, shopDomain, draftOrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.UpdateDraftOrder(ctx, shopDomain, &goshopify.DraftOrder{
+		Id:              current.Id,
+		AppliedDiscount: discount,
+		ShippingLine:    current.ShippingLine,
+	})
+}
+
+// SetDraftOrderShippingLine sets the shipping line of a draft order, nil removes it
+func (s *ShopifyService) SetDraftOrderShippingLine(ctx context.Context, shopDomain string, draftOrderID int64, shippingLine *goshopify.ShippingLines) (*goshopify.DraftOrder, error) {
+	current, err := s.GetDraftOrder(ctx, shopDomain, draftOrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.UpdateDraftOrder(ctx, shopDomain, &goshopify.DraftOrder{
+		Id:              current.Id,
+		AppliedDiscount: current.AppliedDiscount,
+		ShippingLine:    shippingLine,
+	})
+}
+
+// DeleteDraftOrder deletes a draft order
+func (s *ShopifyService) DeleteDraftOrder(ctx context.Context, shopDomain string, draftOrderID int64) error {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeleteDraftOrder(ctx, shopDomain, accessToken, draftOrderID); err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Int64("draftOrderID", draftOrderID).Msg("Failed to delete draft order")
+		return fmt.Errorf("failed to delete draft order: %w", err)
+	}
+
+	return nil
+}
+
+// SendDraftOrderInvoice emails the invoice of a draft order, with a link to pay it through checkout
+func (s *ShopifyService) SendDraftOrderInvoice(ctx context.Context, shopDomain string, draftOrderID int64, invoice goshopify.DraftOrderInvoice) (*goshopify.DraftOrderInvoice, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	sent, err := client.SendDraftOrderInvoice(ctx, shopDomain, accessToken, draftOrderID, invoice)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Int64("draftOrderID", draftOrderID).Msg("Failed to send draft order invoice")
+		return nil, fmt.Errorf("failed to send draft order invoice: %w", err)
+	}
+
+	s.logger.Info().Str("domain", shopDomain).Int64("draftOrderID", draftOrderID).Msg("Draft order invoice sent")
+	return sent, nil
+}
+
+// CompleteDraftOrder turns a draft order into an order, marked as paid unless paymentPending is set
+func (s *ShopifyService) CompleteDraftOrder(ctx context.Context, shopDomain string, draftOrderID int64, paymentPending bool) (*goshopify.DraftOrder, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	completed, err := client.CompleteDraftOrder(ctx, shopDomain, accessToken, draftOrderID, paymentPending)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Int64("draftOrderID", draftOrderID).Msg("Failed to complete draft order")
+		return nil, fmt.Errorf("failed to complete draft order: %w", err)
+	}
+
+	s.logger.Info().Str("domain", shopDomain).Int64("draftOrderID", draftOrderID).Uint64("orderID", completed.OrderId).Bool("paymentPending", paymentPending).Msg("Draft order completed")
+	return completed, nil
+}
@@ -0,0 +1,208 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+)
+
+// ListFulfillmentOrders retrieves the fulfillment orders of an order, only those assigned to locationID when it is set
+func (s *ShopifyService) ListFulfillmentOrders(ctx context.Context, domain string, orderID int64, locationID int64) ([]goshopify.FulfillmentOrder, error) {
+	client, accessToken, err := s.shopClient(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	fulfillmentOrders, err := client.ListOrderFulfillmentOrders(ctx, domain, accessToken, orderID)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", domain).Int64("orderID", orderID).Msg("Failed to list fulfillment orders")
+		return nil, fmt.Errorf("failed to list fulfillment orders: %w", err)
+	}
+
+	if locationID == 0 {
+		return fulfillmentOrders, nil
+	}
+	assigned := make([]goshopify.FulfillmentOrder, 0, len(fulfillmentOrders))
+	for _, fulfillmentOrder := range fulfillmentOrders {
+		if fulfillmentOrder.AssignedLocationId == uint64(locationID) {
+			assigned = append(assigned, fulfillmentOrder)
+		}
+	}
+	return assigned, nil
+}
+
+// ListAssignedFulfillmentOrders retrieves the fulfillment orders assigned to the app's locations, by assignment status
+func (s *ShopifyService) ListAssignedFulfillmentOrders(ctx context.Context, domain string, options interface{}) ([]goshopify.FulfillmentOrder, error) {
+	client, accessToken, err := s.shopClient(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	fulfillmentOrders, err := client.ListAssignedFulfillmentOrders(ctx, domain, accessToken, options)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", domain).Msg("Failed to list assigned fulfillment orders")
+		return nil, fmt.Errorf("failed to list assigned fulfillment orders: %w", err)
+	}
+
+	return fulfillmentOrders, nil
+}
+
+// GetFulfillmentOrder retrieves a fulfillment order by ID
+func (s *ShopifyService) GetFulfillmentOrder(ctx context.Context, domain string, fulfillmentOrderID int64) (*goshopify.FulfillmentOrder, error) {
+	client, accessToken, err := s.shopClient(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	fulfillmentOrder, err := client.GetFulfillmentOrder(ctx, domain, accessToken, fulfillmentOrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fulfillment order: %w", err)
+	}
+
+	return fulfillmentOrder, nil
+}
+
+// AcceptFulfillmentRequest accepts the fulfillment request sent to a fulfillment order's location
+func (s *ShopifyService) AcceptFulfillmentRequest(ctx context.Context, domain string, fulfillmentOrderID int64, message string) (*goshopify.FulfillmentOrder, error) {
+	client, accessToken, err := s.shopClient(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	fulfillmentOrder, err := client.AcceptFulfillmentRequest(ctx, domain, accessToken, fulfillmentOrderID, message)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", domain).Int64("fulfillmentOrderID", fulfillmentOrderID).Msg("Failed to accept fulfillment request")
+		return nil, fmt.Errorf("failed to accept fulfillment request: %w", err)
+	}
+
+	return fulfillmentOrder, nil
+}
+
+// RejectFulfillmentRequest rejects the fulfillment request sent to a fulfillment order's location
+func (s *ShopifyService) RejectFulfillmentRequest(ctx context.Context, domain string, fulfillmentOrderID int64, request goshopify.FulfillmentRequest) (*goshopify.FulfillmentOrder, error) {
+	client, accessToken, err := s.shopClient(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	fulfillmentOrder, err := client.RejectFulfillmentRequest(ctx, domain, accessToken, fulfillmentOrderID, request)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", domain).Int64("fulfillmentOrderID", fulfillmentOrderID).Msg("Failed to reject fulfillment request")
+		return nil, fmt.Errorf("failed to reject fulfillment request: %w", err)
+	}
+
+	return fulfillmentOrder, nil
+}
+
+// HoldFulfillmentOrder puts a fulfillment order on hold
+func (s *ShopifyService) HoldFulfillmentOrder(ctx context.Context, domain string, fulfillmentOrderID int64, hold goshopify.FulfillmentOrderHold, notifyMerchant bool) (*goshopify.FulfillmentOrder, error) {
+	client, accessToken, err := s.shopClient(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	fulfillmentOrder, err := client.HoldFulfillmentOrder(ctx, domain, accessToken, fulfillmentOrderID, hold, notifyMerchant)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", domain).Int64("fulfillmentOrderID", fulfillmentOrderID).Msg("Failed to hold fulfillment order")
+		return nil, fmt.Errorf("failed to hold fulfillment order: %w", err)
+	}
+
+	return fulfillmentOrder, nil
+}
+
+// ReleaseFulfillmentOrderHold releases the holds of a fulfillment order
+func (s *ShopifyService) ReleaseFulfillmentOrderHold(ctx context.Context, domain string, fulfillmentOrderID int64) (*goshopify.FulfillmentOrder, error) {
+	client, accessToken, err := s.shopClient(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	fulfillmentOrder, err := client.ReleaseFulfillmentOrderHold(ctx, domain, accessToken, fulfillmentOrderID)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", domain).Int64("fulfillmentOrderID", fulfillmentOrderID).Msg("Failed to release fulfillment order hold")
+		return nil, fmt.Errorf("failed to release fulfillment order hold: %w", err)
+	}
+
+	return fulfillmentOrder, nil
+}
+
+// MoveFulfillmentOrder moves a fulfillment order, or some of its line items, to another location
+func (s *ShopifyService) MoveFulfillmentOrder(ctx context.Context, domain string, fulfillmentOrderID int64, move goshopify.FulfillmentOrderMoveRequest) (*goshopify.FulfillmentOrderMoveResource, error) {
+	client, accessToken, err := s.shopClient(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	moved, err := client.MoveFulfillmentOrder(ctx, domain, accessToken, fulfillmentOrderID, move)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", domain).Int64("fulfillmentOrderID", fulfillmentOrderID).Uint64("locationID", move.NewLocationId).Msg("Failed to move fulfillment order")
+		return nil, fmt.Errorf("failed to move fulfillment order: %w", err)
+	}
+
+	return moved, nil
+}
+
+// GetOrderFulfillments retrieves the fulfillments of an order
+func (s *ShopifyService) GetOrderFulfillments(ctx context.Context, domain string, orderID int64) ([]goshopify.Fulfillment, error) {
+	client, accessToken, err := s.shopClient(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	fulfillments, err := client.ListOrderFulfillments(ctx, domain, accessToken, orderID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order fulfillments: %w", err)
+	}
+
+	return fulfillments, nil
+}
+
+// CreateFulfillment fulfills line items of one or more fulfillment orders of the same order and location
+func (s *ShopifyService) CreateFulfillment(ctx context.Context, domain string, fulfillment *goshopify.Fulfillment) (*goshopify.Fulfillment, error) {
+	client, accessToken, err := s.shopClient(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := client.CreateFulfillment(ctx, domain, accessToken, fulfillment)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", domain).Msg("Failed to create fulfillment")
+		return nil, fmt.Errorf("failed to create fulfillment: %w", err)
+	}
+
+	s.logger.Info().Str("domain", domain).Uint64("fulfillmentID", created.Id).Uint64("orderID", created.OrderId).Msg("Fulfillment created")
+	return created, nil
+}
+
+// UpdateFulfillmentTracking replaces the tracking information of a fulfillment
+func (s *ShopifyService) UpdateFulfillmentTracking(ctx context.Context, domain string, fulfillmentID int64, tracking goshopify.FulfillmentTrackingInfo, notifyCustomer bool) (*goshopify.Fulfillment, error) {
+	client, accessToken, err := s.shopClient(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := client.UpdateFulfillmentTracking(ctx, domain, accessToken, fulfillmentID, tracking, notifyCustomer)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", domain).Int64("fulfillmentID", fulfillmentID).Msg("Failed to update fulfillment tracking")
+		return nil, fmt.Errorf("failed to update fulfillment tracking: %w", err)
+	}
+
+	return updated, nil
+}
+
+// CancelFulfillment cancels a fulfillment, reopening its fulfillment order line items
+func (s *ShopifyService) CancelFulfillment(ctx context.Context, domain string, fulfillmentID int64) (*goshopify.Fulfillment, error) {
+	client, accessToken, err := s.shopClient(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	cancelled, err := client.CancelFulfillment(ctx, domain, accessToken, fulfillmentID)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", domain).Int64("fulfillmentID", fulfillmentID).Msg("Failed to cancel fulfillment")
+		return nil, fmt.Errorf("failed to cancel fulfillment: %w", err)
+	}
+
+	return cancelled, nil
+}
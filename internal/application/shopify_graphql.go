@@ -0,0 +1,145 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"archie-core-shopify-layer/internal/domain"
+	"archie-core-shopify-layer/internal/ports"
+)
+
+// EnableGraphQL sets the Admin GraphQL adapter and the operations served from it
+// Operations missing from routes keep using the REST client
+func (s *ShopifyService) EnableGraphQL(client ports.ShopifyGraphQLClient, routes map[domain.ShopifyOperation]domain.ShopifyAPI) {
+	s.graphQLClient = client
+	s.apiRoutes = routes
+}
+
+// ParseShopifyAPIRoutes parses "operation:api" pairs separated by commas, as set in SHOPIFY_API_ROUTES
+// The operation "*" sets the API of every routable operation, later pairs override it
+func ParseShopifyAPIRoutes(value string) (map[domain.ShopifyOperation]domain.ShopifyAPI, error) {
+	routes := map[domain.ShopifyOperation]domain.ShopifyAPI{}
+	for i, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, api, ok := strings.Cut(pair, ":")
+		if !ok || (api != string(domain.ShopifyAPIREST) && api != string(domain.ShopifyAPIGraphQL)) {
+			return nil, fmt.Errorf("invalid API route entry %d, expected operation:rest or operation:graphql", i+1)
+		}
+
+		operation := domain.ShopifyOperation(name)
+		switch {
+		case name == "*":
+			for _, routable := range domain.RoutableShopifyOperations {
+				routes[routable] = domain.ShopifyAPI(api)
+			}
+		case operation.IsRoutable():
+			routes[operation] = domain.ShopifyAPI(api)
+		default:
+			return nil, fmt.Errorf("invalid API route entry %d: %q cannot be routed", i+1, name)
+		}
+	}
+	return routes, nil
+}
+
+// apiFor returns the API an operation is served from, REST unless routed to an enabled GraphQL adapter
+func (s *ShopifyService) apiFor(operation domain.ShopifyOperation) domain.ShopifyAPI {
+	if s.graphQLClient != nil && s.apiRoutes[operation] == domain.ShopifyAPIGraphQL {
+		return domain.ShopifyAPIGraphQL
+	}
+	return domain.ShopifyAPIREST
+}
+
+// resourceReader returns the client an operation is routed to and the decrypted access token for a shop
+func (s *ShopifyService) resourceReader(ctx context.Context, shopDomain string, operation domain.ShopifyOperation) (ports.ShopifyResourceReader, string, error) {
+	if s.apiFor(operation) == domain.ShopifyAPIGraphQL {
+		accessToken, err := s.getDecryptedAccessToken(ctx, shopDomain)
+		if err != nil {
+			return nil, "", err
+		}
+		return s.graphQLClient, accessToken, nil
+	}
+	return s.shopClient(ctx, shopDomain)
+}
+
+// graphQLShopClient returns the GraphQL adapter and the decrypted access token for a shop
+func (s *ShopifyService) graphQLShopClient(ctx context.Context, shopDomain string) (ports.ShopifyGraphQLClient, string, error) {
+	if s.graphQLClient == nil {
+		return nil, "", domain.NewInternalError("Shopify GraphQL API is not enabled", nil)
+	}
+	accessToken, err := s.getDecryptedAccessToken(ctx, shopDomain)
+	if err != nil {
+		return nil, "", err
+	}
+	return s.graphQLClient, accessToken, nil
+}
+
+// ExecuteGraphQL sends a request to the Admin GraphQL API of a shop as is
+// GraphQL errors, including throttling that outlasted the retries, are returned in the response
+func (s *ShopifyService) ExecuteGraphQL(ctx context.Context, shopDomain string, request domain.GraphQLRequest) (*domain.GraphQLResponse, error) {
+	client, accessToken, err := s.graphQLShopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := client.Execute(ctx, shopDomain, accessToken, request)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Str("operationName", request.OperationName).Msg("Failed to execute GraphQL request")
+		return nil, fmt.Errorf("failed to execute GraphQL request: %w", err)
+	}
+
+	return response, nil
+}
+
+// QueryGraphQL runs a query or mutation against a shop and decodes its data into response
+func (s *ShopifyService) QueryGraphQL(ctx context.Context, shopDomain string, query string, variables map[string]interface{}, response interface{}) error {
+	client, accessToken, err := s.graphQLShopClient(ctx, shopDomain)
+	if err != nil {
+		return err
+	}
+
+	if err := client.Query(ctx, shopDomain, accessToken, query, variables, response); err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Msg("Failed to run GraphQL query")
+		return fmt.Errorf("failed to run GraphQL query: %w", err)
+	}
+
+	return nil
+}
+
+// PaginateGraphQL runs a query for every page of the connection at path, see ports.ShopifyGraphQLClient
+func (s *ShopifyService) PaginateGraphQL(ctx context.Context, shopDomain string, query string, variables map[string]interface{}, path string, handle func(nodes []json.RawMessage) error) error {
+	client, accessToken, err := s.graphQLShopClient(ctx, shopDomain)
+	if err != nil {
+		return err
+	}
+
+	if err := client.Paginate(ctx, shopDomain, accessToken, query, variables, path, handle); err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Str("path", path).Msg("Failed to paginate GraphQL connection")
+		return fmt.Errorf("failed to paginate GraphQL connection: %w", err)
+	}
+
+	return nil
+}
+
+// CollectGraphQLNodes decodes the nodes of every page of the connection at path
+func CollectGraphQLNodes[T any](ctx context.Context, s *ShopifyService, shopDomain string, query string, variables map[string]interface{}, path string) ([]T, error) {
+	var nodes []T
+	err := s.PaginateGraphQL(ctx, shopDomain, query, variables, path, func(page []json.RawMessage) error {
+		for _, raw := range page {
+			var node T
+			if err := json.Unmarshal(raw, &node); err != nil {
+				return domain.NewShopifyAPIError("failed to decode Shopify GraphQL node", err)
+			}
+			nodes = append(nodes, node)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
@@ -0,0 +1,247 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"archie-core-shopify-layer/internal/domain"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+)
+
+// inventoryItemsPerRequest is the most inventory items Shopify returns for one list of IDs
+const inventoryItemsPerRequest = 100
+
+// VariantInventory joins a variant to its inventory item and its stock at every location of the shop
+type VariantInventory struct {
+	Variant       *goshopify.Variant
+	InventoryItem *goshopify.InventoryItem // Nil when the variant has no inventory item
+	Levels        []LocationInventoryLevel
+}
+
+// LocationInventoryLevel is the stock of an inventory item at a location, Level is nil when the location does not stock it
+type LocationInventoryLevel struct {
+	Location goshopify.Location
+	Level    *goshopify.InventoryLevel
+}
+
+// ListLocations retrieves the locations of a shop
+func (s *ShopifyService) ListLocations(ctx context.Context, shopDomain string) ([]goshopify.Location, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	locations, err := client.ListLocations(ctx, shopDomain, accessToken)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Msg("Failed to list locations")
+		return nil, fmt.Errorf("failed to list locations: %w", err)
+	}
+
+	return locations, nil
+}
+
+// GetLocation retrieves a location by ID
+func (s *ShopifyService) GetLocation(ctx context.Context, shopDomain string, locationID int64) (*goshopify.Location, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := client.GetLocation(ctx, shopDomain, accessToken, locationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get location: %w", err)
+	}
+
+	return location, nil
+}
+
+// ListInventoryItems retrieves inventory items by ID, in requests of up to 100 items
+func (s *ShopifyService) ListInventoryItems(ctx context.Context, shopDomain string, inventoryItemIDs []uint64) ([]goshopify.InventoryItem, error) {
+	if len(inventoryItemIDs) == 0 {
+		return []goshopify.InventoryItem{}, nil
+	}
+
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]goshopify.InventoryItem, 0, len(inventoryItemIDs))
+	for start := 0; start < len(inventoryItemIDs); start += inventoryItemsPerRequest {
+		end := min(start+inventoryItemsPerRequest, len(inventoryItemIDs))
+		page, err := client.ListInventoryItems(ctx, shopDomain, accessToken, inventoryItemIDs[start:end])
+		if err != nil {
+			s.logger.Error().Err(err).Str("domain", shopDomain).Int("count", len(inventoryItemIDs)).Msg("Failed to list inventory items")
+			return nil, fmt.Errorf("failed to list inventory items: %w", err)
+		}
+		items = append(items, page...)
+	}
+
+	return items, nil
+}
+
+// GetInventoryItem retrieves an inventory item by ID
+func (s *ShopifyService) GetInventoryItem(ctx context.Context, shopDomain string, inventoryItemID int64) (*goshopify.InventoryItem, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := client.GetInventoryItem(ctx, shopDomain, accessToken, inventoryItemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inventory item: %w", err)
+	}
+
+	return item, nil
+}
+
+// UpdateInventoryItem updates the cost, tracking and customs information of an inventory item
+func (s *ShopifyService) UpdateInventoryItem(ctx context.Context, shopDomain string, item *goshopify.InventoryItem) (*goshopify.InventoryItem, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := client.UpdateInventoryItem(ctx, shopDomain, accessToken, item)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Uint64("inventoryItemID", item.Id).Msg("Failed to update inventory item")
+		return nil, fmt.Errorf("failed to update inventory item: %w", err)
+	}
+
+	return updated, nil
+}
+
+// SetInventoryLevel sets the available stock of an inventory item at a location
+func (s *ShopifyService) SetInventoryLevel(ctx context.Context, shopDomain string, inventoryItemID int64, locationID int64, available int, disconnectIfNecessary bool) (*goshopify.InventoryLevel, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	level, err := client.SetInventoryLevel(ctx, shopDomain, accessToken, inventoryItemID, locationID, available, disconnectIfNecessary)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Int64("inventoryItemID", inventoryItemID).Int64("locationID", locationID).Msg("Failed to set inventory level")
+		return nil, fmt.Errorf("failed to set inventory level: %w", err)
+	}
+
+	s.logger.Info().Str("domain", shopDomain).Int64("inventoryItemID", inventoryItemID).Int64("locationID", locationID).Int("available", available).Msg("Inventory level set")
+	return level, nil
+}
+
+// AdjustInventory changes the available stock of an inventory item at a location
+// Shopify records the reason and reference with the adjustment; it is also logged with the principal that made it
+func (s *ShopifyService) AdjustInventory(ctx context.Context, shopDomain string, adjustment domain.InventoryAdjustment) (*goshopify.InventoryLevel, error) {
+	if adjustment.Delta == 0 {
+		return nil, domain.NewValidationError("an inventory adjustment must change the stock", nil).WithFieldError("delta", "must not be zero")
+	}
+	if !adjustment.Reason.IsValid() {
+		return nil, domain.NewValidationError(fmt.Sprintf("unknown inventory adjustment reason %q", adjustment.Reason), nil).WithFieldError("reason", "is not a Shopify inventory reason")
+	}
+
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	level, err := client.AdjustInventoryLevel(ctx, shopDomain, accessToken, adjustment)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Uint64("inventoryItemID", adjustment.InventoryItemID).Uint64("locationID", adjustment.LocationID).Msg("Failed to adjust inventory level")
+		return nil, fmt.Errorf("failed to adjust inventory level: %w", err)
+	}
+
+	event := s.logger.Info().
+		Str("domain", shopDomain).
+		Uint64("inventoryItemID", adjustment.InventoryItemID).
+		Uint64("locationID", adjustment.LocationID).
+		Int("delta", adjustment.Delta).
+		Int("available", level.Available).
+		Str("reason", string(adjustment.Reason))
+	if adjustment.Reference != "" {
+		event = event.Str("reference", adjustment.Reference)
+	}
+	if principal := domain.GetPrincipalFromContext(ctx); principal != nil {
+		event = event.Str("subject", principal.Subject)
+	}
+	event.Msg("Inventory adjusted")
+
+	return level, nil
+}
+
+// ConnectInventoryLevel starts stocking an inventory item at a location
+func (s *ShopifyService) ConnectInventoryLevel(ctx context.Context, shopDomain string, inventoryItemID int64, locationID int64, relocateIfNecessary bool) (*goshopify.InventoryLevel, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	level, err := client.ConnectInventoryLevel(ctx, shopDomain, accessToken, inventoryItemID, locationID, relocateIfNecessary)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Int64("inventoryItemID", inventoryItemID).Int64("locationID", locationID).Msg("Failed to connect inventory level")
+		return nil, fmt.Errorf("failed to connect inventory level: %w", err)
+	}
+
+	return level, nil
+}
+
+// DisconnectInventoryLevel stops stocking an inventory item at a location, dropping its stock there
+func (s *ShopifyService) DisconnectInventoryLevel(ctx context.Context, shopDomain string, inventoryItemID int64, locationID int64) error {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return err
+	}
+
+	if err := client.DisconnectInventoryLevel(ctx, shopDomain, accessToken, inventoryItemID, locationID); err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Int64("inventoryItemID", inventoryItemID).Int64("locationID", locationID).Msg("Failed to disconnect inventory level")
+		return fmt.Errorf("failed to disconnect inventory level: %w", err)
+	}
+
+	s.logger.Info().Str("domain", shopDomain).Int64("inventoryItemID", inventoryItemID).Int64("locationID", locationID).Msg("Inventory level disconnected")
+	return nil
+}
+
+// GetVariantInventory retrieves a variant with its inventory item and its stock at each location of the shop
+func (s *ShopifyService) GetVariantInventory(ctx context.Context, shopDomain string, variantID int64) (*VariantInventory, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	variant, err := client.GetVariant(ctx, shopDomain, accessToken, variantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get variant: %w", err)
+	}
+	locations, err := client.ListLocations(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list locations: %w", err)
+	}
+
+	inventory := &VariantInventory{Variant: variant, Levels: make([]LocationInventoryLevel, len(locations))}
+	for i, location := range locations {
+		inventory.Levels[i] = LocationInventoryLevel{Location: location}
+	}
+	if variant.InventoryItemId == 0 {
+		return inventory, nil
+	}
+
+	inventory.InventoryItem, err = client.GetInventoryItem(ctx, shopDomain, accessToken, int64(variant.InventoryItemId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inventory item: %w", err)
+	}
+
+	options := goshopify.InventoryLevelListOptions{InventoryItemIds: []uint64{variant.InventoryItemId}, Limit: 250}
+	levels, _, err := client.GetInventoryLevels(ctx, shopDomain, accessToken, options)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Int64("variantID", variantID).Msg("Failed to get variant inventory levels")
+		return nil, fmt.Errorf("failed to get variant inventory levels: %w", err)
+	}
+	for i := range levels {
+		for j := range inventory.Levels {
+			if inventory.Levels[j].Location.Id == levels[i].LocationId {
+				inventory.Levels[j].Level = &levels[i]
+			}
+		}
+	}
+
+	return inventory, nil
+}
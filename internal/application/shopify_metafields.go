@@ -0,0 +1,431 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"archie-core-shopify-layer/internal/domain"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+)
+
+// metafieldListOptions narrows a metafield list to a namespace and key
+type metafieldListOptions struct {
+	goshopify.ListOptions
+	Namespace string `url:"namespace,omitempty"`
+	Key       string `url:"key,omitempty"`
+}
+
+// normalizeMetafieldValue checks a value against its metafield type, reporting failures on field
+func normalizeMetafieldValue(field string, metafieldType string, value string) (string, error) {
+	normalized, err := domain.NormalizeMetafieldValue(metafieldType, value)
+	if err != nil {
+		return "", domain.NewValidationError(fmt.Sprintf("invalid %s value", metafieldType), err).WithFieldError(field, err.Error())
+	}
+	return normalized, nil
+}
+
+// ListMetafields retrieves a page of the metafields of an owner
+func (s *ShopifyService) ListMetafields(ctx context.Context, shopDomain string, owner domain.MetafieldOwner, options interface{}) ([]goshopify.Metafield, *goshopify.Pagination, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	metafields, pagination, err := client.ListMetafields(ctx, shopDomain, accessToken, owner, options)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Str("ownerType", string(owner.Type)).Uint64("ownerID", owner.ID).Msg("Failed to list metafields")
+		return nil, nil, fmt.Errorf("failed to list metafields: %w", err)
+	}
+
+	return metafields, pagination, nil
+}
+
+// ListOwnerMetafields retrieves up to 250 metafields of an owner, optionally of a single namespace
+func (s *ShopifyService) ListOwnerMetafields(ctx context.Context, shopDomain string, owner domain.MetafieldOwner, namespace string) ([]goshopify.Metafield, error) {
+	options := &metafieldListOptions{ListOptions: goshopify.ListOptions{Limit: 250}, Namespace: namespace}
+	metafields, _, err := s.ListMetafields(ctx, shopDomain, owner, options)
+	return metafields, err
+}
+
+// GetMetafieldByKey retrieves the metafield of an owner with a namespace and key, nil when it has none
+func (s *ShopifyService) GetMetafieldByKey(ctx context.Context, shopDomain string, owner domain.MetafieldOwner, namespace string, key string) (*goshopify.Metafield, error) {
+	options := &metafieldListOptions{ListOptions: goshopify.ListOptions{Limit: 1}, Namespace: namespace, Key: key}
+	metafields, _, err := s.ListMetafields(ctx, shopDomain, owner, options)
+	if err != nil || len(metafields) == 0 {
+		return nil, err
+	}
+	return &metafields[0], nil
+}
+
+// GetMetafield retrieves a metafield of an owner by ID
+func (s *ShopifyService) GetMetafield(ctx context.Context, shopDomain string, owner domain.MetafieldOwner, metafieldID int64) (*goshopify.Metafield, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	metafield, err := client.GetMetafield(ctx, shopDomain, accessToken, owner, metafieldID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metafield: %w", err)
+	}
+
+	return metafield, nil
+}
+
+// CreateMetafield creates a metafield on an owner after checking its value against its type
+// Shopify replaces the value when the owner already has a metafield with the namespace and key
+func (s *ShopifyService) CreateMetafield(ctx context.Context, shopDomain string, owner domain.MetafieldOwner, metafield *goshopify.Metafield) (*goshopify.Metafield, error) {
+	value, err := normalizeMetafieldValue("value", string(metafield.Type), fmt.Sprint(metafield.Value))
+	if err != nil {
+		return nil, err
+	}
+	metafield.Value = value
+
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := client.CreateMetafield(ctx, shopDomain, accessToken, owner, metafield)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Str("ownerType", string(owner.Type)).Uint64("ownerID", owner.ID).Str("namespace", metafield.Namespace).Str("key", metafield.Key).Msg("Failed to create metafield")
+		return nil, fmt.Errorf("failed to create metafield: %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateMetafield changes the value of a metafield, keeping its current type unless one is given
+func (s *ShopifyService) UpdateMetafield(ctx context.Context, shopDomain string, owner domain.MetafieldOwner, metafield *goshopify.Metafield) (*goshopify.Metafield, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	if metafield.Type == "" {
+		current, err := client.GetMetafield(ctx, shopDomain, accessToken, owner, int64(metafield.Id))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get metafield: %w", err)
+		}
+		metafield.Type = current.Type
+	}
+	value, err := normalizeMetafieldValue("value", string(metafield.Type), fmt.Sprint(metafield.Value))
+	if err != nil {
+		return nil, err
+	}
+	metafield.Value = value
+
+	updated, err := client.UpdateMetafield(ctx, shopDomain, accessToken, owner, metafield)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Uint64("metafieldID", metafield.Id).Msg("Failed to update metafield")
+		return nil, fmt.Errorf("failed to update metafield: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteMetafield deletes a metafield of an owner
+func (s *ShopifyService) DeleteMetafield(ctx context.Context, shopDomain string, owner domain.MetafieldOwner, metafieldID int64) error {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeleteMetafield(ctx, shopDomain, accessToken, owner, metafieldID); err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Int64("metafieldID", metafieldID).Msg("Failed to delete metafield")
+		return fmt.Errorf("failed to delete metafield: %w", err)
+	}
+
+	return nil
+}
+
+// ListMetafieldDefinitions retrieves the metafield definitions of an owner type, optionally of a single namespace
+func (s *ShopifyService) ListMetafieldDefinitions(ctx context.Context, shopDomain string, ownerType domain.MetafieldOwnerType, namespace string) ([]domain.MetafieldDefinition, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions, err := client.ListMetafieldDefinitions(ctx, shopDomain, accessToken, ownerType, namespace)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Str("ownerType", string(ownerType)).Msg("Failed to list metafield definitions")
+		return nil, fmt.Errorf("failed to list metafield definitions: %w", err)
+	}
+
+	return definitions, nil
+}
+
+// CreateMetafieldDefinition creates a metafield definition
+func (s *ShopifyService) CreateMetafieldDefinition(ctx context.Context, shopDomain string, definition domain.MetafieldDefinition) (*domain.MetafieldDefinition, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := client.CreateMetafieldDefinition(ctx, shopDomain, accessToken, definition)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Str("namespace", definition.Namespace).Str("key", definition.Key).Msg("Failed to create metafield definition")
+		return nil, fmt.Errorf("failed to create metafield definition: %w", err)
+	}
+
+	s.logger.Info().Str("domain", shopDomain).Str("ownerType", string(created.OwnerType)).Str("namespace", created.Namespace).Str("key", created.Key).Msg("Metafield definition created")
+	return created, nil
+}
+
+// UpdateMetafieldDefinition updates the name, description and validations of the definition of an owner type, namespace and key
+func (s *ShopifyService) UpdateMetafieldDefinition(ctx context.Context, shopDomain string, definition domain.MetafieldDefinition) (*domain.MetafieldDefinition, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := client.UpdateMetafieldDefinition(ctx, shopDomain, accessToken, definition)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Str("namespace", definition.Namespace).Str("key", definition.Key).Msg("Failed to update metafield definition")
+		return nil, fmt.Errorf("failed to update metafield definition: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteMetafieldDefinition deletes a metafield definition, and the metafields it describes when deleteMetafields is set
+func (s *ShopifyService) DeleteMetafieldDefinition(ctx context.Context, shopDomain string, definitionID int64, deleteMetafields bool) error {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeleteMetafieldDefinition(ctx, shopDomain, accessToken, definitionID, deleteMetafields); err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Int64("definitionID", definitionID).Msg("Failed to delete metafield definition")
+		return fmt.Errorf("failed to delete metafield definition: %w", err)
+	}
+
+	s.logger.Info().Str("domain", shopDomain).Int64("definitionID", definitionID).Bool("deleteMetafields", deleteMetafields).Msg("Metafield definition deleted")
+	return nil
+}
+
+// ListMetaobjectDefinitions retrieves the metaobject definitions of a shop
+func (s *ShopifyService) ListMetaobjectDefinitions(ctx context.Context, shopDomain string) ([]domain.MetaobjectDefinition, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions, err := client.ListMetaobjectDefinitions(ctx, shopDomain, accessToken)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Msg("Failed to list metaobject definitions")
+		return nil, fmt.Errorf("failed to list metaobject definitions: %w", err)
+	}
+
+	return definitions, nil
+}
+
+// GetMetaobjectDefinitionByType retrieves the metaobject definition of a type
+func (s *ShopifyService) GetMetaobjectDefinitionByType(ctx context.Context, shopDomain string, objectType string) (*domain.MetaobjectDefinition, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	definition, err := client.GetMetaobjectDefinitionByType(ctx, shopDomain, accessToken, objectType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metaobject definition: %w", err)
+	}
+
+	return definition, nil
+}
+
+// CreateMetaobjectDefinition creates a metaobject definition
+func (s *ShopifyService) CreateMetaobjectDefinition(ctx context.Context, shopDomain string, definition domain.MetaobjectDefinition) (*domain.MetaobjectDefinition, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := client.CreateMetaobjectDefinition(ctx, shopDomain, accessToken, definition)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Str("type", definition.Type).Msg("Failed to create metaobject definition")
+		return nil, fmt.Errorf("failed to create metaobject definition: %w", err)
+	}
+
+	s.logger.Info().Str("domain", shopDomain).Str("type", created.Type).Int("fields", len(created.Fields)).Msg("Metaobject definition created")
+	return created, nil
+}
+
+// UpdateMetaobjectDefinition changes a metaobject definition and creates, updates or deletes its fields
+func (s *ShopifyService) UpdateMetaobjectDefinition(ctx context.Context, shopDomain string, definitionID int64, update domain.MetaobjectDefinitionUpdate) (*domain.MetaobjectDefinition, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := client.UpdateMetaobjectDefinition(ctx, shopDomain, accessToken, definitionID, update)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Int64("definitionID", definitionID).Msg("Failed to update metaobject definition")
+		return nil, fmt.Errorf("failed to update metaobject definition: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteMetaobjectDefinition deletes a metaobject definition and its metaobjects
+func (s *ShopifyService) DeleteMetaobjectDefinition(ctx context.Context, shopDomain string, definitionID int64) error {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeleteMetaobjectDefinition(ctx, shopDomain, accessToken, definitionID); err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Int64("definitionID", definitionID).Msg("Failed to delete metaobject definition")
+		return fmt.Errorf("failed to delete metaobject definition: %w", err)
+	}
+
+	s.logger.Info().Str("domain", shopDomain).Int64("definitionID", definitionID).Msg("Metaobject definition deleted")
+	return nil
+}
+
+// ListMetaobjects retrieves a page of the metaobjects of a type, backwards from before when it is set
+func (s *ShopifyService) ListMetaobjects(ctx context.Context, shopDomain string, objectType string, first int, after string, before string) (*domain.MetaobjectPage, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := client.ListMetaobjects(ctx, shopDomain, accessToken, objectType, first, after, before)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Str("type", objectType).Msg("Failed to list metaobjects")
+		return nil, fmt.Errorf("failed to list metaobjects: %w", err)
+	}
+
+	return page, nil
+}
+
+// GetMetaobject retrieves a metaobject by ID
+func (s *ShopifyService) GetMetaobject(ctx context.Context, shopDomain string, metaobjectID int64) (*domain.Metaobject, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	metaobject, err := client.GetMetaobject(ctx, shopDomain, accessToken, metaobjectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metaobject: %w", err)
+	}
+
+	return metaobject, nil
+}
+
+// GetMetaobjectByHandle retrieves a metaobject by type and handle
+func (s *ShopifyService) GetMetaobjectByHandle(ctx context.Context, shopDomain string, objectType string, handle string) (*domain.Metaobject, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	metaobject, err := client.GetMetaobjectByHandle(ctx, shopDomain, accessToken, objectType, handle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metaobject: %w", err)
+	}
+
+	return metaobject, nil
+}
+
+// CreateMetaobject creates a metaobject after checking its field values against the definition of its type
+func (s *ShopifyService) CreateMetaobject(ctx context.Context, shopDomain string, metaobject domain.Metaobject) (*domain.Metaobject, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	definition, err := client.GetMetaobjectDefinitionByType(ctx, shopDomain, accessToken, metaobject.Type)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metaobject definition: %w", err)
+	}
+	if err := normalizeMetaobjectFields(definition, metaobject.Fields); err != nil {
+		return nil, err
+	}
+
+	created, err := client.CreateMetaobject(ctx, shopDomain, accessToken, metaobject)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Str("type", metaobject.Type).Msg("Failed to create metaobject")
+		return nil, fmt.Errorf("failed to create metaobject: %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateMetaobject sets field values and the handle of a metaobject, checking the values against its definition
+func (s *ShopifyService) UpdateMetaobject(ctx context.Context, shopDomain string, metaobject domain.Metaobject) (*domain.Metaobject, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := client.GetMetaobject(ctx, shopDomain, accessToken, int64(metaobject.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metaobject: %w", err)
+	}
+	definition, err := client.GetMetaobjectDefinitionByType(ctx, shopDomain, accessToken, current.Type)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metaobject definition: %w", err)
+	}
+	if err := normalizeMetaobjectFields(definition, metaobject.Fields); err != nil {
+		return nil, err
+	}
+
+	updated, err := client.UpdateMetaobject(ctx, shopDomain, accessToken, metaobject)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Uint64("metaobjectID", metaobject.ID).Msg("Failed to update metaobject")
+		return nil, fmt.Errorf("failed to update metaobject: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteMetaobject deletes a metaobject
+func (s *ShopifyService) DeleteMetaobject(ctx context.Context, shopDomain string, metaobjectID int64) error {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeleteMetaobject(ctx, shopDomain, accessToken, metaobjectID); err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Int64("metaobjectID", metaobjectID).Msg("Failed to delete metaobject")
+		return fmt.Errorf("failed to delete metaobject: %w", err)
+	}
+
+	return nil
+}
+
+// normalizeMetaobjectFields checks field values against the field types of a definition, normalizing them in place
+func normalizeMetaobjectFields(definition *domain.MetaobjectDefinition, fields []domain.MetaobjectField) error {
+	types := make(map[string]string, len(definition.Fields))
+	for _, field := range definition.Fields {
+		types[field.Key] = field.Type
+	}
+
+	appErr := domain.NewValidationError("invalid metaobject fields", nil)
+	for i := range fields {
+		path := fmt.Sprintf("fields[%d].value", i)
+		fieldType, ok := types[fields[i].Key]
+		if !ok {
+			appErr = appErr.WithFieldError(fmt.Sprintf("fields[%d].key", i), fmt.Sprintf("is not a field of %s", definition.Type))
+			continue
+		}
+		fields[i].Type = fieldType
+		if fields[i].Value == nil || *fields[i].Value == "" {
+			continue
+		}
+		normalized, err := domain.NormalizeMetafieldValue(fieldType, *fields[i].Value)
+		if err != nil {
+			appErr = appErr.WithFieldError(path, err.Error())
+			continue
+		}
+		fields[i].Value = &normalized
+	}
+	if len(appErr.Fields) > 0 {
+		return appErr
+	}
+	return nil
+}
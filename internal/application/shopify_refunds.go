@@ -0,0 +1,222 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"archie-core-shopify-layer/internal/domain"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+	"github.com/shopspring/decimal"
+)
+
+// ListOrderRefunds retrieves the refunds of an order
+func (s *ShopifyService) ListOrderRefunds(ctx context.Context, shopDomain string, orderID int64) ([]goshopify.Refund, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	refunds, err := client.ListOrderRefunds(ctx, shopDomain, accessToken, orderID)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Int64("orderID", orderID).Msg("Failed to list order refunds")
+		return nil, fmt.Errorf("failed to list order refunds: %w", err)
+	}
+
+	return refunds, nil
+}
+
+// GetRefund retrieves a refund of an order
+func (s *ShopifyService) GetRefund(ctx context.Context, shopDomain string, orderID int64, refundID int64) (*goshopify.Refund, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	refund, err := client.GetRefund(ctx, shopDomain, accessToken, orderID, refundID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get refund: %w", err)
+	}
+
+	return refund, nil
+}
+
+// CalculateRefund computes the amounts of a refund and the transactions that would pay it back, without creating it
+func (s *ShopifyService) CalculateRefund(ctx context.Context, shopDomain string, orderID int64, request domain.RefundRequest) (*domain.RefundCalculation, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	calculation, err := client.CalculateRefund(ctx, shopDomain, accessToken, orderID, request)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Int64("orderID", orderID).Msg("Failed to calculate refund")
+		return nil, fmt.Errorf("failed to calculate refund: %w", err)
+	}
+
+	return calculation, nil
+}
+
+// CreateRefund refunds line items, shipping or an amount of an order
+// When the request has no transactions, the ones Shopify suggests for it are used, so the calculated amount is paid back
+func (s *ShopifyService) CreateRefund(ctx context.Context, shopDomain string, orderID int64, request domain.RefundRequest) (*goshopify.Refund, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(request.Transactions) == 0 {
+		calculation, err := client.CalculateRefund(ctx, shopDomain, accessToken, orderID, request)
+		if err != nil {
+			s.logger.Error().Err(err).Str("domain", shopDomain).Int64("orderID", orderID).Msg("Failed to calculate refund")
+			return nil, fmt.Errorf("failed to calculate refund: %w", err)
+		}
+		request.Transactions = suggestedRefundTransactions(calculation)
+		if request.Currency == "" {
+			request.Currency = calculation.Currency
+		}
+	}
+
+	refund, err := client.CreateRefund(ctx, shopDomain, accessToken, orderID, request)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Int64("orderID", orderID).Msg("Failed to create refund")
+		return nil, fmt.Errorf("failed to create refund: %w", err)
+	}
+
+	s.logger.Info().Str("domain", shopDomain).Int64("orderID", orderID).Uint64("refundID", refund.Id).Int("lineItems", len(request.LineItems)).Msg("Refund created")
+	return refund, nil
+}
+
+// suggestedRefundTransactions turns the transactions suggested by a refund calculation into refund transactions
+func suggestedRefundTransactions(calculation *domain.RefundCalculation) []domain.RefundTransactionRequest {
+	transactions := make([]domain.RefundTransactionRequest, 0, len(calculation.Transactions))
+	for _, suggested := range calculation.Transactions {
+		if suggested.Amount == nil || !suggested.Amount.IsPositive() {
+			continue
+		}
+		transactions = append(transactions, domain.RefundTransactionRequest{
+			ParentID: suggested.ParentID,
+			Amount:   *suggested.Amount,
+			Kind:     "refund",
+			Gateway:  suggested.Gateway,
+		})
+	}
+	return transactions
+}
+
+// CaptureTransaction captures an authorized payment of an order, the whole authorization when amount is nil
+func (s *ShopifyService) CaptureTransaction(ctx context.Context, shopDomain string, orderID int64, authorizationID int64, amount *decimal.Decimal, currency string) (*goshopify.Transaction, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	parentID := authorizationID
+	transaction := &goshopify.Transaction{
+		Kind:     "capture",
+		ParentId: &parentID,
+		Amount:   amount,
+		Currency: currency,
+	}
+	captured, err := client.CreateOrderTransaction(ctx, shopDomain, accessToken, orderID, transaction)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Int64("orderID", orderID).Int64("authorizationID", authorizationID).Msg("Failed to capture transaction")
+		return nil, fmt.Errorf("failed to capture transaction: %w", err)
+	}
+
+	s.logger.Info().Str("domain", shopDomain).Int64("orderID", orderID).Uint64("transactionID", captured.Id).Str("status", captured.Status).Msg("Payment captured")
+	return captured, nil
+}
+
+// ListOrderReturns retrieves the returns of an order
+func (s *ShopifyService) ListOrderReturns(ctx context.Context, shopDomain string, orderID int64) ([]domain.OrderReturn, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	returns, err := client.ListOrderReturns(ctx, shopDomain, accessToken, orderID)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Int64("orderID", orderID).Msg("Failed to list order returns")
+		return nil, fmt.Errorf("failed to list order returns: %w", err)
+	}
+
+	return returns, nil
+}
+
+// CreateReturn opens a return for fulfilled line items of an order
+func (s *ShopifyService) CreateReturn(ctx context.Context, shopDomain string, request domain.ReturnRequest) (*domain.OrderReturn, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := client.CreateReturn(ctx, shopDomain, accessToken, request)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Uint64("orderID", request.OrderID).Msg("Failed to create return")
+		return nil, fmt.Errorf("failed to create return: %w", err)
+	}
+
+	s.logger.Info().Str("domain", shopDomain).Uint64("orderID", request.OrderID).Uint64("returnID", created.ID).Msg("Return created")
+	return created, nil
+}
+
+// ApproveReturnRequest approves a return requested by a customer
+func (s *ShopifyService) ApproveReturnRequest(ctx context.Context, shopDomain string, returnID int64) (*domain.OrderReturn, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	approved, err := client.ApproveReturnRequest(ctx, shopDomain, accessToken, returnID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to approve return request: %w", err)
+	}
+
+	return approved, nil
+}
+
+// DeclineReturnRequest declines a return requested by a customer
+func (s *ShopifyService) DeclineReturnRequest(ctx context.Context, shopDomain string, returnID int64, reason domain.ReturnDeclineReason) (*domain.OrderReturn, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	declined, err := client.DeclineReturnRequest(ctx, shopDomain, accessToken, returnID, reason)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decline return request: %w", err)
+	}
+
+	return declined, nil
+}
+
+// CloseReturn closes a return once its items are received and refunded
+func (s *ShopifyService) CloseReturn(ctx context.Context, shopDomain string, returnID int64) (*domain.OrderReturn, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	closed, err := client.CloseReturn(ctx, shopDomain, accessToken, returnID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to close return: %w", err)
+	}
+
+	return closed, nil
+}
+
+// CancelReturn cancels a return that has not been processed
+func (s *ShopifyService) CancelReturn(ctx context.Context, shopDomain string, returnID int64) (*domain.OrderReturn, error) {
+	client, accessToken, err := s.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	canceled, err := client.CancelReturn(ctx, shopDomain, accessToken, returnID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel return: %w", err)
+	}
+
+	return canceled, nil
+}
@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"archie-core-shopify-layer/internal/domain"
 	"archie-core-shopify-layer/internal/ports"
@@ -21,9 +22,13 @@ type ShopifyService struct {
 	configRepo     ports.ShopifyConfigRepository
 	encryptionSvc  ports.EncryptionService
 	clientPool     ports.ShopifyClientPool
+	tokenRefresher ports.TokenRefresher
 	logger         zerolog.Logger
 	webhookBaseURL string
 	validateTokens bool // Feature flag for token validation
+	graphQLClient  ports.ShopifyGraphQLClient
+	apiRoutes      map[domain.ShopifyOperation]domain.ShopifyAPI // Operations served from the GraphQL API
+	installations  ports.InstallationRepository                  // Optional, lets token exchange detect reinstalls
 }
 
 // NewShopifyService creates a new Shopify application service
@@ -32,6 +37,7 @@ func NewShopifyService(
 	configRepo ports.ShopifyConfigRepository,
 	encryptionSvc ports.EncryptionService,
 	clientPool ports.ShopifyClientPool,
+	tokenRefresher ports.TokenRefresher,
 	logger zerolog.Logger,
 	webhookBaseURL string,
 ) *ShopifyService {
@@ -40,6 +46,7 @@ func NewShopifyService(
 		configRepo:     configRepo,
 		encryptionSvc:  encryptionSvc,
 		clientPool:     clientPool,
+		tokenRefresher: tokenRefresher,
 		logger:         logger,
 		webhookBaseURL: webhookBaseURL,
 		validateTokens: true, // Enable token validation by default
@@ -52,6 +59,7 @@ func NewShopifyServiceWithOptions(
 	configRepo ports.ShopifyConfigRepository,
 	encryptionSvc ports.EncryptionService,
 	clientPool ports.ShopifyClientPool,
+	tokenRefresher ports.TokenRefresher,
 	logger zerolog.Logger,
 	webhookBaseURL string,
 	validateTokens bool,
@@ -61,6 +69,7 @@ func NewShopifyServiceWithOptions(
 		configRepo:     configRepo,
 		encryptionSvc:  encryptionSvc,
 		clientPool:     clientPool,
+		tokenRefresher: tokenRefresher,
 		logger:         logger,
 		webhookBaseURL: webhookBaseURL,
 		validateTokens: validateTokens,
@@ -69,6 +78,7 @@ func NewShopifyServiceWithOptions(
 
 // GetClientForTenant retrieves a Shopify client for a project and environment
 // tenantID is actually projectID in this context
+// The app handle in context selects the app when the environment has several
 func (s *ShopifyService) GetClientForTenant(ctx context.Context, tenantID string) (ports.ShopifyClient, error) {
 	// Extract projectID and environment from context (type-safe)
 	projectID := domain.GetProjectIDFromContext(ctx)
@@ -95,8 +105,8 @@ func (s *ShopifyService) GetClientForTenant(ctx context.Context, tenantID string
 		return nil, fmt.Errorf("failed to decrypt API secret: %w", err)
 	}
 
-	// Get client from pool using projectID-environment as key
-	return s.clientPool.GetClient(ctx, projectID+"-"+environment, config.APIKey, apiSecret)
+	// Get client from pool using projectID-environment-appHandle as key
+	return s.clientPool.GetClient(ctx, projectID+"-"+environment+"-"+config.AppHandle, config.APIKey, apiSecret)
 }
 
 // GetConfig retrieves the Shopify configuration for a project and environment
@@ -276,26 +286,32 @@ func (s *ShopifyService) ExchangeToken(ctx context.Context, shop string, code st
 		}
 	}
 
+	// Request an expiring offline token when the project opted in
+	expiring := false
+	if config, err := s.GetConfig(ctx, ""); err == nil {
+		expiring = config.ExpiringOfflineTokens
+	}
+
 	// Exchange code for access token
 	// Shopify requires the same redirect_uri that was used in the authorization request
-	accessToken, err := client.ExchangeToken(ctx, shop, code, redirectURI)
+	token, err := client.ExchangeToken(ctx, shop, code, redirectURI, expiring)
 	if err != nil {
 		s.logger.Error().Err(err).Str("shop", shop).Str("redirect_uri", redirectURI).Msg("Failed to exchange token")
 		return nil, fmt.Errorf("failed to exchange token: %w", err)
 	}
 
 	// Get shop information
-	shopInfo, err := client.GetShop(ctx, shop, accessToken)
+	shopInfo, err := client.GetShop(ctx, shop, token.AccessToken)
 	if err != nil {
 		s.logger.Error().Err(err).Str("shop", shop).Msg("Failed to get shop info")
 		return nil, fmt.Errorf("failed to get shop info: %w", err)
 	}
 
-	// Encrypt access token before storage
-	encryptedToken, err := s.encryptionSvc.Encrypt(accessToken)
+	// Encrypt access and refresh tokens before storage
+	encryptedToken, encryptedRefreshToken, err := s.encryptToken(token)
 	if err != nil {
 		s.logger.Error().Err(err).Str("shop", shop).Msg("Failed to encrypt access token")
-		return nil, fmt.Errorf("failed to encrypt access token: %w", err)
+		return nil, err
 	}
 
 	// Get scopes from session (they were stored during OAuth initiation)
@@ -310,12 +326,16 @@ func (s *ShopifyService) ExchangeToken(ctx context.Context, shop string, code st
 		}
 	}
 
-	// Create domain shop entity
+	// Create domain shop entity within the tenant
+	key := s.ShopKey(ctx, shopInfo.Domain)
 	domainShop := &domain.Shop{
+		ProjectID:   key.ProjectID,
+		Environment: key.Environment,
 		Domain:      shopInfo.Domain,
-		AccessToken: encryptedToken, // Store encrypted token
-		Scopes:      scopes,         // Store scopes from OAuth session
+		Scopes:      scopes, // Store scopes from OAuth session
+		AppHandle:   key.AppHandle,
 	}
+	domainShop.ApplyToken(token, encryptedToken, encryptedRefreshToken, time.Now()) // Store encrypted tokens
 
 	// Save shop to repository
 	if err := s.repository.SaveShop(ctx, domainShop); err != nil {
@@ -326,21 +346,140 @@ func (s *ShopifyService) ExchangeToken(ctx context.Context, shop string, code st
 	return domainShop, nil
 }
 
+// ExchangeSessionToken exchanges an App Bridge session token for the shop's offline access token and saves it
+// The session token must already be verified and the project/environment set in context
+func (s *ShopifyService) ExchangeSessionToken(ctx context.Context, shopDomain string, sessionToken string) (*domain.Shop, error) {
+	config, err := s.GetConfig(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := s.GetClientForTenant(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := client.ExchangeSessionToken(ctx, shopDomain, sessionToken, domain.RequestedTokenTypeOffline, config.ExpiringOfflineTokens)
+	if err != nil {
+		s.logger.Error().Err(err).Str("shop", shopDomain).Msg("Failed to exchange session token")
+		return nil, fmt.Errorf("failed to exchange session token: %w", err)
+	}
+
+	// Shopify returns the granted scopes as a comma-separated list
+	scopes := []string{}
+	for _, scope := range strings.Split(token.Scope, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	s.logger.Info().
+		Str("shop", shopDomain).
+		Strs("scopes", scopes).
+		Msg("Session token exchanged for offline access token")
+
+	return s.SaveShop(ctx, shopDomain, token, scopes)
+}
+
+// EnableInstallationTracking sets the installation repository EnsureOfflineToken checks for uninstalled shops
+func (s *ShopifyService) EnableInstallationTracking(installations ports.InstallationRepository) {
+	s.installations = installations
+}
+
+// EnsureOfflineToken makes sure an embedded-app shop has an offline token, exchanging the session token if it has none
+// or if the app was uninstalled since the token was stored, in which case Shopify has revoked it
+// The returned bool reports whether a token exchange took place
+func (s *ShopifyService) EnsureOfflineToken(ctx context.Context, shopDomain string, sessionToken string) (*domain.Shop, bool, error) {
+	key := s.ShopKey(ctx, shopDomain)
+	shop, err := s.repository.GetShop(ctx, key)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get shop: %w", err)
+	}
+	if shop != nil && shop.AccessToken != "" {
+		uninstalled, err := s.isUninstalled(ctx, key)
+		if err != nil {
+			return nil, false, err
+		}
+		if !uninstalled {
+			return shop, false, nil
+		}
+	}
+
+	shop, err = s.ExchangeSessionToken(ctx, shopDomain, sessionToken)
+	if err != nil {
+		return nil, false, err
+	}
+	return shop, true, nil
+}
+
+// isUninstalled returns true if the app's installation in the shop was recorded as uninstalled
+func (s *ShopifyService) isUninstalled(ctx context.Context, key domain.ShopKey) (bool, error) {
+	if s.installations == nil {
+		return false, nil
+	}
+	installation, err := s.installations.Get(ctx, key)
+	if err != nil {
+		return false, fmt.Errorf("failed to get installation: %w", err)
+	}
+	return installation != nil && installation.State == domain.InstallStateUninstalled, nil
+}
+
+// ClearShopTokens removes the stored tokens of a shop after the app was uninstalled
+// The shop record itself is kept, a reinstall stores new tokens on it
+func (s *ShopifyService) ClearShopTokens(ctx context.Context, shopDomain string) error {
+	shop, err := s.repository.GetShop(ctx, s.ShopKey(ctx, shopDomain))
+	if err != nil {
+		return fmt.Errorf("failed to get shop: %w", err)
+	}
+	if shop == nil {
+		return nil
+	}
+
+	shop.ClearTokens()
+	if err := s.repository.SaveShop(ctx, shop); err != nil {
+		return fmt.Errorf("failed to save shop: %w", err)
+	}
+	return nil
+}
+
+// encryptToken encrypts the access token and, for expiring tokens, the refresh token
+func (s *ShopifyService) encryptToken(token *domain.Token) (string, string, error) {
+	encryptedToken, err := s.encryptionSvc.Encrypt(token.AccessToken)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encrypt access token: %w", err)
+	}
+
+	encryptedRefreshToken := ""
+	if token.RefreshToken != "" {
+		encryptedRefreshToken, err = s.encryptionSvc.Encrypt(token.RefreshToken)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to encrypt refresh token: %w", err)
+		}
+	}
+
+	return encryptedToken, encryptedRefreshToken, nil
+}
+
 // SaveShop saves shop data with access token and scopes
-func (s *ShopifyService) SaveShop(ctx context.Context, shopDomain string, accessToken string, scopes []string) (*domain.Shop, error) {
-	// Encrypt access token before storage
-	encryptedToken, err := s.encryptionSvc.Encrypt(accessToken)
+// Expiring tokens also store the encrypted refresh token and expiry
+func (s *ShopifyService) SaveShop(ctx context.Context, shopDomain string, token *domain.Token, scopes []string) (*domain.Shop, error) {
+	// Encrypt tokens before storage
+	encryptedToken, encryptedRefreshToken, err := s.encryptToken(token)
 	if err != nil {
 		s.logger.Error().Err(err).Str("domain", shopDomain).Msg("Failed to encrypt access token")
-		return nil, fmt.Errorf("failed to encrypt access token: %w", err)
+		return nil, err
 	}
 
-	// Create domain shop entity
+	// Create domain shop entity within the tenant and app the token was issued for
+	key := s.ShopKey(ctx, shopDomain)
 	domainShop := &domain.Shop{
+		ProjectID:   key.ProjectID,
+		Environment: key.Environment,
 		Domain:      shopDomain,
-		AccessToken: encryptedToken, // Store encrypted token
 		Scopes:      scopes,
+		AppHandle:   key.AppHandle,
 	}
+	domainShop.ApplyToken(token, encryptedToken, encryptedRefreshToken, time.Now()) // Store encrypted tokens
 
 	// Save shop to repository
 	if err := s.repository.SaveShop(ctx, domainShop); err != nil {
@@ -352,9 +491,27 @@ func (s *ShopifyService) SaveShop(ctx context.Context, shopDomain string, access
 	return domainShop, nil
 }
 
+// ShopKey builds the key of a shop in the project, environment and app of the context
+// Without an app handle in context the configured app is used, so single-app projects resolve their only app
+func (s *ShopifyService) ShopKey(ctx context.Context, shopDomain string) domain.ShopKey {
+	projectID := domain.GetProjectIDFromContext(ctx)
+	if projectID == "" {
+		projectID = domain.GetTenantIDFromContext(ctx) // Fallback
+	}
+
+	appHandle := domain.GetAppHandleFromContext(ctx)
+	if appHandle == "" {
+		if config, err := s.GetConfig(ctx, projectID); err == nil {
+			appHandle = config.AppHandle
+		}
+	}
+
+	return domain.NewShopKey(projectID, domain.GetEnvironmentFromContext(ctx), appHandle, shopDomain)
+}
+
 // GetShop retrieves shop information
 func (s *ShopifyService) GetShop(ctx context.Context, domain string) (*domain.Shop, error) {
-	shop, err := s.repository.GetShop(ctx, domain)
+	shop, err := s.repository.GetShop(ctx, s.ShopKey(ctx, domain))
 	if err != nil {
 		s.logger.Error().Err(err).Str("domain", domain).Msg("Failed to get shop")
 		return nil, fmt.Errorf("failed to get shop: %w", err)
@@ -364,12 +521,12 @@ func (s *ShopifyService) GetShop(ctx context.Context, domain string) (*domain.Sh
 		return nil, nil
 	}
 
-	// Decrypt access token
+	// Decrypt access token, refreshing expiring tokens first
 	if shop.AccessToken != "" {
-		decryptedToken, err := s.encryptionSvc.Decrypt(shop.AccessToken)
+		decryptedToken, err := s.resolveAccessToken(ctx, shop)
 		if err != nil {
 			s.logger.Error().Err(err).Str("domain", domain).Msg("Failed to decrypt access token")
-			return nil, fmt.Errorf("failed to decrypt access token: %w", err)
+			return nil, err
 		}
 
 		// Validate token if validation is enabled
@@ -392,9 +549,18 @@ func (s *ShopifyService) GetShop(ctx context.Context, domain string) (*domain.Sh
 	return shop, nil
 }
 
-// ListShops retrieves all connected shops
+// ListShops retrieves the shops connected to the project environment in context
 func (s *ShopifyService) ListShops(ctx context.Context) ([]*domain.Shop, error) {
-	shops, err := s.repository.ListShops(ctx)
+	projectID := domain.GetProjectIDFromContext(ctx)
+	if projectID == "" {
+		projectID = domain.GetTenantIDFromContext(ctx) // Fallback
+	}
+	environment := domain.GetEnvironmentFromContext(ctx)
+	if environment == "" {
+		environment = domain.DefaultEnvironment // Default
+	}
+
+	shops, err := s.repository.ListShops(ctx, projectID, environment)
 	if err != nil {
 		s.logger.Error().Err(err).Msg("Failed to list shops")
 		return nil, fmt.Errorf("failed to list shops: %w", err)
@@ -442,8 +608,7 @@ func (s *ShopifyService) validateAccessToken(ctx context.Context, token string,
 	_, err = client.GetShop(ctx, shopDomain, token)
 	if err != nil {
 		// Check if error is authentication-related
-		errStr := fmt.Sprintf("%v", err)
-		if containsAny(errStr, []string{"401", "unauthorized", "authentication", "invalid token", "forbidden"}) {
+		if appErr, ok := domain.AsAppError(err); ok && appErr.Type == domain.ErrorTypeUnauthorized {
 			return fmt.Errorf("token validation failed: token is invalid or revoked: %w", err)
 		}
 		// Other errors (network, timeout) - log but don't fail
@@ -458,179 +623,227 @@ func (s *ShopifyService) validateAccessToken(ctx context.Context, token string,
 	return nil
 }
 
-// containsAny checks if a string contains any of the substrings (case-insensitive)
-func containsAny(s string, substrings []string) bool {
-	sLower := strings.ToLower(s)
-	for _, substr := range substrings {
-		if strings.Contains(sLower, strings.ToLower(substr)) {
-			return true
-		}
-	}
-	return false
-}
-
 // getDecryptedAccessToken retrieves and decrypts the access token for a shop
-func (s *ShopifyService) getDecryptedAccessToken(ctx context.Context, domain string) (string, error) {
-	shop, err := s.repository.GetShop(ctx, domain)
+func (s *ShopifyService) getDecryptedAccessToken(ctx context.Context, shopDomain string) (string, error) {
+	shop, err := s.repository.GetShop(ctx, s.ShopKey(ctx, shopDomain))
 	if err != nil {
 		return "", fmt.Errorf("failed to get shop: %w", err)
 	}
 
 	if shop == nil {
-		return "", fmt.Errorf("shop not found: %s", domain)
+		return "", domain.NewNotFoundError(fmt.Sprintf("shop %s", shopDomain))
 	}
 
 	if shop.AccessToken == "" {
-		return "", fmt.Errorf("shop has no access token: %s", domain)
+		return "", domain.NewUnauthorizedError(fmt.Sprintf("shop has no access token: %s", shopDomain))
+	}
+
+	// Decrypt access token, refreshing expiring tokens first
+	decryptedToken, err := s.resolveAccessToken(ctx, shop)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Msg("Failed to decrypt access token")
+		return "", err
+	}
+
+	return decryptedToken, nil
+}
+
+// resolveAccessToken returns the decrypted access token for a shop
+// Expiring offline tokens are handed to the token refresher so they are rotated before expiry
+func (s *ShopifyService) resolveAccessToken(ctx context.Context, shop *domain.Shop) (string, error) {
+	if shop.HasExpiringToken() && s.tokenRefresher != nil {
+		// Refresh with the app that issued the token
+		client, err := s.GetClientForTenant(domain.WithAppHandle(ctx, shop.AppHandle), "")
+		if err != nil {
+			return "", fmt.Errorf("failed to get client for token refresh: %w", err)
+		}
+		return s.tokenRefresher.GetAccessToken(ctx, shop, client)
 	}
 
-	// Decrypt access token
 	decryptedToken, err := s.encryptionSvc.Decrypt(shop.AccessToken)
 	if err != nil {
-		s.logger.Error().Err(err).Str("domain", domain).Msg("Failed to decrypt access token")
 		return "", fmt.Errorf("failed to decrypt access token: %w", err)
 	}
-
 	return decryptedToken, nil
 }
 
-// GetProducts retrieves products for a shop
-func (s *ShopifyService) GetProducts(ctx context.Context, domain string) ([]goshopify.Product, error) {
+// shopClient returns the Shopify client and decrypted access token for a shop
+func (s *ShopifyService) shopClient(ctx context.Context, shopDomain string) (ports.ShopifyClient, string, error) {
+	accessToken, err := s.getDecryptedAccessToken(ctx, shopDomain)
+	if err != nil {
+		return nil, "", err
+	}
+
+	client, err := s.GetClientForTenant(ctx, "")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get client: %w", err)
+	}
+
+	return client, accessToken, nil
+}
+
+// GetProducts retrieves a page of products for a shop
+func (s *ShopifyService) GetProducts(ctx context.Context, domain string, options interface{}) ([]goshopify.Product, *goshopify.Pagination, error) {
 	// Get and decrypt access token
 	accessToken, err := s.getDecryptedAccessToken(ctx, domain)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Get client for tenant
 	client, err := s.GetClientForTenant(ctx, "")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get client: %w", err)
+		return nil, nil, fmt.Errorf("failed to get client: %w", err)
 	}
 
 	// Get products from Shopify API
-	products, err := client.GetProducts(ctx, domain, accessToken, nil)
+	products, pagination, err := client.GetProducts(ctx, domain, accessToken, options)
 	if err != nil {
 		s.logger.Error().Err(err).Str("domain", domain).Msg("Failed to get products")
-		return nil, fmt.Errorf("failed to get products: %w", err)
+		return nil, nil, fmt.Errorf("failed to get products: %w", err)
 	}
 
-	return products, nil
+	return products, pagination, nil
 }
 
-// GetProduct retrieves a single product by ID
-func (s *ShopifyService) GetProduct(ctx context.Context, domain string, productID int64) (*goshopify.Product, error) {
-	// Get and decrypt access token
-	accessToken, err := s.getDecryptedAccessToken(ctx, domain)
+// GetProduct retrieves a single product by ID, from the API the operation is routed to
+func (s *ShopifyService) GetProduct(ctx context.Context, shopDomain string, productID int64) (*goshopify.Product, error) {
+	client, accessToken, err := s.resourceReader(ctx, shopDomain, domain.OperationGetProduct)
 	if err != nil {
 		return nil, err
 	}
 
-	client, err := s.GetClientForTenant(ctx, "")
+	product, err := client.GetProduct(ctx, shopDomain, accessToken, productID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get client: %w", err)
-	}
-
-	product, err := client.GetProduct(ctx, domain, accessToken, productID)
-	if err != nil {
-		s.logger.Error().Err(err).Str("domain", domain).Int64("productID", productID).Msg("Failed to get product")
+		s.logger.Error().Err(err).Str("domain", shopDomain).Int64("productID", productID).Msg("Failed to get product")
 		return nil, fmt.Errorf("failed to get product: %w", err)
 	}
 
 	return product, nil
 }
 
-// GetOrders retrieves orders for a shop
-func (s *ShopifyService) GetOrders(ctx context.Context, domain string) ([]goshopify.Order, error) {
+// GetOrders retrieves a page of orders for a shop
+func (s *ShopifyService) GetOrders(ctx context.Context, domain string, options interface{}) ([]goshopify.Order, *goshopify.Pagination, error) {
 	// Get and decrypt access token
 	accessToken, err := s.getDecryptedAccessToken(ctx, domain)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	client, err := s.GetClientForTenant(ctx, "")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get client: %w", err)
+		return nil, nil, fmt.Errorf("failed to get client: %w", err)
 	}
 
-	orders, err := client.GetOrders(ctx, domain, accessToken, nil)
+	orders, pagination, err := client.GetOrders(ctx, domain, accessToken, options)
 	if err != nil {
 		s.logger.Error().Err(err).Str("domain", domain).Msg("Failed to get orders")
-		return nil, fmt.Errorf("failed to get orders: %w", err)
+		return nil, nil, fmt.Errorf("failed to get orders: %w", err)
 	}
 
-	return orders, nil
+	return orders, pagination, nil
 }
 
-// GetOrder retrieves a single order by ID
-func (s *ShopifyService) GetOrder(ctx context.Context, domain string, orderID int64) (*goshopify.Order, error) {
+// GetOrder retrieves a single order by ID, from the API the operation is routed to
+func (s *ShopifyService) GetOrder(ctx context.Context, shopDomain string, orderID int64) (*goshopify.Order, error) {
+	client, accessToken, err := s.resourceReader(ctx, shopDomain, domain.OperationGetOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := client.GetOrder(ctx, shopDomain, accessToken, orderID)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Int64("orderID", orderID).Msg("Failed to get order")
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	return order, nil
+}
+
+// GetCustomers retrieves a page of customers for a shop
+func (s *ShopifyService) GetCustomers(ctx context.Context, domain string, options interface{}) ([]goshopify.Customer, *goshopify.Pagination, error) {
 	// Get and decrypt access token
 	accessToken, err := s.getDecryptedAccessToken(ctx, domain)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	client, err := s.GetClientForTenant(ctx, "")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get client: %w", err)
+		return nil, nil, fmt.Errorf("failed to get client: %w", err)
 	}
 
-	order, err := client.GetOrder(ctx, domain, accessToken, orderID)
+	customers, pagination, err := client.GetCustomers(ctx, domain, accessToken, options)
 	if err != nil {
-		s.logger.Error().Err(err).Str("domain", domain).Int64("orderID", orderID).Msg("Failed to get order")
-		return nil, fmt.Errorf("failed to get order: %w", err)
+		s.logger.Error().Err(err).Str("domain", domain).Msg("Failed to get customers")
+		return nil, nil, fmt.Errorf("failed to get customers: %w", err)
 	}
 
-	return order, nil
+	return customers, pagination, nil
+}
+
+// GetCustomer retrieves a single customer by ID, from the API the operation is routed to
+func (s *ShopifyService) GetCustomer(ctx context.Context, shopDomain string, customerID int64) (*goshopify.Customer, error) {
+	client, accessToken, err := s.resourceReader(ctx, shopDomain, domain.OperationGetCustomer)
+	if err != nil {
+		return nil, err
+	}
+
+	customer, err := client.GetCustomer(ctx, shopDomain, accessToken, customerID)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Int64("customerID", customerID).Msg("Failed to get customer")
+		return nil, fmt.Errorf("failed to get customer: %w", err)
+	}
+
+	return customer, nil
 }
 
-// GetCustomers retrieves customers for a shop
-func (s *ShopifyService) GetCustomers(ctx context.Context, domain string) ([]goshopify.Customer, error) {
+// SearchCustomers retrieves a page of customers matching a search query
+func (s *ShopifyService) SearchCustomers(ctx context.Context, domain string, options interface{}) ([]goshopify.Customer, *goshopify.Pagination, error) {
 	// Get and decrypt access token
 	accessToken, err := s.getDecryptedAccessToken(ctx, domain)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	client, err := s.GetClientForTenant(ctx, "")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get client: %w", err)
+		return nil, nil, fmt.Errorf("failed to get client: %w", err)
 	}
 
-	customers, err := client.GetCustomers(ctx, domain, accessToken, nil)
+	customers, pagination, err := client.SearchCustomers(ctx, domain, accessToken, options)
 	if err != nil {
-		s.logger.Error().Err(err).Str("domain", domain).Msg("Failed to get customers")
-		return nil, fmt.Errorf("failed to get customers: %w", err)
+		s.logger.Error().Err(err).Str("domain", domain).Msg("Failed to search customers")
+		return nil, nil, fmt.Errorf("failed to search customers: %w", err)
 	}
 
-	return customers, nil
+	return customers, pagination, nil
 }
 
-// GetCustomer retrieves a single customer by ID
-func (s *ShopifyService) GetCustomer(ctx context.Context, domain string, customerID int64) (*goshopify.Customer, error) {
+// GetInventoryLevels retrieves a page of inventory levels for a shop
+func (s *ShopifyService) GetInventoryLevels(ctx context.Context, domain string, options interface{}) ([]goshopify.InventoryLevel, *goshopify.Pagination, error) {
 	// Get and decrypt access token
 	accessToken, err := s.getDecryptedAccessToken(ctx, domain)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	client, err := s.GetClientForTenant(ctx, "")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get client: %w", err)
+		return nil, nil, fmt.Errorf("failed to get client: %w", err)
 	}
 
-	customer, err := client.GetCustomer(ctx, domain, accessToken, customerID)
+	levels, pagination, err := client.GetInventoryLevels(ctx, domain, accessToken, options)
 	if err != nil {
-		s.logger.Error().Err(err).Str("domain", domain).Int64("customerID", customerID).Msg("Failed to get customer")
-		return nil, fmt.Errorf("failed to get customer: %w", err)
+		s.logger.Error().Err(err).Str("domain", domain).Msg("Failed to get inventory levels")
+		return nil, nil, fmt.Errorf("failed to get inventory levels: %w", err)
 	}
 
-	return customer, nil
+	return levels, pagination, nil
 }
 
-// SearchCustomers searches customers by query string
-func (s *ShopifyService) SearchCustomers(ctx context.Context, domain string, query string) ([]goshopify.Customer, error) {
-	// Get and decrypt access token
+// GetCustomerOrders retrieves the orders of a customer
+func (s *ShopifyService) GetCustomerOrders(ctx context.Context, domain string, customerID int64) ([]goshopify.Order, error) {
 	accessToken, err := s.getDecryptedAccessToken(ctx, domain)
 	if err != nil {
 		return nil, err
@@ -641,18 +854,17 @@ func (s *ShopifyService) SearchCustomers(ctx context.Context, domain string, que
 		return nil, fmt.Errorf("failed to get client: %w", err)
 	}
 
-	customers, err := client.SearchCustomers(ctx, domain, accessToken, query)
+	orders, err := client.ListCustomerOrders(ctx, domain, accessToken, customerID, nil)
 	if err != nil {
-		s.logger.Error().Err(err).Str("domain", domain).Str("query", query).Msg("Failed to search customers")
-		return nil, fmt.Errorf("failed to search customers: %w", err)
+		s.logger.Error().Err(err).Str("domain", domain).Int64("customerID", customerID).Msg("Failed to get customer orders")
+		return nil, fmt.Errorf("failed to get customer orders: %w", err)
 	}
 
-	return customers, nil
+	return orders, nil
 }
 
-// GetInventoryLevels retrieves inventory levels for a shop
-func (s *ShopifyService) GetInventoryLevels(ctx context.Context, domain string) ([]goshopify.InventoryLevel, error) {
-	// Get and decrypt access token
+// GetOrderTransactions retrieves the payment transactions of an order
+func (s *ShopifyService) GetOrderTransactions(ctx context.Context, domain string, orderID int64) ([]goshopify.Transaction, error) {
 	accessToken, err := s.getDecryptedAccessToken(ctx, domain)
 	if err != nil {
 		return nil, err
@@ -663,17 +875,18 @@ func (s *ShopifyService) GetInventoryLevels(ctx context.Context, domain string)
 		return nil, fmt.Errorf("failed to get client: %w", err)
 	}
 
-	levels, err := client.GetInventoryLevels(ctx, domain, accessToken, nil)
+	transactions, err := client.ListOrderTransactions(ctx, domain, accessToken, orderID)
 	if err != nil {
-		s.logger.Error().Err(err).Str("domain", domain).Msg("Failed to get inventory levels")
-		return nil, fmt.Errorf("failed to get inventory levels: %w", err)
+		s.logger.Error().Err(err).Str("domain", domain).Int64("orderID", orderID).Msg("Failed to get order transactions")
+		return nil, fmt.Errorf("failed to get order transactions: %w", err)
 	}
 
-	return levels, nil
+	return transactions, nil
 }
 
 // CreateProduct creates a new product
-func (s *ShopifyService) CreateProduct(ctx context.Context, domain string, product *goshopify.Product) (*goshopify.Product, error) {
+// variantSettings holds the variant booleans set explicitly, by variant position, see domain.VariantSettings
+func (s *ShopifyService) CreateProduct(ctx context.Context, domain string, product *goshopify.Product, variantSettings []domain.VariantSettings) (*goshopify.Product, error) {
 	accessToken, err := s.getDecryptedAccessToken(ctx, domain)
 	if err != nil {
 		return nil, err
@@ -684,7 +897,7 @@ func (s *ShopifyService) CreateProduct(ctx context.Context, domain string, produ
 		return nil, fmt.Errorf("failed to get client: %w", err)
 	}
 
-	created, err := client.CreateProduct(ctx, domain, accessToken, product)
+	created, err := client.CreateProduct(ctx, domain, accessToken, product, variantSettings)
 	if err != nil {
 		s.logger.Error().Err(err).Str("domain", domain).Msg("Failed to create product")
 		return nil, fmt.Errorf("failed to create product: %w", err)
@@ -694,7 +907,8 @@ func (s *ShopifyService) CreateProduct(ctx context.Context, domain string, produ
 }
 
 // UpdateProduct updates an existing product
-func (s *ShopifyService) UpdateProduct(ctx context.Context, domain string, product *goshopify.Product) (*goshopify.Product, error) {
+// Variants keep their current requires_shipping and taxable unless set in variantSettings
+func (s *ShopifyService) UpdateProduct(ctx context.Context, domain string, product *goshopify.Product, variantSettings []domain.VariantSettings) (*goshopify.Product, error) {
 	accessToken, err := s.getDecryptedAccessToken(ctx, domain)
 	if err != nil {
 		return nil, err
@@ -705,7 +919,7 @@ func (s *ShopifyService) UpdateProduct(ctx context.Context, domain string, produ
 		return nil, fmt.Errorf("failed to get client: %w", err)
 	}
 
-	updated, err := client.UpdateProduct(ctx, domain, accessToken, product)
+	updated, err := client.UpdateProduct(ctx, domain, accessToken, product, variantSettings)
 	if err != nil {
 		s.logger.Error().Err(err).Str("domain", domain).Uint64("productID", product.Id).Msg("Failed to update product")
 		return nil, fmt.Errorf("failed to update product: %w", err)
@@ -777,8 +991,8 @@ func (s *ShopifyService) UpdateOrder(ctx context.Context, domain string, order *
 	return updated, nil
 }
 
-// CancelOrder cancels an order
-func (s *ShopifyService) CancelOrder(ctx context.Context, domain string, orderID int64) (*goshopify.Order, error) {
+// CancelOrder cancels an order, optionally restocking its items and refunding it
+func (s *ShopifyService) CancelOrder(ctx context.Context, domain string, orderID int64, cancellation domain.OrderCancellation) (*goshopify.Order, error) {
 	accessToken, err := s.getDecryptedAccessToken(ctx, domain)
 	if err != nil {
 		return nil, err
@@ -789,12 +1003,14 @@ func (s *ShopifyService) CancelOrder(ctx context.Context, domain string, orderID
 		return nil, fmt.Errorf("failed to get client: %w", err)
 	}
 
-	canceled, err := client.CancelOrder(ctx, domain, accessToken, orderID)
+	canceled, err := client.CancelOrder(ctx, domain, accessToken, orderID, cancellation)
 	if err != nil {
 		s.logger.Error().Err(err).Str("domain", domain).Int64("orderID", orderID).Msg("Failed to cancel order")
 		return nil, fmt.Errorf("failed to cancel order: %w", err)
 	}
 
+	s.logger.Info().Str("domain", domain).Int64("orderID", orderID).Str("reason", string(cancellation.Reason)).Bool("restock", cancellation.Restock).Bool("refund", cancellation.Refund != nil).Msg("Order canceled")
+
 	return canceled, nil
 }
 
@@ -865,10 +1081,12 @@ func (s *ShopifyService) DeleteCustomer(ctx context.Context, domain string, cust
 func (s *ShopifyService) ProcessWebhook(ctx context.Context, topic string, shop string, payload []byte, verified bool) error {
 	// Create webhook event
 	event := &domain.WebhookEvent{
-		Topic:    topic,
-		Shop:     shop,
-		Payload:  payload,
-		Verified: verified,
+		ProjectID:   domain.GetProjectIDFromContext(ctx),
+		Environment: domain.GetEnvironmentFromContext(ctx),
+		Topic:       topic,
+		Shop:        shop,
+		Payload:     payload,
+		Verified:    verified,
 	}
 
 	// Log webhook to repository
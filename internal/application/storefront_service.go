@@ -0,0 +1,213 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"archie-core-shopify-layer/internal/domain"
+	"archie-core-shopify-layer/internal/ports"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+	"github.com/rs/zerolog"
+)
+
+// defaultStorefrontTokenTitle names tokens created without a title
+const defaultStorefrontTokenTitle = "Storefront"
+
+// StorefrontService manages the Storefront API access tokens of shops and sends Storefront API requests with them
+// Tokens are created through the Admin API and stored encrypted per shop; requests use the most recent token
+type StorefrontService struct {
+	repo           ports.StorefrontTokenRepository
+	shopifyService *ShopifyService
+	client         ports.ShopifyStorefrontClient
+	logger         zerolog.Logger
+}
+
+// NewStorefrontService creates a new Storefront service
+func NewStorefrontService(
+	repo ports.StorefrontTokenRepository,
+	shopifyService *ShopifyService,
+	client ports.ShopifyStorefrontClient,
+	logger zerolog.Logger,
+) *StorefrontService {
+	return &StorefrontService{
+		repo:           repo,
+		shopifyService: shopifyService,
+		client:         client,
+		logger:         logger,
+	}
+}
+
+// CreateStorefrontToken creates a Storefront access token for a shop and stores it
+// The returned token holds the plaintext access token, which is not returned by later listings
+func (s *StorefrontService) CreateStorefrontToken(ctx context.Context, shopDomain string, title string) (*domain.StorefrontToken, error) {
+	if strings.TrimSpace(title) == "" {
+		title = defaultStorefrontTokenTitle
+	}
+
+	client, accessToken, err := s.shopifyService.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := client.CreateStorefrontAccessToken(ctx, shopDomain, accessToken, title)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Msg("Failed to create storefront access token")
+		return nil, fmt.Errorf("failed to create storefront access token: %w", err)
+	}
+
+	token := toDomainStorefrontToken(s.shopifyService.ShopKey(ctx, shopDomain), created)
+	if principal := domain.GetPrincipalFromContext(ctx); principal != nil {
+		token.CreatedBy = string(principal.Type) + ":" + principal.Subject
+	}
+	if err := s.save(ctx, token, created.AccessToken); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().
+		Str("domain", shopDomain).
+		Uint64("tokenID", token.ID).
+		Str("createdBy", token.CreatedBy).
+		Msg("Created storefront access token")
+
+	token.AccessToken = created.AccessToken
+	return token, nil
+}
+
+// ListStorefrontTokens lists the Storefront access tokens of a shop without their secrets
+// Shopify's list is authoritative: tokens created elsewhere are stored, and tokens revoked in Shopify are removed
+func (s *StorefrontService) ListStorefrontTokens(ctx context.Context, shopDomain string) ([]*domain.StorefrontToken, error) {
+	client, accessToken, err := s.shopifyService.shopClient(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	listed, err := client.ListStorefrontAccessTokens(ctx, shopDomain, accessToken)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Msg("Failed to list storefront access tokens")
+		return nil, fmt.Errorf("failed to list storefront access tokens: %w", err)
+	}
+
+	key := s.shopifyService.ShopKey(ctx, shopDomain)
+	stored, err := s.repo.List(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list storefront tokens: %w", err)
+	}
+	storedByID := make(map[uint64]*domain.StorefrontToken, len(stored))
+	for _, token := range stored {
+		storedByID[token.ID] = token
+	}
+
+	tokens := make([]*domain.StorefrontToken, 0, len(listed))
+	for i := range listed {
+		token := toDomainStorefrontToken(key, &listed[i])
+		if existing, ok := storedByID[token.ID]; ok {
+			token.CreatedBy = existing.CreatedBy
+			delete(storedByID, token.ID)
+		} else if listed[i].AccessToken != "" {
+			if err := s.save(ctx, token, listed[i].AccessToken); err != nil {
+				return nil, err
+			}
+		}
+		token.AccessToken = ""
+		tokens = append(tokens, token)
+	}
+
+	for id := range storedByID {
+		if err := s.repo.Delete(ctx, key, id); err != nil {
+			return nil, fmt.Errorf("failed to delete storefront token: %w", err)
+		}
+		s.logger.Info().Str("domain", shopDomain).Uint64("tokenID", id).Msg("Removed storefront access token revoked in Shopify")
+	}
+
+	return tokens, nil
+}
+
+// DeleteStorefrontToken revokes a Storefront access token in Shopify and removes it
+func (s *StorefrontService) DeleteStorefrontToken(ctx context.Context, shopDomain string, tokenID uint64) error {
+	client, accessToken, err := s.shopifyService.shopClient(ctx, shopDomain)
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeleteStorefrontAccessToken(ctx, shopDomain, accessToken, int64(tokenID)); err != nil {
+		// A token already revoked in Shopify is still removed here
+		if appErr, ok := domain.AsAppError(err); !ok || appErr.Type != domain.ErrorTypeNotFound {
+			s.logger.Error().Err(err).Str("domain", shopDomain).Uint64("tokenID", tokenID).Msg("Failed to delete storefront access token")
+			return fmt.Errorf("failed to delete storefront access token: %w", err)
+		}
+	}
+
+	if err := s.repo.Delete(ctx, s.shopifyService.ShopKey(ctx, shopDomain), tokenID); err != nil {
+		return fmt.Errorf("failed to delete storefront token: %w", err)
+	}
+	return nil
+}
+
+// ExecuteStorefront sends a request to the Storefront API of a shop with its most recent token
+func (s *StorefrontService) ExecuteStorefront(ctx context.Context, shopDomain string, request domain.GraphQLRequest) (*domain.GraphQLResponse, error) {
+	storefrontToken, err := s.storefrontAccessToken(ctx, shopDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.client.Execute(ctx, shopDomain, storefrontToken, request)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", shopDomain).Str("operationName", request.OperationName).Msg("Failed to execute Storefront request")
+		return nil, fmt.Errorf("failed to execute Storefront request: %w", err)
+	}
+
+	return response, nil
+}
+
+// storefrontAccessToken returns the decrypted access token of the most recent stored token of a shop
+func (s *StorefrontService) storefrontAccessToken(ctx context.Context, shopDomain string) (string, error) {
+	tokens, err := s.repo.List(ctx, s.shopifyService.ShopKey(ctx, shopDomain))
+	if err != nil {
+		return "", fmt.Errorf("failed to list storefront tokens: %w", err)
+	}
+	if len(tokens) == 0 {
+		return "", domain.NewNotFoundError("storefront access token")
+	}
+
+	accessToken, err := s.shopifyService.encryptionSvc.Decrypt(tokens[0].AccessToken)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt storefront access token: %w", err)
+	}
+	return accessToken, nil
+}
+
+// save stores a token with its access token encrypted
+func (s *StorefrontService) save(ctx context.Context, token *domain.StorefrontToken, accessToken string) error {
+	encrypted, err := s.shopifyService.encryptionSvc.Encrypt(accessToken)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt storefront access token: %w", err)
+	}
+
+	stored := *token
+	stored.AccessToken = encrypted
+	if err := s.repo.Save(ctx, &stored); err != nil {
+		return fmt.Errorf("failed to save storefront token: %w", err)
+	}
+	return nil
+}
+
+// toDomainStorefrontToken converts a Shopify token of a shop, without its secret
+func toDomainStorefrontToken(key domain.ShopKey, token *goshopify.StorefrontAccessToken) *domain.StorefrontToken {
+	result := &domain.StorefrontToken{
+		ID:          token.Id,
+		ProjectID:   key.ProjectID,
+		Environment: key.Environment,
+		AppHandle:   key.AppHandle,
+		ShopDomain:  key.Domain,
+		Title:       token.Title,
+		CreatedAt:   token.CreatedAt,
+	}
+	for _, scope := range strings.Split(token.AccessScope, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			result.AccessScopes = append(result.AccessScopes, scope)
+		}
+	}
+	return result
+}
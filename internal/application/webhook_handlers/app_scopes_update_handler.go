@@ -0,0 +1,68 @@
+package webhook_handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"archie-core-shopify-layer/internal/application"
+	"archie-core-shopify-layer/internal/domain"
+
+	"github.com/rs/zerolog"
+)
+
+// AppScopesUpdateHandler handles app scopes update webhook events
+type AppScopesUpdateHandler struct {
+	logger              zerolog.Logger
+	installationService *application.InstallationService
+}
+
+// NewAppScopesUpdateHandler creates a new app scopes update webhook handler
+func NewAppScopesUpdateHandler(
+	logger zerolog.Logger,
+	installationService *application.InstallationService,
+) *AppScopesUpdateHandler {
+	return &AppScopesUpdateHandler{
+		logger:              logger,
+		installationService: installationService,
+	}
+}
+
+// CanHandle returns true if this handler can process the given topic
+func (h *AppScopesUpdateHandler) CanHandle(topic string) bool {
+	return topic == string(application.TopicAppScopesUpdate)
+}
+
+// Handle processes an app scopes update webhook event
+func (h *AppScopesUpdateHandler) Handle(ctx context.Context, event *domain.WebhookEvent) error {
+	var payload struct {
+		Previous []string `json:"previous"`
+		Current  []string `json:"current"`
+	}
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return fmt.Errorf("failed to parse app scopes update webhook payload: %w", err)
+	}
+
+	projectID := domain.GetProjectIDFromContext(ctx)
+	environment := domain.GetEnvironmentFromContext(ctx)
+	if environment == "" {
+		environment = domain.DefaultEnvironment
+	}
+
+	h.logger.Info().
+		Str("topic", event.Topic).
+		Str("shop", event.Shop).
+		Strs("previous", payload.Previous).
+		Strs("current", payload.Current).
+		Msg("Processing app scopes update webhook event")
+
+	if projectID == "" {
+		return nil
+	}
+
+	if _, err := h.installationService.UpdateGrantedScopes(ctx, domain.NewShopKey(projectID, environment, event.AppHandle, event.Shop), payload.Current); err != nil {
+		return fmt.Errorf("failed to update installation scopes: %w", err)
+	}
+
+	return nil
+}
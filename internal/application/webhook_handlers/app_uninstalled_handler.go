@@ -17,6 +17,7 @@ type AppUninstalledHandler struct {
 	repository                ports.Repository
 	webhookSubscriptionRepo   ports.WebhookSubscriptionRepository
 	shopifyService            *application.ShopifyService
+	installationService       *application.InstallationService
 }
 
 // NewAppUninstalledHandler creates a new app uninstalled webhook handler
@@ -25,12 +26,14 @@ func NewAppUninstalledHandler(
 	repository ports.Repository,
 	webhookSubscriptionRepo ports.WebhookSubscriptionRepository,
 	shopifyService *application.ShopifyService,
+	installationService *application.InstallationService,
 ) *AppUninstalledHandler {
 	return &AppUninstalledHandler{
 		logger:                  logger,
 		repository:              repository,
 		webhookSubscriptionRepo: webhookSubscriptionRepo,
 		shopifyService:          shopifyService,
+		installationService:     installationService,
 	}
 }
 
@@ -68,11 +71,16 @@ func (h *AppUninstalledHandler) Handle(ctx context.Context, event *domain.Webhoo
 	if environment == "" {
 		environment = domain.DefaultEnvironment
 	}
+	// Only the app whose endpoint received the webhook was uninstalled
+	appHandle := event.AppHandle
+	if appHandle == "" {
+		appHandle = domain.GetAppHandleFromContext(ctx)
+	}
 
 	// Cleanup operations:
 	// 1. Delete all webhook subscriptions for this shop
 	if h.webhookSubscriptionRepo != nil && projectID != "" {
-		subscriptions, err := h.webhookSubscriptionRepo.ListWebhookSubscriptions(ctx, projectID, environment, shopDomain)
+		subscriptions, err := h.webhookSubscriptionRepo.ListWebhookSubscriptions(ctx, projectID, environment, appHandle, shopDomain)
 		if err != nil {
 			h.logger.Warn().Err(err).Str("shop", shopDomain).Msg("Failed to list webhook subscriptions for cleanup")
 		} else {
@@ -99,15 +107,27 @@ func (h *AppUninstalledHandler) Handle(ctx context.Context, event *domain.Webhoo
 		}
 	}
 
-	// 2. Delete shop data (access tokens, etc.)
+	// 2. Mark the installation as uninstalled
+	if h.installationService != nil && projectID != "" {
+		if _, err := h.installationService.MarkUninstalled(ctx, domain.NewShopKey(projectID, environment, appHandle, shopDomain)); err != nil {
+			h.logger.Warn().Err(err).Str("shop", shopDomain).Msg("Failed to mark installation as uninstalled")
+		}
+	}
+
+	// 3. Clear the shop's tokens, Shopify has revoked them
 	// Note: We don't delete the shop record itself as it may be needed for audit purposes
-	// Instead, we could mark it as uninstalled or delete sensitive data
+	if h.shopifyService != nil {
+		if err := h.shopifyService.ClearShopTokens(ctx, shopDomain); err != nil {
+			h.logger.Warn().Err(err).Str("shop", shopDomain).Msg("Failed to clear shop tokens")
+		}
+	}
+
 	h.logger.Info().
 		Str("shop", shopDomain).
 		Str("projectId", projectID).
 		Msg("App uninstalled - cleanup completed")
 
-	// 3. Additional cleanup can be added here:
+	// 4. Additional cleanup can be added here:
 	//    - Send notification to administrators
 	//    - Update analytics/metrics
 	//    - Trigger external system cleanup
@@ -0,0 +1,65 @@
+package webhook_handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"archie-core-shopify-layer/internal/application"
+	"archie-core-shopify-layer/internal/domain"
+
+	"github.com/rs/zerolog"
+)
+
+// BulkOperationsFinishHandler handles bulk operation finish webhook events
+type BulkOperationsFinishHandler struct {
+	logger               zerolog.Logger
+	bulkOperationService *application.BulkOperationService
+}
+
+// NewBulkOperationsFinishHandler creates a new bulk operations finish webhook handler
+func NewBulkOperationsFinishHandler(
+	logger zerolog.Logger,
+	bulkOperationService *application.BulkOperationService,
+) *BulkOperationsFinishHandler {
+	return &BulkOperationsFinishHandler{
+		logger:               logger,
+		bulkOperationService: bulkOperationService,
+	}
+}
+
+// CanHandle returns true if this handler can process the given topic
+func (h *BulkOperationsFinishHandler) CanHandle(topic string) bool {
+	return topic == string(application.TopicBulkOperationsFinish)
+}
+
+// Handle processes a bulk operations finish webhook event
+func (h *BulkOperationsFinishHandler) Handle(ctx context.Context, event *domain.WebhookEvent) error {
+	var payload struct {
+		AdminGraphqlApiId string `json:"admin_graphql_api_id"`
+		Status            string `json:"status"`
+		ErrorCode         string `json:"error_code"`
+		Type              string `json:"type"`
+	}
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return fmt.Errorf("failed to parse bulk operations finish webhook payload: %w", err)
+	}
+
+	h.logger.Info().
+		Str("topic", event.Topic).
+		Str("shop", event.Shop).
+		Str("operation", payload.AdminGraphqlApiId).
+		Str("status", payload.Status).
+		Str("errorCode", payload.ErrorCode).
+		Msg("Processing bulk operations finish webhook event")
+
+	if domain.GetProjectIDFromContext(ctx) == "" {
+		return nil
+	}
+
+	if _, err := h.bulkOperationService.HandleBulkOperationFinish(ctx, event.Shop, payload.AdminGraphqlApiId); err != nil {
+		return fmt.Errorf("failed to record finished bulk operation: %w", err)
+	}
+
+	return nil
+}
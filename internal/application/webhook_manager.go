@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"archie-core-shopify-layer/internal/domain"
+	"archie-core-shopify-layer/internal/ports"
 
 	goshopify "github.com/bold-commerce/go-shopify/v4"
 	"github.com/rs/zerolog"
@@ -24,43 +25,56 @@ const (
 	TopicCustomersUpdate WebhookTopic = "customers/update"
 	TopicCustomersDelete WebhookTopic = "customers/delete"
 	TopicAppUninstalled  WebhookTopic = "app/uninstalled"
+	TopicAppScopesUpdate WebhookTopic = "app/scopes_update"
+	// TopicBulkOperationsFinish is sent when a bulk operation completes, fails or is canceled
+	TopicBulkOperationsFinish WebhookTopic = "bulk_operations/finish"
 )
 
 // WebhookManager manages webhook subscriptions
 type WebhookManager struct {
-	shopifyService *ShopifyService
-	logger         zerolog.Logger
-	webhookURL     string
+	shopifyService          *ShopifyService
+	webhookSubscriptionRepo ports.WebhookSubscriptionRepository
+	logger                  zerolog.Logger
+	webhookBaseURL          string
 }
 
 // NewWebhookManager creates a new webhook manager
+// webhookBaseURL is the public URL of the service, webhooks are registered on the receiver route of their app
 func NewWebhookManager(
 	shopifyService *ShopifyService,
+	webhookSubscriptionRepo ports.WebhookSubscriptionRepository,
 	logger zerolog.Logger,
-	webhookURL string,
+	webhookBaseURL string,
 ) *WebhookManager {
 	return &WebhookManager{
-		shopifyService: shopifyService,
-		logger:         logger,
-		webhookURL:     webhookURL,
+		shopifyService:          shopifyService,
+		webhookSubscriptionRepo: webhookSubscriptionRepo,
+		logger:                  logger,
+		webhookBaseURL:          webhookBaseURL,
 	}
 }
 
 // SubscribeToWebhooks subscribes to common webhook topics for a shop
+// Webhooks of additional apps go to their own receiver route, which verifies them with that app's secret
 func (m *WebhookManager) SubscribeToWebhooks(ctx context.Context, shopDomain string, accessToken string, topics []WebhookTopic) error {
 	// Extract projectID and environment from context
 	projectID := domain.GetProjectIDFromContext(ctx)
 	environment := domain.GetEnvironmentFromContext(ctx)
-	
 	if environment == "" {
 		environment = domain.DefaultEnvironment
 	}
 
-	// Build webhook URL with project/environment
-	webhookAddress := fmt.Sprintf("%s/%s/%s", m.webhookURL, projectID, environment)
+	// Get config to get the app and its API key/secret for creating Shopify client
+	config, err := m.shopifyService.GetConfig(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to get config: %w", err)
+	}
+
+	// Build webhook URL with project/environment/app
+	webhookAddress := buildWebhookURL(m.webhookBaseURL, projectID, environment, config.AppHandle)
 
 	for _, topic := range topics {
-		if err := m.createWebhook(ctx, shopDomain, accessToken, string(topic), webhookAddress); err != nil {
+		if err := m.createWebhook(ctx, config, shopDomain, accessToken, string(topic), webhookAddress); err != nil {
 			m.logger.Error().
 				Err(err).
 				Str("shop", shopDomain).
@@ -79,13 +93,7 @@ func (m *WebhookManager) SubscribeToWebhooks(ctx context.Context, shopDomain str
 }
 
 // createWebhook creates a single webhook subscription using Shopify Admin API
-func (m *WebhookManager) createWebhook(ctx context.Context, shopDomain string, accessToken string, topic string, address string) error {
-	// Get config to get API key/secret for creating Shopify client
-	config, err := m.shopifyService.GetConfig(ctx, "")
-	if err != nil {
-		return fmt.Errorf("failed to get config: %w", err)
-	}
-
+func (m *WebhookManager) createWebhook(ctx context.Context, config *domain.ShopifyConfig, shopDomain string, accessToken string, topic string, address string) error {
 	// Decrypt API secret
 	apiSecret, err := m.shopifyService.encryptionSvc.Decrypt(config.EncryptedKey)
 	if err != nil {
@@ -122,9 +130,41 @@ func (m *WebhookManager) createWebhook(ctx context.Context, shopDomain string, a
 		Uint64("webhookId", created.Id).
 		Msg("Webhook subscription created successfully")
 
+	// Record the subscription so environments can be compared and uninstalls can clean up
+	if err := m.recordSubscription(ctx, config.AppHandle, shopDomain, topic, address, int64(created.Id)); err != nil {
+		m.logger.Warn().Err(err).Str("shop", shopDomain).Str("topic", topic).Msg("Failed to record webhook subscription")
+	}
+
 	return nil
 }
 
+// recordSubscription saves a created webhook subscription, replacing an earlier one for the same topic
+func (m *WebhookManager) recordSubscription(ctx context.Context, appHandle string, shopDomain string, topic string, address string, webhookID int64) error {
+	projectID := domain.GetProjectIDFromContext(ctx)
+	environment := domain.GetEnvironmentFromContext(ctx)
+	if environment == "" {
+		environment = domain.DefaultEnvironment
+	}
+
+	subscription, err := m.webhookSubscriptionRepo.GetWebhookSubscription(ctx, projectID, environment, appHandle, shopDomain, topic)
+	if err != nil {
+		return err
+	}
+	if subscription == nil {
+		subscription = &domain.WebhookSubscription{
+			ProjectID:   projectID,
+			Environment: environment,
+			AppHandle:   domain.NormalizeAppHandle(appHandle),
+			ShopDomain:  shopDomain,
+			Topic:       topic,
+		}
+	}
+	subscription.WebhookID = webhookID
+	subscription.Address = address
+
+	return m.webhookSubscriptionRepo.SaveWebhookSubscription(ctx, subscription)
+}
+
 // GetDefaultTopics returns the default webhook topics to subscribe to
 func (m *WebhookManager) GetDefaultTopics() []WebhookTopic {
 	return []WebhookTopic{
@@ -133,6 +173,8 @@ func (m *WebhookManager) GetDefaultTopics() []WebhookTopic {
 		TopicProductsCreate,
 		TopicProductsUpdate,
 		TopicAppUninstalled,
+		TopicAppScopesUpdate,
+		TopicBulkOperationsFinish,
 	}
 }
 
@@ -0,0 +1,94 @@
+package domain
+
+import "time"
+
+// BulkOperationType is the kind of a Shopify bulk operation
+type BulkOperationType string
+
+const (
+	BulkOperationTypeQuery    BulkOperationType = "QUERY"
+	BulkOperationTypeMutation BulkOperationType = "MUTATION"
+)
+
+// BulkOperationStatus is the progress of a Shopify bulk operation
+type BulkOperationStatus string
+
+const (
+	BulkOperationStatusCreated   BulkOperationStatus = "CREATED"
+	BulkOperationStatusRunning   BulkOperationStatus = "RUNNING"
+	BulkOperationStatusCompleted BulkOperationStatus = "COMPLETED"
+	BulkOperationStatusCanceling BulkOperationStatus = "CANCELING"
+	BulkOperationStatusCanceled  BulkOperationStatus = "CANCELED"
+	BulkOperationStatusFailed    BulkOperationStatus = "FAILED"
+	BulkOperationStatusExpired   BulkOperationStatus = "EXPIRED"
+)
+
+// Done returns true once Shopify no longer works on the operation
+func (s BulkOperationStatus) Done() bool {
+	switch s {
+	case BulkOperationStatusCompleted, BulkOperationStatusCanceled, BulkOperationStatusFailed, BulkOperationStatusExpired:
+		return true
+	}
+	return false
+}
+
+// BulkOperation is a Shopify bulk query or mutation started for a shop, tracked per project/environment/app
+// Results are a JSONL file at URL, available for a week after completion; objects nested in connections are
+// separate lines referring to their parent with __parentId
+type BulkOperation struct {
+	ID               uint64              `json:"id"` // Shopify's BulkOperation ID
+	ProjectID        string              `json:"project_id"`
+	Environment      string              `json:"environment"`
+	AppHandle        string              `json:"app_handle"`
+	ShopDomain       string              `json:"shop_domain"`
+	Type             BulkOperationType   `json:"type"`
+	Status           BulkOperationStatus `json:"status"`
+	Query            string              `json:"query"`
+	StagedUploadPath string              `json:"staged_upload_path,omitempty"` // Variables of a bulk mutation
+	ErrorCode        string              `json:"error_code,omitempty"`
+	ObjectCount      int64               `json:"object_count"`
+	RootObjectCount  int64               `json:"root_object_count"`
+	FileSize         int64               `json:"file_size"`
+	URL              string              `json:"url,omitempty"`
+	PartialDataURL   string              `json:"partial_data_url,omitempty"` // Results gathered before a failure
+	StartedBy        string              `json:"started_by,omitempty"`       // Principal that started the operation
+	CreatedAt        *time.Time          `json:"created_at"`
+	CompletedAt      *time.Time          `json:"completed_at"`
+	UpdatedAt        time.Time           `json:"updated_at"`
+}
+
+// Key returns the key of the shop the operation runs on
+func (o *BulkOperation) Key() ShopKey {
+	return NewShopKey(o.ProjectID, o.Environment, o.AppHandle, o.ShopDomain)
+}
+
+// ResultURL returns the URL of the results, the partial data of a failed operation when there are no others
+func (o *BulkOperation) ResultURL() string {
+	if o.URL != "" {
+		return o.URL
+	}
+	return o.PartialDataURL
+}
+
+// StagedUploadParameter is a form field that must be sent with a staged upload
+type StagedUploadParameter struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// StagedUploadTarget is where Shopify accepts a file, POSTed as multipart form with the parameters first
+type StagedUploadTarget struct {
+	URL         string                  `json:"url"`
+	ResourceURL string                  `json:"resourceUrl"`
+	Parameters  []StagedUploadParameter `json:"parameters"`
+}
+
+// Path returns the staged upload path a bulk mutation takes, the "key" parameter of the target
+func (t *StagedUploadTarget) Path() string {
+	for _, parameter := range t.Parameters {
+		if parameter.Name == "key" {
+			return parameter.Value
+		}
+	}
+	return ""
+}
@@ -3,3 +3,14 @@ package domain
 // DefaultEnvironment is the default environment if not specified
 const DefaultEnvironment = "master"
 
+// DefaultAppHandle is the app handle used by single-app projects and configs created before multi-app support
+const DefaultAppHandle = "default"
+
+// NormalizeAppHandle returns the app handle, falling back to DefaultAppHandle when empty
+func NormalizeAppHandle(appHandle string) string {
+	if appHandle == "" {
+		return DefaultAppHandle
+	}
+	return appHandle
+}
+
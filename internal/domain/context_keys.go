@@ -12,6 +12,8 @@ const (
 	EnvironmentKey ContextKey = "environment"
 	// TenantIDKey is the key for the tenant ID in the context (backward compatibility)
 	TenantIDKey ContextKey = "tenantId"
+	// AppHandleKey is the key for the Shopify app handle in the context
+	AppHandleKey ContextKey = "appHandle"
 )
 
 // GetProjectIDFromContext extracts the project ID from context in a type-safe way
@@ -38,6 +40,15 @@ func GetTenantIDFromContext(ctx context.Context) string {
 	return ""
 }
 
+// GetAppHandleFromContext extracts the app handle from context in a type-safe way
+// Returns an empty string if no app was selected, letting single-app projects resolve their only app
+func GetAppHandleFromContext(ctx context.Context) string {
+	if appHandle, ok := ctx.Value(AppHandleKey).(string); ok {
+		return appHandle
+	}
+	return ""
+}
+
 // WithProjectID adds the project ID to the context in a type-safe way
 func WithProjectID(ctx context.Context, projectID string) context.Context {
 	return context.WithValue(ctx, ProjectIDKey, projectID)
@@ -48,6 +59,11 @@ func WithEnvironment(ctx context.Context, environment string) context.Context {
 	return context.WithValue(ctx, EnvironmentKey, environment)
 }
 
+// WithAppHandle adds the app handle to the context in a type-safe way
+func WithAppHandle(ctx context.Context, appHandle string) context.Context {
+	return context.WithValue(ctx, AppHandleKey, appHandle)
+}
+
 // WithTenantID adds the tenant ID to the context in a type-safe way (backward compatibility)
 func WithTenantID(ctx context.Context, tenantID string) context.Context {
 	return context.WithValue(ctx, TenantIDKey, tenantID)
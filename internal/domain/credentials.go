@@ -11,16 +11,19 @@ type ShopifyConfig struct {
 	ID            string
 	ProjectID     string // The project ID (from X-Project-ID header)
 	Environment   string // The environment name (from environment header, e.g., "master")
+	AppHandle     string // Identifies the app when a project environment runs several Shopify apps
 	EncryptedKey  string // Encrypted API secret
 	APIKey        string // API key (not encrypted, public)
 	WebhookSecret string // Webhook secret for verification
 	WebhookURL    string // Webhook URL
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	// ExpiringOfflineTokens opts the project in to expiring offline access tokens with refresh tokens
+	ExpiringOfflineTokens bool
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
 }
 
 // NewShopifyConfig creates a new Shopify configuration with validation
-func NewShopifyConfig(projectID, environment, encryptedKey, apiKey, webhookSecret, webhookURL string) (*ShopifyConfig, error) {
+func NewShopifyConfig(projectID, environment, appHandle, encryptedKey, apiKey, webhookSecret, webhookURL string) (*ShopifyConfig, error) {
 	// Validate projectID
 	if projectID == "" {
 		return nil, fmt.Errorf("projectID cannot be empty")
@@ -45,6 +48,7 @@ func NewShopifyConfig(projectID, environment, encryptedKey, apiKey, webhookSecre
 	return &ShopifyConfig{
 		ProjectID:     projectID,
 		Environment:   environment,
+		AppHandle:     NormalizeAppHandle(appHandle),
 		EncryptedKey:  encryptedKey,
 		APIKey:        apiKey,
 		WebhookSecret: webhookSecret,
@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// MaxDiscountCodeBatchSize is the most codes Shopify accepts in one discount code batch
+const MaxDiscountCodeBatchSize = 100
+
+// DiscountCodeBatchStatus is the progress of a discount code batch
+type DiscountCodeBatchStatus string
+
+const (
+	DiscountCodeBatchStatusQueued    DiscountCodeBatchStatus = "queued"
+	DiscountCodeBatchStatusRunning   DiscountCodeBatchStatus = "running"
+	DiscountCodeBatchStatusCompleted DiscountCodeBatchStatus = "completed"
+)
+
+// DiscountCodeBatch is a Shopify job creating the discount codes of a price rule in the background
+type DiscountCodeBatch struct {
+	ID            uint64                  `json:"id"`
+	PriceRuleID   uint64                  `json:"price_rule_id"`
+	Status        DiscountCodeBatchStatus `json:"status"`
+	CodesCount    int                     `json:"codes_count"`
+	ImportedCount int                     `json:"imported_count"`
+	FailedCount   int                     `json:"failed_count"`
+	Logs          []string                `json:"logs"`
+	StartedAt     *time.Time              `json:"started_at"`
+	CompletedAt   *time.Time              `json:"completed_at"`
+	CreatedAt     *time.Time              `json:"created_at"`
+	UpdatedAt     *time.Time              `json:"updated_at"`
+}
+
+// Done returns true once Shopify has processed every code of the batch
+func (b *DiscountCodeBatch) Done() bool {
+	return b.Status == DiscountCodeBatchStatusCompleted
+}
+
+// DiscountCodeBatchCode is a code of a batch, with the errors that prevented its creation
+type DiscountCodeBatchCode struct {
+	ID         uint64              `json:"id"` // 0 when the code was not created
+	Code       string              `json:"code"`
+	UsageCount int                 `json:"usage_count"`
+	Errors     map[string][]string `json:"errors"`
+}
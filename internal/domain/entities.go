@@ -4,28 +4,169 @@ import (
 	"time"
 )
 
-// Shop represents a Shopify store tenant
+// TokenStatus describes the lifecycle state of a shop's access token
+type TokenStatus string
+
+const (
+	// TokenStatusNonExpiring is a legacy offline token that never expires
+	TokenStatusNonExpiring TokenStatus = "NON_EXPIRING"
+	// TokenStatusActive is an expiring token that is still valid
+	TokenStatusActive TokenStatus = "ACTIVE"
+	// TokenStatusExpiring is an expiring token inside the proactive refresh window
+	TokenStatusExpiring TokenStatus = "EXPIRING"
+	// TokenStatusExpired is an expiring token past its expiry
+	TokenStatusExpired TokenStatus = "EXPIRED"
+	// TokenStatusRefreshFailed is an expiring token whose last refresh attempt failed
+	TokenStatusRefreshFailed TokenStatus = "REFRESH_FAILED"
+)
+
+// DefaultTokenRefreshWindow is how long before expiry an expiring access token is refreshed
+const DefaultTokenRefreshWindow = 5 * time.Minute
+
+// Shop represents a Shopify store installed in a project environment
+// The same store can be installed by several projects, so a shop is identified by its ShopKey
 type Shop struct {
 	ID          string    `json:"id" bson:"_id"`
+	ProjectID   string    `json:"project_id" bson:"project_id"`
+	Environment string    `json:"environment" bson:"environment"`
 	Domain      string    `json:"domain" bson:"domain"`
 	AccessToken string    `json:"-" bson:"access_token"` // Encrypted
 	Scopes      []string  `json:"scopes" bson:"scopes"`
+	AppHandle   string    `json:"app_handle" bson:"app_handle"` // App that holds the access token
 	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at" bson:"updated_at"`
+
+	// Expiring offline token fields (only set when the project opted in to expiring tokens)
+	RefreshToken          string     `json:"-" bson:"refresh_token"` // Encrypted
+	AccessTokenExpiresAt  *time.Time `json:"access_token_expires_at,omitempty" bson:"access_token_expires_at,omitempty"`
+	RefreshTokenExpiresAt *time.Time `json:"refresh_token_expires_at,omitempty" bson:"refresh_token_expires_at,omitempty"`
+	TokenRefreshedAt      *time.Time `json:"token_refreshed_at,omitempty" bson:"token_refreshed_at,omitempty"`
+	TokenRefreshError     string     `json:"token_refresh_error,omitempty" bson:"token_refresh_error,omitempty"`
+}
+
+// ShopKey is the composite identity of a shop: project, environment, app and store domain
+type ShopKey struct {
+	ProjectID   string
+	Environment string
+	AppHandle   string
+	Domain      string
+}
+
+// NewShopKey creates a shop key, defaulting the environment and app handle
+func NewShopKey(projectID, environment, appHandle, shopDomain string) ShopKey {
+	if environment == "" {
+		environment = DefaultEnvironment
+	}
+	return ShopKey{
+		ProjectID:   projectID,
+		Environment: environment,
+		AppHandle:   NormalizeAppHandle(appHandle),
+		Domain:      shopDomain,
+	}
+}
+
+// String returns a stable representation of the key, usable as a map or lock key
+func (k ShopKey) String() string {
+	return k.ProjectID + "/" + k.Environment + "/" + k.AppHandle + "/" + k.Domain
+}
+
+// Key returns the composite identity of the shop
+func (s *Shop) Key() ShopKey {
+	return NewShopKey(s.ProjectID, s.Environment, s.AppHandle, s.Domain)
+}
+
+// HasExpiringToken returns true if the shop holds an expiring offline token with a refresh token
+func (s *Shop) HasExpiringToken() bool {
+	return s.AccessTokenExpiresAt != nil && s.RefreshToken != ""
+}
+
+// NeedsTokenRefresh returns true if the access token expires within the given window
+func (s *Shop) NeedsTokenRefresh(now time.Time, window time.Duration) bool {
+	if !s.HasExpiringToken() {
+		return false
+	}
+	return now.Add(window).After(*s.AccessTokenExpiresAt)
+}
+
+// TokenStatus returns the current token lifecycle status of the shop
+func (s *Shop) TokenStatus(now time.Time) TokenStatus {
+	if s.AccessTokenExpiresAt == nil {
+		return TokenStatusNonExpiring
+	}
+	if s.TokenRefreshError != "" {
+		return TokenStatusRefreshFailed
+	}
+	if now.After(*s.AccessTokenExpiresAt) {
+		return TokenStatusExpired
+	}
+	if s.NeedsTokenRefresh(now, DefaultTokenRefreshWindow) {
+		return TokenStatusExpiring
+	}
+	return TokenStatusActive
+}
+
+// ApplyToken copies token expiry metadata onto the shop
+// The access and refresh tokens must already be encrypted by the caller
+func (s *Shop) ApplyToken(token *Token, encryptedAccessToken, encryptedRefreshToken string, now time.Time) {
+	s.AccessToken = encryptedAccessToken
+	s.RefreshToken = encryptedRefreshToken
+	s.AccessTokenExpiresAt = nil
+	s.RefreshTokenExpiresAt = nil
+	s.TokenRefreshError = ""
+
+	if token.ExpiresIn > 0 {
+		expiresAt := now.Add(time.Duration(token.ExpiresIn) * time.Second)
+		s.AccessTokenExpiresAt = &expiresAt
+	}
+	if token.RefreshTokenExpiresIn > 0 {
+		refreshExpiresAt := now.Add(time.Duration(token.RefreshTokenExpiresIn) * time.Second)
+		s.RefreshTokenExpiresAt = &refreshExpiresAt
+	}
+}
+
+// ClearTokens removes the shop's tokens, which Shopify revokes when the app is uninstalled
+func (s *Shop) ClearTokens() {
+	s.AccessToken = ""
+	s.RefreshToken = ""
+	s.AccessTokenExpiresAt = nil
+	s.RefreshTokenExpiresAt = nil
+	s.TokenRefreshError = ""
 }
 
 // Token represents an OAuth token
 type Token struct {
-	AccessToken string `json:"access_token"`
-	Scope       string `json:"scope"`
+	AccessToken           string `json:"access_token"`
+	Scope                 string `json:"scope"`
+	ExpiresIn             int    `json:"expires_in,omitempty"`               // Seconds until the access token expires (expiring tokens only)
+	RefreshToken          string `json:"refresh_token,omitempty"`            // Refresh token (expiring tokens only)
+	RefreshTokenExpiresIn int    `json:"refresh_token_expires_in,omitempty"` // Seconds until the refresh token expires
+
+	// Online access tokens only
+	AssociatedUserScope string          `json:"associated_user_scope,omitempty"`
+	AssociatedUser      *AssociatedUser `json:"associated_user,omitempty"`
+}
+
+// AssociatedUser is the staff member an online access token belongs to
+type AssociatedUser struct {
+	ID            int64  `json:"id"`
+	FirstName     string `json:"first_name"`
+	LastName      string `json:"last_name"`
+	Email         string `json:"email"`
+	AccountOwner  bool   `json:"account_owner"`
+	Locale        string `json:"locale"`
+	Collaborator  bool   `json:"collaborator"`
+	EmailVerified bool   `json:"email_verified"`
 }
 
 // WebhookEvent represents a received webhook
 type WebhookEvent struct {
-	ID        string    `json:"id" bson:"_id"`
-	Topic     string    `json:"topic" bson:"topic"`
-	Shop      string    `json:"shop" bson:"shop"`
-	Payload   []byte    `json:"payload" bson:"payload"`
-	Verified  bool      `json:"verified" bson:"verified"`
-	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	ID          string    `json:"id" bson:"_id"`
+	ProjectID   string    `json:"project_id" bson:"project_id"`
+	Environment string    `json:"environment" bson:"environment"`
+	AppHandle   string    `json:"app_handle" bson:"app_handle"` // App whose webhook endpoint received the event
+	Topic       string    `json:"topic" bson:"topic"`
+	Shop        string    `json:"shop" bson:"shop"`
+	Payload     []byte    `json:"payload" bson:"payload"`
+	Verified    bool      `json:"verified" bson:"verified"`
+	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
 }
@@ -0,0 +1,70 @@
+package domain
+
+import "sort"
+
+// EnvironmentSummary describes an environment of a project: its app configurations and install status
+type EnvironmentSummary struct {
+	Name          string
+	Apps          []*ShopifyConfig
+	InstallCounts map[InstallState]int
+	ActiveShops   int // Shops with the app currently installed
+}
+
+// StringSetDiff compares two sets of strings, each list is sorted
+type StringSetDiff struct {
+	OnlyInSource []string
+	OnlyInTarget []string
+	Common       []string
+}
+
+// DiffStrings compares the distinct values of two lists
+func DiffStrings(source, target []string) StringSetDiff {
+	sourceSet := make(map[string]bool, len(source))
+	for _, value := range source {
+		sourceSet[value] = true
+	}
+	targetSet := make(map[string]bool, len(target))
+	for _, value := range target {
+		targetSet[value] = true
+	}
+
+	diff := StringSetDiff{OnlyInSource: []string{}, OnlyInTarget: []string{}, Common: []string{}}
+	for value := range sourceSet {
+		if targetSet[value] {
+			diff.Common = append(diff.Common, value)
+		} else {
+			diff.OnlyInSource = append(diff.OnlyInSource, value)
+		}
+	}
+	for value := range targetSet {
+		if !sourceSet[value] {
+			diff.OnlyInTarget = append(diff.OnlyInTarget, value)
+		}
+	}
+
+	sort.Strings(diff.OnlyInSource)
+	sort.Strings(diff.OnlyInTarget)
+	sort.Strings(diff.Common)
+	return diff
+}
+
+// AppConfigDiff compares the configuration of one app in two environments
+// Secrets are never compared
+type AppConfigDiff struct {
+	AppHandle                   string
+	InSource                    bool
+	InTarget                    bool
+	SameAPIKey                  bool
+	ExpiringOfflineTokensDiffer bool
+}
+
+// EnvironmentComparison compares two environments of a project
+type EnvironmentComparison struct {
+	ProjectID string
+	Source    string
+	Target    string
+	Apps      []AppConfigDiff
+	Topics    StringSetDiff // Subscribed webhook topics
+	Scopes    StringSetDiff // Access scopes requested by installations
+	Shops     StringSetDiff // Shops with the app installed
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 // ErrorType represents the type of error
 type ErrorType string
@@ -9,6 +12,7 @@ const (
 	ErrorTypeValidation   ErrorType = "VALIDATION"
 	ErrorTypeNotFound     ErrorType = "NOT_FOUND"
 	ErrorTypeUnauthorized ErrorType = "UNAUTHORIZED"
+	ErrorTypeForbidden    ErrorType = "FORBIDDEN"
 	ErrorTypeRateLimit    ErrorType = "RATE_LIMIT"
 	ErrorTypeShopifyAPI   ErrorType = "SHOPIFY_API"
 	ErrorTypeDatabase     ErrorType = "DATABASE"
@@ -17,10 +21,18 @@ const (
 
 // AppError represents a structured application error
 type AppError struct {
-	Type    ErrorType
+	Type      ErrorType
+	Message   string
+	Err       error
+	Context   map[string]interface{}
+	RequestID string       // X-Request-Id of the Shopify response that caused the error
+	Fields    []FieldError // Rejected input fields of a validation error
+}
+
+// FieldError describes why the value of an input field was rejected
+type FieldError struct {
+	Field   string
 	Message string
-	Err     error
-	Context map[string]interface{}
 }
 
 // Error implements the error interface
@@ -36,6 +48,33 @@ func (e *AppError) Unwrap() error {
 	return e.Err
 }
 
+// RetryAfter returns the seconds to wait before retrying, 0 when unknown
+func (e *AppError) RetryAfter() int {
+	retryAfter, _ := e.Context["retry_after"].(int)
+	return retryAfter
+}
+
+// WithRequestID records the Shopify request ID of the error
+func (e *AppError) WithRequestID(requestID string) *AppError {
+	e.RequestID = requestID
+	return e
+}
+
+// WithFieldError adds a rejected input field to the error
+func (e *AppError) WithFieldError(field string, message string) *AppError {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: message})
+	return e
+}
+
+// AsAppError finds the first AppError in the chain of err
+func AsAppError(err error) (*AppError, bool) {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr, true
+	}
+	return nil, false
+}
+
 // NewValidationError creates a validation error
 func NewValidationError(message string, err error) *AppError {
 	return &AppError{
@@ -61,6 +100,14 @@ func NewUnauthorizedError(message string) *AppError {
 	}
 }
 
+// NewForbiddenError creates an error for an authenticated caller acting outside what it may access
+func NewForbiddenError(message string) *AppError {
+	return &AppError{
+		Type:    ErrorTypeForbidden,
+		Message: message,
+	}
+}
+
 // NewRateLimitError creates a rate limit error
 func NewRateLimitError(retryAfter int) *AppError {
 	return &AppError{
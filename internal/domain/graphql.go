@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// ShopifyAPIVersion is the Shopify Admin API version used by the GraphQL client and the REST proxy
+const ShopifyAPIVersion = "2025-10"
+
+// ShopifyAPI is one of the Shopify Admin APIs an operation can be served from
+type ShopifyAPI string
+
+const (
+	ShopifyAPIREST    ShopifyAPI = "rest"
+	ShopifyAPIGraphQL ShopifyAPI = "graphql"
+)
+
+// ShopifyOperation is an operation that both the REST and the GraphQL adapters implement
+type ShopifyOperation string
+
+const (
+	OperationGetProduct  ShopifyOperation = "getProduct"
+	OperationGetOrder    ShopifyOperation = "getOrder"
+	OperationGetCustomer ShopifyOperation = "getCustomer"
+)
+
+// RoutableShopifyOperations lists the operations ShopifyService can route to either API
+var RoutableShopifyOperations = []ShopifyOperation{OperationGetProduct, OperationGetOrder, OperationGetCustomer}
+
+// IsRoutable returns true for operations that can be served from either API
+func (o ShopifyOperation) IsRoutable() bool {
+	for _, operation := range RoutableShopifyOperations {
+		if operation == o {
+			return true
+		}
+	}
+	return false
+}
+
+// GraphQLRequest is a request to Shopify's Admin GraphQL API
+type GraphQLRequest struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+	OperationName string                 `json:"operationName,omitempty"`
+}
+
+// GraphQLError is an error of a GraphQL response, Extensions carries Shopify's code such as THROTTLED
+type GraphQLError struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// Code returns the extensions.code of the error, empty when unset
+func (e GraphQLError) Code() string {
+	code, _ := e.Extensions["code"].(string)
+	return code
+}
+
+// GraphQLThrottleStatus is the state of a shop's query cost bucket
+type GraphQLThrottleStatus struct {
+	MaximumAvailable   float64 `json:"maximumAvailable"`
+	CurrentlyAvailable float64 `json:"currentlyAvailable"`
+	RestoreRate        float64 `json:"restoreRate"` // Points restored per second
+}
+
+// GraphQLCost is the extensions.cost of a GraphQL response
+type GraphQLCost struct {
+	RequestedQueryCost float64               `json:"requestedQueryCost"`
+	ActualQueryCost    *float64              `json:"actualQueryCost"` // Nil when the query was throttled
+	ThrottleStatus     GraphQLThrottleStatus `json:"throttleStatus"`
+}
+
+// GraphQLResponse is a response of Shopify's Admin GraphQL API, kept as JSON so it can be passed through
+type GraphQLResponse struct {
+	Data       json.RawMessage `json:"data,omitempty"`
+	Errors     []GraphQLError  `json:"errors,omitempty"`
+	Extensions json.RawMessage `json:"extensions,omitempty"`
+	Cost       *GraphQLCost    `json:"-"`
+}
+
+// Throttled returns true if Shopify rejected the request because the cost bucket was empty
+func (r *GraphQLResponse) Throttled() bool {
+	for _, err := range r.Errors {
+		if err.Code() == "THROTTLED" {
+			return true
+		}
+	}
+	return false
+}
+
+// Err returns the errors of the response as a single error, nil when there are none
+// Access errors are unauthorized, throttling is a rate limit error and other errors come from the Shopify API
+func (r *GraphQLResponse) Err(message string) error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+	messages := make([]string, len(r.Errors))
+	for i, err := range r.Errors {
+		messages[i] = err.Message
+	}
+	detail := errors.New(strings.Join(messages, "; "))
+
+	var appErr *AppError
+	switch r.Errors[0].Code() {
+	case "ACCESS_DENIED":
+		appErr = NewUnauthorizedError(message + ": " + detail.Error())
+	case "THROTTLED":
+		appErr = NewRateLimitError(r.retryAfter())
+		appErr.Message = message + ": Shopify query cost limit exceeded"
+	default:
+		appErr = NewShopifyAPIError(message, detail)
+	}
+	if r.Cost != nil {
+		if appErr.Context == nil {
+			appErr.Context = map[string]interface{}{}
+		}
+		appErr.Context["requested_query_cost"] = r.Cost.RequestedQueryCost
+	}
+	return appErr
+}
+
+// retryAfter estimates the seconds until the bucket holds the requested cost again
+func (r *GraphQLResponse) retryAfter() int {
+	if r.Cost == nil || r.Cost.ThrottleStatus.RestoreRate <= 0 {
+		return 1
+	}
+	missing := r.Cost.RequestedQueryCost - r.Cost.ThrottleStatus.CurrentlyAvailable
+	return max(1, int(missing/r.Cost.ThrottleStatus.RestoreRate)+1)
+}
@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// InstallState represents where a shop is in the app installation lifecycle
+type InstallState string
+
+const (
+	// InstallStatePendingOAuth means an install was started but the OAuth/token exchange has not completed
+	InstallStatePendingOAuth InstallState = "PENDING_OAUTH"
+	// InstallStateInstalled means the app is installed with all requested scopes
+	InstallStateInstalled InstallState = "INSTALLED"
+	// InstallStateScopesOutdated means the app is installed but the granted scopes miss required ones
+	InstallStateScopesOutdated InstallState = "SCOPES_OUTDATED"
+	// InstallStateUninstalled means the merchant uninstalled the app
+	InstallStateUninstalled InstallState = "UNINSTALLED"
+	// InstallStateReinstalled means the app was installed again after an uninstall
+	InstallStateReinstalled InstallState = "REINSTALLED"
+)
+
+// installTransitions lists the allowed target states for each state ("" is a new installation)
+var installTransitions = map[InstallState][]InstallState{
+	"":                         {InstallStatePendingOAuth, InstallStateInstalled, InstallStateScopesOutdated},
+	InstallStatePendingOAuth:   {InstallStatePendingOAuth, InstallStateInstalled, InstallStateScopesOutdated, InstallStateReinstalled, InstallStateUninstalled},
+	InstallStateInstalled:      {InstallStatePendingOAuth, InstallStateInstalled, InstallStateScopesOutdated, InstallStateUninstalled},
+	InstallStateScopesOutdated: {InstallStatePendingOAuth, InstallStateInstalled, InstallStateScopesOutdated, InstallStateReinstalled, InstallStateUninstalled},
+	InstallStateUninstalled:    {InstallStatePendingOAuth, InstallStateReinstalled, InstallStateScopesOutdated},
+	InstallStateReinstalled:    {InstallStatePendingOAuth, InstallStateReinstalled, InstallStateScopesOutdated, InstallStateUninstalled},
+}
+
+// InstallTransition records a single state change of an installation
+type InstallTransition struct {
+	From   InstallState `json:"from" bson:"from"`
+	To     InstallState `json:"to" bson:"to"`
+	Reason string       `json:"reason" bson:"reason"`
+	At     time.Time    `json:"at" bson:"at"`
+}
+
+// Installation tracks the lifecycle of an app installation for a shop in a project/environment
+// Each app of the environment has its own installation of the shop
+type Installation struct {
+	ID              string              `json:"id" bson:"_id"`
+	ProjectID       string              `json:"project_id" bson:"project_id"`
+	Environment     string              `json:"environment" bson:"environment"`
+	AppHandle       string              `json:"app_handle" bson:"app_handle"`
+	ShopDomain      string              `json:"shop_domain" bson:"shop_domain"`
+	State           InstallState        `json:"state" bson:"state"`
+	RequestedScopes []string            `json:"requested_scopes" bson:"requested_scopes"`
+	GrantedScopes   []string            `json:"granted_scopes" bson:"granted_scopes"`
+	History         []InstallTransition `json:"history" bson:"history"`
+	InstalledAt     *time.Time          `json:"installed_at,omitempty" bson:"installed_at,omitempty"`
+	UninstalledAt   *time.Time          `json:"uninstalled_at,omitempty" bson:"uninstalled_at,omitempty"`
+	CreatedAt       time.Time           `json:"created_at" bson:"created_at"`
+	UpdatedAt       time.Time           `json:"updated_at" bson:"updated_at"`
+}
+
+// NewInstallation creates an installation record that has not entered any state yet
+func NewInstallation(key ShopKey) *Installation {
+	now := time.Now()
+	return &Installation{
+		ProjectID:   key.ProjectID,
+		Environment: key.Environment,
+		AppHandle:   key.AppHandle,
+		ShopDomain:  key.Domain,
+		History:     []InstallTransition{},
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// Key returns the project, environment, app and shop the installation belongs to
+func (i *Installation) Key() ShopKey {
+	return NewShopKey(i.ProjectID, i.Environment, i.AppHandle, i.ShopDomain)
+}
+
+// CanTransition returns true if the installation may move to the given state
+func (i *Installation) CanTransition(to InstallState) bool {
+	for _, allowed := range installTransitions[i.State] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Transition moves the installation to a new state and appends it to the history
+func (i *Installation) Transition(to InstallState, reason string, now time.Time) error {
+	if !i.CanTransition(to) {
+		return fmt.Errorf("invalid install state transition from %s to %s", i.State, to)
+	}
+
+	i.History = append(i.History, InstallTransition{
+		From:   i.State,
+		To:     to,
+		Reason: reason,
+		At:     now,
+	})
+	i.State = to
+	i.UpdatedAt = now
+
+	switch to {
+	case InstallStateInstalled, InstallStateReinstalled:
+		i.InstalledAt = &now
+	case InstallStateUninstalled:
+		i.UninstalledAt = &now
+	}
+
+	return nil
+}
+
+// CompletedState returns the state a completed install lands in: REINSTALLED after a prior uninstall,
+// SCOPES_OUTDATED when granted scopes miss requested ones, INSTALLED otherwise
+func (i *Installation) CompletedState() InstallState {
+	if len(MissingScopes(i.RequestedScopes, i.GrantedScopes)) > 0 {
+		return InstallStateScopesOutdated
+	}
+	if i.UninstalledAt != nil {
+		return InstallStateReinstalled
+	}
+	return InstallStateInstalled
+}
+
+// IsActive returns true if the app is currently installed on the shop
+func (i *Installation) IsActive() bool {
+	return i.State == InstallStateInstalled || i.State == InstallStateReinstalled || i.State == InstallStateScopesOutdated
+}
+
+// MissingScopes returns the required scopes not covered by the granted scopes
+// A granted write_X scope implies read_X, as it does in Shopify
+func MissingScopes(required, granted []string) []string {
+	grantedSet := make(map[string]bool, len(granted))
+	for _, scope := range granted {
+		grantedSet[scope] = true
+		if len(scope) > 6 && scope[:6] == "write_" {
+			grantedSet["read_"+scope[6:]] = true
+		}
+	}
+
+	missing := []string{}
+	for _, scope := range required {
+		if !grantedSet[scope] {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
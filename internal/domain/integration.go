@@ -1,16 +1,92 @@
 package domain
 
-import "time"
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// IntegrationKeyPrefix starts every integration key so leaked keys are easy to recognize
+const IntegrationKeyPrefix = "ik_"
+
+// integrationKeyVisibleLength is how many characters of a key are kept to identify it
+const integrationKeyVisibleLength = len(IntegrationKeyPrefix) + 8
+
+// DefaultKeyRotationOverlap is how long a rotated key keeps working when no overlap is given
+const DefaultKeyRotationOverlap = 24 * time.Hour
+
+// IntegrationStatus is the lifecycle status of an integration key
+type IntegrationStatus string
+
+const (
+	// IntegrationStatusActive means the key can authenticate requests
+	IntegrationStatusActive IntegrationStatus = "ACTIVE"
+	// IntegrationStatusExpired means the key passed its expiry (or its rotation overlap ended)
+	IntegrationStatusExpired IntegrationStatus = "EXPIRED"
+	// IntegrationStatusRevoked means the key was revoked
+	IntegrationStatusRevoked IntegrationStatus = "REVOKED"
+)
 
 // Integration represents a Shopify integration key that maps to a project/environment/shop combination
 // This allows apps to authenticate using just an integration key instead of project ID + environment + shop
+// Only the key hash is stored, the plaintext key is returned once when the key is issued
 type Integration struct {
-	ID          string    `json:"id" bson:"_id"`
-	Key         string    `json:"key" bson:"key"`                   // Unique integration key (used for authentication)
-	ProjectID   string    `json:"project_id" bson:"project_id"`   // Project this integration belongs to
-	Environment string    `json:"environment" bson:"environment"`  // Environment (master, staging, etc.)
-	ShopDomain  string    `json:"shop_domain" bson:"shop_domain"`  // Connected Shopify shop domain
-	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at" bson:"updated_at"`
+	ID          string     `json:"id" bson:"_id"`
+	Key         string     `json:"-" bson:"-"`                       // Plaintext key, only set when issued
+	KeyHash     string     `json:"-" bson:"key_hash"`                // SHA-256 of the key (used for authentication)
+	KeyPrefix   string     `json:"key_prefix" bson:"key_prefix"`     // Visible start of the key to identify it
+	ProjectID   string     `json:"project_id" bson:"project_id"`     // Project this integration belongs to
+	Environment string     `json:"environment" bson:"environment"`   // Environment (master, staging, etc.)
+	ShopDomain  string     `json:"shop_domain" bson:"shop_domain"`   // Connected Shopify shop domain
+	AppHandle   string     `json:"app_handle" bson:"app_handle"`     // Shopify app the integration uses
+	Scopes      []string   `json:"scopes" bson:"scopes"`             // Allowed GraphQL operations and REST paths, empty allows all
+	ExpiresAt   *time.Time `json:"expires_at" bson:"expires_at"`     // Optional expiry
+	LastUsedAt  *time.Time `json:"last_used_at" bson:"last_used_at"` // Last successful authentication
+	RevokedAt   *time.Time `json:"revoked_at" bson:"revoked_at"`
+	RotatedToID string     `json:"rotated_to_id" bson:"rotated_to_id"` // Key that replaced this one
+	CreatedAt   time.Time  `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" bson:"updated_at"`
+}
+
+// GenerateIntegrationKey creates a random integration key and returns it with its prefix and hash
+func GenerateIntegrationKey() (key string, prefix string, hash string, err error) {
+	keyBytes := make([]byte, 32)
+	if _, err := rand.Read(keyBytes); err != nil {
+		return "", "", "", fmt.Errorf("failed to generate integration key: %w", err)
+	}
+	key = IntegrationKeyPrefix + hex.EncodeToString(keyBytes)
+	return key, IntegrationKeyVisiblePrefix(key), HashIntegrationKey(key), nil
 }
 
+// HashIntegrationKey returns the stored hash of an integration key
+// Keys are 256-bit random values, so a fast hash is enough to make a leaked database useless
+func HashIntegrationKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// IntegrationKeyVisiblePrefix returns the start of a key that is safe to display and log
+func IntegrationKeyVisiblePrefix(key string) string {
+	if len(key) <= integrationKeyVisibleLength {
+		return key
+	}
+	return key[:integrationKeyVisibleLength]
+}
+
+// Status returns the lifecycle status of the key at the given time
+func (i *Integration) Status(now time.Time) IntegrationStatus {
+	if i.RevokedAt != nil {
+		return IntegrationStatusRevoked
+	}
+	if i.ExpiresAt != nil && !now.Before(*i.ExpiresAt) {
+		return IntegrationStatusExpired
+	}
+	return IntegrationStatusActive
+}
+
+// IsActive returns true if the key can authenticate requests at the given time
+func (i *Integration) IsActive(now time.Time) bool {
+	return i.Status(now) == IntegrationStatusActive
+}
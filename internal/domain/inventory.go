@@ -0,0 +1,38 @@
+package domain
+
+// InventoryAdjustmentReason explains a stock adjustment, using Shopify's inventory reason codes
+type InventoryAdjustmentReason string
+
+const (
+	InventoryAdjustmentReasonCorrection     InventoryAdjustmentReason = "correction"
+	InventoryAdjustmentReasonCycleCount     InventoryAdjustmentReason = "cycle_count_available"
+	InventoryAdjustmentReasonDamaged        InventoryAdjustmentReason = "damaged"
+	InventoryAdjustmentReasonPromotion      InventoryAdjustmentReason = "promotion"
+	InventoryAdjustmentReasonQualityControl InventoryAdjustmentReason = "quality_control"
+	InventoryAdjustmentReasonReceived       InventoryAdjustmentReason = "received"
+	InventoryAdjustmentReasonRestock        InventoryAdjustmentReason = "restock"
+	InventoryAdjustmentReasonSafetyStock    InventoryAdjustmentReason = "safety_stock"
+	InventoryAdjustmentReasonShrinkage      InventoryAdjustmentReason = "shrinkage"
+	InventoryAdjustmentReasonOther          InventoryAdjustmentReason = "other"
+)
+
+// IsValid returns true for the reason codes Shopify accepts
+func (r InventoryAdjustmentReason) IsValid() bool {
+	switch r {
+	case InventoryAdjustmentReasonCorrection, InventoryAdjustmentReasonCycleCount, InventoryAdjustmentReasonDamaged,
+		InventoryAdjustmentReasonPromotion, InventoryAdjustmentReasonQualityControl, InventoryAdjustmentReasonReceived,
+		InventoryAdjustmentReasonRestock, InventoryAdjustmentReasonSafetyStock, InventoryAdjustmentReasonShrinkage,
+		InventoryAdjustmentReasonOther:
+		return true
+	}
+	return false
+}
+
+// InventoryAdjustment changes the available stock of an inventory item at a location by Delta units
+type InventoryAdjustment struct {
+	InventoryItemID uint64
+	LocationID      uint64
+	Delta           int
+	Reason          InventoryAdjustmentReason
+	Reference       string // Optional reference of the adjustment, such as a purchase order or stock count
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Key scopes limit what an integration key can call. An empty scope list allows everything.
+//
+//	graphql:<field>          a root Query/Mutation/Subscription field, e.g. graphql:shopify_products
+//	rest:<METHOD> <path>     a REST proxy path below /shopify/, e.g. rest:GET products.json
+//	                         the Admin GraphQL passthrough is rest:POST graphql.json and the
+//	                         Storefront proxy rest:POST storefront/graphql.json
+//
+// Fields, methods and paths accept "*" alone, and paths and fields a trailing "*" as a prefix wildcard.
+const (
+	scopeGraphQLPrefix = "graphql:"
+	scopeRESTPrefix    = "rest:"
+)
+
+// ValidateKeyScopes checks the syntax of a scope list
+func ValidateKeyScopes(scopes []string) error {
+	for _, scope := range scopes {
+		switch {
+		case strings.HasPrefix(scope, scopeGraphQLPrefix):
+			if strings.TrimPrefix(scope, scopeGraphQLPrefix) == "" {
+				return fmt.Errorf("invalid scope %q: missing GraphQL field", scope)
+			}
+		case strings.HasPrefix(scope, scopeRESTPrefix):
+			method, path := splitRESTScope(scope)
+			if method == "" || path == "" {
+				return fmt.Errorf("invalid scope %q: expected rest:<METHOD> <path>", scope)
+			}
+		default:
+			return fmt.Errorf("invalid scope %q: must start with graphql: or rest:", scope)
+		}
+	}
+	return nil
+}
+
+// AllowsGraphQLField returns true if the scopes allow calling a root GraphQL field
+func AllowsGraphQLField(scopes []string, field string) bool {
+	if len(scopes) == 0 {
+		return true
+	}
+	for _, scope := range scopes {
+		if strings.HasPrefix(scope, scopeGraphQLPrefix) && matchScopePattern(strings.TrimPrefix(scope, scopeGraphQLPrefix), field) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsRESTRequest returns true if the scopes allow a REST proxy request
+// path is the Shopify resource path below /shopify/, without a leading slash
+func AllowsRESTRequest(scopes []string, method string, path string) bool {
+	if len(scopes) == 0 {
+		return true
+	}
+	path = strings.TrimPrefix(path, "/")
+	for _, scope := range scopes {
+		if !strings.HasPrefix(scope, scopeRESTPrefix) {
+			continue
+		}
+		scopeMethod, scopePath := splitRESTScope(scope)
+		if (scopeMethod == "*" || strings.EqualFold(scopeMethod, method)) && matchScopePattern(strings.TrimPrefix(scopePath, "/"), path) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitRESTScope splits "rest:GET products*" into its method and path, a path alone means any method
+func splitRESTScope(scope string) (string, string) {
+	value := strings.TrimSpace(strings.TrimPrefix(scope, scopeRESTPrefix))
+	if method, path, ok := strings.Cut(value, " "); ok {
+		return strings.TrimSpace(method), strings.TrimSpace(path)
+	}
+	return "*", value
+}
+
+// matchScopePattern matches "*", a trailing "*" prefix wildcard, or an exact value
+func matchScopePattern(pattern string, value string) bool {
+	if pattern == "*" {
+		return true
+	}
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(value, strings.TrimSuffix(pattern, "*"))
+	}
+	return pattern == value
+}
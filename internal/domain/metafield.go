@@ -0,0 +1,286 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// MetafieldOwnerType is a kind of Shopify resource that carries metafields
+type MetafieldOwnerType string
+
+const (
+	MetafieldOwnerProduct    MetafieldOwnerType = "product"
+	MetafieldOwnerVariant    MetafieldOwnerType = "variant"
+	MetafieldOwnerCollection MetafieldOwnerType = "collection"
+	MetafieldOwnerCustomer   MetafieldOwnerType = "customer"
+	MetafieldOwnerOrder      MetafieldOwnerType = "order"
+	MetafieldOwnerDraftOrder MetafieldOwnerType = "draft_order"
+	MetafieldOwnerShop       MetafieldOwnerType = "shop"
+)
+
+// IsValid returns true for the owner types metafields can be managed on
+func (t MetafieldOwnerType) IsValid() bool {
+	return t.Resource() != "" || t == MetafieldOwnerShop
+}
+
+// Resource returns the REST resource path of the owner type, empty for the shop whose metafields are top-level
+func (t MetafieldOwnerType) Resource() string {
+	switch t {
+	case MetafieldOwnerProduct:
+		return "products"
+	case MetafieldOwnerVariant:
+		return "variants"
+	case MetafieldOwnerCollection:
+		return "collections"
+	case MetafieldOwnerCustomer:
+		return "customers"
+	case MetafieldOwnerOrder:
+		return "orders"
+	case MetafieldOwnerDraftOrder:
+		return "draft_orders"
+	}
+	return ""
+}
+
+// GraphQLOwnerType returns the MetafieldOwnerType enum value of Shopify's GraphQL Admin API
+func (t MetafieldOwnerType) GraphQLOwnerType() string {
+	switch t {
+	case MetafieldOwnerVariant:
+		return "PRODUCTVARIANT"
+	case MetafieldOwnerDraftOrder:
+		return "DRAFTORDER"
+	}
+	return strings.ToUpper(string(t))
+}
+
+// MetafieldOwnerTypeFromGraphQL converts a MetafieldOwnerType enum value of Shopify's GraphQL Admin API
+func MetafieldOwnerTypeFromGraphQL(value string) MetafieldOwnerType {
+	switch value {
+	case "PRODUCTVARIANT":
+		return MetafieldOwnerVariant
+	case "DRAFTORDER":
+		return MetafieldOwnerDraftOrder
+	}
+	return MetafieldOwnerType(strings.ToLower(value))
+}
+
+// MetafieldOwner is the resource a metafield belongs to, ID is unused for the shop
+type MetafieldOwner struct {
+	Type MetafieldOwnerType
+	ID   uint64
+}
+
+// Metafield list types hold a JSON array of values of their item type, such as list.number_integer
+const metafieldListPrefix = "list."
+
+var (
+	metafieldColorPattern     = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+	metafieldReferencePattern = regexp.MustCompile(`^gid://shopify/[A-Za-z]+/[0-9]+$`)
+	metafieldURLSchemes       = map[string]bool{"https": true, "http": true, "mailto": true, "sms": true, "tel": true}
+)
+
+// NormalizeMetafieldValue checks a metafield value against its Shopify type and returns it in Shopify's format
+// Unknown types are passed through unchanged so types Shopify adds keep working
+func NormalizeMetafieldValue(metafieldType string, value string) (string, error) {
+	if itemType, ok := strings.CutPrefix(metafieldType, metafieldListPrefix); ok {
+		return normalizeMetafieldList(itemType, value)
+	}
+
+	switch {
+	case metafieldType == "single_line_text_field":
+		if strings.ContainsAny(value, "\r\n") {
+			return "", fmt.Errorf("must be a single line of text")
+		}
+	case metafieldType == "number_integer":
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return "", fmt.Errorf("must be an integer")
+		}
+	case metafieldType == "number_decimal":
+		if _, err := decimal.NewFromString(value); err != nil {
+			return "", fmt.Errorf("must be a decimal number")
+		}
+	case metafieldType == "boolean":
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("must be true or false")
+		}
+		return strconv.FormatBool(parsed), nil
+	case metafieldType == "date":
+		if _, err := time.Parse(time.DateOnly, value); err != nil {
+			return "", fmt.Errorf("must be a date such as 2024-01-31")
+		}
+	case metafieldType == "date_time":
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			if _, err := time.Parse("2006-01-02T15:04:05", value); err != nil {
+				return "", fmt.Errorf("must be an ISO 8601 date and time")
+			}
+		}
+	case metafieldType == "color":
+		if !metafieldColorPattern.MatchString(value) {
+			return "", fmt.Errorf("must be a hexadecimal color such as #1a2b3c")
+		}
+	case metafieldType == "url":
+		parsed, err := url.Parse(value)
+		if err != nil || !metafieldURLSchemes[parsed.Scheme] {
+			return "", fmt.Errorf("must be an https, http, mailto, sms or tel URL")
+		}
+	case metafieldType == "json" || metafieldType == "rich_text_field" || metafieldType == "link":
+		return compactMetafieldJSON(value, false)
+	case metafieldType == "dimension" || metafieldType == "volume" || metafieldType == "weight" ||
+		metafieldType == "money" || metafieldType == "rating":
+		return compactMetafieldJSON(value, true)
+	case strings.HasSuffix(metafieldType, "_reference"):
+		if !metafieldReferencePattern.MatchString(value) {
+			return "", fmt.Errorf("must be a Shopify global ID such as gid://shopify/Product/123")
+		}
+	}
+	return value, nil
+}
+
+// normalizeMetafieldList checks each item of a list value against the item type
+func normalizeMetafieldList(itemType string, value string) (string, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal([]byte(value), &items); err != nil {
+		return "", fmt.Errorf("must be a JSON array")
+	}
+	normalized := make([]json.RawMessage, len(items))
+	for i, item := range items {
+		// Text, dates and references are JSON strings; numbers and measurements are listed as they are
+		var text string
+		isString := json.Unmarshal(item, &text) == nil
+		if !isString {
+			text = string(item)
+		}
+		itemValue, err := NormalizeMetafieldValue(itemType, text)
+		if err != nil {
+			return "", fmt.Errorf("item %d %w", i, err)
+		}
+		if isString {
+			item, _ = json.Marshal(itemValue)
+		} else {
+			item = json.RawMessage(itemValue)
+		}
+		normalized[i] = item
+	}
+	encoded, err := json.Marshal(normalized)
+	if err != nil {
+		return "", fmt.Errorf("must be a JSON array")
+	}
+	return string(encoded), nil
+}
+
+// compactMetafieldJSON checks and compacts a JSON value, which must be an object when requireObject is set
+func compactMetafieldJSON(value string, requireObject bool) (string, error) {
+	var buffer bytes.Buffer
+	if err := json.Compact(&buffer, []byte(value)); err != nil {
+		return "", fmt.Errorf("must be valid JSON")
+	}
+	if requireObject && !strings.HasPrefix(buffer.String(), "{") {
+		return "", fmt.Errorf("must be a JSON object")
+	}
+	return buffer.String(), nil
+}
+
+// DecodeMetafieldValue returns a metafield value as the JSON value its type describes
+// Numbers and booleans are decoded, JSON and list types are parsed, other values stay strings
+func DecodeMetafieldValue(metafieldType string, value string) interface{} {
+	switch {
+	case metafieldType == "number_integer" || metafieldType == "number_decimal" || metafieldType == "boolean" ||
+		metafieldType == "json" || metafieldType == "rich_text_field" || metafieldType == "link" ||
+		metafieldType == "dimension" || metafieldType == "volume" || metafieldType == "weight" ||
+		metafieldType == "money" || metafieldType == "rating" || strings.HasPrefix(metafieldType, metafieldListPrefix):
+		decoder := json.NewDecoder(strings.NewReader(value))
+		decoder.UseNumber()
+		var decoded interface{}
+		if err := decoder.Decode(&decoded); err == nil {
+			return decoded
+		}
+	}
+	return value
+}
+
+// MetafieldValidation is a constraint of a metafield definition, such as min or regex
+type MetafieldValidation struct {
+	Name  string
+	Value string
+}
+
+// MetafieldDefinition describes the type and constraints of the metafields of a namespace and key
+// Definitions only exist in Shopify's GraphQL Admin API, IDs are the numeric part of their global IDs
+type MetafieldDefinition struct {
+	ID          uint64
+	OwnerType   MetafieldOwnerType
+	Namespace   string
+	Key         string
+	Name        string
+	Description string
+	Type        string // Ignored on update, Shopify does not change the type of a definition
+	Validations []MetafieldValidation
+	Pinned      bool // Shown in the Shopify admin of the owner; on create, pins the definition
+}
+
+// MetaobjectFieldDefinition is a field of a metaobject definition
+type MetaobjectFieldDefinition struct {
+	Key         string
+	Name        string
+	Description string
+	Type        string
+	Required    bool
+	Validations []MetafieldValidation
+}
+
+// MetaobjectDefinition describes the fields of the metaobjects of a type
+type MetaobjectDefinition struct {
+	ID               uint64
+	Type             string
+	Name             string
+	Description      string
+	DisplayNameKey   string // Field used as the display name of the entries
+	Fields           []MetaobjectFieldDefinition
+	MetaobjectsCount int
+}
+
+// MetaobjectDefinitionUpdate changes a metaobject definition
+// Fields are created or updated by key; the keys of DeleteFields are removed
+type MetaobjectDefinitionUpdate struct {
+	Name           *string
+	Description    *string
+	DisplayNameKey *string
+	Fields         []MetaobjectFieldDefinition
+	DeleteFields   []string
+}
+
+// MetaobjectField is a field value of a metaobject, Value is nil when unset
+type MetaobjectField struct {
+	Key   string
+	Type  string
+	Value *string
+}
+
+// Metaobject is an entry of a metaobject definition
+type Metaobject struct {
+	ID          uint64
+	Type        string
+	Handle      string
+	DisplayName string
+	Fields      []MetaobjectField
+	UpdatedAt   *time.Time
+}
+
+// MetaobjectPage is a page of metaobjects of Shopify's cursor pagination
+type MetaobjectPage struct {
+	Metaobjects     []Metaobject
+	Cursors         []string // Cursor of each metaobject
+	StartCursor     string
+	EndCursor       string
+	HasNextPage     bool
+	HasPreviousPage bool
+}
@@ -0,0 +1,73 @@
+package domain
+
+// ReturnStatus is the progress of an order return
+type ReturnStatus string
+
+const (
+	ReturnStatusRequested ReturnStatus = "requested"
+	ReturnStatusOpen      ReturnStatus = "open"
+	ReturnStatusClosed    ReturnStatus = "closed"
+	ReturnStatusDeclined  ReturnStatus = "declined"
+	ReturnStatusCanceled  ReturnStatus = "canceled"
+)
+
+// ReturnReason is why a customer sends a line item back
+type ReturnReason string
+
+const (
+	ReturnReasonColor          ReturnReason = "color"
+	ReturnReasonDefective      ReturnReason = "defective"
+	ReturnReasonNotAsDescribed ReturnReason = "not_as_described"
+	ReturnReasonSizeTooLarge   ReturnReason = "size_too_large"
+	ReturnReasonSizeTooSmall   ReturnReason = "size_too_small"
+	ReturnReasonStyle          ReturnReason = "style"
+	ReturnReasonUnwanted       ReturnReason = "unwanted"
+	ReturnReasonWrongItem      ReturnReason = "wrong_item"
+	ReturnReasonOther          ReturnReason = "other"
+	ReturnReasonUnknown        ReturnReason = "unknown"
+)
+
+// ReturnDeclineReason is why a merchant declines a return requested by a customer
+type ReturnDeclineReason string
+
+const (
+	ReturnDeclineReasonReturnPeriodEnded ReturnDeclineReason = "return_period_ended"
+	ReturnDeclineReasonFinalSale         ReturnDeclineReason = "final_sale"
+	ReturnDeclineReasonOther             ReturnDeclineReason = "other"
+)
+
+// OrderReturn is a return of fulfilled line items of an order
+// Returns only exist in Shopify's GraphQL Admin API, IDs are the numeric part of their global IDs
+type OrderReturn struct {
+	ID            uint64
+	OrderID       uint64
+	Name          string
+	Status        ReturnStatus
+	TotalQuantity int
+	LineItems     []ReturnLineItem
+}
+
+// ReturnLineItem is a returned quantity of a fulfilled line item
+type ReturnLineItem struct {
+	ID                    uint64
+	FulfillmentLineItemID uint64
+	LineItemID            uint64
+	Quantity              int
+	Reason                ReturnReason
+	ReasonNote            string
+}
+
+// ReturnRequest describes a return to create for an order
+type ReturnRequest struct {
+	OrderID        uint64
+	LineItems      []ReturnLineItemRequest
+	NotifyCustomer bool
+}
+
+// ReturnLineItemRequest returns a quantity of an order line item, taken from its fulfillments
+type ReturnLineItemRequest struct {
+	LineItemID uint64
+	Quantity   int
+	Reason     ReturnReason
+	ReasonNote string
+}
@@ -0,0 +1,159 @@
+package domain
+
+import "strings"
+
+// Permission is an action a principal may perform, written as <action>:<resource>
+type Permission string
+
+const (
+	PermissionReadShops         Permission = "read:shops"
+	PermissionWriteShops        Permission = "write:shops"
+	PermissionReadProducts      Permission = "read:products"
+	PermissionWriteProducts     Permission = "write:products"
+	PermissionReadOrders        Permission = "read:orders"
+	PermissionWriteOrders       Permission = "write:orders"
+	PermissionReadCustomers     Permission = "read:customers"
+	PermissionWriteCustomers    Permission = "write:customers"
+	PermissionReadInventory     Permission = "read:inventory"
+	PermissionWriteInventory    Permission = "write:inventory"
+	PermissionReadDiscounts     Permission = "read:discounts"
+	PermissionWriteDiscounts    Permission = "write:discounts"
+	PermissionReadMetafields    Permission = "read:metafields"
+	PermissionWriteMetafields   Permission = "write:metafields"
+	PermissionReadWebhooks      Permission = "read:webhooks"
+	PermissionWriteWebhooks     Permission = "write:webhooks"
+	PermissionReadConfig        Permission = "read:config"
+	PermissionAdminConfig       Permission = "admin:config"
+	PermissionReadIntegrations  Permission = "read:integrations"
+	PermissionAdminIntegrations Permission = "admin:integrations"
+	PermissionReadUsage         Permission = "read:usage"
+	PermissionReadGraphQL       Permission = "read:graphql"     // Admin GraphQL passthrough queries
+	PermissionWriteGraphQL      Permission = "write:graphql"    // Admin GraphQL passthrough mutations
+	PermissionReadStorefront    Permission = "read:storefront"  // Storefront tokens and proxy queries
+	PermissionWriteStorefront   Permission = "write:storefront" // Storefront token management and proxy mutations
+	PermissionAdminProxy        Permission = "admin:proxy"      // REST proxy paths without a specific rule
+	permissionWildcard          Permission = "*"
+	permissionResourceWildcard             = "*"
+)
+
+// Role is a named set of permissions
+type Role string
+
+const (
+	// RoleAdmin may do everything, including configuring apps and managing integration keys
+	RoleAdmin Role = "admin"
+	// RoleOperator may read and change shop data but not manage apps or integration keys
+	RoleOperator Role = "operator"
+	// RoleIntegration is the default of integration keys: shop data only, narrowed further by key scopes
+	RoleIntegration Role = "integration"
+	// RoleViewer may only read
+	RoleViewer Role = "viewer"
+)
+
+// RolePermissions lists the permissions granted by each role
+var RolePermissions = map[Role][]Permission{
+	RoleAdmin:    {permissionWildcard},
+	RoleOperator: {"read:*", "write:*"},
+	RoleIntegration: {
+		PermissionReadShops,
+		PermissionReadProducts, PermissionWriteProducts,
+		PermissionReadOrders, PermissionWriteOrders,
+		PermissionReadCustomers, PermissionWriteCustomers,
+		PermissionReadInventory, PermissionWriteInventory,
+		PermissionReadDiscounts, PermissionWriteDiscounts,
+		PermissionReadMetafields, PermissionWriteMetafields,
+		PermissionReadWebhooks,
+		PermissionReadStorefront, PermissionWriteStorefront,
+		PermissionReadUsage,
+	},
+	RoleViewer: {"read:*"},
+}
+
+// DefaultRoleForPrincipal returns the role of principals whose credential carries no roles or permissions
+// Service tokens and platform tokens without claims keep full access, as before permissions existed
+func DefaultRoleForPrincipal(principalType PrincipalType) Role {
+	switch principalType {
+	case PrincipalTypeIntegrationKey:
+		return RoleIntegration
+	case PrincipalTypeSessionToken:
+		return RoleOperator
+	default:
+		return RoleAdmin
+	}
+}
+
+// IsKnownRole returns true if a role is defined
+func IsKnownRole(role Role) bool {
+	_, ok := RolePermissions[role]
+	return ok
+}
+
+// grantsPermission matches a granted permission, "*" or "<action>:*", against a required one
+func grantsPermission(granted Permission, required Permission) bool {
+	if granted == permissionWildcard || granted == required {
+		return true
+	}
+	action, resource, ok := strings.Cut(string(granted), ":")
+	return ok && resource == permissionResourceWildcard && strings.HasPrefix(string(required), action+":")
+}
+
+// RESTPermissionRule maps REST proxy requests to the permission they require
+// Method "*" matches any method; Pattern accepts "*" and a trailing "*" prefix wildcard, like key scopes
+type RESTPermissionRule struct {
+	Method     string
+	Pattern    string
+	Permission Permission
+}
+
+// RESTPermissionRules are evaluated in order, the first match wins
+// Paths are relative to /shopify/ and match Admin API resources such as products.json or orders/123.json
+var RESTPermissionRules = []RESTPermissionRule{
+	{Method: "GET", Pattern: "shop.json", Permission: PermissionReadShops},
+	{Method: "GET", Pattern: "products*", Permission: PermissionReadProducts},
+	{Method: "*", Pattern: "products*", Permission: PermissionWriteProducts},
+	{Method: "GET", Pattern: "variants*", Permission: PermissionReadProducts},
+	{Method: "*", Pattern: "variants*", Permission: PermissionWriteProducts},
+	{Method: "GET", Pattern: "custom_collections*", Permission: PermissionReadProducts},
+	{Method: "*", Pattern: "custom_collections*", Permission: PermissionWriteProducts},
+	{Method: "GET", Pattern: "smart_collections*", Permission: PermissionReadProducts},
+	{Method: "*", Pattern: "smart_collections*", Permission: PermissionWriteProducts},
+	{Method: "GET", Pattern: "collections*", Permission: PermissionReadProducts},
+	{Method: "*", Pattern: "collections*", Permission: PermissionWriteProducts},
+	{Method: "GET", Pattern: "collects*", Permission: PermissionReadProducts},
+	{Method: "*", Pattern: "collects*", Permission: PermissionWriteProducts},
+	{Method: "GET", Pattern: "orders*", Permission: PermissionReadOrders},
+	{Method: "*", Pattern: "orders*", Permission: PermissionWriteOrders},
+	{Method: "GET", Pattern: "fulfillment_orders*", Permission: PermissionReadOrders},
+	{Method: "*", Pattern: "fulfillment_orders*", Permission: PermissionWriteOrders},
+	{Method: "GET", Pattern: "assigned_fulfillment_orders*", Permission: PermissionReadOrders},
+	{Method: "*", Pattern: "assigned_fulfillment_orders*", Permission: PermissionWriteOrders},
+	{Method: "GET", Pattern: "draft_orders*", Permission: PermissionReadOrders},
+	{Method: "*", Pattern: "draft_orders*", Permission: PermissionWriteOrders},
+	{Method: "GET", Pattern: "fulfillments*", Permission: PermissionReadOrders},
+	{Method: "*", Pattern: "fulfillments*", Permission: PermissionWriteOrders},
+	{Method: "GET", Pattern: "customers*", Permission: PermissionReadCustomers},
+	{Method: "*", Pattern: "customers*", Permission: PermissionWriteCustomers},
+	{Method: "GET", Pattern: "inventory_*", Permission: PermissionReadInventory},
+	{Method: "*", Pattern: "inventory_*", Permission: PermissionWriteInventory},
+	{Method: "GET", Pattern: "locations*", Permission: PermissionReadInventory},
+	{Method: "GET", Pattern: "price_rules*", Permission: PermissionReadDiscounts},
+	{Method: "*", Pattern: "price_rules*", Permission: PermissionWriteDiscounts},
+	{Method: "GET", Pattern: "discount_codes*", Permission: PermissionReadDiscounts},
+	{Method: "*", Pattern: "discount_codes*", Permission: PermissionWriteDiscounts},
+	{Method: "GET", Pattern: "metafields*", Permission: PermissionReadMetafields},
+	{Method: "*", Pattern: "metafields*", Permission: PermissionWriteMetafields},
+	{Method: "GET", Pattern: "webhooks*", Permission: PermissionReadWebhooks},
+	{Method: "*", Pattern: "webhooks*", Permission: PermissionWriteWebhooks},
+	{Method: "*", Pattern: "*", Permission: PermissionAdminProxy},
+}
+
+// RequiredRESTPermission returns the permission a REST proxy request requires
+func RequiredRESTPermission(method string, path string) Permission {
+	path = strings.TrimPrefix(path, "/")
+	for _, rule := range RESTPermissionRules {
+		if (rule.Method == "*" || strings.EqualFold(rule.Method, method)) && matchScopePattern(rule.Pattern, path) {
+			return rule.Permission
+		}
+	}
+	return PermissionAdminProxy
+}
@@ -0,0 +1,104 @@
+package domain
+
+import "context"
+
+// PrincipalType identifies how a request was authenticated
+type PrincipalType string
+
+const (
+	// PrincipalTypeIntegrationKey is a request authenticated with an X-Integration-Key
+	PrincipalTypeIntegrationKey PrincipalType = "INTEGRATION_KEY"
+	// PrincipalTypePlatformJWT is a request authenticated with a JWT signed by the platform
+	PrincipalTypePlatformJWT PrincipalType = "PLATFORM_JWT"
+	// PrincipalTypeServiceToken is a request from an internal service using a static token
+	PrincipalTypeServiceToken PrincipalType = "SERVICE_TOKEN"
+	// PrincipalTypeSessionToken is an embedded app request authenticated with an App Bridge session token
+	PrincipalTypeSessionToken PrincipalType = "SESSION_TOKEN"
+	// PrincipalTypeDevHeader is an unauthenticated X-Project-ID request, only accepted in local development
+	PrincipalTypeDevHeader PrincipalType = "DEV_HEADER"
+)
+
+// Principal is the authenticated caller of a request and the tenant it may act on
+type Principal struct {
+	Type        PrincipalType
+	Subject     string // Integration ID, JWT subject, service name or Shopify user ID
+	ProjectID   string
+	Environment string
+	AppHandle   string       // Empty lets the request select the app
+	ShopDomain  string       // Set when the credential is bound to a single shop
+	Scopes      []string     // Key scopes limiting GraphQL fields and REST paths, empty allows all
+	Roles       []Role       // Roles granted by the credential
+	Permissions []Permission // Permissions granted on top of the roles
+}
+
+// EffectiveRoles returns the roles of the principal, or the default role of its type when the credential grants none
+func (p *Principal) EffectiveRoles() []Role {
+	if len(p.Roles) == 0 && len(p.Permissions) == 0 {
+		return []Role{DefaultRoleForPrincipal(p.Type)}
+	}
+	return p.Roles
+}
+
+// HasPermission returns true if the roles or permissions of the principal grant a permission
+func (p *Principal) HasPermission(permission Permission) bool {
+	for _, granted := range p.Permissions {
+		if grantsPermission(granted, permission) {
+			return true
+		}
+	}
+	for _, role := range p.EffectiveRoles() {
+		for _, granted := range RolePermissions[role] {
+			if grantsPermission(granted, permission) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AllowsGraphQLField returns true if the principal may call a root GraphQL field
+func (p *Principal) AllowsGraphQLField(field string) bool {
+	return AllowsGraphQLField(p.Scopes, field)
+}
+
+// AllowsEnvironment returns true if the principal may act on an environment of its project: its own environment,
+// or any when it is not bound to one or is an admin
+func (p *Principal) AllowsEnvironment(environment string) bool {
+	if p.Environment == "" {
+		return true
+	}
+	for _, role := range p.EffectiveRoles() {
+		if role == RoleAdmin {
+			return true
+		}
+	}
+	if environment == "" {
+		environment = DefaultEnvironment
+	}
+	return environment == p.Environment
+}
+
+// AllowsRESTRequest returns true if the principal may call a REST proxy path on a shop
+func (p *Principal) AllowsRESTRequest(shopDomain string, method string, path string) bool {
+	if p.ShopDomain != "" && p.ShopDomain != shopDomain {
+		return false
+	}
+	return AllowsRESTRequest(p.Scopes, method, path)
+}
+
+// principalKey is the context key for the authenticated principal
+const principalKey ContextKey = "principal"
+
+// WithPrincipal adds the authenticated principal to the context
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey, principal)
+}
+
+// GetPrincipalFromContext extracts the authenticated principal from context
+// Returns nil for unauthenticated requests (public routes and webhooks)
+func GetPrincipalFromContext(ctx context.Context) *Principal {
+	if principal, ok := ctx.Value(principalKey).(*Principal); ok {
+		return principal
+	}
+	return nil
+}
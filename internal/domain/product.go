@@ -0,0 +1,9 @@
+package domain
+
+// VariantSettings are the boolean settings of a product variant that the caller set explicitly
+// go-shopify's Variant always sends requires_shipping and never a false taxable, so product writes carry these
+// separately, by variant position, and leave unset ones to Shopify: its default on create, the current value on update
+type VariantSettings struct {
+	RequiresShipping *bool
+	Taxable          *bool
+}
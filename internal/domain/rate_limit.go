@@ -0,0 +1,112 @@
+package domain
+
+import "time"
+
+// RateLimitTier bounds the inbound API traffic of a project
+// Zero values mean unlimited
+type RateLimitTier struct {
+	Name                 string
+	RequestsPerMinute    int   // Per project, across environments and credentials
+	KeyRequestsPerMinute int   // Per integration key
+	DailyQuota           int64 // Requests per UTC day
+	MonthlyQuota         int64 // Requests per UTC month
+}
+
+// DefaultRateLimitTier applies to projects without an assigned tier
+const DefaultRateLimitTier = "standard"
+
+// DefaultRateLimitTiers are the built-in tiers
+var DefaultRateLimitTiers = map[string]RateLimitTier{
+	"free": {
+		Name:                 "free",
+		RequestsPerMinute:    60,
+		KeyRequestsPerMinute: 30,
+		DailyQuota:           10000,
+		MonthlyQuota:         200000,
+	},
+	"standard": {
+		Name:                 "standard",
+		RequestsPerMinute:    600,
+		KeyRequestsPerMinute: 300,
+		DailyQuota:           200000,
+		MonthlyQuota:         5000000,
+	},
+	"enterprise": {
+		Name:                 "enterprise",
+		RequestsPerMinute:    3000,
+		KeyRequestsPerMinute: 1500,
+	},
+}
+
+// UsagePeriod is the accounting period of a usage counter
+type UsagePeriod string
+
+const (
+	UsagePeriodDay   UsagePeriod = "DAY"
+	UsagePeriodMonth UsagePeriod = "MONTH"
+)
+
+// UsageCounter is the number of requests accepted in the current period
+type UsageCounter struct {
+	Period   UsagePeriod
+	Used     int64
+	Limit    int64 // 0 means unlimited
+	ResetsAt time.Time
+}
+
+// Remaining returns the requests left in the period, or -1 when unlimited
+func (c UsageCounter) Remaining() int64 {
+	if c.Limit <= 0 {
+		return -1
+	}
+	if c.Used >= c.Limit {
+		return 0
+	}
+	return c.Limit - c.Used
+}
+
+// Exhausted returns true if the quota of the period is used up
+func (c UsageCounter) Exhausted() bool {
+	return c.Limit > 0 && c.Used >= c.Limit
+}
+
+// APIUsage is the inbound API usage of a project or integration key
+type APIUsage struct {
+	ProjectID     string
+	IntegrationID string // Set for the usage of a single integration key
+	Tier          RateLimitTier
+	Daily         UsageCounter
+	Monthly       UsageCounter
+}
+
+// UsageSubjectForProject returns the usage counter subject of a project
+func UsageSubjectForProject(projectID string) string {
+	return "project:" + projectID
+}
+
+// UsageSubjectForIntegration returns the usage counter subject of an integration key
+func UsageSubjectForIntegration(integrationID string) string {
+	return "key:" + integrationID
+}
+
+// UsageDay returns the UTC day a request is accounted to, e.g. 2024-01-31
+func UsageDay(t time.Time) string {
+	return t.UTC().Format("2006-01-02")
+}
+
+// UsageMonth returns the UTC month a request is accounted to, e.g. 2024-01
+func UsageMonth(t time.Time) string {
+	return t.UTC().Format("2006-01")
+}
+
+// NextUsageDay returns when the daily counters reset
+func NextUsageDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+}
+
+// NextUsageMonth returns when the monthly counters reset
+func NextUsageMonth(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,100 @@
+package domain
+
+import "github.com/shopspring/decimal"
+
+// OrderCancelReason is why an order was canceled
+type OrderCancelReason string
+
+const (
+	OrderCancelReasonCustomer  OrderCancelReason = "customer"
+	OrderCancelReasonFraud     OrderCancelReason = "fraud"
+	OrderCancelReasonInventory OrderCancelReason = "inventory"
+	OrderCancelReasonDeclined  OrderCancelReason = "declined"
+	OrderCancelReasonStaff     OrderCancelReason = "staff"
+	OrderCancelReasonOther     OrderCancelReason = "other"
+)
+
+// OrderCancellation holds the options of an order cancellation
+type OrderCancellation struct {
+	Reason  OrderCancelReason
+	Email   bool           // Send the cancellation email to the customer
+	Restock bool           // Restock the line items of the order
+	Refund  *RefundRequest // Refund issued with the cancellation, nil to refund nothing
+}
+
+// RestockType decides what happens to the stock of a refunded line item
+type RestockType string
+
+const (
+	RestockTypeNoRestock RestockType = "no_restock"
+	RestockTypeCancel    RestockType = "cancel" // The line item was not fulfilled
+	RestockTypeReturn    RestockType = "return" // The line item was fulfilled and sent back
+)
+
+// RefundRequest describes a refund to calculate or create, in the shape of Shopify's refund endpoints
+type RefundRequest struct {
+	Currency     string                     `json:"currency,omitempty"`
+	Note         string                     `json:"note,omitempty"`
+	Notify       bool                       `json:"notify"`
+	Shipping     *RefundShippingRequest     `json:"shipping,omitempty"`
+	LineItems    []RefundLineItemRequest    `json:"refund_line_items,omitempty"`
+	Transactions []RefundTransactionRequest `json:"transactions,omitempty"`
+}
+
+// RefundShippingRequest refunds all the shipping of an order or an amount of it
+type RefundShippingRequest struct {
+	FullRefund bool             `json:"full_refund,omitempty"`
+	Amount     *decimal.Decimal `json:"amount,omitempty"`
+}
+
+// RefundLineItemRequest refunds a quantity of a line item, restocking it at LocationID unless RestockType is no_restock
+type RefundLineItemRequest struct {
+	LineItemID  uint64      `json:"line_item_id"`
+	Quantity    int         `json:"quantity"`
+	RestockType RestockType `json:"restock_type,omitempty"`
+	LocationID  uint64      `json:"location_id,omitempty"`
+}
+
+// RefundTransactionRequest pays back an amount of a captured or sale transaction
+type RefundTransactionRequest struct {
+	ParentID uint64          `json:"parent_id"`
+	Amount   decimal.Decimal `json:"amount"`
+	Kind     string          `json:"kind"`
+	Gateway  string          `json:"gateway"`
+}
+
+// RefundCalculation is Shopify's suggestion for a refund, with the transactions that would pay it back
+type RefundCalculation struct {
+	Currency     string                       `json:"currency"`
+	Shipping     RefundShippingCalculation    `json:"shipping"`
+	LineItems    []RefundLineItemCalculation  `json:"refund_line_items"`
+	Transactions []SuggestedRefundTransaction `json:"transactions"`
+}
+
+// RefundShippingCalculation is the shipping amount a refund would pay back
+type RefundShippingCalculation struct {
+	Amount            *decimal.Decimal `json:"amount"`
+	Tax               *decimal.Decimal `json:"tax"`
+	MaximumRefundable *decimal.Decimal `json:"maximum_refundable"`
+}
+
+// RefundLineItemCalculation is the amount a refund would pay back for a line item
+type RefundLineItemCalculation struct {
+	LineItemID      uint64           `json:"line_item_id"`
+	Quantity        int              `json:"quantity"`
+	RestockType     RestockType      `json:"restock_type"`
+	LocationID      uint64           `json:"location_id"`
+	Price           *decimal.Decimal `json:"price"`
+	DiscountedPrice *decimal.Decimal `json:"discounted_price"`
+	Subtotal        *decimal.Decimal `json:"subtotal"`
+	TotalTax        *decimal.Decimal `json:"total_tax"`
+}
+
+// SuggestedRefundTransaction is a transaction a refund could be paid back with
+type SuggestedRefundTransaction struct {
+	ParentID          uint64           `json:"parent_id"`
+	Amount            *decimal.Decimal `json:"amount"`
+	MaximumRefundable *decimal.Decimal `json:"maximum_refundable"`
+	Kind              string           `json:"kind"`
+	Gateway           string           `json:"gateway"`
+}
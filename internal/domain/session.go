@@ -10,6 +10,7 @@ type Session struct {
 	Scopes      []string  `json:"scopes" bson:"scopes"`
 	ProjectID   string    `json:"project_id" bson:"project_id"`
 	Environment string    `json:"environment" bson:"environment"`
+	AppHandle   string    `json:"app_handle,omitempty" bson:"app_handle,omitempty"` // App the OAuth flow was started for
 	ReturnURL   string    `json:"return_url" bson:"return_url"`
 	RedirectURI string    `json:"redirect_uri" bson:"redirect_uri"` // OAuth redirect URI used in authorization request
 	ExpiresAt   time.Time `json:"expires_at" bson:"expires_at"`
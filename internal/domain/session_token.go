@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+// SessionTokenClaims represents the claims of an App Bridge session token (JWT signed with the app secret)
+type SessionTokenClaims struct {
+	Iss  string `json:"iss"`  // Shop admin URL, e.g. https://store.myshopify.com/admin
+	Dest string `json:"dest"` // Shop URL, e.g. https://store.myshopify.com
+	Aud  string `json:"aud"`  // API key of the app
+	Sub  string `json:"sub"`  // Shopify user ID
+	Exp  int64  `json:"exp"`
+	Nbf  int64  `json:"nbf"`
+	Iat  int64  `json:"iat"`
+	Jti  string `json:"jti"`
+	Sid  string `json:"sid"`
+}
+
+// ShopDomain returns the shop domain from the dest claim
+func (c *SessionTokenClaims) ShopDomain() string {
+	parsed, err := url.Parse(c.Dest)
+	if err != nil || parsed.Host == "" {
+		return strings.TrimPrefix(c.Dest, "https://")
+	}
+	return parsed.Host
+}
+
+// RequestedTokenType is the token type requested from Shopify's token-exchange grant
+type RequestedTokenType string
+
+const (
+	// RequestedTokenTypeOffline requests an offline access token bound to the shop
+	RequestedTokenTypeOffline RequestedTokenType = "urn:shopify:params:oauth:token-type:offline-access-token"
+	// RequestedTokenTypeOnline requests an online access token bound to the session user
+	RequestedTokenTypeOnline RequestedTokenType = "urn:shopify:params:oauth:token-type:online-access-token"
+)
+
+// sessionTokenClaimsKey is the context key for verified session token claims
+const sessionTokenClaimsKey ContextKey = "sessionTokenClaims"
+
+// WithSessionTokenClaims adds verified session token claims to the context
+func WithSessionTokenClaims(ctx context.Context, claims *SessionTokenClaims) context.Context {
+	return context.WithValue(ctx, sessionTokenClaimsKey, claims)
+}
+
+// GetSessionTokenClaimsFromContext extracts verified session token claims from context
+func GetSessionTokenClaimsFromContext(ctx context.Context) *SessionTokenClaims {
+	if claims, ok := ctx.Value(sessionTokenClaimsKey).(*SessionTokenClaims); ok {
+		return claims
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package domain
+
+import "time"
+
+// StorefrontToken is a Storefront API access token created for a shop, tracked per project/environment/app
+// The token authorizes public Storefront API queries, the storefront proxy uses the most recent one of a shop
+type StorefrontToken struct {
+	ID           uint64     `json:"id"` // Shopify's StorefrontAccessToken ID
+	ProjectID    string     `json:"project_id"`
+	Environment  string     `json:"environment"`
+	AppHandle    string     `json:"app_handle"`
+	ShopDomain   string     `json:"shop_domain"`
+	Title        string     `json:"title"`
+	AccessToken  string     `json:"-"` // Encrypted when stored
+	AccessScopes []string   `json:"access_scopes"`
+	CreatedBy    string     `json:"created_by,omitempty"` // Principal that created the token
+	CreatedAt    *time.Time `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// Key returns the key of the shop the token belongs to
+func (t *StorefrontToken) Key() ShopKey {
+	return NewShopKey(t.ProjectID, t.Environment, t.AppHandle, t.ShopDomain)
+}
+
+// StorefrontCatalogFields are the Storefront API root fields that only read public catalog data, so their responses
+// are the same for every buyer and can be cached
+var StorefrontCatalogFields = map[string]bool{
+	"article":                true,
+	"articles":               true,
+	"blog":                   true,
+	"blogs":                  true,
+	"collection":             true,
+	"collections":            true,
+	"localization":           true,
+	"menu":                   true,
+	"metaobject":             true,
+	"metaobjects":            true,
+	"page":                   true,
+	"pages":                  true,
+	"predictiveSearch":       true,
+	"product":                true,
+	"productRecommendations": true,
+	"productTags":            true,
+	"productTypes":           true,
+	"products":               true,
+	"search":                 true,
+	"shop":                   true,
+	"sitemap":                true,
+	"urlRedirects":           true,
+	"__typename":             true,
+}
@@ -17,6 +17,7 @@ type WebhookSubscription struct {
 	ID          string    `json:"id" bson:"_id"`
 	ProjectID   string    `json:"project_id" bson:"project_id"`
 	Environment string    `json:"environment" bson:"environment"`
+	AppHandle   string    `json:"app_handle" bson:"app_handle"`
 	ShopDomain  string    `json:"shop_domain" bson:"shop_domain"`
 	WebhookID   int64     `json:"webhook_id" bson:"webhook_id"` // Shopify webhook ID
 	Topic       string    `json:"topic" bson:"topic"`
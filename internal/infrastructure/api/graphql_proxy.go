@@ -0,0 +1,155 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"archie-core-shopify-layer/internal/application"
+	"archie-core-shopify-layer/internal/domain"
+	"archie-core-shopify-layer/internal/infrastructure/auth"
+	"github.com/rs/zerolog"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// maxGraphQLProxyBody bounds the size of a passthrough request
+const maxGraphQLProxyBody = 1 << 20
+
+// graphQLProxyPath is the Admin API path key scopes match the passthrough against
+const graphQLProxyPath = "graphql.json"
+
+// GraphQLProxy passes GraphQL requests through to the Admin GraphQL API of the tenant's shops
+type GraphQLProxy struct {
+	shopifyService *application.ShopifyService
+	logger         zerolog.Logger
+}
+
+// NewGraphQLProxy creates a new GraphQL passthrough
+func NewGraphQLProxy(shopifyService *application.ShopifyService, logger zerolog.Logger) *GraphQLProxy {
+	return &GraphQLProxy{
+		shopifyService: shopifyService,
+		logger:         logger,
+	}
+}
+
+// HandleRequest handles a POSTed {query, variables, operationName} for the shop in ?shop= or X-Shop-Domain
+// Shopify's response is written as is, including its errors and extensions.cost
+func (p *GraphQLProxy) HandleRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "GraphQL requests must be POSTed", "")
+		return
+	}
+	if domain.GetProjectIDFromContext(ctx) == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "X-Project-ID header is required", "")
+		return
+	}
+
+	shopDomain := r.URL.Query().Get("shop")
+	if shopDomain == "" {
+		shopDomain = r.Header.Get("X-Shop-Domain")
+	}
+	if shopDomain == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "shop parameter or X-Shop-Domain header is required", "")
+		return
+	}
+
+	var request domain.GraphQLRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxGraphQLProxyBody)).Decode(&request); err != nil || request.Query == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "Body must be a JSON object with a query", "")
+		return
+	}
+	operation, err := parseGraphQLOperation(request)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error(), "")
+		return
+	}
+
+	// Mutations require write access, then the shop binding and scopes of integration keys apply
+	principal := domain.GetPrincipalFromContext(ctx)
+	if principal == nil {
+		writeError(w, http.StatusUnauthorized, "UNAUTHENTICATED", "Authentication required", "")
+		return
+	}
+	permission := domain.PermissionReadGraphQL
+	if operation.Operation == ast.Mutation {
+		permission = domain.PermissionWriteGraphQL
+	}
+	auditOperation := "graphql " + string(operation.Operation)
+	if !principal.HasPermission(permission) {
+		auth.AuditPermissionDenied(p.logger, principal, auditOperation, permission)
+		writeError(w, http.StatusForbidden, "FORBIDDEN", "Missing permission "+string(permission), permission)
+		return
+	}
+	if !principal.AllowsRESTRequest(shopDomain, http.MethodPost, graphQLProxyPath) {
+		auth.AuditPermissionDenied(p.logger, principal, auditOperation, "")
+		writeError(w, http.StatusForbidden, "FORBIDDEN", "Integration key is not allowed to call the GraphQL API", "")
+		return
+	}
+
+	response, err := p.shopifyService.ExecuteGraphQL(ctx, shopDomain, request)
+	if err != nil {
+		writeServiceError(w, p.logger, shopDomain, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		p.logger.Error().Err(err).Msg("Failed to write response")
+	}
+}
+
+// writeServiceError maps an error of the service to a status code
+func writeServiceError(w http.ResponseWriter, logger zerolog.Logger, shopDomain string, err error) {
+	appErr, ok := domain.AsAppError(err)
+	if !ok {
+		logger.Error().Err(err).Str("shop", shopDomain).Msg("Failed to proxy GraphQL request")
+		writeError(w, http.StatusBadGateway, "SHOPIFY_API", "Failed to call Shopify", "")
+		return
+	}
+
+	status := http.StatusBadGateway
+	switch appErr.Type {
+	case domain.ErrorTypeNotFound:
+		status = http.StatusNotFound
+	case domain.ErrorTypeUnauthorized:
+		status = http.StatusUnauthorized
+	case domain.ErrorTypeForbidden:
+		status = http.StatusForbidden
+	case domain.ErrorTypeValidation:
+		status = http.StatusBadRequest
+	case domain.ErrorTypeRateLimit:
+		status = http.StatusTooManyRequests
+		w.Header().Set("Retry-After", strconv.Itoa(appErr.RetryAfter()))
+	case domain.ErrorTypeInternal, domain.ErrorTypeDatabase:
+		status = http.StatusInternalServerError
+	}
+	writeError(w, status, string(appErr.Type), appErr.Message, "")
+}
+
+// parseGraphQLOperation parses a request and returns the operation it runs
+func parseGraphQLOperation(request domain.GraphQLRequest) (*ast.OperationDefinition, error) {
+	document, err := parser.ParseQuery(&ast.Source{Input: request.Query})
+	if err != nil {
+		return nil, err
+	}
+
+	var selected *ast.OperationDefinition
+	switch {
+	case request.OperationName != "":
+		selected = document.Operations.ForName(request.OperationName)
+	case len(document.Operations) == 1:
+		selected = document.Operations[0]
+	}
+	if selected == nil {
+		return nil, errors.New("operationName must name one of the operations of the query")
+	}
+	if selected.Operation == ast.Subscription {
+		return nil, errors.New("subscriptions are not supported")
+	}
+	return selected, nil
+}
@@ -9,6 +9,7 @@ import (
 
 	"archie-core-shopify-layer/internal/application"
 	"archie-core-shopify-layer/internal/domain"
+	"archie-core-shopify-layer/internal/infrastructure/auth"
 	"github.com/rs/zerolog"
 )
 
@@ -48,14 +49,6 @@ func (p *RESTProxy) HandleProxyRequest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get shop to retrieve access token
-	shop, err := p.shopifyService.GetShop(ctx, shopDomain)
-	if err != nil {
-		p.logger.Error().Err(err).Str("shop", shopDomain).Msg("Failed to get shop")
-		http.Error(w, "Shop not found or not authenticated", http.StatusNotFound)
-		return
-	}
-
 	// Extract Shopify API path from request
 	// Format: /api/v1/{project}/{environment}/shopify/{resource}
 	pathParts := strings.Split(r.URL.Path, "/")
@@ -72,7 +65,35 @@ func (p *RESTProxy) HandleProxyRequest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	shopifyPath := "/admin/api/2024-01/" + strings.Join(pathParts[shopifyPathIndex:], "/")
+	resourcePath := strings.Join(pathParts[shopifyPathIndex:], "/")
+
+	// Check the permission required by the route, then the shop binding and scopes of integration keys
+	principal := domain.GetPrincipalFromContext(ctx)
+	if principal == nil {
+		writeError(w, http.StatusUnauthorized, "UNAUTHENTICATED", "Authentication required", "")
+		return
+	}
+	operation := r.Method + " " + resourcePath
+	if permission := domain.RequiredRESTPermission(r.Method, resourcePath); !principal.HasPermission(permission) {
+		auth.AuditPermissionDenied(p.logger, principal, operation, permission)
+		writeError(w, http.StatusForbidden, "FORBIDDEN", "Missing permission "+string(permission), permission)
+		return
+	}
+	if !principal.AllowsRESTRequest(shopDomain, r.Method, resourcePath) {
+		auth.AuditPermissionDenied(p.logger, principal, operation, "")
+		writeError(w, http.StatusForbidden, "FORBIDDEN", "Integration key is not allowed to call this path", "")
+		return
+	}
+
+	// Get shop to retrieve access token
+	shop, err := p.shopifyService.GetShop(ctx, shopDomain)
+	if err != nil {
+		p.logger.Error().Err(err).Str("shop", shopDomain).Msg("Failed to get shop")
+		http.Error(w, "Shop not found or not authenticated", http.StatusNotFound)
+		return
+	}
+
+	shopifyPath := "/admin/api/" + domain.ShopifyAPIVersion + "/" + resourcePath
 	if r.URL.RawQuery != "" {
 		shopifyPath += "?" + r.URL.RawQuery
 	}
@@ -88,9 +109,9 @@ func (p *RESTProxy) HandleProxyRequest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Copy headers (excluding host and connection)
+	// Copy headers (excluding host, connection and the caller's credentials)
 	for key, values := range r.Header {
-		if key != "Host" && key != "Connection" && !strings.HasPrefix(key, "X-") {
+		if key != "Host" && key != "Connection" && key != "Authorization" && !strings.HasPrefix(key, "X-") {
 			for _, value := range values {
 				req.Header.Add(key, value)
 			}
@@ -162,3 +183,17 @@ func (p *RESTProxy) transformResponse(body []byte, path string) ([]byte, error)
 	return json.Marshal(transformed)
 }
 
+// writeError writes a structured JSON error, with the missing permission for authorization failures
+func writeError(w http.ResponseWriter, status int, code string, message string, permission domain.Permission) {
+	body := map[string]interface{}{
+		"code":    code,
+		"message": message,
+	}
+	if permission != "" {
+		body["permission"] = permission
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{"error": body})
+}
@@ -0,0 +1,179 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"archie-core-shopify-layer/internal/application"
+	"archie-core-shopify-layer/internal/domain"
+	"archie-core-shopify-layer/internal/infrastructure/auth"
+	"archie-core-shopify-layer/internal/infrastructure/cache"
+	"github.com/rs/zerolog"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// storefrontProxyPath is the path key scopes match the Storefront proxy against
+const storefrontProxyPath = "storefront/graphql.json"
+
+// DefaultStorefrontCacheTTL is how long catalog responses of the Storefront proxy are cached by default
+const DefaultStorefrontCacheTTL = time.Minute
+
+// maxStorefrontCacheEntries bounds the responses the Storefront proxy keeps in memory
+const maxStorefrontCacheEntries = 1000
+
+// StorefrontProxy passes GraphQL requests through to the Storefront API of the tenant's shops
+// Queries that only read the public catalog are answered from a cache for the TTL
+type StorefrontProxy struct {
+	storefrontService *application.StorefrontService
+	cache             *cache.ResponseCache
+	cacheTTL          time.Duration
+	logger            zerolog.Logger
+}
+
+// NewStorefrontProxy creates a new Storefront proxy, a zero cacheTTL disables caching
+func NewStorefrontProxy(storefrontService *application.StorefrontService, cacheTTL time.Duration, logger zerolog.Logger) *StorefrontProxy {
+	proxy := &StorefrontProxy{
+		storefrontService: storefrontService,
+		cacheTTL:          cacheTTL,
+		logger:            logger,
+	}
+	if cacheTTL > 0 {
+		proxy.cache = cache.NewResponseCache(logger, cacheTTL, maxStorefrontCacheEntries)
+	}
+	return proxy
+}
+
+// HandleRequest handles a POSTed {query, variables, operationName} for the shop in ?shop= or X-Shop-Domain
+// Shopify's response is written as is; X-Cache tells whether it came from the cache
+func (p *StorefrontProxy) HandleRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Storefront requests must be POSTed", "")
+		return
+	}
+	if domain.GetProjectIDFromContext(ctx) == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "X-Project-ID header is required", "")
+		return
+	}
+
+	shopDomain := r.URL.Query().Get("shop")
+	if shopDomain == "" {
+		shopDomain = r.Header.Get("X-Shop-Domain")
+	}
+	if shopDomain == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "shop parameter or X-Shop-Domain header is required", "")
+		return
+	}
+
+	var request domain.GraphQLRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxGraphQLProxyBody)).Decode(&request); err != nil || request.Query == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "Body must be a JSON object with a query", "")
+		return
+	}
+	operation, err := parseGraphQLOperation(request)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error(), "")
+		return
+	}
+
+	// Mutations such as cart changes require write access, then the shop binding and scopes of integration keys apply
+	principal := domain.GetPrincipalFromContext(ctx)
+	if principal == nil {
+		writeError(w, http.StatusUnauthorized, "UNAUTHENTICATED", "Authentication required", "")
+		return
+	}
+	permission := domain.PermissionReadStorefront
+	if operation.Operation == ast.Mutation {
+		permission = domain.PermissionWriteStorefront
+	}
+	auditOperation := "storefront " + string(operation.Operation)
+	if !principal.HasPermission(permission) {
+		auth.AuditPermissionDenied(p.logger, principal, auditOperation, permission)
+		writeError(w, http.StatusForbidden, "FORBIDDEN", "Missing permission "+string(permission), permission)
+		return
+	}
+	if !principal.AllowsRESTRequest(shopDomain, http.MethodPost, storefrontProxyPath) {
+		auth.AuditPermissionDenied(p.logger, principal, auditOperation, "")
+		writeError(w, http.StatusForbidden, "FORBIDDEN", "Integration key is not allowed to call the Storefront API", "")
+		return
+	}
+
+	cacheKey := ""
+	if p.cache != nil && isStorefrontCatalogOperation(operation) {
+		cacheKey = storefrontCacheKey(r, shopDomain, request)
+		if cached, ok := p.cache.Get(ctx, cacheKey); ok {
+			writeStorefrontResponse(w, p.logger, cached.([]byte), "HIT")
+			return
+		}
+	}
+
+	response, err := p.storefrontService.ExecuteStorefront(ctx, shopDomain, request)
+	if err != nil {
+		writeServiceError(w, p.logger, shopDomain, err)
+		return
+	}
+
+	body, err := json.Marshal(response)
+	if err != nil {
+		p.logger.Error().Err(err).Msg("Failed to encode Storefront response")
+		writeError(w, http.StatusInternalServerError, "INTERNAL", "Failed to encode response", "")
+		return
+	}
+
+	cacheStatus := "BYPASS"
+	if cacheKey != "" {
+		cacheStatus = "MISS"
+		// Errors may be transient, only complete responses are cached
+		if len(response.Errors) == 0 {
+			_ = p.cache.Set(ctx, cacheKey, body, p.cacheTTL)
+		}
+	}
+	writeStorefrontResponse(w, p.logger, body, cacheStatus)
+}
+
+// writeStorefrontResponse writes an encoded Storefront response
+func writeStorefrontResponse(w http.ResponseWriter, logger zerolog.Logger, body []byte, cacheStatus string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Cache", cacheStatus)
+	if _, err := w.Write(body); err != nil {
+		logger.Error().Err(err).Msg("Failed to write response")
+	}
+}
+
+// isStorefrontCatalogOperation returns true for queries that only select public catalog fields and carry no buyer
+// identity, so every caller of the shop gets the same response
+func isStorefrontCatalogOperation(operation *ast.OperationDefinition) bool {
+	if operation.Operation != ast.Query || len(operation.SelectionSet) == 0 {
+		return false
+	}
+	for _, directive := range operation.Directives {
+		if directive.Name == "inContext" && directive.Arguments.ForName("buyer") != nil {
+			return false
+		}
+	}
+	for _, selection := range operation.SelectionSet {
+		field, ok := selection.(*ast.Field)
+		if !ok || !domain.StorefrontCatalogFields[field.Name] {
+			return false
+		}
+	}
+	return true
+}
+
+// storefrontCacheKey keys a catalog request by tenant, shop and the request itself
+func storefrontCacheKey(r *http.Request, shopDomain string, request domain.GraphQLRequest) string {
+	ctx := r.Context()
+	variables, _ := json.Marshal(request.Variables)
+	return cache.GenerateKey(
+		domain.GetProjectIDFromContext(ctx),
+		domain.GetEnvironmentFromContext(ctx),
+		domain.GetAppHandleFromContext(ctx),
+		shopDomain,
+		request.OperationName,
+		request.Query,
+		string(variables),
+	)
+}
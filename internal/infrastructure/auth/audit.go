@@ -0,0 +1,29 @@
+package auth
+
+import (
+	"archie-core-shopify-layer/internal/domain"
+
+	"github.com/rs/zerolog"
+)
+
+// AuditPermissionDenied records a request refused because the principal lacks a permission
+// operation is the GraphQL field or "METHOD path" of the REST request, permission is empty for key scope denials
+func AuditPermissionDenied(logger zerolog.Logger, principal *domain.Principal, operation string, permission domain.Permission) {
+	event := logger.Warn().Str("operation", operation)
+	if permission != "" {
+		event = event.Str("permission", string(permission))
+	}
+	if principal != nil {
+		roles := make([]string, 0, len(principal.EffectiveRoles()))
+		for _, role := range principal.EffectiveRoles() {
+			roles = append(roles, string(role))
+		}
+		event = event.
+			Str("principalType", string(principal.Type)).
+			Str("subject", principal.Subject).
+			Str("projectId", principal.ProjectID).
+			Str("environment", principal.Environment).
+			Strs("roles", roles)
+	}
+	event.Msg("Security audit: Permission denied")
+}
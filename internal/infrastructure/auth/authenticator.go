@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"archie-core-shopify-layer/internal/domain"
+)
+
+// ErrNoCredentials is returned by an authenticator when the request carries none of its credentials
+var ErrNoCredentials = errors.New("no credentials")
+
+// Authenticator resolves the principal of a request from one kind of credential
+type Authenticator interface {
+	// Name identifies the authenticator in logs
+	Name() string
+	// Authenticate returns ErrNoCredentials when its credential is absent, or an error when it is invalid
+	Authenticate(r *http.Request) (*domain.Principal, error)
+}
+
+// Chain tries authenticators in order until one finds its credential
+type Chain struct {
+	authenticators []Authenticator
+}
+
+// NewChain creates an authenticator chain
+func NewChain(authenticators ...Authenticator) *Chain {
+	return &Chain{authenticators: authenticators}
+}
+
+// Authenticate returns the principal of the first authenticator whose credential is present
+// An invalid credential fails the request instead of falling through to the next authenticator
+func (c *Chain) Authenticate(r *http.Request) (*domain.Principal, string, error) {
+	for _, authenticator := range c.authenticators {
+		principal, err := authenticator.Authenticate(r)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		return principal, authenticator.Name(), err
+	}
+	return nil, "", ErrNoCredentials
+}
+
+// Names lists the authenticators of the chain
+func (c *Chain) Names() []string {
+	names := make([]string, len(c.authenticators))
+	for i, authenticator := range c.authenticators {
+		names[i] = authenticator.Name()
+	}
+	return names
+}
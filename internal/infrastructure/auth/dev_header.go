@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"net/http"
+
+	"archie-core-shopify-layer/internal/domain"
+)
+
+// Tenant selection headers
+const (
+	ProjectIDHeader   = "X-Project-ID"
+	EnvironmentHeader = "environment"
+	AppHandleHeader   = "X-App-Handle"
+)
+
+// DevHeaderAuthenticator trusts the X-Project-ID header without any credential
+// It must only be enabled for local development, anyone knowing a project ID can act on it
+type DevHeaderAuthenticator struct{}
+
+// NewDevHeaderAuthenticator creates a development header authenticator
+func NewDevHeaderAuthenticator() *DevHeaderAuthenticator {
+	return &DevHeaderAuthenticator{}
+}
+
+// Name identifies the authenticator in logs
+func (a *DevHeaderAuthenticator) Name() string {
+	return "dev_header"
+}
+
+// Authenticate takes the tenant from the X-Project-ID, environment and X-App-Handle headers
+func (a *DevHeaderAuthenticator) Authenticate(r *http.Request) (*domain.Principal, error) {
+	projectID := r.Header.Get(ProjectIDHeader)
+	if projectID == "" {
+		return nil, ErrNoCredentials
+	}
+
+	return &domain.Principal{
+		Type:        domain.PrincipalTypeDevHeader,
+		Subject:     "dev",
+		ProjectID:   projectID,
+		Environment: r.Header.Get(EnvironmentHeader),
+		AppHandle:   r.Header.Get(AppHandleHeader),
+	}, nil
+}
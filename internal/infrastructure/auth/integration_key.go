@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"archie-core-shopify-layer/internal/domain"
+)
+
+// IntegrationKeyHeader carries the integration key issued after a shop install
+const IntegrationKeyHeader = "X-Integration-Key"
+
+// IntegrationLookup finds the active integration that owns a key
+type IntegrationLookup interface {
+	AuthenticateKey(ctx context.Context, key string) (*domain.Integration, error)
+}
+
+// IntegrationKeyAuthenticator authenticates requests with an integration key bound to a project, environment and shop
+type IntegrationKeyAuthenticator struct {
+	integrations IntegrationLookup
+}
+
+// NewIntegrationKeyAuthenticator creates an integration key authenticator
+func NewIntegrationKeyAuthenticator(integrations IntegrationLookup) *IntegrationKeyAuthenticator {
+	return &IntegrationKeyAuthenticator{integrations: integrations}
+}
+
+// Name identifies the authenticator in logs
+func (a *IntegrationKeyAuthenticator) Name() string {
+	return "integration_key"
+}
+
+// Authenticate resolves the integration of the X-Integration-Key header
+// Expired and revoked keys are rejected
+func (a *IntegrationKeyAuthenticator) Authenticate(r *http.Request) (*domain.Principal, error) {
+	key := r.Header.Get(IntegrationKeyHeader)
+	if key == "" {
+		return nil, ErrNoCredentials
+	}
+
+	integration, err := a.integrations.AuthenticateKey(r.Context(), key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid integration key: %w", err)
+	}
+
+	return &domain.Principal{
+		Type:        domain.PrincipalTypeIntegrationKey,
+		Subject:     integration.ID,
+		ProjectID:   integration.ProjectID,
+		Environment: integration.Environment,
+		AppHandle:   integration.AppHandle,
+		ShopDomain:  integration.ShopDomain,
+		Scopes:      integration.Scopes,
+	}, nil
+}
@@ -0,0 +1,157 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"archie-core-shopify-layer/internal/domain"
+)
+
+// platformJWTLeeway tolerates small clock skew between the platform and this service
+const platformJWTLeeway = 30 * time.Second
+
+// PlatformClaims are the claims of a JWT issued by the platform for a project environment
+type PlatformClaims struct {
+	Iss         string   `json:"iss"`
+	Sub         string   `json:"sub"`
+	Aud         string   `json:"aud"`
+	Exp         int64    `json:"exp"`
+	Nbf         int64    `json:"nbf"`
+	Iat         int64    `json:"iat"`
+	ProjectID   string   `json:"project_id"`
+	Environment string   `json:"environment"`
+	AppHandle   string   `json:"app_handle,omitempty"`
+	Roles       []string `json:"roles,omitempty"`       // Roles of the caller, the admin role applies when neither roles nor permissions are set
+	Permissions []string `json:"permissions,omitempty"` // Permissions granted on top of the roles
+}
+
+// PlatformJWTAuthenticator authenticates requests with an HS256 JWT signed by the platform
+// The project and environment come from the token claims, request headers cannot override them
+type PlatformJWTAuthenticator struct {
+	secret   []byte
+	issuer   string // Optional, checked when set
+	audience string // Optional, checked when set
+	now      func() time.Time
+}
+
+// NewPlatformJWTAuthenticator creates a platform JWT authenticator
+func NewPlatformJWTAuthenticator(secret string, issuer string, audience string) *PlatformJWTAuthenticator {
+	return &PlatformJWTAuthenticator{
+		secret:   []byte(secret),
+		issuer:   issuer,
+		audience: audience,
+		now:      time.Now,
+	}
+}
+
+// Name identifies the authenticator in logs
+func (a *PlatformJWTAuthenticator) Name() string {
+	return "platform_jwt"
+}
+
+// Authenticate verifies the bearer token of the Authorization header
+func (a *PlatformJWTAuthenticator) Authenticate(r *http.Request) (*domain.Principal, error) {
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, "Bearer ") {
+		return nil, ErrNoCredentials
+	}
+
+	claims, err := a.verify(strings.TrimSpace(strings.TrimPrefix(header, "Bearer ")))
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Principal{
+		Type:        domain.PrincipalTypePlatformJWT,
+		Subject:     claims.Sub,
+		ProjectID:   claims.ProjectID,
+		Environment: claims.Environment,
+		AppHandle:   claims.AppHandle,
+		Roles:       toRoles(claims.Roles),
+		Permissions: toPermissions(claims.Permissions),
+	}, nil
+}
+
+// verify checks the signature, validity window, issuer, audience and tenant claims of a token
+func (a *PlatformJWTAuthenticator) verify(token string) (*PlatformClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed platform token")
+	}
+
+	headerBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode platform token header: %w", err)
+	}
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := json.Unmarshal(headerBytes, &header); err != nil {
+		return nil, fmt.Errorf("failed to parse platform token header: %w", err)
+	}
+	if header.Alg != "HS256" {
+		return nil, fmt.Errorf("unsupported platform token algorithm: %s", header.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode platform token signature: %w", err)
+	}
+	mac := hmac.New(sha256.New, a.secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, fmt.Errorf("platform token signature verification failed")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode platform token payload: %w", err)
+	}
+	var claims PlatformClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("failed to parse platform token claims: %w", err)
+	}
+
+	now := a.now()
+	if claims.Exp == 0 || now.After(time.Unix(claims.Exp, 0).Add(platformJWTLeeway)) {
+		return nil, fmt.Errorf("platform token has expired")
+	}
+	if claims.Nbf != 0 && now.Add(platformJWTLeeway).Before(time.Unix(claims.Nbf, 0)) {
+		return nil, fmt.Errorf("platform token is not yet valid")
+	}
+	if a.issuer != "" && claims.Iss != a.issuer {
+		return nil, fmt.Errorf("platform token issuer mismatch")
+	}
+	if a.audience != "" && claims.Aud != a.audience {
+		return nil, fmt.Errorf("platform token audience mismatch")
+	}
+	if claims.ProjectID == "" {
+		return nil, fmt.Errorf("platform token has no project_id claim")
+	}
+
+	return &claims, nil
+}
+
+// toRoles converts role claims to domain roles
+func toRoles(values []string) []domain.Role {
+	roles := make([]domain.Role, 0, len(values))
+	for _, value := range values {
+		roles = append(roles, domain.Role(value))
+	}
+	return roles
+}
+
+// toPermissions converts permission claims to domain permissions
+func toPermissions(values []string) []domain.Permission {
+	permissions := make([]domain.Permission, 0, len(values))
+	for _, value := range values {
+		permissions = append(permissions, domain.Permission(value))
+	}
+	return permissions
+}
@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"archie-core-shopify-layer/internal/domain"
+)
+
+// ServiceTokenHeader carries the static token of an internal service
+const ServiceTokenHeader = "X-Service-Token"
+
+// ServiceTokenAuthenticator authenticates trusted internal services with static tokens
+// Services act on any project, so they select the tenant with the X-Project-ID and environment headers
+type ServiceTokenAuthenticator struct {
+	tokens map[string]string // service name -> token
+}
+
+// NewServiceTokenAuthenticator creates a service token authenticator
+func NewServiceTokenAuthenticator(tokens map[string]string) *ServiceTokenAuthenticator {
+	return &ServiceTokenAuthenticator{tokens: tokens}
+}
+
+// ParseServiceTokens parses "name:token" pairs separated by commas, as set in SERVICE_TOKENS
+func ParseServiceTokens(value string) (map[string]string, error) {
+	tokens := map[string]string{}
+	for i, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, token, ok := strings.Cut(pair, ":")
+		if !ok || name == "" || token == "" {
+			return nil, fmt.Errorf("invalid service token entry %d, expected name:token", i+1)
+		}
+		tokens[name] = token
+	}
+	return tokens, nil
+}
+
+// Name identifies the authenticator in logs
+func (a *ServiceTokenAuthenticator) Name() string {
+	return "service_token"
+}
+
+// Authenticate compares the X-Service-Token header with the configured tokens in constant time
+func (a *ServiceTokenAuthenticator) Authenticate(r *http.Request) (*domain.Principal, error) {
+	presented := r.Header.Get(ServiceTokenHeader)
+	if presented == "" {
+		return nil, ErrNoCredentials
+	}
+
+	service := ""
+	for name, token := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(presented), []byte(token)) == 1 {
+			service = name
+		}
+	}
+	if service == "" {
+		return nil, fmt.Errorf("invalid service token")
+	}
+
+	projectID := r.Header.Get(ProjectIDHeader)
+	if projectID == "" {
+		return nil, fmt.Errorf("%s header is required for service tokens", ProjectIDHeader)
+	}
+
+	return &domain.Principal{
+		Type:        domain.PrincipalTypeServiceToken,
+		Subject:     service,
+		ProjectID:   projectID,
+		Environment: r.Header.Get(EnvironmentHeader),
+		AppHandle:   r.Header.Get(AppHandleHeader),
+	}, nil
+}
@@ -41,6 +41,28 @@ func NewRedisCache(addr string, password string, db int) (*RedisCache, error) {
 	return &RedisCache{client: client}, nil
 }
 
+// NewRedisClientFromURL creates a Redis client from a redis:// URL and verifies the connection
+// The client is shared by Redis-backed stores that need commands beyond the Cache interface
+func NewRedisClientFromURL(redisURL string) (*redis.Client, error) {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Redis URL: %w", err)
+	}
+
+	client := redis.NewClient(opts)
+
+	// Test connection
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return client, nil
+}
+
 // Get retrieves a value from cache
 func (c *RedisCache) Get(ctx context.Context, key string, dest interface{}) error {
 	val, err := c.client.Get(ctx, key).Result()
@@ -50,6 +50,15 @@ var (
 		[]string{"operation", "error_type"},
 	)
 
+	// Token refresh metrics
+	TokenRefreshesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "shopify_token_refreshes_total",
+			Help: "Total number of expiring access token refresh attempts",
+		},
+		[]string{"status"},
+	)
+
 	// Cache metrics
 	CacheHits = promauto.NewCounter(
 		prometheus.CounterOpts{
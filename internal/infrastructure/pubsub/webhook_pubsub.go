@@ -22,8 +22,10 @@ type WebhookEventChannel struct {
 
 // WebhookEventFilter filters webhook events
 type WebhookEventFilter struct {
-	Topics []string // Filter by topics
-	Shop   string   // Filter by shop domain
+	ProjectID   string   // Filter by project
+	Environment string   // Filter by environment
+	Topics      []string // Filter by topics
+	Shop        string   // Filter by shop domain
 }
 
 // WebhookPubSub manages webhook event subscriptions
@@ -136,6 +138,14 @@ func (ps *WebhookPubSub) matchesFilter(event *domain.WebhookEvent, filter *Webho
 		return true // No filter, match all
 	}
 
+	// Check tenant filter
+	if filter.ProjectID != "" && event.ProjectID != filter.ProjectID {
+		return false
+	}
+	if filter.Environment != "" && event.Environment != filter.Environment {
+		return false
+	}
+
 	// Check topic filter
 	if len(filter.Topics) > 0 {
 		topicMatch := false
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"archie-core-shopify-layer/internal/domain"
+	"archie-core-shopify-layer/internal/infrastructure/repository/entity"
+	"archie-core-shopify-layer/internal/ports"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoBulkOperationRepository implements BulkOperationRepository using MongoDB
+type MongoBulkOperationRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoBulkOperationRepository creates a new MongoDB bulk operation repository
+func NewMongoBulkOperationRepository(db *mongo.Database) ports.BulkOperationRepository {
+	return &MongoBulkOperationRepository{
+		collection: db.Collection("bulk_operations"),
+	}
+}
+
+// bulkOperationShopFilter matches the documents of a shop
+func bulkOperationShopFilter(key domain.ShopKey) bson.M {
+	return bson.M{
+		"projectId":   key.ProjectID,
+		"environment": key.Environment,
+		"appHandle":   key.AppHandle,
+		"shopDomain":  key.Domain,
+	}
+}
+
+// Get retrieves a bulk operation of a shop by Shopify ID
+func (r *MongoBulkOperationRepository) Get(ctx context.Context, key domain.ShopKey, operationID uint64) (*domain.BulkOperation, error) {
+	filter := bulkOperationShopFilter(key)
+	filter["operationId"] = operationID
+
+	var doc entity.MongoBulkOperationDoc
+	err := r.collection.FindOne(ctx, filter).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bulk operation: %w", err)
+	}
+
+	return doc.ToDomain(), nil
+}
+
+// Save upserts a bulk operation keyed by shop and Shopify ID
+func (r *MongoBulkOperationRepository) Save(ctx context.Context, operation *domain.BulkOperation) error {
+	operation.UpdatedAt = time.Now()
+	doc := entity.MongoBulkOperationDocFromDomain(operation)
+
+	// Create unique index on shop/operation and the listing index if they don't exist
+	indexModels := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "projectId", Value: 1},
+				{Key: "environment", Value: 1},
+				{Key: "appHandle", Value: 1},
+				{Key: "shopDomain", Value: 1},
+				{Key: "operationId", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				{Key: "projectId", Value: 1},
+				{Key: "environment", Value: 1},
+				{Key: "appHandle", Value: 1},
+				{Key: "shopDomain", Value: 1},
+				{Key: "createdAt", Value: -1},
+			},
+		},
+	}
+	_, _ = r.collection.Indexes().CreateMany(ctx, indexModels)
+
+	filter := bulkOperationShopFilter(operation.Key())
+	filter["operationId"] = operation.ID
+
+	if _, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": doc}, options.Update().SetUpsert(true)); err != nil {
+		return fmt.Errorf("failed to save bulk operation: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves the most recent bulk operations of a shop, newest first
+func (r *MongoBulkOperationRepository) List(ctx context.Context, key domain.ShopKey, limit int) ([]*domain.BulkOperation, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}}).SetLimit(int64(limit))
+	cursor, err := r.collection.Find(ctx, bulkOperationShopFilter(key), opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bulk operations: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var operations []*domain.BulkOperation
+	for cursor.Next(ctx) {
+		var doc entity.MongoBulkOperationDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode bulk operation: %w", err)
+		}
+		operations = append(operations, doc.ToDomain())
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return operations, nil
+}
@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"time"
+
+	"archie-core-shopify-layer/internal/domain"
+)
+
+// MongoBulkOperationDoc represents a Shopify bulk operation in MongoDB
+type MongoBulkOperationDoc struct {
+	OperationID      uint64     `bson:"operationId"`
+	ProjectID        string     `bson:"projectId"`
+	Environment      string     `bson:"environment"`
+	AppHandle        string     `bson:"appHandle"`
+	ShopDomain       string     `bson:"shopDomain"`
+	Type             string     `bson:"type"`
+	Status           string     `bson:"status"`
+	Query            string     `bson:"query"`
+	StagedUploadPath string     `bson:"stagedUploadPath,omitempty"`
+	ErrorCode        string     `bson:"errorCode,omitempty"`
+	ObjectCount      int64      `bson:"objectCount"`
+	RootObjectCount  int64      `bson:"rootObjectCount"`
+	FileSize         int64      `bson:"fileSize"`
+	URL              string     `bson:"url,omitempty"`
+	PartialDataURL   string     `bson:"partialDataUrl,omitempty"`
+	StartedBy        string     `bson:"startedBy,omitempty"`
+	CreatedAt        *time.Time `bson:"createdAt"`
+	CompletedAt      *time.Time `bson:"completedAt"`
+	UpdatedAt        time.Time  `bson:"updatedAt"`
+}
+
+// ToDomain converts the MongoDB document to a domain entity
+func (d *MongoBulkOperationDoc) ToDomain() *domain.BulkOperation {
+	return &domain.BulkOperation{
+		ID:               d.OperationID,
+		ProjectID:        d.ProjectID,
+		Environment:      d.Environment,
+		AppHandle:        d.AppHandle,
+		ShopDomain:       d.ShopDomain,
+		Type:             domain.BulkOperationType(d.Type),
+		Status:           domain.BulkOperationStatus(d.Status),
+		Query:            d.Query,
+		StagedUploadPath: d.StagedUploadPath,
+		ErrorCode:        d.ErrorCode,
+		ObjectCount:      d.ObjectCount,
+		RootObjectCount:  d.RootObjectCount,
+		FileSize:         d.FileSize,
+		URL:              d.URL,
+		PartialDataURL:   d.PartialDataURL,
+		StartedBy:        d.StartedBy,
+		CreatedAt:        d.CreatedAt,
+		CompletedAt:      d.CompletedAt,
+		UpdatedAt:        d.UpdatedAt,
+	}
+}
+
+// MongoBulkOperationDocFromDomain converts a domain entity to a MongoDB document
+func MongoBulkOperationDocFromDomain(operation *domain.BulkOperation) *MongoBulkOperationDoc {
+	key := operation.Key()
+	return &MongoBulkOperationDoc{
+		OperationID:      operation.ID,
+		ProjectID:        key.ProjectID,
+		Environment:      key.Environment,
+		AppHandle:        key.AppHandle,
+		ShopDomain:       key.Domain,
+		Type:             string(operation.Type),
+		Status:           string(operation.Status),
+		Query:            operation.Query,
+		StagedUploadPath: operation.StagedUploadPath,
+		ErrorCode:        operation.ErrorCode,
+		ObjectCount:      operation.ObjectCount,
+		RootObjectCount:  operation.RootObjectCount,
+		FileSize:         operation.FileSize,
+		URL:              operation.URL,
+		PartialDataURL:   operation.PartialDataURL,
+		StartedBy:        operation.StartedBy,
+		CreatedAt:        operation.CreatedAt,
+		CompletedAt:      operation.CompletedAt,
+		UpdatedAt:        operation.UpdatedAt,
+	}
+}
@@ -0,0 +1,111 @@
+package entity
+
+import (
+	"time"
+
+	"archie-core-shopify-layer/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MongoInstallTransitionDoc represents a single install state transition in MongoDB
+type MongoInstallTransitionDoc struct {
+	From   string    `bson:"from"`
+	To     string    `bson:"to"`
+	Reason string    `bson:"reason"`
+	At     time.Time `bson:"at"`
+}
+
+// MongoInstallationDoc represents an app installation in MongoDB
+type MongoInstallationDoc struct {
+	ID              primitive.ObjectID          `bson:"_id,omitempty"`
+	ProjectID       string                      `bson:"projectId"`
+	Environment     string                      `bson:"environment"`
+	AppHandle       string                      `bson:"appHandle"`
+	ShopDomain      string                      `bson:"shopDomain"`
+	State           string                      `bson:"state"`
+	RequestedScopes []string                    `bson:"requestedScopes"`
+	GrantedScopes   []string                    `bson:"grantedScopes"`
+	History         []MongoInstallTransitionDoc `bson:"history"`
+	InstalledAt     *time.Time                  `bson:"installedAt"`
+	UninstalledAt   *time.Time                  `bson:"uninstalledAt"`
+	CreatedAt       time.Time                   `bson:"createdAt"`
+	UpdatedAt       time.Time                   `bson:"updatedAt"`
+}
+
+// ToDomain converts the MongoDB document to a domain entity
+func (d *MongoInstallationDoc) ToDomain() *domain.Installation {
+	history := make([]domain.InstallTransition, len(d.History))
+	for i, t := range d.History {
+		history[i] = domain.InstallTransition{
+			From:   domain.InstallState(t.From),
+			To:     domain.InstallState(t.To),
+			Reason: t.Reason,
+			At:     t.At,
+		}
+	}
+
+	return &domain.Installation{
+		ID:              d.ID.Hex(),
+		ProjectID:       d.ProjectID,
+		Environment:     d.Environment,
+		AppHandle:       domain.NormalizeAppHandle(d.AppHandle),
+		ShopDomain:      d.ShopDomain,
+		State:           domain.InstallState(d.State),
+		RequestedScopes: d.RequestedScopes,
+		GrantedScopes:   d.GrantedScopes,
+		History:         history,
+		InstalledAt:     d.InstalledAt,
+		UninstalledAt:   d.UninstalledAt,
+		CreatedAt:       d.CreatedAt,
+		UpdatedAt:       d.UpdatedAt,
+	}
+}
+
+// MongoInstallationDocFromDomain converts a domain entity to a MongoDB document
+func MongoInstallationDocFromDomain(installation *domain.Installation) *MongoInstallationDoc {
+	history := make([]MongoInstallTransitionDoc, len(installation.History))
+	for i, t := range installation.History {
+		history[i] = MongoInstallTransitionDoc{
+			From:   string(t.From),
+			To:     string(t.To),
+			Reason: t.Reason,
+			At:     t.At,
+		}
+	}
+
+	doc := &MongoInstallationDoc{
+		ProjectID:       installation.ProjectID,
+		Environment:     installation.Environment,
+		AppHandle:       domain.NormalizeAppHandle(installation.AppHandle),
+		ShopDomain:      installation.ShopDomain,
+		State:           string(installation.State),
+		RequestedScopes: installation.RequestedScopes,
+		GrantedScopes:   installation.GrantedScopes,
+		History:         history,
+		InstalledAt:     installation.InstalledAt,
+		UninstalledAt:   installation.UninstalledAt,
+		CreatedAt:       installation.CreatedAt,
+		UpdatedAt:       installation.UpdatedAt,
+	}
+
+	if installation.ID != "" {
+		if objID, err := primitive.ObjectIDFromHex(installation.ID); err == nil {
+			doc.ID = objID
+		}
+	}
+
+	return doc
+}
+
+// InstallationKeyFilter returns the Mongo filter matching the installation of a shop key
+// Installations saved before multi-app support have no handle and belong to the default app
+func InstallationKeyFilter(key domain.ShopKey) bson.M {
+	return bson.M{
+		"projectId":   key.ProjectID,
+		"environment": key.Environment,
+		"appHandle":   AppHandleFilter(key.AppHandle),
+		"shopDomain":  key.Domain,
+	}
+}
@@ -9,36 +9,60 @@ import (
 )
 
 // MongoIntegrationDoc represents an integration in MongoDB
+// Key is only present on documents written before keys were hashed, it is removed on first use
 type MongoIntegrationDoc struct {
 	ID          primitive.ObjectID `bson:"_id,omitempty"`
-	Key         string            `bson:"key"`
-	ProjectID   string            `bson:"projectId"`
-	Environment string            `bson:"environment"`
-	ShopDomain  string            `bson:"shopDomain"`
-	CreatedAt   time.Time         `bson:"createdAt"`
-	UpdatedAt   time.Time         `bson:"updatedAt"`
+	Key         string             `bson:"key,omitempty"`
+	KeyHash     string             `bson:"keyHash,omitempty"`
+	KeyPrefix   string             `bson:"keyPrefix"`
+	ProjectID   string             `bson:"projectId"`
+	Environment string             `bson:"environment"`
+	ShopDomain  string             `bson:"shopDomain"`
+	AppHandle   string             `bson:"appHandle,omitempty"`
+	Scopes      []string           `bson:"scopes,omitempty"`
+	ExpiresAt   *time.Time         `bson:"expiresAt,omitempty"`
+	LastUsedAt  *time.Time         `bson:"lastUsedAt,omitempty"`
+	RevokedAt   *time.Time         `bson:"revokedAt,omitempty"`
+	RotatedToID string             `bson:"rotatedToId,omitempty"`
+	CreatedAt   time.Time          `bson:"createdAt"`
+	UpdatedAt   time.Time          `bson:"updatedAt"`
 }
 
 // ToDomain converts the MongoDB document to a domain entity
 func (d *MongoIntegrationDoc) ToDomain() *domain.Integration {
 	return &domain.Integration{
 		ID:          d.ID.Hex(),
-		Key:         d.Key,
+		KeyHash:     d.KeyHash,
+		KeyPrefix:   d.KeyPrefix,
 		ProjectID:   d.ProjectID,
 		Environment: d.Environment,
 		ShopDomain:  d.ShopDomain,
+		AppHandle:   domain.NormalizeAppHandle(d.AppHandle),
+		Scopes:      d.Scopes,
+		ExpiresAt:   d.ExpiresAt,
+		LastUsedAt:  d.LastUsedAt,
+		RevokedAt:   d.RevokedAt,
+		RotatedToID: d.RotatedToID,
 		CreatedAt:   d.CreatedAt,
 		UpdatedAt:   d.UpdatedAt,
 	}
 }
 
 // MongoIntegrationDocFromDomain converts a domain entity to a MongoDB document
+// The plaintext key is never stored
 func MongoIntegrationDocFromDomain(integration *domain.Integration) *MongoIntegrationDoc {
 	doc := &MongoIntegrationDoc{
-		Key:         integration.Key,
+		KeyHash:     integration.KeyHash,
+		KeyPrefix:   integration.KeyPrefix,
 		ProjectID:   integration.ProjectID,
 		Environment: integration.Environment,
 		ShopDomain:  integration.ShopDomain,
+		AppHandle:   integration.AppHandle,
+		Scopes:      integration.Scopes,
+		ExpiresAt:   integration.ExpiresAt,
+		LastUsedAt:  integration.LastUsedAt,
+		RevokedAt:   integration.RevokedAt,
+		RotatedToID: integration.RotatedToID,
 		CreatedAt:   integration.CreatedAt,
 		UpdatedAt:   integration.UpdatedAt,
 	}
@@ -51,4 +75,3 @@ func MongoIntegrationDocFromDomain(integration *domain.Integration) *MongoIntegr
 
 	return doc
 }
-
@@ -4,15 +4,16 @@ import (
 	"time"
 
 	"archie-core-shopify-layer/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // MongoProjectDoc represents the MongoDB project document structure
 type MongoProjectDoc struct {
-	ID        primitive.ObjectID      `bson:"_id,omitempty"`
-	ProjectID string                  `bson:"projectId"`
-	Settings  MongoProjectSettings    `bson:"settings"`
-	UpdatedAt time.Time               `bson:"updatedAt"`
+	ID        primitive.ObjectID   `bson:"_id,omitempty"`
+	ProjectID string               `bson:"projectId"`
+	Settings  MongoProjectSettings `bson:"settings"`
+	UpdatedAt time.Time            `bson:"updatedAt"`
 }
 
 // MongoProjectSettings represents the settings section of a project
@@ -22,41 +23,63 @@ type MongoProjectSettings struct {
 
 // MongoShopifyConfigDoc represents a Shopify config within settings.shopify_configs[]
 type MongoShopifyConfigDoc struct {
-	ID            primitive.ObjectID `bson:"_id,omitempty"`
-	Env           string             `bson:"env"` // Environment name (e.g., "master")
-	EncryptedKey  string             `bson:"encryptedKey"`
-	APIKey        string             `bson:"apiKey"`
-	WebhookSecret string             `bson:"webhookSecret,omitempty"`
-	WebhookURL    string             `bson:"webhookURL"`
-	CreatedAt     time.Time          `bson:"createdAt"`
-	UpdatedAt     time.Time          `bson:"updatedAt"`
+	ID                    primitive.ObjectID `bson:"_id,omitempty"`
+	Env                   string             `bson:"env"`                 // Environment name (e.g., "master")
+	AppHandle             string             `bson:"appHandle,omitempty"` // App handle, empty for configs created before multi-app support
+	EncryptedKey          string             `bson:"encryptedKey"`
+	APIKey                string             `bson:"apiKey"`
+	WebhookSecret         string             `bson:"webhookSecret,omitempty"`
+	WebhookURL            string             `bson:"webhookURL"`
+	ExpiringOfflineTokens bool               `bson:"expiringOfflineTokens,omitempty"` // Opt-in to expiring offline tokens
+	CreatedAt             time.Time          `bson:"createdAt"`
+	UpdatedAt             time.Time          `bson:"updatedAt"`
 }
 
 // ToDomain converts the MongoDB document to a domain entity
 func (d *MongoShopifyConfigDoc) ToDomain(projectID, environment string) *domain.ShopifyConfig {
 	return &domain.ShopifyConfig{
-		ID:            d.ID.Hex(),
-		ProjectID:     projectID,
-		Environment:   environment,
-		EncryptedKey:  d.EncryptedKey,
-		APIKey:        d.APIKey,
-		WebhookSecret: d.WebhookSecret,
-		WebhookURL:    d.WebhookURL,
-		CreatedAt:     d.CreatedAt,
-		UpdatedAt:     d.UpdatedAt,
+		ID:                    d.ID.Hex(),
+		ProjectID:             projectID,
+		Environment:           environment,
+		AppHandle:             domain.NormalizeAppHandle(d.AppHandle),
+		EncryptedKey:          d.EncryptedKey,
+		APIKey:                d.APIKey,
+		WebhookSecret:         d.WebhookSecret,
+		WebhookURL:            d.WebhookURL,
+		ExpiringOfflineTokens: d.ExpiringOfflineTokens,
+		CreatedAt:             d.CreatedAt,
+		UpdatedAt:             d.UpdatedAt,
 	}
 }
 
+// MatchesAppHandle returns true if the config belongs to the given app handle
+// Configs without a handle are treated as the default app
+func (d *MongoShopifyConfigDoc) MatchesAppHandle(appHandle string) bool {
+	return domain.NormalizeAppHandle(d.AppHandle) == domain.NormalizeAppHandle(appHandle)
+}
+
+// AppHandleFilter returns the Mongo filter value matching an app handle
+// The default app also matches documents written before multi-app support, which have no handle
+func AppHandleFilter(appHandle string) interface{} {
+	appHandle = domain.NormalizeAppHandle(appHandle)
+	if appHandle == domain.DefaultAppHandle {
+		return bson.M{"$in": []interface{}{nil, "", domain.DefaultAppHandle}}
+	}
+	return appHandle
+}
+
 // MongoShopifyConfigDocFromDomain converts a domain entity to a MongoDB document
 func MongoShopifyConfigDocFromDomain(config *domain.ShopifyConfig) *MongoShopifyConfigDoc {
 	doc := &MongoShopifyConfigDoc{
-		Env:           config.Environment,
-		EncryptedKey:  config.EncryptedKey,
-		APIKey:        config.APIKey,
-		WebhookSecret: config.WebhookSecret,
-		WebhookURL:    config.WebhookURL,
-		CreatedAt:     config.CreatedAt,
-		UpdatedAt:     config.UpdatedAt,
+		Env:                   config.Environment,
+		AppHandle:             domain.NormalizeAppHandle(config.AppHandle),
+		EncryptedKey:          config.EncryptedKey,
+		APIKey:                config.APIKey,
+		WebhookSecret:         config.WebhookSecret,
+		WebhookURL:            config.WebhookURL,
+		ExpiringOfflineTokens: config.ExpiringOfflineTokens,
+		CreatedAt:             config.CreatedAt,
+		UpdatedAt:             config.UpdatedAt,
 	}
 
 	if config.ID != "" {
@@ -67,4 +90,3 @@ func MongoShopifyConfigDocFromDomain(config *domain.ShopifyConfig) *MongoShopify
 
 	return doc
 }
-
@@ -5,39 +5,75 @@ import (
 
 	"archie-core-shopify-layer/internal/domain"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // MongoShopDoc represents a Shopify store in MongoDB
 type MongoShopDoc struct {
-	ID          primitive.ObjectID `bson:"_id,omitempty"`
-	Domain      string             `bson:"domain"`
-	AccessToken string             `bson:"accessToken"` // Encrypted
-	Scopes      []string           `bson:"scopes"`
-	CreatedAt   time.Time          `bson:"createdAt"`
-	UpdatedAt   time.Time          `bson:"updatedAt"`
+	ID                    primitive.ObjectID `bson:"_id,omitempty"`
+	ProjectID             string             `bson:"projectId"`
+	Environment           string             `bson:"environment"`
+	Domain                string             `bson:"domain"`
+	AccessToken           string             `bson:"accessToken"` // Encrypted
+	Scopes                []string           `bson:"scopes"`
+	AppHandle             string             `bson:"appHandle"`
+	RefreshToken          string             `bson:"refreshToken"` // Encrypted, only for expiring offline tokens
+	AccessTokenExpiresAt  *time.Time         `bson:"accessTokenExpiresAt"`
+	RefreshTokenExpiresAt *time.Time         `bson:"refreshTokenExpiresAt"`
+	TokenRefreshedAt      *time.Time         `bson:"tokenRefreshedAt"`
+	TokenRefreshError     string             `bson:"tokenRefreshError"`
+	CreatedAt             time.Time          `bson:"createdAt"`
+	UpdatedAt             time.Time          `bson:"updatedAt"`
+}
+
+// ShopKeyFilter returns the Mongo filter selecting the shop document of a key
+func ShopKeyFilter(key domain.ShopKey) bson.M {
+	return bson.M{
+		"projectId":   key.ProjectID,
+		"environment": key.Environment,
+		"appHandle":   key.AppHandle,
+		"domain":      key.Domain,
+	}
 }
 
 // ToDomain converts the MongoDB document to a domain entity
 func (d *MongoShopDoc) ToDomain() *domain.Shop {
 	return &domain.Shop{
-		ID:          d.ID.Hex(),
-		Domain:      d.Domain,
-		AccessToken: d.AccessToken,
-		Scopes:      d.Scopes,
-		CreatedAt:   d.CreatedAt,
-		UpdatedAt:   d.UpdatedAt,
+		ID:                    d.ID.Hex(),
+		ProjectID:             d.ProjectID,
+		Environment:           d.Environment,
+		Domain:                d.Domain,
+		AccessToken:           d.AccessToken,
+		Scopes:                d.Scopes,
+		AppHandle:             domain.NormalizeAppHandle(d.AppHandle),
+		RefreshToken:          d.RefreshToken,
+		AccessTokenExpiresAt:  d.AccessTokenExpiresAt,
+		RefreshTokenExpiresAt: d.RefreshTokenExpiresAt,
+		TokenRefreshedAt:      d.TokenRefreshedAt,
+		TokenRefreshError:     d.TokenRefreshError,
+		CreatedAt:             d.CreatedAt,
+		UpdatedAt:             d.UpdatedAt,
 	}
 }
 
 // MongoShopDocFromDomain converts a domain entity to a MongoDB document
 func MongoShopDocFromDomain(shop *domain.Shop) *MongoShopDoc {
+	key := shop.Key()
 	doc := &MongoShopDoc{
-		Domain:      shop.Domain,
-		AccessToken: shop.AccessToken,
-		Scopes:      shop.Scopes,
-		CreatedAt:   shop.CreatedAt,
-		UpdatedAt:   shop.UpdatedAt,
+		ProjectID:             key.ProjectID,
+		Environment:           key.Environment,
+		Domain:                shop.Domain,
+		AccessToken:           shop.AccessToken,
+		Scopes:                shop.Scopes,
+		AppHandle:             key.AppHandle,
+		RefreshToken:          shop.RefreshToken,
+		AccessTokenExpiresAt:  shop.AccessTokenExpiresAt,
+		RefreshTokenExpiresAt: shop.RefreshTokenExpiresAt,
+		TokenRefreshedAt:      shop.TokenRefreshedAt,
+		TokenRefreshError:     shop.TokenRefreshError,
+		CreatedAt:             shop.CreatedAt,
+		UpdatedAt:             shop.UpdatedAt,
 	}
 
 	if shop.ID != "" {
@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"time"
+
+	"archie-core-shopify-layer/internal/domain"
+)
+
+// MongoStorefrontTokenDoc represents a Storefront API access token in MongoDB
+type MongoStorefrontTokenDoc struct {
+	TokenID      uint64     `bson:"tokenId"`
+	ProjectID    string     `bson:"projectId"`
+	Environment  string     `bson:"environment"`
+	AppHandle    string     `bson:"appHandle"`
+	ShopDomain   string     `bson:"shopDomain"`
+	Title        string     `bson:"title"`
+	AccessToken  string     `bson:"accessToken"` // Encrypted
+	AccessScopes []string   `bson:"accessScopes,omitempty"`
+	CreatedBy    string     `bson:"createdBy,omitempty"`
+	CreatedAt    *time.Time `bson:"createdAt"`
+	UpdatedAt    time.Time  `bson:"updatedAt"`
+}
+
+// ToDomain converts the MongoDB document to a domain entity
+func (d *MongoStorefrontTokenDoc) ToDomain() *domain.StorefrontToken {
+	return &domain.StorefrontToken{
+		ID:           d.TokenID,
+		ProjectID:    d.ProjectID,
+		Environment:  d.Environment,
+		AppHandle:    d.AppHandle,
+		ShopDomain:   d.ShopDomain,
+		Title:        d.Title,
+		AccessToken:  d.AccessToken,
+		AccessScopes: d.AccessScopes,
+		CreatedBy:    d.CreatedBy,
+		CreatedAt:    d.CreatedAt,
+		UpdatedAt:    d.UpdatedAt,
+	}
+}
+
+// MongoStorefrontTokenDocFromDomain converts a domain entity to a MongoDB document
+func MongoStorefrontTokenDocFromDomain(token *domain.StorefrontToken) *MongoStorefrontTokenDoc {
+	key := token.Key()
+	return &MongoStorefrontTokenDoc{
+		TokenID:      token.ID,
+		ProjectID:    key.ProjectID,
+		Environment:  key.Environment,
+		AppHandle:    key.AppHandle,
+		ShopDomain:   key.Domain,
+		Title:        token.Title,
+		AccessToken:  token.AccessToken,
+		AccessScopes: token.AccessScopes,
+		CreatedBy:    token.CreatedBy,
+		CreatedAt:    token.CreatedAt,
+		UpdatedAt:    token.UpdatedAt,
+	}
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"archie-core-shopify-layer/internal/domain"
+	"archie-core-shopify-layer/internal/infrastructure/repository/entity"
+	"archie-core-shopify-layer/internal/ports"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoInstallationRepository implements InstallationRepository using MongoDB
+type MongoInstallationRepository struct {
+	collection *mongo.Collection
+}
+
+var _ ports.InstallationRepository = (*MongoInstallationRepository)(nil)
+
+// NewMongoInstallationRepository creates a new MongoDB installation repository
+func NewMongoInstallationRepository(db *mongo.Database) *MongoInstallationRepository {
+	return &MongoInstallationRepository{
+		collection: db.Collection("installations"),
+	}
+}
+
+// EnsureIndexes replaces the project/environment/shop unique index with one that includes the app handle,
+// so each app of an environment has its own installation of a shop
+func (r *MongoInstallationRepository) EnsureIndexes(ctx context.Context) error {
+	// Ignore the error, the index does not exist on new databases
+	_, _ = r.collection.Indexes().DropOne(ctx, "projectId_1_environment_1_shopDomain_1")
+
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "projectId", Value: 1},
+			{Key: "environment", Value: 1},
+			{Key: "appHandle", Value: 1},
+			{Key: "shopDomain", Value: 1},
+		},
+		Options: options.Index().SetUnique(true).SetName("installation_tenant_unique"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create installation indexes: %w", err)
+	}
+	return nil
+}
+
+// Get retrieves the installation of an app for a project/environment/shop
+func (r *MongoInstallationRepository) Get(ctx context.Context, key domain.ShopKey) (*domain.Installation, error) {
+	var doc entity.MongoInstallationDoc
+	err := r.collection.FindOne(ctx, entity.InstallationKeyFilter(key)).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get installation: %w", err)
+	}
+
+	return doc.ToDomain(), nil
+}
+
+// Save upserts an installation keyed by project/environment/app/shop
+// Saving an installation written before multi-app support records its app handle
+func (r *MongoInstallationRepository) Save(ctx context.Context, installation *domain.Installation) error {
+	doc := entity.MongoInstallationDocFromDomain(installation)
+	doc.UpdatedAt = time.Now()
+	if doc.CreatedAt.IsZero() {
+		doc.CreatedAt = time.Now()
+	}
+
+	filter := entity.InstallationKeyFilter(installation.Key())
+	update := bson.M{"$set": doc}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to save installation: %w", err)
+	}
+
+	if oid, ok := result.UpsertedID.(primitive.ObjectID); ok {
+		installation.ID = oid.Hex()
+	}
+
+	return nil
+}
+
+// List retrieves installations for a project/environment, newest first
+func (r *MongoInstallationRepository) List(ctx context.Context, projectID, environment string, state *domain.InstallState) ([]*domain.Installation, error) {
+	filter := bson.M{
+		"projectId":   projectID,
+		"environment": environment,
+	}
+	if state != nil {
+		filter["state"] = string(*state)
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "updatedAt", Value: -1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list installations: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var installations []*domain.Installation
+	for cursor.Next(ctx) {
+		var doc entity.MongoInstallationDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode installation: %w", err)
+		}
+		installations = append(installations, doc.ToDomain())
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return installations, nil
+}
+
+// ListEnvironments retrieves the environments of a project that have installations
+func (r *MongoInstallationRepository) ListEnvironments(ctx context.Context, projectID string) ([]string, error) {
+	values, err := r.collection.Distinct(ctx, "environment", bson.M{"projectId": projectID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list installation environments: %w", err)
+	}
+
+	environments := make([]string, 0, len(values))
+	for _, value := range values {
+		if environment, ok := value.(string); ok && environment != "" {
+			environments = append(environments, environment)
+		}
+	}
+	return environments, nil
+}
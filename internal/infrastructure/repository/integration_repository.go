@@ -10,6 +10,7 @@ import (
 	"archie-core-shopify-layer/internal/ports"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -19,13 +20,52 @@ type MongoIntegrationRepository struct {
 	collection *mongo.Collection
 }
 
+// Ensure MongoIntegrationRepository implements ports.IntegrationRepository
+var _ ports.IntegrationRepository = (*MongoIntegrationRepository)(nil)
+
 // NewMongoIntegrationRepository creates a new MongoDB integration repository
-func NewMongoIntegrationRepository(db *mongo.Database) ports.IntegrationRepository {
+func NewMongoIntegrationRepository(db *mongo.Database) *MongoIntegrationRepository {
 	return &MongoIntegrationRepository{
 		collection: db.Collection("integrations"),
 	}
 }
 
+// EnsureIndexes creates the integration indexes
+// The unique index on the plaintext key is replaced by one on the key hash, as upgraded documents no longer have a key
+func (r *MongoIntegrationRepository) EnsureIndexes(ctx context.Context) error {
+	// Ignore the error, the index does not exist on new databases
+	_, _ = r.collection.Indexes().DropOne(ctx, "key_1")
+
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "keyHash", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetName("integration_key_hash_unique").
+				SetPartialFilterExpression(bson.M{"keyHash": bson.M{"$exists": true}}),
+		},
+		{
+			Keys: bson.D{{Key: "key", Value: 1}},
+			Options: options.Index().
+				SetName("integration_legacy_key").
+				SetPartialFilterExpression(bson.M{"key": bson.M{"$exists": true}}),
+		},
+		{
+			Keys: bson.D{
+				{Key: "projectId", Value: 1},
+				{Key: "environment", Value: 1},
+				{Key: "shopDomain", Value: 1},
+				{Key: "createdAt", Value: -1},
+			},
+			Options: options.Index().SetName("integration_tenant_shop"),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create integration indexes: %w", err)
+	}
+	return nil
+}
+
 // Create creates a new integration
 func (r *MongoIntegrationRepository) Create(ctx context.Context, integration *domain.Integration) error {
 	doc := entity.MongoIntegrationDocFromDomain(integration)
@@ -34,47 +74,68 @@ func (r *MongoIntegrationRepository) Create(ctx context.Context, integration *do
 		doc.CreatedAt = time.Now()
 	}
 
-	// Create unique index on key if it doesn't exist
-	indexModel := mongo.IndexModel{
-		Keys:    bson.D{{Key: "key", Value: 1}},
-		Options: options.Index().SetUnique(true),
-	}
-	_, _ = r.collection.Indexes().CreateOne(ctx, indexModel)
-
-	_, err := r.collection.InsertOne(ctx, doc)
+	result, err := r.collection.InsertOne(ctx, doc)
 	if err != nil {
 		return fmt.Errorf("failed to create integration: %w", err)
 	}
 
+	if objID, ok := result.InsertedID.(primitive.ObjectID); ok {
+		integration.ID = objID.Hex()
+	}
 	return nil
 }
 
-// GetByKey retrieves an integration by its key
-func (r *MongoIntegrationRepository) GetByKey(ctx context.Context, key string) (*domain.Integration, error) {
+// GetByID retrieves an integration by its ID
+func (r *MongoIntegrationRepository) GetByID(ctx context.Context, id string) (*domain.Integration, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+	return r.findOne(ctx, bson.M{"_id": objID})
+}
+
+// GetByKeyHash retrieves an integration by the hash of its key
+func (r *MongoIntegrationRepository) GetByKeyHash(ctx context.Context, keyHash string) (*domain.Integration, error) {
+	return r.findOne(ctx, bson.M{"keyHash": keyHash})
+}
+
+// UpgradeLegacyKey hashes a plaintext key stored before keys were hashed and removes the plaintext
+func (r *MongoIntegrationRepository) UpgradeLegacyKey(ctx context.Context, key string, keyHash string, keyPrefix string) (*domain.Integration, error) {
 	var doc entity.MongoIntegrationDoc
-	filter := bson.M{"key": key}
+	update := bson.M{
+		"$set": bson.M{
+			"keyHash":   keyHash,
+			"keyPrefix": keyPrefix,
+			"updatedAt": time.Now(),
+		},
+		"$unset": bson.M{"key": ""},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
 
-	err := r.collection.FindOne(ctx, filter).Decode(&doc)
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"key": key}, update, opts).Decode(&doc)
 	if err == mongo.ErrNoDocuments {
 		return nil, nil
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get integration: %w", err)
+		return nil, fmt.Errorf("failed to upgrade integration key: %w", err)
 	}
 
 	return doc.ToDomain(), nil
 }
 
-// GetByProjectAndShop retrieves an integration by project ID, environment, and shop domain
-func (r *MongoIntegrationRepository) GetByProjectAndShop(ctx context.Context, projectID, environment, shopDomain string) (*domain.Integration, error) {
-	var doc entity.MongoIntegrationDoc
+// GetByProjectAndShop retrieves the newest non-revoked integration by project ID, environment, shop domain and app handle
+func (r *MongoIntegrationRepository) GetByProjectAndShop(ctx context.Context, projectID, environment, shopDomain, appHandle string) (*domain.Integration, error) {
 	filter := bson.M{
-		"projectId":  projectID,
+		"projectId":   projectID,
 		"environment": environment,
-		"shopDomain": shopDomain,
+		"shopDomain":  shopDomain,
+		"appHandle":   entity.AppHandleFilter(appHandle),
+		"revokedAt":   bson.M{"$exists": false},
 	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "createdAt", Value: -1}})
 
-	err := r.collection.FindOne(ctx, filter).Decode(&doc)
+	var doc entity.MongoIntegrationDoc
+	err := r.collection.FindOne(ctx, filter, opts).Decode(&doc)
 	if err == mongo.ErrNoDocuments {
 		return nil, nil
 	}
@@ -85,10 +146,100 @@ func (r *MongoIntegrationRepository) GetByProjectAndShop(ctx context.Context, pr
 	return doc.ToDomain(), nil
 }
 
-// Delete deletes an integration by key
-func (r *MongoIntegrationRepository) Delete(ctx context.Context, key string) error {
-	filter := bson.M{"key": key}
-	result, err := r.collection.DeleteOne(ctx, filter)
+// List retrieves the integrations of a project environment, newest first
+func (r *MongoIntegrationRepository) List(ctx context.Context, projectID, environment string) ([]*domain.Integration, error) {
+	filter := bson.M{
+		"projectId":   projectID,
+		"environment": environment,
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list integrations: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	integrations := []*domain.Integration{}
+	for cursor.Next(ctx) {
+		var doc entity.MongoIntegrationDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode integration: %w", err)
+		}
+		integrations = append(integrations, doc.ToDomain())
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return integrations, nil
+}
+
+// Update saves the lifecycle fields of an integration
+func (r *MongoIntegrationRepository) Update(ctx context.Context, integration *domain.Integration) error {
+	objID, err := primitive.ObjectIDFromHex(integration.ID)
+	if err != nil {
+		return fmt.Errorf("invalid integration ID: %w", err)
+	}
+
+	integration.UpdatedAt = time.Now()
+	set := bson.M{
+		"scopes":    integration.Scopes,
+		"updatedAt": integration.UpdatedAt,
+	}
+	unset := bson.M{}
+	for field, value := range map[string]*time.Time{
+		"expiresAt": integration.ExpiresAt,
+		"revokedAt": integration.RevokedAt,
+	} {
+		if value != nil {
+			set[field] = *value
+		} else {
+			unset[field] = ""
+		}
+	}
+	if integration.RotatedToID != "" {
+		set["rotatedToId"] = integration.RotatedToID
+	}
+
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update integration: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("integration not found")
+	}
+	return nil
+}
+
+// TouchLastUsed records a successful authentication
+func (r *MongoIntegrationRepository) TouchLastUsed(ctx context.Context, id string, at time.Time) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid integration ID: %w", err)
+	}
+
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": bson.M{"lastUsedAt": at}})
+	if err != nil {
+		return fmt.Errorf("failed to update integration last use: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes an integration by ID
+func (r *MongoIntegrationRepository) Delete(ctx context.Context, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid integration ID: %w", err)
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objID})
 	if err != nil {
 		return fmt.Errorf("failed to delete integration: %w", err)
 	}
@@ -98,3 +249,16 @@ func (r *MongoIntegrationRepository) Delete(ctx context.Context, key string) err
 	return nil
 }
 
+// findOne retrieves a single integration matching a filter
+func (r *MongoIntegrationRepository) findOne(ctx context.Context, filter bson.M) (*domain.Integration, error) {
+	var doc entity.MongoIntegrationDoc
+	err := r.collection.FindOne(ctx, filter).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get integration: %w", err)
+	}
+
+	return doc.ToDomain(), nil
+}
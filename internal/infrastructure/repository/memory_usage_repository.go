@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"archie-core-shopify-layer/internal/ports"
+)
+
+// memoryCounter is a counter with an expiry
+type memoryCounter struct {
+	value     int64
+	expiresAt time.Time
+}
+
+// MemoryUsageRepository implements UsageRepository in process memory
+// Limits are per instance and counters are lost on restart, so it is meant for local development
+type MemoryUsageRepository struct {
+	mu        sync.Mutex
+	counters  map[string]*memoryCounter
+	lastSweep time.Time
+}
+
+// NewMemoryUsageRepository creates a new in-memory usage repository
+func NewMemoryUsageRepository() ports.UsageRepository {
+	return &MemoryUsageRepository{
+		counters: make(map[string]*memoryCounter),
+	}
+}
+
+// IncrementWindow counts a request in a fixed window
+func (r *MemoryUsageRepository) IncrementWindow(ctx context.Context, windowKey string, ttl time.Duration) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.increment(rateLimitKeyPrefix+windowKey, ttl), nil
+}
+
+// IncrementUsage counts a request in the daily and monthly counters
+func (r *MemoryUsageRepository) IncrementUsage(ctx context.Context, subject string, at time.Time) (int64, int64, error) {
+	dayKey, monthKey := usageKeys(subject, at)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.increment(dayKey, dailyUsageTTL), r.increment(monthKey, monthlyUsageTTL), nil
+}
+
+// GetUsage returns the daily and monthly counters of a subject
+func (r *MemoryUsageRepository) GetUsage(ctx context.Context, subject string, at time.Time) (int64, int64, error) {
+	dayKey, monthKey := usageKeys(subject, at)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.get(dayKey), r.get(monthKey), nil
+}
+
+// increment adds one to a counter, creating it with a TTL, and drops expired counters once a minute
+func (r *MemoryUsageRepository) increment(key string, ttl time.Duration) int64 {
+	now := time.Now()
+	if now.Sub(r.lastSweep) > time.Minute {
+		for k, counter := range r.counters {
+			if now.After(counter.expiresAt) {
+				delete(r.counters, k)
+			}
+		}
+		r.lastSweep = now
+	}
+
+	counter, ok := r.counters[key]
+	if !ok || now.After(counter.expiresAt) {
+		counter = &memoryCounter{expiresAt: now.Add(ttl)}
+		r.counters[key] = counter
+	}
+	counter.value++
+	return counter.value
+}
+
+// get returns the value of a counter, 0 if missing or expired
+func (r *MemoryUsageRepository) get(key string) int64 {
+	counter, ok := r.counters[key]
+	if !ok || time.Now().After(counter.expiresAt) {
+		return 0
+	}
+	return counter.value
+}
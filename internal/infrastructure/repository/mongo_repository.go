@@ -23,7 +23,7 @@ type MongoRepository struct {
 }
 
 // NewMongoRepository creates a new MongoDB repository
-func NewMongoRepository(db *mongo.Database) ports.Repository {
+func NewMongoRepository(db *mongo.Database) *MongoRepository {
 	return &MongoRepository{
 		shopsCollection:       db.Collection("shops"),
 		webhooksCollection:    db.Collection("webhook_events"),
@@ -31,8 +31,43 @@ func NewMongoRepository(db *mongo.Database) ports.Repository {
 	}
 }
 
-// SaveShop saves or updates a shop
+// Ensure MongoRepository implements ports.Repository
+var _ ports.Repository = (*MongoRepository)(nil)
+
+// EnsureIndexes creates the shop indexes
+// Shops are unique per project, environment, app and domain, and listed per project environment
+func (r *MongoRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.shopsCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "projectId", Value: 1},
+				{Key: "environment", Value: 1},
+				{Key: "appHandle", Value: 1},
+				{Key: "domain", Value: 1},
+			},
+			Options: options.Index().SetUnique(true).SetName("shop_tenant_unique"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "projectId", Value: 1},
+				{Key: "environment", Value: 1},
+				{Key: "createdAt", Value: 1},
+			},
+			Options: options.Index().SetName("shop_tenant_list"),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create shop indexes: %w", err)
+	}
+	return nil
+}
+
+// SaveShop saves or updates a shop within its project environment
 func (r *MongoRepository) SaveShop(ctx context.Context, shop *domain.Shop) error {
+	if shop.ProjectID == "" {
+		return fmt.Errorf("failed to save shop: project ID is required")
+	}
+
 	doc := entity.MongoShopDocFromDomain(shop)
 	doc.UpdatedAt = time.Now()
 	if doc.CreatedAt.IsZero() {
@@ -40,7 +75,7 @@ func (r *MongoRepository) SaveShop(ctx context.Context, shop *domain.Shop) error
 	}
 
 	opts := options.Update().SetUpsert(true)
-	filter := bson.M{"domain": shop.Domain}
+	filter := entity.ShopKeyFilter(shop.Key())
 	update := bson.M{"$set": doc}
 
 	_, err := r.shopsCollection.UpdateOne(ctx, filter, update, opts)
@@ -51,10 +86,10 @@ func (r *MongoRepository) SaveShop(ctx context.Context, shop *domain.Shop) error
 	return nil
 }
 
-// GetShop retrieves a shop by domain
-func (r *MongoRepository) GetShop(ctx context.Context, shopDomain string) (*domain.Shop, error) {
+// GetShop retrieves a shop by its composite key
+func (r *MongoRepository) GetShop(ctx context.Context, key domain.ShopKey) (*domain.Shop, error) {
 	var doc entity.MongoShopDoc
-	filter := bson.M{"domain": shopDomain}
+	filter := entity.ShopKeyFilter(key)
 
 	err := r.shopsCollection.FindOne(ctx, filter).Decode(&doc)
 	if err == mongo.ErrNoDocuments {
@@ -67,15 +102,21 @@ func (r *MongoRepository) GetShop(ctx context.Context, shopDomain string) (*doma
 	return doc.ToDomain(), nil
 }
 
-// ListShops retrieves all shops
-func (r *MongoRepository) ListShops(ctx context.Context) ([]*domain.Shop, error) {
-	cursor, err := r.shopsCollection.Find(ctx, bson.M{})
+// ListShops retrieves the shops of a project environment, across all of its apps
+func (r *MongoRepository) ListShops(ctx context.Context, projectID string, environment string) ([]*domain.Shop, error) {
+	filter := bson.M{
+		"projectId":   projectID,
+		"environment": environment,
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
+
+	cursor, err := r.shopsCollection.Find(ctx, filter, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list shops: %w", err)
 	}
 	defer cursor.Close(ctx)
 
-	var shops []*domain.Shop
+	shops := []*domain.Shop{}
 	for cursor.Next(ctx) {
 		var doc entity.MongoShopDoc
 		if err := cursor.Decode(&doc); err != nil {
@@ -91,6 +132,47 @@ func (r *MongoRepository) ListShops(ctx context.Context) ([]*domain.Shop, error)
 	return shops, nil
 }
 
+// AcquireTokenRefreshLock takes a short-lived lease on a shop's token refresh
+// The lease lives on the shop document so that it is shared by every replica
+func (r *MongoRepository) AcquireTokenRefreshLock(ctx context.Context, key domain.ShopKey, owner string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	filter := entity.ShopKeyFilter(key)
+	filter["$or"] = []bson.M{
+		{"tokenRefreshLock.expiresAt": bson.M{"$exists": false}},
+		{"tokenRefreshLock.expiresAt": bson.M{"$lt": now}},
+		{"tokenRefreshLock.owner": owner},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"tokenRefreshLock": bson.M{
+				"owner":     owner,
+				"expiresAt": now.Add(ttl),
+			},
+		},
+	}
+
+	result, err := r.shopsCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire token refresh lock: %w", err)
+	}
+
+	return result.MatchedCount == 1, nil
+}
+
+// ReleaseTokenRefreshLock releases a shop's token refresh lease if it is still held by owner
+func (r *MongoRepository) ReleaseTokenRefreshLock(ctx context.Context, key domain.ShopKey, owner string) error {
+	filter := entity.ShopKeyFilter(key)
+	filter["tokenRefreshLock.owner"] = owner
+	update := bson.M{"$unset": bson.M{"tokenRefreshLock": ""}}
+
+	_, err := r.shopsCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to release token refresh lock: %w", err)
+	}
+
+	return nil
+}
+
 // LogWebhook logs a webhook event
 func (r *MongoRepository) LogWebhook(ctx context.Context, event *domain.WebhookEvent) error {
 	doc := entity.MongoWebhookDocFromDomain(event)
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"archie-core-shopify-layer/internal/domain"
+	"archie-core-shopify-layer/internal/ports"
+
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// sessionKeyPrefix namespaces OAuth sessions in Redis
+const sessionKeyPrefix = "shopify:session:"
+
+// RedisSessionRepository implements SessionRepository using Redis
+// Sessions are stored under their state with a TTL matching ExpiresAt, so no cleanup is needed
+type RedisSessionRepository struct {
+	client *redis.Client
+}
+
+// NewRedisSessionRepository creates a new Redis session repository
+func NewRedisSessionRepository(client *redis.Client) ports.SessionRepository {
+	return &RedisSessionRepository{
+		client: client,
+	}
+}
+
+// CreateSession creates a new session
+func (r *RedisSessionRepository) CreateSession(ctx context.Context, session *domain.Session) error {
+	if session.ID == "" {
+		session.ID = primitive.NewObjectID().Hex()
+	}
+	if session.CreatedAt.IsZero() {
+		session.CreatedAt = time.Now()
+	}
+
+	ttl := time.Until(session.ExpiresAt)
+	if ttl <= 0 {
+		return fmt.Errorf("failed to create session: session already expired")
+	}
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+
+	// NX prevents a colliding state from overwriting an existing session
+	created, err := r.client.SetNX(ctx, sessionKeyPrefix+session.State, data, ttl).Result()
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	if !created {
+		return fmt.Errorf("failed to create session: state already exists")
+	}
+
+	return nil
+}
+
+// GetSession retrieves a session by state
+func (r *RedisSessionRepository) GetSession(ctx context.Context, state string) (*domain.Session, error) {
+	data, err := r.client.Get(ctx, sessionKeyPrefix+state).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return decodeSession(data)
+}
+
+// DeleteSession deletes a session by state
+func (r *RedisSessionRepository) DeleteSession(ctx context.Context, state string) error {
+	if err := r.client.Del(ctx, sessionKeyPrefix+state).Err(); err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
+
+// ConsumeSession atomically retrieves and deletes a session by state using GETDEL
+func (r *RedisSessionRepository) ConsumeSession(ctx context.Context, state string) (*domain.Session, error) {
+	data, err := r.client.GetDel(ctx, sessionKeyPrefix+state).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume session: %w", err)
+	}
+
+	return decodeSession(data)
+}
+
+// CleanupExpiredSessions is a no-op, Redis expires sessions through their TTL
+func (r *RedisSessionRepository) CleanupExpiredSessions(ctx context.Context) error {
+	return nil
+}
+
+// decodeSession unmarshals a stored session
+func decodeSession(data []byte) (*domain.Session, error) {
+	var session domain.Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal session: %w", err)
+	}
+	return &session, nil
+}
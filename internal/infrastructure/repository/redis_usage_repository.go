@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"archie-core-shopify-layer/internal/domain"
+	"archie-core-shopify-layer/internal/ports"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// rateLimitKeyPrefix namespaces rate limit windows in Redis
+	rateLimitKeyPrefix = "shopify:ratelimit:"
+	// usageKeyPrefix namespaces usage counters in Redis
+	usageKeyPrefix = "shopify:usage:"
+
+	// Counters outlive their period so the previous day and month stay queryable
+	dailyUsageTTL   = 48 * time.Hour
+	monthlyUsageTTL = 62 * 24 * time.Hour
+)
+
+// incrementScript increments each key and sets its TTL (ARGV, in seconds) when it has none yet
+// TTL is checked in the script rather than with EXPIRE NX, which needs Redis 7.0
+var incrementScript = redis.NewScript(`
+local counts = {}
+for i, key in ipairs(KEYS) do
+	counts[i] = redis.call('INCR', key)
+	if redis.call('TTL', key) < 0 then
+		redis.call('EXPIRE', key, ARGV[i])
+	end
+end
+return counts
+`)
+
+// RedisUsageRepository implements UsageRepository using Redis counters shared by all instances
+type RedisUsageRepository struct {
+	client *redis.Client
+}
+
+// NewRedisUsageRepository creates a new Redis usage repository
+func NewRedisUsageRepository(client *redis.Client) ports.UsageRepository {
+	return &RedisUsageRepository{
+		client: client,
+	}
+}
+
+// IncrementWindow counts a request in a fixed window
+func (r *RedisUsageRepository) IncrementWindow(ctx context.Context, windowKey string, ttl time.Duration) (int64, error) {
+	counts, err := r.increment(ctx, []string{rateLimitKeyPrefix + windowKey}, []time.Duration{ttl})
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment rate limit window: %w", err)
+	}
+	return counts[0], nil
+}
+
+// IncrementUsage counts a request in the daily and monthly counters
+func (r *RedisUsageRepository) IncrementUsage(ctx context.Context, subject string, at time.Time) (int64, int64, error) {
+	dayKey, monthKey := usageKeys(subject, at)
+	counts, err := r.increment(ctx, []string{dayKey, monthKey}, []time.Duration{dailyUsageTTL, monthlyUsageTTL})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to increment usage: %w", err)
+	}
+	return counts[0], counts[1], nil
+}
+
+// increment runs the increment script on keys with their TTLs and returns the new counts
+func (r *RedisUsageRepository) increment(ctx context.Context, keys []string, ttls []time.Duration) ([]int64, error) {
+	args := make([]interface{}, len(ttls))
+	for i, ttl := range ttls {
+		args[i] = int64(ttl / time.Second)
+	}
+	counts, err := incrementScript.Run(ctx, r.client, keys, args...).Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+	if len(counts) != len(keys) {
+		return nil, fmt.Errorf("unexpected counter count %d for %d keys", len(counts), len(keys))
+	}
+	return counts, nil
+}
+
+// GetUsage returns the daily and monthly counters of a subject
+func (r *RedisUsageRepository) GetUsage(ctx context.Context, subject string, at time.Time) (int64, int64, error) {
+	dayKey, monthKey := usageKeys(subject, at)
+	values, err := r.client.MGet(ctx, dayKey, monthKey).Result()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get usage: %w", err)
+	}
+
+	counts := make([]int64, len(values))
+	for i, value := range values {
+		if value == nil {
+			continue
+		}
+		if _, err := fmt.Sscan(value.(string), &counts[i]); err != nil {
+			return 0, 0, fmt.Errorf("failed to parse usage counter: %w", err)
+		}
+	}
+	return counts[0], counts[1], nil
+}
+
+// usageKeys returns the daily and monthly counter keys of a subject
+func usageKeys(subject string, at time.Time) (string, string) {
+	return usageKeyPrefix + subject + ":day:" + domain.UsageDay(at),
+		usageKeyPrefix + subject + ":month:" + domain.UsageMonth(at)
+}
@@ -10,22 +10,44 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// SessionRepository handles session persistence
-type SessionRepository struct {
+// MongoSessionRepository implements SessionRepository using MongoDB
+type MongoSessionRepository struct {
 	collection *mongo.Collection
 }
 
-// NewSessionRepository creates a new session repository
-func NewSessionRepository(db *mongo.Database) *SessionRepository {
-	return &SessionRepository{
+// NewMongoSessionRepository creates a new MongoDB session repository
+func NewMongoSessionRepository(db *mongo.Database) *MongoSessionRepository {
+	return &MongoSessionRepository{
 		collection: db.Collection("sessions"),
 	}
 }
 
+// EnsureIndexes creates the unique state index and a TTL index that lets MongoDB purge expired sessions
+func (r *MongoSessionRepository) EnsureIndexes(ctx context.Context) error {
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "state", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			// expireAfterSeconds 0 removes each session as soon as its expires_at is reached
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	}
+
+	if _, err := r.collection.Indexes().CreateMany(ctx, indexModels); err != nil {
+		return fmt.Errorf("failed to create session indexes: %w", err)
+	}
+
+	return nil
+}
+
 // CreateSession creates a new session
-func (r *SessionRepository) CreateSession(ctx context.Context, session *domain.Session) error {
+func (r *MongoSessionRepository) CreateSession(ctx context.Context, session *domain.Session) error {
 	if session.ID == "" {
 		session.ID = primitive.NewObjectID().Hex()
 	}
@@ -42,7 +64,7 @@ func (r *SessionRepository) CreateSession(ctx context.Context, session *domain.S
 }
 
 // GetSession retrieves a session by state
-func (r *SessionRepository) GetSession(ctx context.Context, state string) (*domain.Session, error) {
+func (r *MongoSessionRepository) GetSession(ctx context.Context, state string) (*domain.Session, error) {
 	var session domain.Session
 	filter := bson.M{"state": state}
 
@@ -58,7 +80,7 @@ func (r *SessionRepository) GetSession(ctx context.Context, state string) (*doma
 }
 
 // DeleteSession deletes a session by state
-func (r *SessionRepository) DeleteSession(ctx context.Context, state string) error {
+func (r *MongoSessionRepository) DeleteSession(ctx context.Context, state string) error {
 	filter := bson.M{"state": state}
 
 	_, err := r.collection.DeleteOne(ctx, filter)
@@ -69,8 +91,27 @@ func (r *SessionRepository) DeleteSession(ctx context.Context, state string) err
 	return nil
 }
 
+// ConsumeSession atomically retrieves and deletes a session by state
+func (r *MongoSessionRepository) ConsumeSession(ctx context.Context, state string) (*domain.Session, error) {
+	var session domain.Session
+	filter := bson.M{
+		"state":      state,
+		"expires_at": bson.M{"$gt": time.Now()}, // The TTL monitor may not have removed it yet
+	}
+
+	err := r.collection.FindOneAndDelete(ctx, filter).Decode(&session)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume session: %w", err)
+	}
+
+	return &session, nil
+}
+
 // CleanupExpiredSessions removes expired sessions
-func (r *SessionRepository) CleanupExpiredSessions(ctx context.Context) error {
+func (r *MongoSessionRepository) CleanupExpiredSessions(ctx context.Context) error {
 	filter := bson.M{
 		"expires_at": bson.M{"$lt": time.Now()},
 	}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"archie-core-shopify-layer/internal/domain"
+	"archie-core-shopify-layer/internal/infrastructure/repository/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ShopMigrationResult summarizes a run of MigrateLegacyShops
+type ShopMigrationResult struct {
+	Migrated   int      // Legacy documents moved to their tenant and removed
+	Scoped     int      // Tenant-scoped documents created from legacy documents
+	Unresolved []string // Domains with no tenant or several candidate tenants, left untouched until reinstalled
+}
+
+// MigrateLegacyShops scopes shop documents stored before tenant isolation (keyed by domain only)
+// The owning tenant is taken from the integrations and installations that reference the domain; a legacy
+// document is moved to it when exactly one tenant references the domain. Existing tenant documents are kept.
+// A legacy document was overwritten by whichever project installed last, so when several tenants reference the
+// domain its tokens belong to only one of them: it is left in place and reported, and those tenants must
+// reinstall. Documents with no tenant are reported too. The migration is idempotent.
+func MigrateLegacyShops(ctx context.Context, db *mongo.Database) (*ShopMigrationResult, error) {
+	result := &ShopMigrationResult{Unresolved: []string{}}
+	shopsCollection := db.Collection("shops")
+
+	legacyFilter := bson.M{"$or": []bson.M{
+		{"projectId": bson.M{"$exists": false}},
+		{"projectId": ""},
+	}}
+	cursor, err := shopsCollection.Find(ctx, legacyFilter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find legacy shops: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc bson.M
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode legacy shop: %w", err)
+		}
+		shopDomain, _ := doc["domain"].(string)
+
+		keys, err := legacyShopTenants(ctx, db, shopDomain)
+		if err != nil {
+			return nil, err
+		}
+		if len(keys) != 1 {
+			result.Unresolved = append(result.Unresolved, shopDomain)
+			continue
+		}
+		key := keys[0]
+
+		scoped := bson.M{}
+		for field, value := range doc {
+			if field != "_id" && field != "tokenRefreshLock" {
+				scoped[field] = value
+			}
+		}
+		scoped["projectId"] = key.ProjectID
+		scoped["environment"] = key.Environment
+		scoped["appHandle"] = key.AppHandle
+		scoped["updatedAt"] = time.Now()
+
+		// Insert only, a shop reinstalled since the upgrade already has a newer token
+		upsert, err := shopsCollection.UpdateOne(ctx,
+			entity.ShopKeyFilter(key),
+			bson.M{"$setOnInsert": scoped},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to migrate shop %s: %w", shopDomain, err)
+		}
+		if upsert.UpsertedCount > 0 {
+			result.Scoped++
+		}
+
+		if id, ok := doc["_id"].(primitive.ObjectID); ok {
+			if _, err := shopsCollection.DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+				return nil, fmt.Errorf("failed to remove legacy shop %s: %w", shopDomain, err)
+			}
+		}
+		result.Migrated++
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return result, nil
+}
+
+// legacyShopTenants finds the project environments (and apps) that reference a shop domain
+func legacyShopTenants(ctx context.Context, db *mongo.Database, shopDomain string) ([]domain.ShopKey, error) {
+	seen := map[string]bool{}
+	keys := []domain.ShopKey{}
+	add := func(projectID, environment, appHandle string) {
+		if projectID == "" {
+			return
+		}
+		key := domain.NewShopKey(projectID, environment, appHandle, shopDomain)
+		if !seen[key.String()] {
+			seen[key.String()] = true
+			keys = append(keys, key)
+		}
+	}
+
+	var integrations []entity.MongoIntegrationDoc
+	cursor, err := db.Collection("integrations").Find(ctx, bson.M{"shopDomain": shopDomain})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find integrations for shop %s: %w", shopDomain, err)
+	}
+	if err := cursor.All(ctx, &integrations); err != nil {
+		return nil, fmt.Errorf("failed to decode integrations for shop %s: %w", shopDomain, err)
+	}
+	for _, integration := range integrations {
+		add(integration.ProjectID, integration.Environment, integration.AppHandle)
+	}
+
+	var installations []entity.MongoInstallationDoc
+	cursor, err = db.Collection("installations").Find(ctx, bson.M{"shopDomain": shopDomain})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find installations for shop %s: %w", shopDomain, err)
+	}
+	if err := cursor.All(ctx, &installations); err != nil {
+		return nil, fmt.Errorf("failed to decode installations for shop %s: %w", shopDomain, err)
+	}
+	for _, installation := range installations {
+		add(installation.ProjectID, installation.Environment, domain.DefaultAppHandle)
+	}
+
+	return keys, nil
+}
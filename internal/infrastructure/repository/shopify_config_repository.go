@@ -55,13 +55,29 @@ func (r *MongoShopifyConfigRepository) GetByTenantID(ctx context.Context, tenant
 		return nil, fmt.Errorf("failed to get project: %w", err)
 	}
 
-	// Find shopify_config within settings.shopify_configs[] matching the environment
+	// Find shopify_config within settings.shopify_configs[] matching the environment and app handle
+	// Without an app handle in context, the default app wins, then the first app of the environment
+	appHandle := domain.GetAppHandleFromContext(ctx)
 	var shopifyConfig *entity.MongoShopifyConfigDoc
 	for i := range project.Settings.ShopifyConfigs {
-		if project.Settings.ShopifyConfigs[i].Env == environment {
-			shopifyConfig = &project.Settings.ShopifyConfigs[i]
+		candidate := &project.Settings.ShopifyConfigs[i]
+		if candidate.Env != environment {
+			continue
+		}
+		if appHandle != "" {
+			if candidate.MatchesAppHandle(appHandle) {
+				shopifyConfig = candidate
+				break
+			}
+			continue
+		}
+		if candidate.MatchesAppHandle(domain.DefaultAppHandle) {
+			shopifyConfig = candidate
 			break
 		}
+		if shopifyConfig == nil {
+			shopifyConfig = candidate
+		}
 	}
 
 	if shopifyConfig == nil {
@@ -71,11 +87,109 @@ func (r *MongoShopifyConfigRepository) GetByTenantID(ctx context.Context, tenant
 	return shopifyConfig.ToDomain(projectID, environment), nil
 }
 
+// ListByTenantID retrieves every app configuration of a project environment
+func (r *MongoShopifyConfigRepository) ListByTenantID(ctx context.Context, tenantID string) ([]*domain.ShopifyConfig, error) {
+	projectID := domain.GetProjectIDFromContext(ctx)
+	environment := domain.GetEnvironmentFromContext(ctx)
+
+	if projectID == "" {
+		projectID = tenantID // Fallback
+	}
+	if environment == "" {
+		environment = domain.DefaultEnvironment // Default
+	}
+
+	var project entity.MongoProjectDoc
+	err := r.collection.FindOne(ctx, bson.M{"projectId": projectID}).Decode(&project)
+	if err == mongo.ErrNoDocuments {
+		return []*domain.ShopifyConfig{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	configs := []*domain.ShopifyConfig{}
+	for i := range project.Settings.ShopifyConfigs {
+		if project.Settings.ShopifyConfigs[i].Env == environment {
+			configs = append(configs, project.Settings.ShopifyConfigs[i].ToDomain(projectID, environment))
+		}
+	}
+
+	return configs, nil
+}
+
+// ListByProject retrieves the configuration of every app in every environment of a project
+func (r *MongoShopifyConfigRepository) ListByProject(ctx context.Context, projectID string) ([]*domain.ShopifyConfig, error) {
+	var project entity.MongoProjectDoc
+	err := r.collection.FindOne(ctx, bson.M{"projectId": projectID}).Decode(&project)
+	if err == mongo.ErrNoDocuments {
+		return []*domain.ShopifyConfig{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	configs := make([]*domain.ShopifyConfig, 0, len(project.Settings.ShopifyConfigs))
+	for i := range project.Settings.ShopifyConfigs {
+		shopifyConfig := &project.Settings.ShopifyConfigs[i]
+		configs = append(configs, shopifyConfig.ToDomain(projectID, shopifyConfig.Env))
+	}
+
+	return configs, nil
+}
+
+// GetByAPIKey retrieves the Shopify configuration whose app uses the given API key
+// An API key shared by several configurations is an error, as the app it identifies would be ambiguous
+func (r *MongoShopifyConfigRepository) GetByAPIKey(ctx context.Context, apiKey string) (*domain.ShopifyConfig, error) {
+	if apiKey == "" {
+		return nil, nil
+	}
+
+	// Two projects are enough to tell the key is shared
+	opts := options.Find().SetLimit(2)
+	cursor, err := r.collection.Find(ctx, bson.M{"settings.shopify_configs.apiKey": apiKey}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project by API key: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var matches []*domain.ShopifyConfig
+	for cursor.Next(ctx) {
+		var project entity.MongoProjectDoc
+		if err := cursor.Decode(&project); err != nil {
+			return nil, fmt.Errorf("failed to decode project: %w", err)
+		}
+		for i := range project.Settings.ShopifyConfigs {
+			if project.Settings.ShopifyConfigs[i].APIKey == apiKey {
+				shopifyConfig := &project.Settings.ShopifyConfigs[i]
+				matches = append(matches, shopifyConfig.ToDomain(project.ProjectID, shopifyConfig.Env))
+			}
+		}
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, nil // No project uses this API key
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("API key is used by more than one Shopify configuration")
+	}
+}
+
 // Create creates a new Shopify configuration within a project's settings.shopify_configs[]
 func (r *MongoShopifyConfigRepository) Create(ctx context.Context, config *domain.ShopifyConfig) error {
 	projectID := config.ProjectID
 	environment := config.Environment
 
+	if err := r.ensureAPIKeyUnused(ctx, config.APIKey, primitive.NilObjectID); err != nil {
+		return err
+	}
+
 	// Find project or create if it doesn't exist
 	var project entity.MongoProjectDoc
 	err := r.collection.FindOne(ctx, bson.M{"projectId": projectID}).Decode(&project)
@@ -97,10 +211,10 @@ func (r *MongoShopifyConfigRepository) Create(ctx context.Context, config *domai
 		return fmt.Errorf("failed to get project: %w", err)
 	}
 
-	// Check if shopify_config already exists for this environment
+	// Check if shopify_config already exists for this environment and app
 	for _, existingConfig := range project.Settings.ShopifyConfigs {
-		if existingConfig.Env == environment {
-			return fmt.Errorf("shopify config already exists for project %s and environment %s", projectID, environment)
+		if existingConfig.Env == environment && existingConfig.MatchesAppHandle(config.AppHandle) {
+			return fmt.Errorf("shopify config already exists for project %s, environment %s and app %s", projectID, environment, domain.NormalizeAppHandle(config.AppHandle))
 		}
 	}
 
@@ -150,14 +264,20 @@ func (r *MongoShopifyConfigRepository) Update(ctx context.Context, tenantID stri
 		return fmt.Errorf("invalid config ID: %w", err)
 	}
 
+	if err := r.ensureAPIKeyUnused(ctx, config.APIKey, objID); err != nil {
+		return err
+	}
+
 	// Update the specific shopify_config within the array
 	update := bson.M{
 		"$set": bson.M{
-			"settings.shopify_configs.$[elem].encryptedKey":  config.EncryptedKey,
-			"settings.shopify_configs.$[elem].apiKey":        config.APIKey,
-			"settings.shopify_configs.$[elem].webhookSecret": config.WebhookSecret,
-			"settings.shopify_configs.$[elem].webhookURL":    config.WebhookURL,
-			"settings.shopify_configs.$[elem].updatedAt":     time.Now(),
+			"settings.shopify_configs.$[elem].encryptedKey":          config.EncryptedKey,
+			"settings.shopify_configs.$[elem].apiKey":                config.APIKey,
+			"settings.shopify_configs.$[elem].appHandle":             domain.NormalizeAppHandle(config.AppHandle),
+			"settings.shopify_configs.$[elem].webhookSecret":         config.WebhookSecret,
+			"settings.shopify_configs.$[elem].webhookURL":            config.WebhookURL,
+			"settings.shopify_configs.$[elem].expiringOfflineTokens": config.ExpiringOfflineTokens,
+			"settings.shopify_configs.$[elem].updatedAt":             time.Now(),
 			"updatedAt": time.Now(),
 		},
 	}
@@ -183,6 +303,27 @@ func (r *MongoShopifyConfigRepository) Update(ctx context.Context, tenantID stri
 	return nil
 }
 
+// ensureAPIKeyUnused checks that no other configuration uses an API key, as session tokens find their app by it
+func (r *MongoShopifyConfigRepository) ensureAPIKeyUnused(ctx context.Context, apiKey string, configID primitive.ObjectID) error {
+	if apiKey == "" {
+		return nil
+	}
+
+	filter := bson.M{
+		"settings.shopify_configs": bson.M{
+			"$elemMatch": bson.M{"apiKey": apiKey, "_id": bson.M{"$ne": configID}},
+		},
+	}
+	count, err := r.collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return fmt.Errorf("failed to check API key: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("API key is already used by another Shopify configuration")
+	}
+	return nil
+}
+
 // Delete deletes a Shopify configuration from a project's settings.shopify_configs[]
 func (r *MongoShopifyConfigRepository) Delete(ctx context.Context, tenantID string) error {
 	// Extract projectID and environment from context (type-safe)
@@ -196,11 +337,14 @@ func (r *MongoShopifyConfigRepository) Delete(ctx context.Context, tenantID stri
 		environment = domain.DefaultEnvironment // Default
 	}
 
-	// Remove the shopify_config from the array
+	// Remove the shopify_config of the selected app from the array
+	// Configs created before multi-app support have no appHandle and belong to the default app
+	appHandle := domain.NormalizeAppHandle(domain.GetAppHandleFromContext(ctx))
 	update := bson.M{
 		"$pull": bson.M{
 			"settings.shopify_configs": bson.M{
-				"env": environment,
+				"env":       environment,
+				"appHandle": entity.AppHandleFilter(appHandle),
 			},
 		},
 		"$set": bson.M{
@@ -222,7 +366,7 @@ func (r *MongoShopifyConfigRepository) Delete(ctx context.Context, tenantID stri
 	}
 
 	if result.ModifiedCount == 0 {
-		return fmt.Errorf("shopify config not found for project %s and environment %s", projectID, environment)
+		return fmt.Errorf("shopify config not found for project %s, environment %s and app %s", projectID, environment, appHandle)
 	}
 
 	return nil
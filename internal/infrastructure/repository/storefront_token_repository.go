@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"archie-core-shopify-layer/internal/domain"
+	"archie-core-shopify-layer/internal/infrastructure/repository/entity"
+	"archie-core-shopify-layer/internal/ports"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoStorefrontTokenRepository implements StorefrontTokenRepository using MongoDB
+type MongoStorefrontTokenRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoStorefrontTokenRepository creates a new MongoDB Storefront token repository
+func NewMongoStorefrontTokenRepository(db *mongo.Database) ports.StorefrontTokenRepository {
+	return &MongoStorefrontTokenRepository{
+		collection: db.Collection("storefront_tokens"),
+	}
+}
+
+// storefrontTokenShopFilter matches the documents of a shop
+func storefrontTokenShopFilter(key domain.ShopKey) bson.M {
+	return bson.M{
+		"projectId":   key.ProjectID,
+		"environment": key.Environment,
+		"appHandle":   key.AppHandle,
+		"shopDomain":  key.Domain,
+	}
+}
+
+// Save upserts a token keyed by shop and Shopify ID
+func (r *MongoStorefrontTokenRepository) Save(ctx context.Context, token *domain.StorefrontToken) error {
+	token.UpdatedAt = time.Now()
+	doc := entity.MongoStorefrontTokenDocFromDomain(token)
+
+	// Create unique index on shop/token if it doesn't exist
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "projectId", Value: 1},
+			{Key: "environment", Value: 1},
+			{Key: "appHandle", Value: 1},
+			{Key: "shopDomain", Value: 1},
+			{Key: "tokenId", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	}
+	_, _ = r.collection.Indexes().CreateOne(ctx, indexModel)
+
+	filter := storefrontTokenShopFilter(token.Key())
+	filter["tokenId"] = token.ID
+
+	if _, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": doc}, options.Update().SetUpsert(true)); err != nil {
+		return fmt.Errorf("failed to save storefront token: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves the tokens of a shop, newest first
+func (r *MongoStorefrontTokenRepository) List(ctx context.Context, key domain.ShopKey) ([]*domain.StorefrontToken, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+	cursor, err := r.collection.Find(ctx, storefrontTokenShopFilter(key), opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list storefront tokens: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var tokens []*domain.StorefrontToken
+	for cursor.Next(ctx) {
+		var doc entity.MongoStorefrontTokenDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode storefront token: %w", err)
+		}
+		tokens = append(tokens, doc.ToDomain())
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// Delete removes a token of a shop
+func (r *MongoStorefrontTokenRepository) Delete(ctx context.Context, key domain.ShopKey, tokenID uint64) error {
+	filter := storefrontTokenShopFilter(key)
+	filter["tokenId"] = tokenID
+
+	if _, err := r.collection.DeleteOne(ctx, filter); err != nil {
+		return fmt.Errorf("failed to delete storefront token: %w", err)
+	}
+
+	return nil
+}
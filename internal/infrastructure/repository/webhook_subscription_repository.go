@@ -29,6 +29,7 @@ func (r *MongoWebhookSubscriptionRepository) SaveWebhookSubscription(ctx context
 	doc := bson.M{
 		"projectId":   subscription.ProjectID,
 		"environment": subscription.Environment,
+		"appHandle":   domain.NormalizeAppHandle(subscription.AppHandle),
 		"shopDomain":  subscription.ShopDomain,
 		"webhookId":   subscription.WebhookID,
 		"topic":       subscription.Topic,
@@ -61,11 +62,12 @@ func (r *MongoWebhookSubscriptionRepository) SaveWebhookSubscription(ctx context
 }
 
 // GetWebhookSubscription retrieves a webhook subscription
-func (r *MongoWebhookSubscriptionRepository) GetWebhookSubscription(ctx context.Context, projectID string, environment string, shopDomain string, topic string) (*domain.WebhookSubscription, error) {
+func (r *MongoWebhookSubscriptionRepository) GetWebhookSubscription(ctx context.Context, projectID string, environment string, appHandle string, shopDomain string, topic string) (*domain.WebhookSubscription, error) {
 	var doc struct {
 		ID          primitive.ObjectID `bson:"_id"`
 		ProjectID   string             `bson:"projectId"`
 		Environment string             `bson:"environment"`
+		AppHandle   string             `bson:"appHandle"`
 		ShopDomain  string             `bson:"shopDomain"`
 		WebhookID   int64              `bson:"webhookId"`
 		Topic       string             `bson:"topic"`
@@ -77,6 +79,7 @@ func (r *MongoWebhookSubscriptionRepository) GetWebhookSubscription(ctx context.
 	filter := bson.M{
 		"projectId":   projectID,
 		"environment": environment,
+		"appHandle":   webhookAppHandleFilter(appHandle),
 		"shopDomain":  shopDomain,
 		"topic":       topic,
 	}
@@ -93,6 +96,7 @@ func (r *MongoWebhookSubscriptionRepository) GetWebhookSubscription(ctx context.
 		ID:          doc.ID.Hex(),
 		ProjectID:   doc.ProjectID,
 		Environment: doc.Environment,
+		AppHandle:   domain.NormalizeAppHandle(doc.AppHandle),
 		ShopDomain:  doc.ShopDomain,
 		WebhookID:   doc.WebhookID,
 		Topic:       doc.Topic,
@@ -102,14 +106,56 @@ func (r *MongoWebhookSubscriptionRepository) GetWebhookSubscription(ctx context.
 	}, nil
 }
 
-// ListWebhookSubscriptions lists webhook subscriptions for a shop
-func (r *MongoWebhookSubscriptionRepository) ListWebhookSubscriptions(ctx context.Context, projectID string, environment string, shopDomain string) ([]*domain.WebhookSubscription, error) {
+// ListWebhookSubscriptions lists webhook subscriptions of an app for a shop
+func (r *MongoWebhookSubscriptionRepository) ListWebhookSubscriptions(ctx context.Context, projectID string, environment string, appHandle string, shopDomain string) ([]*domain.WebhookSubscription, error) {
 	filter := bson.M{
 		"projectId":   projectID,
 		"environment": environment,
+		"appHandle":   webhookAppHandleFilter(appHandle),
 		"shopDomain":  shopDomain,
 	}
 
+	return r.findSubscriptions(ctx, filter)
+}
+
+// ListEnvironmentWebhookSubscriptions lists the webhook subscriptions of every shop in a project environment
+func (r *MongoWebhookSubscriptionRepository) ListEnvironmentWebhookSubscriptions(ctx context.Context, projectID string, environment string) ([]*domain.WebhookSubscription, error) {
+	filter := bson.M{
+		"projectId":   projectID,
+		"environment": environment,
+	}
+
+	return r.findSubscriptions(ctx, filter)
+}
+
+// webhookAppHandleFilter matches the subscriptions of an app, subscriptions recorded before multi-app support
+// belong to the default app
+func webhookAppHandleFilter(appHandle string) interface{} {
+	appHandle = domain.NormalizeAppHandle(appHandle)
+	if appHandle == domain.DefaultAppHandle {
+		return bson.M{"$in": bson.A{appHandle, "", nil}}
+	}
+	return appHandle
+}
+
+// DeleteWebhookSubscription deletes a webhook subscription
+func (r *MongoWebhookSubscriptionRepository) DeleteWebhookSubscription(ctx context.Context, subscriptionID string) error {
+	objID, err := primitive.ObjectIDFromHex(subscriptionID)
+	if err != nil {
+		return fmt.Errorf("invalid subscription ID: %w", err)
+	}
+
+	filter := bson.M{"_id": objID}
+	_, err = r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+// findSubscriptions lists the webhook subscriptions matching a filter
+func (r *MongoWebhookSubscriptionRepository) findSubscriptions(ctx context.Context, filter bson.M) ([]*domain.WebhookSubscription, error) {
 	cursor, err := r.collection.Find(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
@@ -122,6 +168,7 @@ func (r *MongoWebhookSubscriptionRepository) ListWebhookSubscriptions(ctx contex
 			ID          primitive.ObjectID `bson:"_id"`
 			ProjectID   string             `bson:"projectId"`
 			Environment string             `bson:"environment"`
+			AppHandle   string             `bson:"appHandle"`
 			ShopDomain  string             `bson:"shopDomain"`
 			WebhookID   int64              `bson:"webhookId"`
 			Topic       string             `bson:"topic"`
@@ -138,6 +185,7 @@ func (r *MongoWebhookSubscriptionRepository) ListWebhookSubscriptions(ctx contex
 			ID:          doc.ID.Hex(),
 			ProjectID:   doc.ProjectID,
 			Environment: doc.Environment,
+			AppHandle:   domain.NormalizeAppHandle(doc.AppHandle),
 			ShopDomain:  doc.ShopDomain,
 			WebhookID:   doc.WebhookID,
 			Topic:       doc.Topic,
@@ -149,20 +197,3 @@ func (r *MongoWebhookSubscriptionRepository) ListWebhookSubscriptions(ctx contex
 
 	return subscriptions, nil
 }
-
-// DeleteWebhookSubscription deletes a webhook subscription
-func (r *MongoWebhookSubscriptionRepository) DeleteWebhookSubscription(ctx context.Context, subscriptionID string) error {
-	objID, err := primitive.ObjectIDFromHex(subscriptionID)
-	if err != nil {
-		return fmt.Errorf("invalid subscription ID: %w", err)
-	}
-
-	filter := bson.M{"_id": objID}
-	_, err = r.collection.DeleteOne(ctx, filter)
-	if err != nil {
-		return fmt.Errorf("failed to delete webhook subscription: %w", err)
-	}
-
-	return nil
-}
-
@@ -0,0 +1,126 @@
+package shopify
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"archie-core-shopify-layer/internal/domain"
+	"archie-core-shopify-layer/internal/ports"
+
+	"github.com/rs/zerolog"
+)
+
+// bulkFileHeaderTimeout bounds the wait for the response headers of a transfer; bodies can take minutes, so the
+// transfer itself is only bounded by the context
+const bulkFileHeaderTimeout = 30 * time.Second
+
+type bulkFileClient struct {
+	httpClient *http.Client
+	logger     zerolog.Logger
+}
+
+// NewBulkFileClient creates a client for the staged uploads and result files of bulk operations
+func NewBulkFileClient(logger zerolog.Logger) ports.ShopifyBulkFileClient {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = bulkFileHeaderTimeout
+	return &bulkFileClient{
+		httpClient: &http.Client{Transport: transport},
+		logger:     logger,
+	}
+}
+
+// Upload posts a file as multipart form, the target's parameters first as the storage provider requires
+func (c *bulkFileClient) Upload(ctx context.Context, target domain.StagedUploadTarget, filename string, content io.Reader) error {
+	body, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+	go func() {
+		for _, parameter := range target.Parameters {
+			if err := form.WriteField(parameter.Name, parameter.Value); err != nil {
+				writer.CloseWithError(err)
+				return
+			}
+		}
+		part, err := form.CreateFormFile("file", filename)
+		if err == nil {
+			_, err = io.Copy(part, content)
+		}
+		if err == nil {
+			err = form.Close()
+		}
+		writer.CloseWithError(err)
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.URL, body)
+	if err != nil {
+		body.Close()
+		return fmt.Errorf("failed to create staged upload request: %w", err)
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return domain.NewShopifyAPIError("failed to upload staged file", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		appErr := domain.NewShopifyAPIError(fmt.Sprintf("staged upload returned status %d", resp.StatusCode), errors.New(strings.TrimSpace(string(detail))))
+		appErr.Context = map[string]interface{}{"status": resp.StatusCode}
+		return appErr
+	}
+
+	c.logger.Debug().Str("filename", filename).Str("resourceUrl", target.ResourceURL).Msg("Uploaded staged file")
+	return nil
+}
+
+// StreamJSONL downloads a JSONL file line by line, without holding it in memory
+func (c *bulkFileClient) StreamJSONL(ctx context.Context, url string, handle func(line json.RawMessage) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create download request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return domain.NewShopifyAPIError("failed to download bulk operation results", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusForbidden:
+		// Result URLs are signed and expire a week after the operation completed
+		return domain.NewNotFoundError("bulk operation results")
+	case resp.StatusCode != http.StatusOK:
+		return domain.NewShopifyAPIError(fmt.Sprintf("bulk operation results returned status %d", resp.StatusCode), nil)
+	}
+
+	// Lines are single objects of unbounded size, so read whole lines rather than scanning with a buffer limit
+	reader := bufio.NewReaderSize(resp.Body, 64*1024)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 {
+			if !json.Valid(trimmed) {
+				return domain.NewShopifyAPIError("invalid line in bulk operation results", nil)
+			}
+			if handleErr := handle(json.RawMessage(trimmed)); handleErr != nil {
+				return handleErr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return domain.NewShopifyAPIError("failed to read bulk operation results", err)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"archie-core-shopify-layer/internal/domain"
+)
+
+// Bulk operations run a query or a mutation over a whole shop in the background, outside the query cost limit
+
+const bulkOperationFields = `
+fragment BulkOperationFields on BulkOperation {
+  id type status query errorCode objectCount rootObjectCount fileSize url partialDataUrl createdAt completedAt
+}`
+
+const bulkOperationRunQueryMutation = `
+mutation BulkOperationRunQuery($query: String!) {
+  bulkOperationRunQuery(query: $query) {
+    bulkOperation { ...BulkOperationFields }
+    userErrors { field message }
+  }
+}` + bulkOperationFields
+
+const bulkOperationRunMutationMutation = `
+mutation BulkOperationRunMutation($mutation: String!, $stagedUploadPath: String!) {
+  bulkOperationRunMutation(mutation: $mutation, stagedUploadPath: $stagedUploadPath) {
+    bulkOperation { ...BulkOperationFields }
+    userErrors { field message }
+  }
+}` + bulkOperationFields
+
+const bulkOperationCancelMutation = `
+mutation BulkOperationCancel($id: ID!) {
+  bulkOperationCancel(id: $id) {
+    bulkOperation { ...BulkOperationFields }
+    userErrors { field message }
+  }
+}` + bulkOperationFields
+
+const bulkOperationQuery = `
+query BulkOperation($id: ID!) {
+  node(id: $id) { ...BulkOperationFields }
+}` + bulkOperationFields
+
+const stagedUploadsCreateMutation = `
+mutation StagedUploadsCreate($input: [StagedUploadInput!]!) {
+  stagedUploadsCreate(input: $input) {
+    stagedTargets { url resourceUrl parameters { name value } }
+    userErrors { field message }
+  }
+}`
+
+// bulkOperationNode is a BulkOperation of the GraphQL Admin API, counts are UnsignedInt64 encoded as strings
+type bulkOperationNode struct {
+	ID              string     `json:"id"`
+	Type            string     `json:"type"`
+	Status          string     `json:"status"`
+	Query           string     `json:"query"`
+	ErrorCode       string     `json:"errorCode"`
+	ObjectCount     string     `json:"objectCount"`
+	RootObjectCount string     `json:"rootObjectCount"`
+	FileSize        string     `json:"fileSize"`
+	URL             string     `json:"url"`
+	PartialDataURL  string     `json:"partialDataUrl"`
+	CreatedAt       *time.Time `json:"createdAt"`
+	CompletedAt     *time.Time `json:"completedAt"`
+}
+
+// bulkOperationPayload is the payload of the bulk operation mutations
+type bulkOperationPayload struct {
+	BulkOperation *bulkOperationNode `json:"bulkOperation"`
+	UserErrors    []userError        `json:"userErrors"`
+}
+
+// toDomainBulkOperation converts a GraphQL Admin API bulk operation, the caller sets the shop it belongs to
+func toDomainBulkOperation(node *bulkOperationNode) *domain.BulkOperation {
+	objectCount, _ := strconv.ParseInt(node.ObjectCount, 10, 64)
+	rootObjectCount, _ := strconv.ParseInt(node.RootObjectCount, 10, 64)
+	fileSize, _ := strconv.ParseInt(node.FileSize, 10, 64)
+	return &domain.BulkOperation{
+		ID:              legacyID(node.ID),
+		Type:            domain.BulkOperationType(node.Type),
+		Status:          domain.BulkOperationStatus(node.Status),
+		Query:           node.Query,
+		ErrorCode:       node.ErrorCode,
+		ObjectCount:     objectCount,
+		RootObjectCount: rootObjectCount,
+		FileSize:        fileSize,
+		URL:             node.URL,
+		PartialDataURL:  node.PartialDataURL,
+		CreatedAt:       node.CreatedAt,
+		CompletedAt:     node.CompletedAt,
+	}
+}
+
+// runBulkOperationMutation runs one of the bulk operation mutations and converts its payload
+func (c *graphQLClient) runBulkOperationMutation(ctx context.Context, shopDomain string, accessToken string, mutation string, field string, variables map[string]interface{}, message string) (*domain.BulkOperation, error) {
+	var response map[string]bulkOperationPayload
+	if err := c.Query(ctx, shopDomain, accessToken, mutation, variables, &response); err != nil {
+		return nil, fmt.Errorf("%s: %w", message, err)
+	}
+	payload := response[field]
+	if err := userErrorsToError(message, payload.UserErrors); err != nil {
+		return nil, err
+	}
+	if payload.BulkOperation == nil {
+		return nil, domain.NewShopifyAPIError(message+": Shopify returned no bulk operation", nil)
+	}
+	return toDomainBulkOperation(payload.BulkOperation), nil
+}
+
+func (c *graphQLClient) RunBulkQuery(ctx context.Context, shopDomain string, accessToken string, query string) (*domain.BulkOperation, error) {
+	variables := map[string]interface{}{"query": query}
+	return c.runBulkOperationMutation(ctx, shopDomain, accessToken, bulkOperationRunQueryMutation, "bulkOperationRunQuery", variables, "failed to run bulk query")
+}
+
+func (c *graphQLClient) RunBulkMutation(ctx context.Context, shopDomain string, accessToken string, mutation string, stagedUploadPath string) (*domain.BulkOperation, error) {
+	variables := map[string]interface{}{"mutation": mutation, "stagedUploadPath": stagedUploadPath}
+	return c.runBulkOperationMutation(ctx, shopDomain, accessToken, bulkOperationRunMutationMutation, "bulkOperationRunMutation", variables, "failed to run bulk mutation")
+}
+
+func (c *graphQLClient) CancelBulkOperation(ctx context.Context, shopDomain string, accessToken string, operationID uint64) (*domain.BulkOperation, error) {
+	variables := map[string]interface{}{"id": globalID("BulkOperation", int64(operationID))}
+	return c.runBulkOperationMutation(ctx, shopDomain, accessToken, bulkOperationCancelMutation, "bulkOperationCancel", variables, "failed to cancel bulk operation")
+}
+
+func (c *graphQLClient) GetBulkOperation(ctx context.Context, shopDomain string, accessToken string, operationID uint64) (*domain.BulkOperation, error) {
+	node, err := queryNode[bulkOperationNode](ctx, c, shopDomain, accessToken, bulkOperationQuery, "node", "BulkOperation", int64(operationID))
+	if err != nil {
+		return nil, err
+	}
+	if node.ID == "" {
+		// The ID belongs to another kind of node
+		return nil, domain.NewNotFoundError(fmt.Sprintf("bulk operation %d", operationID))
+	}
+	return toDomainBulkOperation(node), nil
+}
+
+func (c *graphQLClient) CreateBulkMutationUpload(ctx context.Context, shopDomain string, accessToken string, filename string) (*domain.StagedUploadTarget, error) {
+	var response struct {
+		Payload struct {
+			StagedTargets []domain.StagedUploadTarget `json:"stagedTargets"`
+			UserErrors    []userError                 `json:"userErrors"`
+		} `json:"stagedUploadsCreate"`
+	}
+	variables := map[string]interface{}{
+		"input": []map[string]interface{}{{
+			"resource":   "BULK_MUTATION_VARIABLES",
+			"filename":   filename,
+			"mimeType":   "text/jsonl",
+			"httpMethod": "POST",
+		}},
+	}
+	if err := c.Query(ctx, shopDomain, accessToken, stagedUploadsCreateMutation, variables, &response); err != nil {
+		return nil, fmt.Errorf("failed to create staged upload: %w", err)
+	}
+	if err := userErrorsToError("failed to create staged upload", response.Payload.UserErrors); err != nil {
+		return nil, err
+	}
+	if len(response.Payload.StagedTargets) == 0 {
+		return nil, domain.NewShopifyAPIError("failed to create staged upload: Shopify returned no target", nil)
+	}
+	return &response.Payload.StagedTargets[0], nil
+}
@@ -8,7 +8,9 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
+	"archie-core-shopify-layer/internal/domain"
 	"archie-core-shopify-layer/internal/ports"
 
 	goshopify "github.com/bold-commerce/go-shopify/v4"
@@ -51,12 +53,13 @@ func NewClientWithOptions(
 }
 
 // createClient is a helper to create a goshopify client
-func (c *client) createClient(shopDomain string, accessToken string) (*goshopify.Client, error) {
-	client, err := goshopify.NewClient(c.app, shopDomain, accessToken)
+// createClient creates a go-shopify client for a shop, and a context that records the request ID of its responses
+func (c *client) createClient(ctx context.Context, shopDomain string, accessToken string) (context.Context, *goshopify.Client, error) {
+	client, err := goshopify.NewClient(c.app, shopDomain, accessToken, goshopify.WithHTTPClient(newHTTPClient()))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create client: %w", err)
+		return ctx, nil, fmt.Errorf("failed to create client: %w", err)
 	}
-	return client, nil
+	return withRequestIDRecorder(ctx), client, nil
 }
 
 // Authentication methods
@@ -94,300 +97,911 @@ func (c *client) GenerateAuthURL(shop string, scopes []string, redirectURI strin
 	return authURL, nil
 }
 
-func (c *client) ExchangeToken(ctx context.Context, shop string, code string, redirectURI string) (string, error) {
+func (c *client) ExchangeToken(ctx context.Context, shop string, code string, redirectURI string, expiring bool) (*domain.Token, error) {
 	// Shopify requires the redirect_uri parameter to match the one used in authorization
-	// The go-shopify library's GetAccessToken doesn't expose redirect_uri, so we make a direct HTTP call
-	if redirectURI != "" {
-		// Make direct HTTP call to Shopify's token endpoint with redirect_uri
-		tokenURL := fmt.Sprintf("https://%s/admin/oauth/access_token", shop)
-		
+	// The go-shopify library's GetAccessToken doesn't expose redirect_uri or expiring tokens, so we make a direct HTTP call
+	if redirectURI != "" || expiring {
 		values := url.Values{}
 		values.Set("client_id", c.apiKey)
 		values.Set("client_secret", c.apiSecret)
 		values.Set("code", code)
-		values.Set("redirect_uri", redirectURI)
-		
-		req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, strings.NewReader(values.Encode()))
-		if err != nil {
-			return "", fmt.Errorf("failed to create token request: %w", err)
-		}
-		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-		
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			return "", fmt.Errorf("failed to exchange token: %w", err)
-		}
-		defer resp.Body.Close()
-		
-		if resp.StatusCode != http.StatusOK {
-			bodyBytes, _ := io.ReadAll(resp.Body)
-			return "", fmt.Errorf("failed to exchange token: status %d, body: %s", resp.StatusCode, string(bodyBytes))
+		if redirectURI != "" {
+			values.Set("redirect_uri", redirectURI)
 		}
-		
-		var tokenResponse struct {
-			AccessToken string `json:"access_token"`
-			Scope       string `json:"scope"`
+		if expiring {
+			// Request an expiring offline token together with a refresh token
+			values.Set("expiring", "1")
 		}
-		
-		if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
-			return "", fmt.Errorf("failed to decode token response: %w", err)
+
+		token, err := c.requestToken(ctx, shop, values)
+		if err != nil {
+			return nil, fmt.Errorf("failed to exchange token: %w", err)
 		}
-		
-		return tokenResponse.AccessToken, nil
+		return token, nil
 	}
-	
+
 	// Fallback to go-shopify library if redirectURI not provided (for backward compatibility)
-	token, err := c.app.GetAccessToken(ctx, shop, code)
+	accessToken, err := c.app.GetAccessToken(ctx, shop, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange token: %w", err)
+	}
+	return &domain.Token{AccessToken: accessToken}, nil
+}
+
+func (c *client) RefreshAccessToken(ctx context.Context, shop string, refreshToken string) (*domain.Token, error) {
+	if refreshToken == "" {
+		return nil, fmt.Errorf("refresh token is required")
+	}
+
+	values := url.Values{}
+	values.Set("client_id", c.apiKey)
+	values.Set("client_secret", c.apiSecret)
+	values.Set("grant_type", "refresh_token")
+	values.Set("refresh_token", refreshToken)
+
+	token, err := c.requestToken(ctx, shop, values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh access token: %w", err)
+	}
+	return token, nil
+}
+
+func (c *client) ExchangeSessionToken(ctx context.Context, shop string, sessionToken string, requestedTokenType domain.RequestedTokenType, expiring bool) (*domain.Token, error) {
+	if sessionToken == "" {
+		return nil, fmt.Errorf("session token is required")
+	}
+	if requestedTokenType == "" {
+		requestedTokenType = domain.RequestedTokenTypeOffline
+	}
+
+	values := url.Values{}
+	values.Set("client_id", c.apiKey)
+	values.Set("client_secret", c.apiSecret)
+	values.Set("grant_type", "urn:ietf:params:oauth:grant-type:token-exchange")
+	values.Set("subject_token", sessionToken)
+	values.Set("subject_token_type", "urn:ietf:params:oauth:token-type:id_token")
+	values.Set("requested_token_type", string(requestedTokenType))
+	if expiring && requestedTokenType == domain.RequestedTokenTypeOffline {
+		values.Set("expiring", "1")
+	}
+
+	token, err := c.requestToken(ctx, shop, values)
 	if err != nil {
-		return "", fmt.Errorf("failed to exchange token: %w", err)
+		return nil, fmt.Errorf("failed to exchange session token: %w", err)
 	}
 	return token, nil
 }
 
+// requestToken posts a grant to Shopify's access token endpoint and decodes the token response
+func (c *client) requestToken(ctx context.Context, shop string, values url.Values) (*domain.Token, error) {
+	tokenURL := fmt.Sprintf("https://%s/admin/oauth/access_token", shop)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		responseErr := goshopify.ResponseError{Status: resp.StatusCode, Message: fmt.Sprintf("status %d, body: %s", resp.StatusCode, string(bodyBytes))}
+		return nil, translateResponseError("token request rejected", responseErr).WithRequestID(resp.Header.Get("X-Request-Id"))
+	}
+
+	var token domain.Token
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("token response did not include an access token")
+	}
+
+	return &token, nil
+}
+
 // Shop API
 
 func (c *client) GetShop(ctx context.Context, shopDomain string, accessToken string) (*goshopify.Shop, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
 	shop, err := client.Shop.Get(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get shop: %w", err)
+		return nil, wrapError(ctx, "failed to get shop", err)
 	}
 	return shop, nil
 }
 
 // Product API
 
-func (c *client) GetProducts(ctx context.Context, shopDomain string, accessToken string, options interface{}) ([]goshopify.Product, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+func (c *client) GetProducts(ctx context.Context, shopDomain string, accessToken string, options interface{}) ([]goshopify.Product, *goshopify.Pagination, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	products, err := client.Product.List(ctx, options)
+	products, pagination, err := client.Product.ListWithPagination(ctx, options)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list products: %w", err)
+		return nil, nil, wrapError(ctx, "failed to list products", err)
 	}
-	return products, nil
+	return products, pagination, nil
 }
 
 func (c *client) GetProduct(ctx context.Context, shopDomain string, accessToken string, productID int64) (*goshopify.Product, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
 	product, err := client.Product.Get(ctx, uint64(productID), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get product: %w", err)
+		return nil, wrapError(ctx, "failed to get product", err)
 	}
 	return product, nil
 }
 
-func (c *client) CreateProduct(ctx context.Context, shopDomain string, accessToken string, product *goshopify.Product) (*goshopify.Product, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+func (c *client) CreateProduct(ctx context.Context, shopDomain string, accessToken string, product *goshopify.Product, variantSettings []domain.VariantSettings) (*goshopify.Product, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
-	created, err := client.Product.Create(ctx, *product)
+	payload, err := productPayload(product, variantSettings)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create product: %w", err)
+		return nil, err
 	}
-	return created, nil
+	resource := new(goshopify.ProductResource)
+	if err := client.Post(ctx, "products.json", payload, resource); err != nil {
+		return nil, wrapError(ctx, "failed to create product", err)
+	}
+	return resource.Product, nil
 }
 
-func (c *client) UpdateProduct(ctx context.Context, shopDomain string, accessToken string, product *goshopify.Product) (*goshopify.Product, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+func (c *client) UpdateProduct(ctx context.Context, shopDomain string, accessToken string, product *goshopify.Product, variantSettings []domain.VariantSettings) (*goshopify.Product, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
-	updated, err := client.Product.Update(ctx, *product)
+	payload, err := productPayload(product, variantSettings)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update product: %w", err)
+		return nil, err
 	}
-	return updated, nil
+	resource := new(goshopify.ProductResource)
+	if err := client.Put(ctx, fmt.Sprintf("products/%d.json", product.Id), payload, resource); err != nil {
+		return nil, wrapError(ctx, "failed to update product", err)
+	}
+	return resource.Product, nil
+}
+
+// productPayload encodes a product write, replacing the requires_shipping and taxable go-shopify sets on every
+// variant with the explicitly set variant settings
+func productPayload(product *goshopify.Product, variantSettings []domain.VariantSettings) (map[string]interface{}, error) {
+	encoded, err := json.Marshal(product)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode product: %w", err)
+	}
+	// Numbers are kept as written so IDs are not rounded
+	decoder := json.NewDecoder(strings.NewReader(string(encoded)))
+	decoder.UseNumber()
+	var fields map[string]interface{}
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, fmt.Errorf("failed to encode product: %w", err)
+	}
+
+	variants, _ := fields["variants"].([]interface{})
+	for i, value := range variants {
+		variant, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		delete(variant, "requires_shipping")
+		delete(variant, "taxable")
+		if i >= len(variantSettings) {
+			continue
+		}
+		if settings := variantSettings[i]; settings.RequiresShipping != nil {
+			variant["requires_shipping"] = *settings.RequiresShipping
+		}
+		if settings := variantSettings[i]; settings.Taxable != nil {
+			variant["taxable"] = *settings.Taxable
+		}
+	}
+
+	return map[string]interface{}{"product": fields}, nil
 }
 
 func (c *client) DeleteProduct(ctx context.Context, shopDomain string, accessToken string, productID int64) error {
-	client, err := c.createClient(shopDomain, accessToken)
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return err
 	}
 	err = client.Product.Delete(ctx, uint64(productID))
 	if err != nil {
-		return fmt.Errorf("failed to delete product: %w", err)
+		return wrapError(ctx, "failed to delete product", err)
 	}
 	return nil
 }
 
 // Order API
 
-func (c *client) GetOrders(ctx context.Context, shopDomain string, accessToken string, options interface{}) ([]goshopify.Order, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+func (c *client) GetOrders(ctx context.Context, shopDomain string, accessToken string, options interface{}) ([]goshopify.Order, *goshopify.Pagination, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	orders, err := client.Order.List(ctx, options)
+	orders, pagination, err := client.Order.ListWithPagination(ctx, options)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list orders: %w", err)
+		return nil, nil, wrapError(ctx, "failed to list orders", err)
 	}
-	return orders, nil
+	return orders, pagination, nil
 }
 
 func (c *client) GetOrder(ctx context.Context, shopDomain string, accessToken string, orderID int64) (*goshopify.Order, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
 	order, err := client.Order.Get(ctx, uint64(orderID), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get order: %w", err)
+		return nil, wrapError(ctx, "failed to get order", err)
 	}
 	return order, nil
 }
 
 func (c *client) CreateOrder(ctx context.Context, shopDomain string, accessToken string, order *goshopify.Order) (*goshopify.Order, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
 	created, err := client.Order.Create(ctx, *order)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create order: %w", err)
+		return nil, wrapError(ctx, "failed to create order", err)
 	}
 	return created, nil
 }
 
 func (c *client) UpdateOrder(ctx context.Context, shopDomain string, accessToken string, order *goshopify.Order) (*goshopify.Order, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
 	updated, err := client.Order.Update(ctx, *order)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update order: %w", err)
+		return nil, wrapError(ctx, "failed to update order", err)
 	}
 	return updated, nil
 }
 
-func (c *client) CancelOrder(ctx context.Context, shopDomain string, accessToken string, orderID int64) (*goshopify.Order, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+func (c *client) CancelOrder(ctx context.Context, shopDomain string, accessToken string, orderID int64, cancellation domain.OrderCancellation) (*goshopify.Order, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
-	cancelled, err := client.Order.Cancel(ctx, uint64(orderID), nil)
+	request := map[string]interface{}{
+		"email":   cancellation.Email,
+		"restock": cancellation.Restock,
+	}
+	if cancellation.Reason != "" {
+		request["reason"] = cancellation.Reason
+	}
+	if cancellation.Refund != nil {
+		request["refund"] = cancellation.Refund
+	}
+	resource := new(goshopify.OrderResource)
+	path := fmt.Sprintf("orders/%d/cancel.json", orderID)
+	if err := client.Post(ctx, path, request, resource); err != nil {
+		return nil, wrapError(ctx, "failed to cancel order", err)
+	}
+	return resource.Order, nil
+}
+
+func (c *client) ListOrderTransactions(ctx context.Context, shopDomain string, accessToken string, orderID int64) ([]goshopify.Transaction, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
-		return nil, fmt.Errorf("failed to cancel order: %w", err)
+		return nil, err
 	}
-	return cancelled, nil
+	transactions, err := client.Transaction.List(ctx, uint64(orderID), nil)
+	if err != nil {
+		return nil, wrapError(ctx, "failed to list order transactions", err)
+	}
+	return transactions, nil
 }
 
-// Customer API
+func (c *client) CreateOrderTransaction(ctx context.Context, shopDomain string, accessToken string, orderID int64, transaction *goshopify.Transaction) (*goshopify.Transaction, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	created, err := client.Transaction.Create(ctx, uint64(orderID), *transaction)
+	if err != nil {
+		return nil, wrapError(ctx, "failed to create order transaction", err)
+	}
+	return created, nil
+}
+
+// Refund API
+
+// refundResource is the envelope of Shopify's refund endpoints, go-shopify has no refund service
+type refundResource struct {
+	Refund *goshopify.Refund `json:"refund"`
+}
+
+type refundsResource struct {
+	Refunds []goshopify.Refund `json:"refunds"`
+}
+
+type refundCalculationResource struct {
+	Refund *domain.RefundCalculation `json:"refund"`
+}
+
+func (c *client) ListOrderRefunds(ctx context.Context, shopDomain string, accessToken string, orderID int64) ([]goshopify.Refund, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	resource := new(refundsResource)
+	path := fmt.Sprintf("orders/%d/refunds.json", orderID)
+	if err := client.Get(ctx, path, resource, nil); err != nil {
+		return nil, wrapError(ctx, "failed to list order refunds", err)
+	}
+	return resource.Refunds, nil
+}
+
+func (c *client) GetRefund(ctx context.Context, shopDomain string, accessToken string, orderID int64, refundID int64) (*goshopify.Refund, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	resource := new(refundResource)
+	path := fmt.Sprintf("orders/%d/refunds/%d.json", orderID, refundID)
+	if err := client.Get(ctx, path, resource, nil); err != nil {
+		return nil, wrapError(ctx, "failed to get refund", err)
+	}
+	return resource.Refund, nil
+}
+
+func (c *client) CalculateRefund(ctx context.Context, shopDomain string, accessToken string, orderID int64, request domain.RefundRequest) (*domain.RefundCalculation, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	resource := new(refundCalculationResource)
+	path := fmt.Sprintf("orders/%d/refunds/calculate.json", orderID)
+	if err := client.Post(ctx, path, map[string]interface{}{"refund": request}, resource); err != nil {
+		return nil, wrapError(ctx, "failed to calculate refund", err)
+	}
+	return resource.Refund, nil
+}
+
+func (c *client) CreateRefund(ctx context.Context, shopDomain string, accessToken string, orderID int64, request domain.RefundRequest) (*goshopify.Refund, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	resource := new(refundResource)
+	path := fmt.Sprintf("orders/%d/refunds.json", orderID)
+	if err := client.Post(ctx, path, map[string]interface{}{"refund": request}, resource); err != nil {
+		return nil, wrapError(ctx, "failed to create refund", err)
+	}
+	return resource.Refund, nil
+}
+
+// Draft Order API
+
+func (c *client) ListDraftOrders(ctx context.Context, shopDomain string, accessToken string, options interface{}) ([]goshopify.DraftOrder, *goshopify.Pagination, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	resource := new(goshopify.DraftOrdersResource)
+	pagination, err := client.ListWithPagination(ctx, "draft_orders.json", resource, options)
+	if err != nil {
+		return nil, nil, wrapError(ctx, "failed to list draft orders", err)
+	}
+	return resource.DraftOrders, pagination, nil
+}
+
+func (c *client) GetDraftOrder(ctx context.Context, shopDomain string, accessToken string, draftOrderID int64) (*goshopify.DraftOrder, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	draftOrder, err := client.DraftOrder.Get(ctx, uint64(draftOrderID), nil)
+	if err != nil {
+		return nil, wrapError(ctx, "failed to get draft order", err)
+	}
+	return draftOrder, nil
+}
+
+func (c *client) CreateDraftOrder(ctx context.Context, shopDomain string, accessToken string, draftOrder *goshopify.DraftOrder) (*goshopify.DraftOrder, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	created, err := client.DraftOrder.Create(ctx, *draftOrder)
+	if err != nil {
+		return nil, wrapError(ctx, "failed to create draft order", err)
+	}
+	return created, nil
+}
+
+// draftOrderUpdate sends the applied discount and shipping line even when nil, which go-shopify omits
+type draftOrderUpdate struct {
+	*goshopify.DraftOrder
+	AppliedDiscount *goshopify.AppliedDiscount `json:"applied_discount"`
+	ShippingLine    *goshopify.ShippingLines   `json:"shipping_line"`
+}
+
+func (c *client) UpdateDraftOrder(ctx context.Context, shopDomain string, accessToken string, draftOrder *goshopify.DraftOrder) (*goshopify.DraftOrder, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	update := draftOrderUpdate{DraftOrder: draftOrder, AppliedDiscount: draftOrder.AppliedDiscount, ShippingLine: draftOrder.ShippingLine}
+	resource := new(goshopify.DraftOrderResource)
+	path := fmt.Sprintf("draft_orders/%d.json", draftOrder.Id)
+	if err := client.Put(ctx, path, map[string]interface{}{"draft_order": update}, resource); err != nil {
+		return nil, wrapError(ctx, "failed to update draft order", err)
+	}
+	return resource.DraftOrder, nil
+}
+
+func (c *client) DeleteDraftOrder(ctx context.Context, shopDomain string, accessToken string, draftOrderID int64) error {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return err
+	}
+	if err := client.DraftOrder.Delete(ctx, uint64(draftOrderID)); err != nil {
+		return wrapError(ctx, "failed to delete draft order", err)
+	}
+	return nil
+}
+
+func (c *client) SendDraftOrderInvoice(ctx context.Context, shopDomain string, accessToken string, draftOrderID int64, invoice goshopify.DraftOrderInvoice) (*goshopify.DraftOrderInvoice, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	sent, err := client.DraftOrder.Invoice(ctx, uint64(draftOrderID), invoice)
+	if err != nil {
+		return nil, wrapError(ctx, "failed to send draft order invoice", err)
+	}
+	return sent, nil
+}
+
+func (c *client) CompleteDraftOrder(ctx context.Context, shopDomain string, accessToken string, draftOrderID int64, paymentPending bool) (*goshopify.DraftOrder, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	completed, err := client.DraftOrder.Complete(ctx, uint64(draftOrderID), paymentPending)
+	if err != nil {
+		return nil, wrapError(ctx, "failed to complete draft order", err)
+	}
+	return completed, nil
+}
+
+// Metafield API
+
+// metafieldPath returns the REST path of the metafields of an owner, followed by suffix
+func metafieldPath(owner domain.MetafieldOwner, suffix string) string {
+	return goshopify.MetafieldPathPrefix(owner.Type.Resource(), owner.ID) + suffix
+}
+
+func (c *client) ListMetafields(ctx context.Context, shopDomain string, accessToken string, owner domain.MetafieldOwner, options interface{}) ([]goshopify.Metafield, *goshopify.Pagination, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	resource := new(goshopify.MetafieldsResource)
+	pagination, err := client.ListWithPagination(ctx, metafieldPath(owner, ".json"), resource, options)
+	if err != nil {
+		return nil, nil, wrapError(ctx, "failed to list metafields", err)
+	}
+	return resource.Metafields, pagination, nil
+}
+
+func (c *client) GetMetafield(ctx context.Context, shopDomain string, accessToken string, owner domain.MetafieldOwner, metafieldID int64) (*goshopify.Metafield, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	resource := new(goshopify.MetafieldResource)
+	if err := client.Get(ctx, metafieldPath(owner, fmt.Sprintf("/%d.json", metafieldID)), resource, nil); err != nil {
+		return nil, wrapError(ctx, "failed to get metafield", err)
+	}
+	return resource.Metafield, nil
+}
+
+func (c *client) CreateMetafield(ctx context.Context, shopDomain string, accessToken string, owner domain.MetafieldOwner, metafield *goshopify.Metafield) (*goshopify.Metafield, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	resource := new(goshopify.MetafieldResource)
+	if err := client.Post(ctx, metafieldPath(owner, ".json"), goshopify.MetafieldResource{Metafield: metafield}, resource); err != nil {
+		return nil, wrapError(ctx, "failed to create metafield", err)
+	}
+	return resource.Metafield, nil
+}
 
-func (c *client) GetCustomers(ctx context.Context, shopDomain string, accessToken string, options interface{}) ([]goshopify.Customer, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+func (c *client) UpdateMetafield(ctx context.Context, shopDomain string, accessToken string, owner domain.MetafieldOwner, metafield *goshopify.Metafield) (*goshopify.Metafield, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
-	customers, err := client.Customer.List(ctx, options)
+	resource := new(goshopify.MetafieldResource)
+	path := metafieldPath(owner, fmt.Sprintf("/%d.json", metafield.Id))
+	if err := client.Put(ctx, path, goshopify.MetafieldResource{Metafield: metafield}, resource); err != nil {
+		return nil, wrapError(ctx, "failed to update metafield", err)
+	}
+	return resource.Metafield, nil
+}
+
+func (c *client) DeleteMetafield(ctx context.Context, shopDomain string, accessToken string, owner domain.MetafieldOwner, metafieldID int64) error {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list customers: %w", err)
+		return err
 	}
-	return customers, nil
+	if err := client.Delete(ctx, metafieldPath(owner, fmt.Sprintf("/%d.json", metafieldID))); err != nil {
+		return wrapError(ctx, "failed to delete metafield", err)
+	}
+	return nil
+}
+
+// Customer API
+
+func (c *client) GetCustomers(ctx context.Context, shopDomain string, accessToken string, options interface{}) ([]goshopify.Customer, *goshopify.Pagination, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	customers, pagination, err := client.Customer.ListWithPagination(ctx, options)
+	if err != nil {
+		return nil, nil, wrapError(ctx, "failed to list customers", err)
+	}
+	return customers, pagination, nil
 }
 
 func (c *client) GetCustomer(ctx context.Context, shopDomain string, accessToken string, customerID int64) (*goshopify.Customer, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
 	customer, err := client.Customer.Get(ctx, uint64(customerID), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get customer: %w", err)
+		return nil, wrapError(ctx, "failed to get customer", err)
 	}
 	return customer, nil
 }
 
 func (c *client) CreateCustomer(ctx context.Context, shopDomain string, accessToken string, customer *goshopify.Customer) (*goshopify.Customer, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
 	created, err := client.Customer.Create(ctx, *customer)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create customer: %w", err)
+		return nil, wrapError(ctx, "failed to create customer", err)
 	}
 	return created, nil
 }
 
 func (c *client) UpdateCustomer(ctx context.Context, shopDomain string, accessToken string, customer *goshopify.Customer) (*goshopify.Customer, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
 	updated, err := client.Customer.Update(ctx, *customer)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update customer: %w", err)
+		return nil, wrapError(ctx, "failed to update customer", err)
 	}
 	return updated, nil
 }
 
 func (c *client) DeleteCustomer(ctx context.Context, shopDomain string, accessToken string, customerID int64) error {
-	client, err := c.createClient(shopDomain, accessToken)
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return err
 	}
 	err = client.Customer.Delete(ctx, uint64(customerID))
 	if err != nil {
-		return fmt.Errorf("failed to delete customer: %w", err)
+		return wrapError(ctx, "failed to delete customer", err)
 	}
 	return nil
 }
 
-func (c *client) SearchCustomers(ctx context.Context, shopDomain string, accessToken string, query string) ([]goshopify.Customer, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+func (c *client) SearchCustomers(ctx context.Context, shopDomain string, accessToken string, options interface{}) ([]goshopify.Customer, *goshopify.Pagination, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	// go-shopify's Search does not return the Link header, so the search endpoint is listed directly
+	resource := new(goshopify.CustomersResource)
+	pagination, err := client.ListWithPagination(ctx, "customers/search.json", resource, options)
+	if err != nil {
+		return nil, nil, wrapError(ctx, "failed to search customers", err)
+	}
+	return resource.Customers, pagination, nil
+}
+
+func (c *client) ListCustomerOrders(ctx context.Context, shopDomain string, accessToken string, customerID int64, options interface{}) ([]goshopify.Order, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
-	customers, err := client.Customer.Search(ctx, query)
+	orders, err := client.Customer.ListOrders(ctx, uint64(customerID), options)
 	if err != nil {
-		return nil, fmt.Errorf("failed to search customers: %w", err)
+		return nil, wrapError(ctx, "failed to list customer orders", err)
 	}
-	return customers, nil
+	return orders, nil
 }
 
 // Inventory API
 
-func (c *client) GetInventoryLevels(ctx context.Context, shopDomain string, accessToken string, options interface{}) ([]goshopify.InventoryLevel, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+func (c *client) GetInventoryLevels(ctx context.Context, shopDomain string, accessToken string, options interface{}) ([]goshopify.InventoryLevel, *goshopify.Pagination, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	// go-shopify's List does not return the Link header, so the endpoint is listed directly
+	resource := new(goshopify.InventoryLevelsResource)
+	pagination, err := client.ListWithPagination(ctx, "inventory_levels.json", resource, options)
+	if err != nil {
+		return nil, nil, wrapError(ctx, "failed to list inventory levels", err)
+	}
+	return resource.InventoryLevels, pagination, nil
+}
+
+func (c *client) SetInventoryLevel(ctx context.Context, shopDomain string, accessToken string, inventoryItemID int64, locationID int64, available int, disconnectIfNecessary bool) (*goshopify.InventoryLevel, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	request := map[string]interface{}{
+		"inventory_item_id":       inventoryItemID,
+		"location_id":             locationID,
+		"available":               available,
+		"disconnect_if_necessary": disconnectIfNecessary,
+	}
+	resource := new(goshopify.InventoryLevelResource)
+	if err := client.Post(ctx, "inventory_levels/set.json", request, resource); err != nil {
+		return nil, wrapError(ctx, "failed to set inventory level", err)
+	}
+	return resource.InventoryLevel, nil
+}
+
+// inventoryAdjustQuantitiesMutation adjusts available stock with a reason, which the REST adjust endpoint drops
+const inventoryAdjustQuantitiesMutation = `
+mutation InventoryAdjustQuantities($input: InventoryAdjustQuantitiesInput!, $locationId: ID!) {
+  inventoryAdjustQuantities(input: $input) {
+    inventoryAdjustmentGroup {
+      changes {
+        item {
+          inventoryLevel(locationId: $locationId) {
+            quantities(names: ["available"]) { name quantity }
+            updatedAt
+          }
+        }
+      }
+    }
+    userErrors { field message }
+  }
+}`
+
+// AdjustInventoryLevel adjusts the available stock through the Admin GraphQL API, so Shopify records the reason
+// and the reference document with the adjustment
+func (c *client) AdjustInventoryLevel(ctx context.Context, shopDomain string, accessToken string, adjustment domain.InventoryAdjustment) (*goshopify.InventoryLevel, error) {
+	locationID := globalID("Location", int64(adjustment.LocationID))
+	input := map[string]interface{}{
+		"name":   "available",
+		"reason": string(adjustment.Reason),
+		"changes": []map[string]interface{}{{
+			"delta":           adjustment.Delta,
+			"inventoryItemId": globalID("InventoryItem", int64(adjustment.InventoryItemID)),
+			"locationId":      locationID,
+		}},
+	}
+	if adjustment.Reference != "" {
+		input["referenceDocumentUri"] = adjustment.Reference
+	}
+
+	var response struct {
+		InventoryAdjustQuantities struct {
+			InventoryAdjustmentGroup *struct {
+				Changes []struct {
+					Item struct {
+						InventoryLevel *struct {
+							Quantities []struct {
+								Name     string `json:"name"`
+								Quantity int    `json:"quantity"`
+							} `json:"quantities"`
+							UpdatedAt *time.Time `json:"updatedAt"`
+						} `json:"inventoryLevel"`
+					} `json:"item"`
+				} `json:"changes"`
+			} `json:"inventoryAdjustmentGroup"`
+			UserErrors []userError `json:"userErrors"`
+		} `json:"inventoryAdjustQuantities"`
+	}
+	variables := map[string]interface{}{"input": input, "locationId": locationID}
+	if err := c.adminGraphQL(ctx, shopDomain, accessToken, inventoryAdjustQuantitiesMutation, variables, &response, "failed to adjust inventory level"); err != nil {
+		return nil, err
+	}
+	payload := response.InventoryAdjustQuantities
+	if err := userErrorsToError("failed to adjust inventory level", payload.UserErrors); err != nil {
+		return nil, err
+	}
+	if payload.InventoryAdjustmentGroup == nil || len(payload.InventoryAdjustmentGroup.Changes) == 0 || payload.InventoryAdjustmentGroup.Changes[0].Item.InventoryLevel == nil {
+		return nil, domain.NewShopifyAPIError("failed to adjust inventory level: Shopify returned no inventory level", nil)
+	}
+
+	inventoryLevel := payload.InventoryAdjustmentGroup.Changes[0].Item.InventoryLevel
+	level := &goshopify.InventoryLevel{
+		InventoryItemId: adjustment.InventoryItemID,
+		LocationId:      adjustment.LocationID,
+		UpdatedAt:       inventoryLevel.UpdatedAt,
+	}
+	for _, quantity := range inventoryLevel.Quantities {
+		if quantity.Name == "available" {
+			level.Available = quantity.Quantity
+		}
+	}
+	return level, nil
+}
+
+func (c *client) ConnectInventoryLevel(ctx context.Context, shopDomain string, accessToken string, inventoryItemID int64, locationID int64, relocateIfNecessary bool) (*goshopify.InventoryLevel, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	request := map[string]interface{}{
+		"inventory_item_id":     inventoryItemID,
+		"location_id":           locationID,
+		"relocate_if_necessary": relocateIfNecessary,
+	}
+	resource := new(goshopify.InventoryLevelResource)
+	if err := client.Post(ctx, "inventory_levels/connect.json", request, resource); err != nil {
+		return nil, wrapError(ctx, "failed to connect inventory level", err)
+	}
+	return resource.InventoryLevel, nil
+}
+
+func (c *client) DisconnectInventoryLevel(ctx context.Context, shopDomain string, accessToken string, inventoryItemID int64, locationID int64) error {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return err
+	}
+	if err := client.InventoryLevel.Delete(ctx, uint64(inventoryItemID), uint64(locationID)); err != nil {
+		return wrapError(ctx, "failed to disconnect inventory level", err)
+	}
+	return nil
+}
+
+func (c *client) ListInventoryItems(ctx context.Context, shopDomain string, accessToken string, inventoryItemIDs []uint64) ([]goshopify.InventoryItem, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	options := struct {
+		IDs   []uint64 `url:"ids,comma"`
+		Limit int      `url:"limit"`
+	}{IDs: inventoryItemIDs, Limit: len(inventoryItemIDs)}
+	items, err := client.InventoryItem.List(ctx, options)
+	if err != nil {
+		return nil, wrapError(ctx, "failed to list inventory items", err)
+	}
+	return items, nil
+}
+
+func (c *client) GetInventoryItem(ctx context.Context, shopDomain string, accessToken string, inventoryItemID int64) (*goshopify.InventoryItem, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
-	levels, err := client.InventoryLevel.List(ctx, options)
+	item, err := client.InventoryItem.Get(ctx, uint64(inventoryItemID), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list inventory levels: %w", err)
+		return nil, wrapError(ctx, "failed to get inventory item", err)
 	}
-	return levels, nil
+	return item, nil
 }
 
-func (c *client) UpdateInventoryLevel(ctx context.Context, shopDomain string, accessToken string, inventoryLevel *goshopify.InventoryLevel) (*goshopify.InventoryLevel, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+func (c *client) UpdateInventoryItem(ctx context.Context, shopDomain string, accessToken string, inventoryItem *goshopify.InventoryItem) (*goshopify.InventoryItem, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
-	// Note: The actual update method may vary based on the goshopify library version
-	// This is a placeholder implementation
-	updated, err := client.InventoryLevel.Set(ctx, *inventoryLevel)
+	updated, err := client.InventoryItem.Update(ctx, *inventoryItem)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update inventory level: %w", err)
+		return nil, wrapError(ctx, "failed to update inventory item", err)
 	}
 	return updated, nil
 }
 
+func (c *client) GetVariant(ctx context.Context, shopDomain string, accessToken string, variantID int64) (*goshopify.Variant, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	variant, err := client.Variant.Get(ctx, uint64(variantID), nil)
+	if err != nil {
+		return nil, wrapError(ctx, "failed to get variant", err)
+	}
+	return variant, nil
+}
+
+// Location API
+
+func (c *client) ListLocations(ctx context.Context, shopDomain string, accessToken string) ([]goshopify.Location, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	locations, err := client.Location.List(ctx, nil)
+	if err != nil {
+		return nil, wrapError(ctx, "failed to list locations", err)
+	}
+	return locations, nil
+}
+
+func (c *client) GetLocation(ctx context.Context, shopDomain string, accessToken string, locationID int64) (*goshopify.Location, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	location, err := client.Location.Get(ctx, uint64(locationID), nil)
+	if err != nil {
+		return nil, wrapError(ctx, "failed to get location", err)
+	}
+	return location, nil
+}
+
+// Storefront access token API
+
+func (c *client) ListStorefrontAccessTokens(ctx context.Context, shopDomain string, accessToken string) ([]goshopify.StorefrontAccessToken, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := client.StorefrontAccessToken.List(ctx, nil)
+	if err != nil {
+		return nil, wrapError(ctx, "failed to list storefront access tokens", err)
+	}
+	return tokens, nil
+}
+
+func (c *client) CreateStorefrontAccessToken(ctx context.Context, shopDomain string, accessToken string, title string) (*goshopify.StorefrontAccessToken, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	created, err := client.StorefrontAccessToken.Create(ctx, goshopify.StorefrontAccessToken{Title: title})
+	if err != nil {
+		return nil, wrapError(ctx, "failed to create storefront access token", err)
+	}
+	return created, nil
+}
+
+func (c *client) DeleteStorefrontAccessToken(ctx context.Context, shopDomain string, accessToken string, tokenID int64) error {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return err
+	}
+	if err := client.StorefrontAccessToken.Delete(ctx, uint64(tokenID)); err != nil {
+		return wrapError(ctx, "failed to delete storefront access token", err)
+	}
+	return nil
+}
+
 // Webhook API
 
 func (c *client) CreateWebhook(ctx context.Context, shopDomain string, accessToken string, topic string, address string) (*goshopify.Webhook, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
@@ -398,62 +1012,62 @@ func (c *client) CreateWebhook(ctx context.Context, shopDomain string, accessTok
 	}
 	created, err := client.Webhook.Create(ctx, webhook)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create webhook: %w", err)
+		return nil, wrapError(ctx, "failed to create webhook", err)
 	}
 	return created, nil
 }
 
 func (c *client) GetWebhook(ctx context.Context, shopDomain string, accessToken string, webhookID int64) (*goshopify.Webhook, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
 	webhook, err := client.Webhook.Get(ctx, uint64(webhookID), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get webhook: %w", err)
+		return nil, wrapError(ctx, "failed to get webhook", err)
 	}
 	return webhook, nil
 }
 
 func (c *client) ListWebhooks(ctx context.Context, shopDomain string, accessToken string, options interface{}) ([]goshopify.Webhook, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
 	webhooks, err := client.Webhook.List(ctx, options)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+		return nil, wrapError(ctx, "failed to list webhooks", err)
 	}
 	return webhooks, nil
 }
 
 func (c *client) UpdateWebhook(ctx context.Context, shopDomain string, accessToken string, webhookID int64, address string) (*goshopify.Webhook, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
 	// First get the webhook to preserve other fields
 	existing, err := client.Webhook.Get(ctx, uint64(webhookID), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get webhook for update: %w", err)
+		return nil, wrapError(ctx, "failed to get webhook for update", err)
 	}
 	// Update address
 	existing.Address = address
 	updated, err := client.Webhook.Update(ctx, *existing)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update webhook: %w", err)
+		return nil, wrapError(ctx, "failed to update webhook", err)
 	}
 	return updated, nil
 }
 
 func (c *client) DeleteWebhook(ctx context.Context, shopDomain string, accessToken string, webhookID int64) error {
-	client, err := c.createClient(shopDomain, accessToken)
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return err
 	}
 	err = client.Webhook.Delete(ctx, uint64(webhookID))
 	if err != nil {
-		return fmt.Errorf("failed to delete webhook: %w", err)
+		return wrapError(ctx, "failed to delete webhook", err)
 	}
 	return nil
 }
@@ -461,221 +1075,563 @@ func (c *client) DeleteWebhook(ctx context.Context, shopDomain string, accessTok
 // Collection API
 
 func (c *client) GetCollection(ctx context.Context, shopDomain string, accessToken string, collectionID int64) (*goshopify.Collection, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
 	collection, err := client.Collection.Get(ctx, uint64(collectionID), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get collection: %w", err)
+		return nil, wrapError(ctx, "failed to get collection", err)
 	}
 	return collection, nil
 }
 
-func (c *client) ListCollectionProducts(ctx context.Context, shopDomain string, accessToken string, collectionID int64, options interface{}) ([]goshopify.Product, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+func (c *client) ListCollectionProducts(ctx context.Context, shopDomain string, accessToken string, collectionID int64, options interface{}) ([]goshopify.Product, *goshopify.Pagination, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	products, err := client.Collection.ListProducts(ctx, uint64(collectionID), options)
+	products, pagination, err := client.Collection.ListProductsWithPagination(ctx, uint64(collectionID), options)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list collection products: %w", err)
+		return nil, nil, wrapError(ctx, "failed to list collection products", err)
 	}
-	return products, nil
+	return products, pagination, nil
 }
 
 func (c *client) GetCustomCollection(ctx context.Context, shopDomain string, accessToken string, collectionID int64) (*goshopify.CustomCollection, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
 	collection, err := client.CustomCollection.Get(ctx, uint64(collectionID), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get custom collection: %w", err)
+		return nil, wrapError(ctx, "failed to get custom collection", err)
 	}
 	return collection, nil
 }
 
-func (c *client) ListCustomCollections(ctx context.Context, shopDomain string, accessToken string, options interface{}) ([]goshopify.CustomCollection, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+func (c *client) ListCustomCollections(ctx context.Context, shopDomain string, accessToken string, options interface{}) ([]goshopify.CustomCollection, *goshopify.Pagination, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	collections, err := client.CustomCollection.List(ctx, options)
+	// go-shopify's List does not return the Link header, so the endpoint is listed directly
+	resource := new(goshopify.CustomCollectionsResource)
+	pagination, err := client.ListWithPagination(ctx, "custom_collections.json", resource, options)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list custom collections: %w", err)
+		return nil, nil, wrapError(ctx, "failed to list custom collections", err)
 	}
-	return collections, nil
+	return resource.Collections, pagination, nil
 }
 
 func (c *client) CreateCustomCollection(ctx context.Context, shopDomain string, accessToken string, collection *goshopify.CustomCollection) (*goshopify.CustomCollection, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
 	created, err := client.CustomCollection.Create(ctx, *collection)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create custom collection: %w", err)
+		return nil, wrapError(ctx, "failed to create custom collection", err)
 	}
 	return created, nil
 }
 
 func (c *client) UpdateCustomCollection(ctx context.Context, shopDomain string, accessToken string, collection *goshopify.CustomCollection) (*goshopify.CustomCollection, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
 	updated, err := client.CustomCollection.Update(ctx, *collection)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update custom collection: %w", err)
+		return nil, wrapError(ctx, "failed to update custom collection", err)
 	}
 	return updated, nil
 }
 
 func (c *client) DeleteCustomCollection(ctx context.Context, shopDomain string, accessToken string, collectionID int64) error {
-	client, err := c.createClient(shopDomain, accessToken)
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return err
 	}
 	err = client.CustomCollection.Delete(ctx, uint64(collectionID))
 	if err != nil {
-		return fmt.Errorf("failed to delete custom collection: %w", err)
+		return wrapError(ctx, "failed to delete custom collection", err)
 	}
 	return nil
 }
 
-// Fulfillment API
+func (c *client) GetSmartCollection(ctx context.Context, shopDomain string, accessToken string, collectionID int64) (*goshopify.SmartCollection, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	collection, err := client.SmartCollection.Get(ctx, uint64(collectionID), nil)
+	if err != nil {
+		return nil, wrapError(ctx, "failed to get smart collection", err)
+	}
+	return collection, nil
+}
+
+func (c *client) ListSmartCollections(ctx context.Context, shopDomain string, accessToken string, options interface{}) ([]goshopify.SmartCollection, *goshopify.Pagination, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	// go-shopify's List does not return the Link header, so the endpoint is listed directly
+	resource := new(goshopify.SmartCollectionsResource)
+	pagination, err := client.ListWithPagination(ctx, "smart_collections.json", resource, options)
+	if err != nil {
+		return nil, nil, wrapError(ctx, "failed to list smart collections", err)
+	}
+	return resource.Collections, pagination, nil
+}
 
-func (c *client) ListFulfillments(ctx context.Context, shopDomain string, accessToken string, options interface{}) ([]goshopify.Fulfillment, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+func (c *client) CreateSmartCollection(ctx context.Context, shopDomain string, accessToken string, collection *goshopify.SmartCollection) (*goshopify.SmartCollection, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
-	fulfillments, err := client.Fulfillment.List(ctx, options)
+	created, err := client.SmartCollection.Create(ctx, *collection)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list fulfillments: %w", err)
+		return nil, wrapError(ctx, "failed to create smart collection", err)
 	}
-	return fulfillments, nil
+	return created, nil
 }
 
-func (c *client) GetFulfillment(ctx context.Context, shopDomain string, accessToken string, fulfillmentID int64) (*goshopify.Fulfillment, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+func (c *client) UpdateSmartCollection(ctx context.Context, shopDomain string, accessToken string, collection *goshopify.SmartCollection) (*goshopify.SmartCollection, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
-	fulfillment, err := client.Fulfillment.Get(ctx, uint64(fulfillmentID), nil)
+	updated, err := client.SmartCollection.Update(ctx, *collection)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get fulfillment: %w", err)
+		return nil, wrapError(ctx, "failed to update smart collection", err)
 	}
-	return fulfillment, nil
+	return updated, nil
 }
 
-func (c *client) CreateFulfillment(ctx context.Context, shopDomain string, accessToken string, fulfillment *goshopify.Fulfillment) (*goshopify.Fulfillment, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+func (c *client) DeleteSmartCollection(ctx context.Context, shopDomain string, accessToken string, collectionID int64) error {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return err
+	}
+	err = client.SmartCollection.Delete(ctx, uint64(collectionID))
+	if err != nil {
+		return wrapError(ctx, "failed to delete smart collection", err)
+	}
+	return nil
+}
+
+func (c *client) ListCollects(ctx context.Context, shopDomain string, accessToken string, options interface{}) ([]goshopify.Collect, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
-	created, err := client.Fulfillment.Create(ctx, *fulfillment)
+	collects, err := client.Collect.List(ctx, options)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create fulfillment: %w", err)
+		return nil, wrapError(ctx, "failed to list collects", err)
+	}
+	return collects, nil
+}
+
+func (c *client) CreateCollect(ctx context.Context, shopDomain string, accessToken string, collect *goshopify.Collect) (*goshopify.Collect, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	created, err := client.Collect.Create(ctx, *collect)
+	if err != nil {
+		return nil, wrapError(ctx, "failed to create collect", err)
 	}
 	return created, nil
 }
 
-func (c *client) UpdateFulfillment(ctx context.Context, shopDomain string, accessToken string, fulfillment *goshopify.Fulfillment) (*goshopify.Fulfillment, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+func (c *client) DeleteCollect(ctx context.Context, shopDomain string, accessToken string, collectID int64) error {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return err
+	}
+	err = client.Collect.Delete(ctx, uint64(collectID))
+	if err != nil {
+		return wrapError(ctx, "failed to delete collect", err)
+	}
+	return nil
+}
+
+// Fulfillment order API
+
+func (c *client) ListOrderFulfillmentOrders(ctx context.Context, shopDomain string, accessToken string, orderID int64) ([]goshopify.FulfillmentOrder, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
-	updated, err := client.Fulfillment.Update(ctx, *fulfillment)
+	fulfillmentOrders, err := client.FulfillmentOrder.List(ctx, uint64(orderID), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update fulfillment: %w", err)
+		return nil, wrapError(ctx, "failed to list fulfillment orders", err)
 	}
-	return updated, nil
+	return fulfillmentOrders, nil
 }
 
-func (c *client) CompleteFulfillment(ctx context.Context, shopDomain string, accessToken string, fulfillmentID int64) (*goshopify.Fulfillment, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+// ListAssignedFulfillmentOrders lists the fulfillment orders assigned to the shop's locations
+// go-shopify's AssignedFulfillmentOrder drops most fields, so the full fulfillment orders are decoded instead
+func (c *client) ListAssignedFulfillmentOrders(ctx context.Context, shopDomain string, accessToken string, options interface{}) ([]goshopify.FulfillmentOrder, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
-	fulfillment, err := client.Fulfillment.Complete(ctx, uint64(fulfillmentID))
+	resource := new(goshopify.FulfillmentOrdersResource)
+	if err := client.Get(ctx, "assigned_fulfillment_orders.json", resource, options); err != nil {
+		return nil, wrapError(ctx, "failed to list assigned fulfillment orders", err)
+	}
+	return resource.FulfillmentOrders, nil
+}
+
+func (c *client) GetFulfillmentOrder(ctx context.Context, shopDomain string, accessToken string, fulfillmentOrderID int64) (*goshopify.FulfillmentOrder, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
-		return nil, fmt.Errorf("failed to complete fulfillment: %w", err)
+		return nil, err
 	}
-	return fulfillment, nil
+	fulfillmentOrder, err := client.FulfillmentOrder.Get(ctx, uint64(fulfillmentOrderID), nil)
+	if err != nil {
+		return nil, wrapError(ctx, "failed to get fulfillment order", err)
+	}
+	return fulfillmentOrder, nil
+}
+
+func (c *client) AcceptFulfillmentRequest(ctx context.Context, shopDomain string, accessToken string, fulfillmentOrderID int64, message string) (*goshopify.FulfillmentOrder, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	fulfillmentOrder, err := client.FulfillmentRequest.Accept(ctx, uint64(fulfillmentOrderID), goshopify.FulfillmentRequest{Message: message})
+	if err != nil {
+		return nil, wrapError(ctx, "failed to accept fulfillment request", err)
+	}
+	return fulfillmentOrder, nil
+}
+
+func (c *client) RejectFulfillmentRequest(ctx context.Context, shopDomain string, accessToken string, fulfillmentOrderID int64, request goshopify.FulfillmentRequest) (*goshopify.FulfillmentOrder, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	fulfillmentOrder, err := client.FulfillmentRequest.Reject(ctx, uint64(fulfillmentOrderID), request)
+	if err != nil {
+		return nil, wrapError(ctx, "failed to reject fulfillment request", err)
+	}
+	return fulfillmentOrder, nil
+}
+
+func (c *client) HoldFulfillmentOrder(ctx context.Context, shopDomain string, accessToken string, fulfillmentOrderID int64, hold goshopify.FulfillmentOrderHold, notifyMerchant bool) (*goshopify.FulfillmentOrder, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	fulfillmentOrder, err := client.FulfillmentOrder.Hold(ctx, uint64(fulfillmentOrderID), notifyMerchant, hold.Reason, hold.ReasonNotes)
+	if err != nil {
+		return nil, wrapError(ctx, "failed to hold fulfillment order", err)
+	}
+	return fulfillmentOrder, nil
+}
+
+func (c *client) ReleaseFulfillmentOrderHold(ctx context.Context, shopDomain string, accessToken string, fulfillmentOrderID int64) (*goshopify.FulfillmentOrder, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	fulfillmentOrder, err := client.FulfillmentOrder.ReleaseHold(ctx, uint64(fulfillmentOrderID))
+	if err != nil {
+		return nil, wrapError(ctx, "failed to release fulfillment order hold", err)
+	}
+	return fulfillmentOrder, nil
+}
+
+func (c *client) MoveFulfillmentOrder(ctx context.Context, shopDomain string, accessToken string, fulfillmentOrderID int64, move goshopify.FulfillmentOrderMoveRequest) (*goshopify.FulfillmentOrderMoveResource, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	moved, err := client.FulfillmentOrder.Move(ctx, uint64(fulfillmentOrderID), move)
+	if err != nil {
+		return nil, wrapError(ctx, "failed to move fulfillment order", err)
+	}
+	return moved, nil
+}
+
+// Fulfillment API
+
+func (c *client) ListOrderFulfillments(ctx context.Context, shopDomain string, accessToken string, orderID int64, options interface{}) ([]goshopify.Fulfillment, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	fulfillments, err := client.Order.ListFulfillments(ctx, uint64(orderID), options)
+	if err != nil {
+		return nil, wrapError(ctx, "failed to list fulfillments", err)
+	}
+	return fulfillments, nil
+}
+
+// CreateFulfillment creates a fulfillment for the line items of one or more fulfillment orders
+func (c *client) CreateFulfillment(ctx context.Context, shopDomain string, accessToken string, fulfillment *goshopify.Fulfillment) (*goshopify.Fulfillment, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	created, err := client.Fulfillment.Create(ctx, *fulfillment)
+	if err != nil {
+		return nil, wrapError(ctx, "failed to create fulfillment", err)
+	}
+	return created, nil
+}
+
+func (c *client) UpdateFulfillmentTracking(ctx context.Context, shopDomain string, accessToken string, fulfillmentID int64, tracking goshopify.FulfillmentTrackingInfo, notifyCustomer bool) (*goshopify.Fulfillment, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	request := map[string]interface{}{
+		"fulfillment": map[string]interface{}{
+			"tracking_info":   tracking,
+			"notify_customer": notifyCustomer,
+		},
+	}
+	resource := new(goshopify.FulfillmentResource)
+	path := fmt.Sprintf("fulfillments/%d/update_tracking.json", fulfillmentID)
+	if err := client.Post(ctx, path, request, resource); err != nil {
+		return nil, wrapError(ctx, "failed to update fulfillment tracking", err)
+	}
+	return resource.Fulfillment, nil
 }
 
 func (c *client) CancelFulfillment(ctx context.Context, shopDomain string, accessToken string, fulfillmentID int64) (*goshopify.Fulfillment, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
 	fulfillment, err := client.Fulfillment.Cancel(ctx, uint64(fulfillmentID))
 	if err != nil {
-		return nil, fmt.Errorf("failed to cancel fulfillment: %w", err)
+		return nil, wrapError(ctx, "failed to cancel fulfillment", err)
 	}
 	return fulfillment, nil
 }
 
-// Discount Code API
+// Price Rule API
+
+func (c *client) ListPriceRules(ctx context.Context, shopDomain string, accessToken string, options interface{}) ([]goshopify.PriceRule, *goshopify.Pagination, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	resource := new(goshopify.PriceRulesResource)
+	pagination, err := client.ListWithPagination(ctx, "price_rules.json", resource, options)
+	if err != nil {
+		return nil, nil, wrapError(ctx, "failed to list price rules", err)
+	}
+	return resource.PriceRules, pagination, nil
+}
+
+func (c *client) GetPriceRule(ctx context.Context, shopDomain string, accessToken string, priceRuleID int64) (*goshopify.PriceRule, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	priceRule, err := client.PriceRule.Get(ctx, uint64(priceRuleID))
+	if err != nil {
+		return nil, wrapError(ctx, "failed to get price rule", err)
+	}
+	return priceRule, nil
+}
 
-func (c *client) ListDiscountCodes(ctx context.Context, shopDomain string, accessToken string, priceRuleID int64) ([]goshopify.PriceRuleDiscountCode, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+func (c *client) CreatePriceRule(ctx context.Context, shopDomain string, accessToken string, priceRule *goshopify.PriceRule) (*goshopify.PriceRule, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
-	codes, err := client.DiscountCode.List(ctx, uint64(priceRuleID))
+	created, err := client.PriceRule.Create(ctx, *priceRule)
+	if err != nil {
+		return nil, wrapError(ctx, "failed to create price rule", err)
+	}
+	return created, nil
+}
+
+// priceRuleUpdate sends the price rule fields whose zero value is meaningful, which go-shopify omits
+type priceRuleUpdate struct {
+	*goshopify.PriceRule
+	OncePerCustomer bool       `json:"once_per_customer"`
+	UsageLimit      *int       `json:"usage_limit"`
+	EndsAt          *time.Time `json:"ends_at"`
+}
+
+func (c *client) UpdatePriceRule(ctx context.Context, shopDomain string, accessToken string, priceRule *goshopify.PriceRule) (*goshopify.PriceRule, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	update := priceRuleUpdate{PriceRule: priceRule, OncePerCustomer: priceRule.OncePerCustomer, EndsAt: priceRule.EndsAt}
+	if priceRule.UsageLimit > 0 {
+		update.UsageLimit = &priceRule.UsageLimit
+	}
+	resource := new(goshopify.PriceRuleResource)
+	path := fmt.Sprintf("price_rules/%d.json", priceRule.Id)
+	if err := client.Put(ctx, path, map[string]interface{}{"price_rule": update}, resource); err != nil {
+		return nil, wrapError(ctx, "failed to update price rule", err)
+	}
+	return resource.PriceRule, nil
+}
+
+func (c *client) DeletePriceRule(ctx context.Context, shopDomain string, accessToken string, priceRuleID int64) error {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return err
+	}
+	if err := client.PriceRule.Delete(ctx, uint64(priceRuleID)); err != nil {
+		return wrapError(ctx, "failed to delete price rule", err)
+	}
+	return nil
+}
+
+// Discount Code API
+
+func (c *client) ListDiscountCodes(ctx context.Context, shopDomain string, accessToken string, priceRuleID int64, options interface{}) ([]goshopify.PriceRuleDiscountCode, *goshopify.Pagination, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	resource := new(goshopify.DiscountCodesResource)
+	path := fmt.Sprintf("price_rules/%d/discount_codes.json", priceRuleID)
+	pagination, err := client.ListWithPagination(ctx, path, resource, options)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list discount codes: %w", err)
+		return nil, nil, wrapError(ctx, "failed to list discount codes", err)
 	}
-	return codes, nil
+	return resource.DiscountCodes, pagination, nil
 }
 
 func (c *client) GetDiscountCode(ctx context.Context, shopDomain string, accessToken string, priceRuleID int64, discountCodeID int64) (*goshopify.PriceRuleDiscountCode, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
 	code, err := client.DiscountCode.Get(ctx, uint64(priceRuleID), uint64(discountCodeID))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get discount code: %w", err)
+		return nil, wrapError(ctx, "failed to get discount code", err)
 	}
 	return code, nil
 }
 
 func (c *client) CreateDiscountCode(ctx context.Context, shopDomain string, accessToken string, priceRuleID int64, discountCode *goshopify.PriceRuleDiscountCode) (*goshopify.PriceRuleDiscountCode, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
 	created, err := client.DiscountCode.Create(ctx, uint64(priceRuleID), *discountCode)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create discount code: %w", err)
+		return nil, wrapError(ctx, "failed to create discount code", err)
 	}
 	return created, nil
 }
 
 func (c *client) UpdateDiscountCode(ctx context.Context, shopDomain string, accessToken string, priceRuleID int64, discountCode *goshopify.PriceRuleDiscountCode) (*goshopify.PriceRuleDiscountCode, error) {
-	client, err := c.createClient(shopDomain, accessToken)
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return nil, err
 	}
 	updated, err := client.DiscountCode.Update(ctx, uint64(priceRuleID), *discountCode)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update discount code: %w", err)
+		return nil, wrapError(ctx, "failed to update discount code", err)
 	}
 	return updated, nil
 }
 
 func (c *client) DeleteDiscountCode(ctx context.Context, shopDomain string, accessToken string, priceRuleID int64, discountCodeID int64) error {
-	client, err := c.createClient(shopDomain, accessToken)
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
 	if err != nil {
 		return err
 	}
 	err = client.DiscountCode.Delete(ctx, uint64(priceRuleID), uint64(discountCodeID))
 	if err != nil {
-		return fmt.Errorf("failed to delete discount code: %w", err)
+		return wrapError(ctx, "failed to delete discount code", err)
 	}
 	return nil
 }
+
+// LookupDiscountCode follows Shopify's redirect from the code to the discount code of its price rule
+func (c *client) LookupDiscountCode(ctx context.Context, shopDomain string, accessToken string, code string) (*goshopify.PriceRuleDiscountCode, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	options := struct {
+		Code string `url:"code"`
+	}{Code: code}
+	resource := new(goshopify.DiscountCodeResource)
+	if err := client.Get(ctx, "discount_codes/lookup.json", resource, options); err != nil {
+		return nil, wrapError(ctx, "failed to look up discount code", err)
+	}
+	if resource.PriceRuleDiscountCode == nil {
+		return nil, domain.NewNotFoundError(fmt.Sprintf("discount code %s", code))
+	}
+	return resource.PriceRuleDiscountCode, nil
+}
+
+func (c *client) CountDiscountCodes(ctx context.Context, shopDomain string, accessToken string, options interface{}) (int, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return 0, err
+	}
+	count, err := client.Count(ctx, "discount_codes/count.json", options)
+	if err != nil {
+		return 0, wrapError(ctx, "failed to count discount codes", err)
+	}
+	return count, nil
+}
+
+// discountCodeBatchResource is the discount_code_creation envelope of Shopify's batch endpoints
+type discountCodeBatchResource struct {
+	Batch *domain.DiscountCodeBatch `json:"discount_code_creation"`
+}
+
+func (c *client) CreateDiscountCodeBatch(ctx context.Context, shopDomain string, accessToken string, priceRuleID int64, codes []string) (*domain.DiscountCodeBatch, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	discountCodes := make([]goshopify.PriceRuleDiscountCode, len(codes))
+	for i, code := range codes {
+		discountCodes[i] = goshopify.PriceRuleDiscountCode{Code: code}
+	}
+	resource := new(discountCodeBatchResource)
+	path := fmt.Sprintf("price_rules/%d/batch.json", priceRuleID)
+	if err := client.Post(ctx, path, goshopify.DiscountCodesResource{DiscountCodes: discountCodes}, resource); err != nil {
+		return nil, wrapError(ctx, "failed to create discount code batch", err)
+	}
+	return resource.Batch, nil
+}
+
+func (c *client) GetDiscountCodeBatch(ctx context.Context, shopDomain string, accessToken string, priceRuleID int64, batchID int64) (*domain.DiscountCodeBatch, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	resource := new(discountCodeBatchResource)
+	path := fmt.Sprintf("price_rules/%d/batch/%d.json", priceRuleID, batchID)
+	if err := client.Get(ctx, path, resource, nil); err != nil {
+		return nil, wrapError(ctx, "failed to get discount code batch", err)
+	}
+	return resource.Batch, nil
+}
+
+func (c *client) ListDiscountCodeBatchCodes(ctx context.Context, shopDomain string, accessToken string, priceRuleID int64, batchID int64) ([]domain.DiscountCodeBatchCode, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	resource := new(struct {
+		DiscountCodes []domain.DiscountCodeBatchCode `json:"discount_codes"`
+	})
+	path := fmt.Sprintf("price_rules/%d/batch/%d/discount_codes.json", priceRuleID, batchID)
+	if err := client.Get(ctx, path, resource, nil); err != nil {
+		return nil, wrapError(ctx, "failed to list discount code batch codes", err)
+	}
+	return resource.DiscountCodes, nil
+}
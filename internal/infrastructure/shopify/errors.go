@@ -0,0 +1,127 @@
+package shopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"archie-core-shopify-layer/internal/domain"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+)
+
+// requestTimeout matches the default timeout of go-shopify clients
+const requestTimeout = 10 * time.Second
+
+// requestIDRecorder holds the X-Request-Id of the last Shopify response of a call, go-shopify does not expose it
+// in errors; it travels in the request context so concurrent calls never see each other's IDs
+type requestIDRecorder struct {
+	mu        sync.Mutex
+	requestID string
+}
+
+// requestIDRecorderKey is the context key for the request ID recorder of a call
+type requestIDRecorderKey struct{}
+
+// withRequestIDRecorder returns a context whose Shopify responses record their request ID
+func withRequestIDRecorder(ctx context.Context) context.Context {
+	return context.WithValue(ctx, requestIDRecorderKey{}, &requestIDRecorder{})
+}
+
+// requestIDTransport records the X-Request-Id of responses in the recorder of the request context
+type requestIDTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *requestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if resp != nil {
+		if recorder, ok := req.Context().Value(requestIDRecorderKey{}).(*requestIDRecorder); ok {
+			recorder.mu.Lock()
+			recorder.requestID = resp.Header.Get("X-Request-Id")
+			recorder.mu.Unlock()
+		}
+	}
+	return resp, err
+}
+
+// newHTTPClient creates the HTTP client of a go-shopify client
+func newHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout:   requestTimeout,
+		Transport: &requestIDTransport{base: http.DefaultTransport},
+	}
+}
+
+// requestIDOf returns the request ID of the last Shopify response received with a context
+func requestIDOf(ctx context.Context) string {
+	recorder, ok := ctx.Value(requestIDRecorderKey{}).(*requestIDRecorder)
+	if !ok {
+		return ""
+	}
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	return recorder.requestID
+}
+
+// wrapError translates an error returned by go-shopify into a domain.AppError
+// Errors that did not come from a Shopify response, such as network errors, are only wrapped
+// The request ID is read from ctx, which must be the context of the failed call
+func wrapError(ctx context.Context, message string, err error) error {
+	var rateLimitErr goshopify.RateLimitError
+	var responseErr goshopify.ResponseError
+	var decodingErr goshopify.ResponseDecodingError
+
+	var appErr *domain.AppError
+	switch {
+	case errors.As(err, &rateLimitErr):
+		appErr = domain.NewRateLimitError(rateLimitErr.RetryAfter)
+		appErr.Message = message + ": Shopify rate limit exceeded"
+		appErr.Err = err
+		appErr.Context["status"] = rateLimitErr.Status
+	case errors.As(err, &responseErr):
+		appErr = translateResponseError(message, responseErr)
+	case errors.As(err, &decodingErr):
+		appErr = domain.NewShopifyAPIError(message+": invalid response from Shopify", err)
+		appErr.Context = map[string]interface{}{"status": decodingErr.Status}
+	default:
+		return fmt.Errorf("%s: %w", message, err)
+	}
+
+	return appErr.WithRequestID(requestIDOf(ctx))
+}
+
+// translateResponseError maps the HTTP status of a Shopify error response to an error type
+func translateResponseError(message string, err goshopify.ResponseError) *domain.AppError {
+	appErr := &domain.AppError{
+		Message: message,
+		Err:     err,
+		Context: map[string]interface{}{"status": err.Status},
+	}
+
+	switch err.Status {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		appErr.Type = domain.ErrorTypeUnauthorized
+	case http.StatusNotFound:
+		appErr.Type = domain.ErrorTypeNotFound
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		appErr.Type = domain.ErrorTypeValidation
+		// go-shopify flattens {"errors": {"title": ["can't be blank"]}} to "title: can't be blank"
+		for _, detail := range err.Errors {
+			field, fieldMessage, ok := strings.Cut(detail, ": ")
+			if !ok {
+				field, fieldMessage = "", detail
+			}
+			appErr.WithFieldError(field, fieldMessage)
+		}
+	default:
+		appErr.Type = domain.ErrorTypeShopifyAPI
+	}
+
+	return appErr
+}
@@ -0,0 +1,261 @@
+package shopify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"archie-core-shopify-layer/internal/domain"
+	"archie-core-shopify-layer/internal/ports"
+
+	"github.com/rs/zerolog"
+)
+
+// maxGraphQLPages bounds Paginate, a connection of 250 nodes per page then holds up to 250,000 nodes
+const maxGraphQLPages = 1000
+
+type graphQLClient struct {
+	httpClient  *http.Client
+	throttle    *GraphQLThrottle
+	retryConfig RetryConfig
+	logger      zerolog.Logger
+}
+
+// NewGraphQLClient creates a Shopify Admin GraphQL API adapter
+// A nil throttle creates one; share a throttle between clients calling the same shops
+func NewGraphQLClient(throttle *GraphQLThrottle, retryConfig RetryConfig, logger zerolog.Logger) ports.ShopifyGraphQLClient {
+	if throttle == nil {
+		throttle = NewGraphQLThrottle(logger)
+	}
+	return &graphQLClient{
+		httpClient:  &http.Client{Timeout: requestTimeout},
+		throttle:    throttle,
+		retryConfig: retryConfig,
+		logger:      logger,
+	}
+}
+
+// graphQLEndpoint returns the Admin GraphQL URL of a shop
+func graphQLEndpoint(shopDomain string) string {
+	return fmt.Sprintf("https://%s/admin/api/%s/graphql.json", shopDomain, domain.ShopifyAPIVersion)
+}
+
+// Execute sends a request, waiting for the cost bucket and retrying throttled requests, server errors and 429s
+func (c *graphQLClient) Execute(ctx context.Context, shopDomain string, accessToken string, request domain.GraphQLRequest) (*domain.GraphQLResponse, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode GraphQL request: %w", err)
+	}
+
+	delay := c.retryConfig.InitialDelay
+	for attempt := 0; ; attempt++ {
+		if err := c.throttle.Wait(ctx, shopDomain, request.Query); err != nil {
+			return nil, err
+		}
+
+		response, retryAfter, err := c.send(ctx, shopDomain, accessToken, body)
+		if err == nil && response.Cost != nil {
+			c.throttle.Update(shopDomain, request.Query, response.Cost)
+		}
+		if retryAfter < 0 || attempt >= c.retryConfig.MaxRetries {
+			return response, err
+		}
+
+		// Throttled requests wait for the bucket to hold their cost, other failures back off
+		wait := delay
+		if retryAfter > 0 {
+			wait = retryAfter
+		}
+		c.logger.Warn().
+			Str("shop", shopDomain).
+			Int("attempt", attempt+1).
+			Dur("delay", wait).
+			Err(err).
+			Msg("Retrying Shopify GraphQL request")
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+		delay = time.Duration(float64(delay) * c.retryConfig.BackoffFactor)
+		if delay > c.retryConfig.MaxDelay {
+			delay = c.retryConfig.MaxDelay
+		}
+	}
+}
+
+// send posts a request once; retryAfter is negative when the result is final, zero to back off, or the wait
+// Shopify asks for
+func (c *graphQLClient) send(ctx context.Context, shopDomain string, accessToken string, body []byte) (*domain.GraphQLResponse, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, graphQLEndpoint(shopDomain), bytes.NewReader(body))
+	if err != nil {
+		return nil, -1, fmt.Errorf("failed to create GraphQL request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Shopify-Access-Token", accessToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, -1, ctx.Err()
+		}
+		return nil, 0, fmt.Errorf("failed to call Shopify GraphQL API: %w", err)
+	}
+	defer resp.Body.Close()
+	requestID := resp.Header.Get("X-Request-Id")
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read Shopify GraphQL response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		appErr := graphQLStatusError(resp, respBody).WithRequestID(requestID)
+		for _, status := range c.retryConfig.RetryableErrors {
+			if resp.StatusCode == status {
+				return nil, time.Duration(appErr.RetryAfter()) * time.Second, appErr
+			}
+		}
+		return nil, -1, appErr
+	}
+
+	response := &domain.GraphQLResponse{}
+	if err := json.Unmarshal(respBody, response); err != nil {
+		appErr := domain.NewShopifyAPIError("invalid response from Shopify GraphQL API", err)
+		return nil, -1, appErr.WithRequestID(requestID)
+	}
+	var extensions struct {
+		Cost *domain.GraphQLCost `json:"cost"`
+	}
+	if len(response.Extensions) > 0 && json.Unmarshal(response.Extensions, &extensions) == nil {
+		response.Cost = extensions.Cost
+	}
+
+	if response.Throttled() {
+		wait := time.Second
+		if cost := response.Cost; cost != nil && cost.ThrottleStatus.RestoreRate > 0 {
+			missing := cost.RequestedQueryCost - cost.ThrottleStatus.CurrentlyAvailable
+			wait = time.Duration(missing / cost.ThrottleStatus.RestoreRate * float64(time.Second))
+		}
+		if wait < c.retryConfig.InitialDelay {
+			wait = c.retryConfig.InitialDelay
+		}
+		return response, wait, nil
+	}
+	return response, -1, nil
+}
+
+// graphQLStatusError translates a non-200 response of the GraphQL endpoint
+func graphQLStatusError(resp *http.Response, body []byte) *domain.AppError {
+	message := fmt.Sprintf("Shopify GraphQL API returned status %d", resp.StatusCode)
+	var appErr *domain.AppError
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		appErr = domain.NewUnauthorizedError(message)
+	case http.StatusNotFound:
+		appErr = domain.NewNotFoundError("shop")
+	case http.StatusTooManyRequests:
+		retryAfter, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+		appErr = domain.NewRateLimitError(retryAfter)
+		appErr.Message = message
+	default:
+		appErr = domain.NewShopifyAPIError(message, errors.New(strings.TrimSpace(string(body))))
+	}
+	if appErr.Context == nil {
+		appErr.Context = map[string]interface{}{}
+	}
+	appErr.Context["status"] = resp.StatusCode
+	return appErr
+}
+
+// Query runs a query or mutation and decodes its data into response
+func (c *graphQLClient) Query(ctx context.Context, shopDomain string, accessToken string, query string, variables map[string]interface{}, response interface{}) error {
+	result, err := c.Execute(ctx, shopDomain, accessToken, domain.GraphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return err
+	}
+	if err := result.Err("Shopify GraphQL query failed"); err != nil {
+		return err
+	}
+	if response == nil || len(result.Data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(result.Data, response); err != nil {
+		return domain.NewShopifyAPIError("failed to decode Shopify GraphQL data", err)
+	}
+	return nil
+}
+
+// graphQLConnection is the part of a connection Paginate reads
+type graphQLConnection struct {
+	Nodes []json.RawMessage `json:"nodes"`
+	Edges []struct {
+		Node json.RawMessage `json:"node"`
+	} `json:"edges"`
+	PageInfo struct {
+		HasNextPage bool   `json:"hasNextPage"`
+		EndCursor   string `json:"endCursor"`
+	} `json:"pageInfo"`
+}
+
+// Paginate follows pageInfo.endCursor of the connection at path until the last page
+func (c *graphQLClient) Paginate(ctx context.Context, shopDomain string, accessToken string, query string, variables map[string]interface{}, path string, handle func(nodes []json.RawMessage) error) error {
+	pageVariables := make(map[string]interface{}, len(variables)+1)
+	for name, value := range variables {
+		pageVariables[name] = value
+	}
+
+	for page := 0; page < maxGraphQLPages; page++ {
+		var data json.RawMessage
+		if err := c.Query(ctx, shopDomain, accessToken, query, pageVariables, &data); err != nil {
+			return err
+		}
+		connection, err := connectionAt(data, path)
+		if err != nil {
+			return err
+		}
+
+		nodes := connection.Nodes
+		if nodes == nil {
+			for _, edge := range connection.Edges {
+				nodes = append(nodes, edge.Node)
+			}
+		}
+		if err := handle(nodes); err != nil {
+			return err
+		}
+
+		if !connection.PageInfo.HasNextPage || connection.PageInfo.EndCursor == "" {
+			return nil
+		}
+		pageVariables["after"] = connection.PageInfo.EndCursor
+	}
+	return domain.NewShopifyAPIError(fmt.Sprintf("connection %s has more than %d pages", path, maxGraphQLPages), nil)
+}
+
+// connectionAt reads the connection at a dot-separated path of the data of a response
+func connectionAt(data json.RawMessage, path string) (*graphQLConnection, error) {
+	value := data
+	for _, field := range strings.Split(path, ".") {
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(value, &object); err != nil || object[field] == nil {
+			return nil, domain.NewValidationError(fmt.Sprintf("no connection at %s in the GraphQL response", path), err)
+		}
+		value = object[field]
+	}
+
+	connection := &graphQLConnection{}
+	if err := json.Unmarshal(value, connection); err != nil {
+		return nil, domain.NewValidationError(fmt.Sprintf("%s is not a connection", path), err)
+	}
+	return connection, nil
+}
@@ -0,0 +1,538 @@
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"archie-core-shopify-layer/internal/domain"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+	"github.com/shopspring/decimal"
+)
+
+// Typed reads of the GraphQL adapter, converted to the go-shopify types the REST adapter returns so that
+// ShopifyService can serve an operation from either API. Fields the GraphQL API does not expose stay empty.
+
+const graphQLProductQuery = `
+query Product($id: ID!) {
+  product(id: $id) {
+    id title descriptionHtml vendor productType handle status tags templateSuffix
+    createdAt updatedAt publishedAt
+    options { id name position values }
+    media(first: 250) { nodes { ... on MediaImage { id alt image { url width height } } } }
+    variants(first: 250) {
+      nodes {
+        id title sku barcode position price compareAtPrice inventoryQuantity inventoryPolicy taxable
+        createdAt updatedAt
+        selectedOptions { name value }
+        inventoryItem { id requiresShipping }
+        image { id }
+      }
+    }
+  }
+}`
+
+const graphQLOrderQuery = `
+query Order($id: ID!) {
+  order(id: $id) {
+    id name email phone note tags test confirmed taxesIncluded currencyCode
+    createdAt updatedAt processedAt cancelledAt closedAt cancelReason
+    displayFinancialStatus displayFulfillmentStatus
+    totalPriceSet { shopMoney { amount } }
+    subtotalPriceSet { shopMoney { amount } }
+    totalTaxSet { shopMoney { amount } }
+    totalDiscountsSet { shopMoney { amount } }
+    currentTotalPriceSet { shopMoney { amount } }
+    customer { id email firstName lastName phone }
+    billingAddress { ...OrderAddress }
+    shippingAddress { ...OrderAddress }
+    lineItems(first: 250) {
+      nodes {
+        id title name sku vendor quantity currentQuantity taxable requiresShipping variantTitle
+        originalUnitPriceSet { shopMoney { amount } }
+        totalDiscountSet { shopMoney { amount } }
+        variant { id }
+        product { id }
+      }
+    }
+  }
+}
+
+fragment OrderAddress on MailingAddress {
+  firstName lastName name company address1 address2 city province provinceCode country countryCodeV2 zip phone
+  latitude longitude
+}`
+
+const graphQLCustomerQuery = `
+query Customer($id: ID!) {
+  customer(id: $id) {
+    id email firstName lastName phone note tags state verifiedEmail taxExempt numberOfOrders
+    createdAt updatedAt
+    amountSpent { amount currencyCode }
+    defaultAddress { ...CustomerAddress }
+    addressesV2(first: 250) { nodes { ...CustomerAddress } }
+  }
+}
+
+fragment CustomerAddress on MailingAddress {
+  id firstName lastName name company address1 address2 city province provinceCode country countryCodeV2 zip phone
+}`
+
+type graphQLMoneySet struct {
+	ShopMoney struct {
+		Amount *decimal.Decimal `json:"amount"`
+	} `json:"shopMoney"`
+}
+
+// amount returns the shop currency amount of a money set, nil when unset
+func (m *graphQLMoneySet) amount() *decimal.Decimal {
+	if m == nil {
+		return nil
+	}
+	return m.ShopMoney.Amount
+}
+
+type graphQLID struct {
+	ID string `json:"id"`
+}
+
+type graphQLProductNode struct {
+	ID              string     `json:"id"`
+	Title           string     `json:"title"`
+	DescriptionHTML string     `json:"descriptionHtml"`
+	Vendor          string     `json:"vendor"`
+	ProductType     string     `json:"productType"`
+	Handle          string     `json:"handle"`
+	Status          string     `json:"status"`
+	Tags            []string   `json:"tags"`
+	TemplateSuffix  string     `json:"templateSuffix"`
+	CreatedAt       *time.Time `json:"createdAt"`
+	UpdatedAt       *time.Time `json:"updatedAt"`
+	PublishedAt     *time.Time `json:"publishedAt"`
+	Options         []struct {
+		ID       string   `json:"id"`
+		Name     string   `json:"name"`
+		Position int      `json:"position"`
+		Values   []string `json:"values"`
+	} `json:"options"`
+	Media struct {
+		Nodes []struct {
+			ID    string `json:"id"`
+			Alt   string `json:"alt"`
+			Image *struct {
+				URL    string `json:"url"`
+				Width  int    `json:"width"`
+				Height int    `json:"height"`
+			} `json:"image"`
+		} `json:"nodes"`
+	} `json:"media"`
+	Variants struct {
+		Nodes []struct {
+			ID                string           `json:"id"`
+			Title             string           `json:"title"`
+			Sku               string           `json:"sku"`
+			Barcode           string           `json:"barcode"`
+			Position          int              `json:"position"`
+			Price             *decimal.Decimal `json:"price"`
+			CompareAtPrice    *decimal.Decimal `json:"compareAtPrice"`
+			InventoryQuantity int              `json:"inventoryQuantity"`
+			InventoryPolicy   string           `json:"inventoryPolicy"`
+			Taxable           bool             `json:"taxable"`
+			CreatedAt         *time.Time       `json:"createdAt"`
+			UpdatedAt         *time.Time       `json:"updatedAt"`
+			SelectedOptions   []struct {
+				Name  string `json:"name"`
+				Value string `json:"value"`
+			} `json:"selectedOptions"`
+			InventoryItem struct {
+				ID               string `json:"id"`
+				RequiresShipping bool   `json:"requiresShipping"`
+			} `json:"inventoryItem"`
+			Image *graphQLID `json:"image"`
+		} `json:"nodes"`
+	} `json:"variants"`
+}
+
+type graphQLAddressNode struct {
+	ID            string  `json:"id"`
+	FirstName     string  `json:"firstName"`
+	LastName      string  `json:"lastName"`
+	Name          string  `json:"name"`
+	Company       string  `json:"company"`
+	Address1      string  `json:"address1"`
+	Address2      string  `json:"address2"`
+	City          string  `json:"city"`
+	Province      string  `json:"province"`
+	ProvinceCode  string  `json:"provinceCode"`
+	Country       string  `json:"country"`
+	CountryCodeV2 string  `json:"countryCodeV2"`
+	Zip           string  `json:"zip"`
+	Phone         string  `json:"phone"`
+	Latitude      float64 `json:"latitude"`
+	Longitude     float64 `json:"longitude"`
+}
+
+type graphQLOrderNode struct {
+	ID                       string           `json:"id"`
+	Name                     string           `json:"name"`
+	Email                    string           `json:"email"`
+	Phone                    string           `json:"phone"`
+	Note                     string           `json:"note"`
+	Tags                     []string         `json:"tags"`
+	Test                     bool             `json:"test"`
+	Confirmed                bool             `json:"confirmed"`
+	TaxesIncluded            bool             `json:"taxesIncluded"`
+	CurrencyCode             string           `json:"currencyCode"`
+	CreatedAt                *time.Time       `json:"createdAt"`
+	UpdatedAt                *time.Time       `json:"updatedAt"`
+	ProcessedAt              *time.Time       `json:"processedAt"`
+	CancelledAt              *time.Time       `json:"cancelledAt"`
+	ClosedAt                 *time.Time       `json:"closedAt"`
+	CancelReason             string           `json:"cancelReason"`
+	DisplayFinancialStatus   string           `json:"displayFinancialStatus"`
+	DisplayFulfillmentStatus string           `json:"displayFulfillmentStatus"`
+	TotalPriceSet            *graphQLMoneySet `json:"totalPriceSet"`
+	SubtotalPriceSet         *graphQLMoneySet `json:"subtotalPriceSet"`
+	TotalTaxSet              *graphQLMoneySet `json:"totalTaxSet"`
+	TotalDiscountsSet        *graphQLMoneySet `json:"totalDiscountsSet"`
+	CurrentTotalPriceSet     *graphQLMoneySet `json:"currentTotalPriceSet"`
+	Customer                 *struct {
+		ID        string `json:"id"`
+		Email     string `json:"email"`
+		FirstName string `json:"firstName"`
+		LastName  string `json:"lastName"`
+		Phone     string `json:"phone"`
+	} `json:"customer"`
+	BillingAddress  *graphQLAddressNode `json:"billingAddress"`
+	ShippingAddress *graphQLAddressNode `json:"shippingAddress"`
+	LineItems       struct {
+		Nodes []struct {
+			ID                   string           `json:"id"`
+			Title                string           `json:"title"`
+			Name                 string           `json:"name"`
+			Sku                  string           `json:"sku"`
+			Vendor               string           `json:"vendor"`
+			Quantity             int              `json:"quantity"`
+			CurrentQuantity      int              `json:"currentQuantity"`
+			Taxable              bool             `json:"taxable"`
+			RequiresShipping     bool             `json:"requiresShipping"`
+			VariantTitle         string           `json:"variantTitle"`
+			OriginalUnitPriceSet *graphQLMoneySet `json:"originalUnitPriceSet"`
+			TotalDiscountSet     *graphQLMoneySet `json:"totalDiscountSet"`
+			Variant              *graphQLID       `json:"variant"`
+			Product              *graphQLID       `json:"product"`
+		} `json:"nodes"`
+	} `json:"lineItems"`
+}
+
+type graphQLCustomerNode struct {
+	ID             string     `json:"id"`
+	Email          string     `json:"email"`
+	FirstName      string     `json:"firstName"`
+	LastName       string     `json:"lastName"`
+	Phone          string     `json:"phone"`
+	Note           string     `json:"note"`
+	Tags           []string   `json:"tags"`
+	State          string     `json:"state"`
+	VerifiedEmail  bool       `json:"verifiedEmail"`
+	TaxExempt      bool       `json:"taxExempt"`
+	NumberOfOrders string     `json:"numberOfOrders"` // UnsignedInt64, encoded as a string
+	CreatedAt      *time.Time `json:"createdAt"`
+	UpdatedAt      *time.Time `json:"updatedAt"`
+	AmountSpent    *struct {
+		Amount       *decimal.Decimal `json:"amount"`
+		CurrencyCode string           `json:"currencyCode"`
+	} `json:"amountSpent"`
+	DefaultAddress *graphQLAddressNode `json:"defaultAddress"`
+	AddressesV2    struct {
+		Nodes []graphQLAddressNode `json:"nodes"`
+	} `json:"addressesV2"`
+}
+
+// queryNode fetches a single node by global ID, returning a not found error when Shopify returns null
+func queryNode[T any](ctx context.Context, c *graphQLClient, shopDomain string, accessToken string, query string, field string, resource string, id int64) (*T, error) {
+	var response map[string]*T
+	variables := map[string]interface{}{"id": globalID(resource, id)}
+	if err := c.Query(ctx, shopDomain, accessToken, query, variables, &response); err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", field, err)
+	}
+	node := response[field]
+	if node == nil {
+		return nil, domain.NewNotFoundError(fmt.Sprintf("%s %d", field, id))
+	}
+	return node, nil
+}
+
+func (c *graphQLClient) GetProduct(ctx context.Context, shopDomain string, accessToken string, productID int64) (*goshopify.Product, error) {
+	node, err := queryNode[graphQLProductNode](ctx, c, shopDomain, accessToken, graphQLProductQuery, "product", "Product", productID)
+	if err != nil {
+		return nil, err
+	}
+	return toShopifyProduct(node), nil
+}
+
+func (c *graphQLClient) GetOrder(ctx context.Context, shopDomain string, accessToken string, orderID int64) (*goshopify.Order, error) {
+	node, err := queryNode[graphQLOrderNode](ctx, c, shopDomain, accessToken, graphQLOrderQuery, "order", "Order", orderID)
+	if err != nil {
+		return nil, err
+	}
+	return toShopifyOrder(node), nil
+}
+
+func (c *graphQLClient) GetCustomer(ctx context.Context, shopDomain string, accessToken string, customerID int64) (*goshopify.Customer, error) {
+	node, err := queryNode[graphQLCustomerNode](ctx, c, shopDomain, accessToken, graphQLCustomerQuery, "customer", "Customer", customerID)
+	if err != nil {
+		return nil, err
+	}
+	return toShopifyCustomer(node), nil
+}
+
+// toShopifyProduct converts a GraphQL product, variant options are matched to the product options by name
+func toShopifyProduct(node *graphQLProductNode) *goshopify.Product {
+	productID := legacyID(node.ID)
+	product := &goshopify.Product{
+		Id:                productID,
+		Title:             node.Title,
+		BodyHTML:          node.DescriptionHTML,
+		Vendor:            node.Vendor,
+		ProductType:       node.ProductType,
+		Handle:            node.Handle,
+		CreatedAt:         node.CreatedAt,
+		UpdatedAt:         node.UpdatedAt,
+		PublishedAt:       node.PublishedAt,
+		Tags:              strings.Join(node.Tags, ", "),
+		Status:            goshopify.ProductStatus(strings.ToLower(node.Status)),
+		TemplateSuffix:    node.TemplateSuffix,
+		AdminGraphqlApiId: node.ID,
+	}
+
+	optionPositions := make(map[string]int, len(node.Options))
+	for _, option := range node.Options {
+		optionPositions[option.Name] = option.Position
+		product.Options = append(product.Options, goshopify.ProductOption{
+			Id:        legacyID(option.ID),
+			ProductId: productID,
+			Name:      option.Name,
+			Position:  option.Position,
+			Values:    option.Values,
+		})
+	}
+
+	for i, media := range node.Media.Nodes {
+		if media.Image == nil {
+			continue
+		}
+		product.Images = append(product.Images, goshopify.Image{
+			Id:                legacyID(media.ID),
+			ProductId:         productID,
+			Position:          i + 1,
+			Width:             media.Image.Width,
+			Height:            media.Image.Height,
+			Src:               media.Image.URL,
+			Alt:               media.Alt,
+			AdminGraphqlApiId: media.ID,
+		})
+	}
+	if len(product.Images) > 0 {
+		product.Image = product.Images[0]
+	}
+
+	for _, variant := range node.Variants.Nodes {
+		converted := goshopify.Variant{
+			Id:                legacyID(variant.ID),
+			ProductId:         productID,
+			Title:             variant.Title,
+			Sku:               variant.Sku,
+			Barcode:           variant.Barcode,
+			Position:          variant.Position,
+			Price:             variant.Price,
+			CompareAtPrice:    variant.CompareAtPrice,
+			InventoryQuantity: variant.InventoryQuantity,
+			InventoryPolicy:   goshopify.VariantInventoryPolicy(strings.ToLower(variant.InventoryPolicy)),
+			Taxable:           variant.Taxable,
+			CreatedAt:         variant.CreatedAt,
+			UpdatedAt:         variant.UpdatedAt,
+			InventoryItemId:   legacyID(variant.InventoryItem.ID),
+			RequireShipping:   variant.InventoryItem.RequiresShipping,
+			AdminGraphqlApiId: variant.ID,
+		}
+		if variant.Image != nil {
+			converted.ImageId = legacyID(variant.Image.ID)
+		}
+		for _, selected := range variant.SelectedOptions {
+			switch optionPositions[selected.Name] {
+			case 1:
+				converted.Option1 = selected.Value
+			case 2:
+				converted.Option2 = selected.Value
+			case 3:
+				converted.Option3 = selected.Value
+			}
+		}
+		product.Variants = append(product.Variants, converted)
+	}
+
+	return product
+}
+
+// toShopifyAddress converts a GraphQL mailing address to an order address
+func toShopifyAddress(node *graphQLAddressNode) *goshopify.Address {
+	if node == nil {
+		return nil
+	}
+	return &goshopify.Address{
+		Address1:     node.Address1,
+		Address2:     node.Address2,
+		City:         node.City,
+		Company:      node.Company,
+		Country:      node.Country,
+		CountryCode:  node.CountryCodeV2,
+		FirstName:    node.FirstName,
+		LastName:     node.LastName,
+		Latitude:     node.Latitude,
+		Longitude:    node.Longitude,
+		Name:         node.Name,
+		Phone:        node.Phone,
+		Province:     node.Province,
+		ProvinceCode: node.ProvinceCode,
+		Zip:          node.Zip,
+	}
+}
+
+// toShopifyOrder converts a GraphQL order, display statuses are lowercased to the REST values
+func toShopifyOrder(node *graphQLOrderNode) *goshopify.Order {
+	order := &goshopify.Order{
+		Id:                legacyID(node.ID),
+		Name:              node.Name,
+		Email:             node.Email,
+		Phone:             node.Phone,
+		Note:              node.Note,
+		Tags:              strings.Join(node.Tags, ", "),
+		Test:              node.Test,
+		Confirmed:         node.Confirmed,
+		TaxesIncluded:     node.TaxesIncluded,
+		Currency:          node.CurrencyCode,
+		CreatedAt:         node.CreatedAt,
+		UpdatedAt:         node.UpdatedAt,
+		ProcessedAt:       node.ProcessedAt,
+		CancelledAt:       node.CancelledAt,
+		ClosedAt:          node.ClosedAt,
+		CancelReason:      goshopify.OrderCancelReason(strings.ToLower(node.CancelReason)),
+		FinancialStatus:   goshopify.OrderFinancialStatus(strings.ToLower(node.DisplayFinancialStatus)),
+		TotalPrice:        node.TotalPriceSet.amount(),
+		SubtotalPrice:     node.SubtotalPriceSet.amount(),
+		TotalTax:          node.TotalTaxSet.amount(),
+		TotalDiscounts:    node.TotalDiscountsSet.amount(),
+		CurrentTotalPrice: node.CurrentTotalPriceSet.amount(),
+		BillingAddress:    toShopifyAddress(node.BillingAddress),
+		ShippingAddress:   toShopifyAddress(node.ShippingAddress),
+	}
+	if name, ok := strings.CutPrefix(node.Name, "#"); ok {
+		fmt.Sscanf(name, "%d", &order.OrderNumber)
+	}
+	// REST leaves fulfillment_status null until something is fulfilled
+	switch node.DisplayFulfillmentStatus {
+	case "FULFILLED":
+		order.FulfillmentStatus = goshopify.OrderFulfillmentStatusFulfilled
+	case "PARTIALLY_FULFILLED":
+		order.FulfillmentStatus = goshopify.OrderFulfillmentStatusPartial
+	}
+
+	if node.Customer != nil {
+		order.Customer = &goshopify.Customer{
+			Id:        legacyID(node.Customer.ID),
+			Email:     node.Customer.Email,
+			FirstName: node.Customer.FirstName,
+			LastName:  node.Customer.LastName,
+			Phone:     node.Customer.Phone,
+		}
+	}
+
+	for _, item := range node.LineItems.Nodes {
+		lineItem := goshopify.LineItem{
+			Id:               legacyID(item.ID),
+			Title:            item.Title,
+			Name:             item.Name,
+			SKU:              item.Sku,
+			Vendor:           item.Vendor,
+			Quantity:         item.Quantity,
+			CurrentQuantity:  item.CurrentQuantity,
+			Taxable:          item.Taxable,
+			RequiresShipping: item.RequiresShipping,
+			VariantTitle:     item.VariantTitle,
+			Price:            item.OriginalUnitPriceSet.amount(),
+			TotalDiscount:    item.TotalDiscountSet.amount(),
+		}
+		if item.Variant != nil {
+			lineItem.VariantId = legacyID(item.Variant.ID)
+		}
+		if item.Product != nil {
+			lineItem.ProductId = legacyID(item.Product.ID)
+			lineItem.ProductExists = true
+		}
+		order.LineItems = append(order.LineItems, lineItem)
+	}
+
+	return order
+}
+
+// toShopifyCustomerAddress converts a GraphQL mailing address to a customer address
+func toShopifyCustomerAddress(customerID uint64, node *graphQLAddressNode, defaultID string) *goshopify.CustomerAddress {
+	return &goshopify.CustomerAddress{
+		Id:           legacyID(node.ID),
+		CustomerId:   customerID,
+		FirstName:    node.FirstName,
+		LastName:     node.LastName,
+		Company:      node.Company,
+		Address1:     node.Address1,
+		Address2:     node.Address2,
+		City:         node.City,
+		Province:     node.Province,
+		Country:      node.Country,
+		Zip:          node.Zip,
+		Phone:        node.Phone,
+		Name:         node.Name,
+		ProvinceCode: node.ProvinceCode,
+		CountryCode:  node.CountryCodeV2,
+		CountryName:  node.Country,
+		Default:      node.ID != "" && node.ID == defaultID,
+	}
+}
+
+// toShopifyCustomer converts a GraphQL customer
+func toShopifyCustomer(node *graphQLCustomerNode) *goshopify.Customer {
+	customerID := legacyID(node.ID)
+	customer := &goshopify.Customer{
+		Id:                customerID,
+		Email:             node.Email,
+		FirstName:         node.FirstName,
+		LastName:          node.LastName,
+		Phone:             node.Phone,
+		Note:              node.Note,
+		Tags:              strings.Join(node.Tags, ", "),
+		State:             strings.ToLower(node.State),
+		VerifiedEmail:     node.VerifiedEmail,
+		TaxExempt:         node.TaxExempt,
+		CreatedAt:         node.CreatedAt,
+		UpdatedAt:         node.UpdatedAt,
+		AdminGraphqlApiId: node.ID,
+	}
+	fmt.Sscanf(node.NumberOfOrders, "%d", &customer.OrdersCount)
+	if node.AmountSpent != nil {
+		customer.TotalSpent = node.AmountSpent.Amount
+		customer.Currency = node.AmountSpent.CurrencyCode
+	}
+
+	defaultID := ""
+	if node.DefaultAddress != nil {
+		defaultID = node.DefaultAddress.ID
+		customer.DefaultAddress = toShopifyCustomerAddress(customerID, node.DefaultAddress, defaultID)
+	}
+	for i := range node.AddressesV2.Nodes {
+		customer.Addresses = append(customer.Addresses, toShopifyCustomerAddress(customerID, &node.AddressesV2.Nodes[i], defaultID))
+	}
+
+	return customer
+}
@@ -0,0 +1,172 @@
+package shopify
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"archie-core-shopify-layer/internal/domain"
+
+	"github.com/rs/zerolog"
+)
+
+// Shopify's smallest Admin GraphQL cost bucket, assumed until a response reports the shop's actual one
+const (
+	defaultGraphQLMaximumAvailable = 1000
+	defaultGraphQLRestoreRate      = 50
+	// defaultGraphQLQueryCost is reserved for queries whose cost has not been reported yet
+	defaultGraphQLQueryCost = 50
+	// maxGraphQLQueryCosts bounds the remembered query costs, raw passthrough queries are all different
+	maxGraphQLQueryCosts = 1000
+	// maxGraphQLThrottleWait bounds a single wait, the bucket is checked again afterwards
+	maxGraphQLThrottleWait = 10 * time.Second
+)
+
+// GraphQLThrottle paces Admin GraphQL requests with the calculated query cost limit of each shop
+// Shopify reports the bucket in extensions.cost of every response; between responses it is restored at
+// restoreRate points per second and each request reserves the cost last requested by the same query
+type GraphQLThrottle struct {
+	mu      sync.Mutex
+	buckets map[string]*costBucket // key: shopDomain
+	costs   map[uint64]float64     // key: hash of the query text
+	logger  zerolog.Logger
+}
+
+type costBucket struct {
+	available   float64
+	maximum     float64
+	restoreRate float64
+	updatedAt   time.Time
+}
+
+// NewGraphQLThrottle creates a query cost throttle
+func NewGraphQLThrottle(logger zerolog.Logger) *GraphQLThrottle {
+	return &GraphQLThrottle{
+		buckets: make(map[string]*costBucket),
+		costs:   make(map[uint64]float64),
+		logger:  logger,
+	}
+}
+
+// Wait waits until the bucket of the shop holds the expected cost of a query, then reserves it
+func (t *GraphQLThrottle) Wait(ctx context.Context, shopDomain string, query string) error {
+	for {
+		delay := t.reserve(shopDomain, query)
+		if delay == 0 {
+			return nil
+		}
+
+		t.logger.Debug().Str("shop", shopDomain).Dur("delay", delay).Msg("Waiting for Shopify GraphQL query cost bucket")
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Update records the bucket state and the requested cost reported by a response
+func (t *GraphQLThrottle) Update(shopDomain string, query string, cost *domain.GraphQLCost) {
+	if cost == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.costs) >= maxGraphQLQueryCosts {
+		t.costs = make(map[uint64]float64)
+	}
+	t.costs[queryHash(query)] = cost.RequestedQueryCost
+	status := cost.ThrottleStatus
+	if status.MaximumAvailable <= 0 {
+		return
+	}
+	t.buckets[shopDomain] = &costBucket{
+		available:   status.CurrentlyAvailable,
+		maximum:     status.MaximumAvailable,
+		restoreRate: status.RestoreRate,
+		updatedAt:   time.Now(),
+	}
+}
+
+// Available returns the points currently available to a shop (for monitoring)
+func (t *GraphQLThrottle) Available(shopDomain string) float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	bucket := t.bucket(shopDomain)
+	bucket.restore(time.Now())
+	return bucket.available
+}
+
+// reserve takes the expected cost of a query from the shop's bucket, or returns how long to wait for it
+func (t *GraphQLThrottle) reserve(shopDomain string, query string) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	bucket := t.bucket(shopDomain)
+	bucket.restore(time.Now())
+
+	cost, ok := t.costs[queryHash(query)]
+	if !ok {
+		cost = defaultGraphQLQueryCost
+	}
+	// A query costing more than the whole bucket only runs when the bucket is full, Shopify then rejects it
+	if cost > bucket.maximum {
+		cost = bucket.maximum
+	}
+
+	if bucket.available >= cost {
+		bucket.available -= cost
+		return 0
+	}
+	if bucket.restoreRate <= 0 {
+		return maxGraphQLThrottleWait
+	}
+	wait := time.Duration((cost - bucket.available) / bucket.restoreRate * float64(time.Second))
+	switch {
+	case wait < 100*time.Millisecond:
+		return 100 * time.Millisecond // Minimum wait
+	case wait > maxGraphQLThrottleWait:
+		return maxGraphQLThrottleWait
+	}
+	return wait
+}
+
+// bucket gets or creates the bucket of a shop, the caller holds the lock
+func (t *GraphQLThrottle) bucket(shopDomain string) *costBucket {
+	bucket, ok := t.buckets[shopDomain]
+	if !ok {
+		bucket = &costBucket{
+			available:   defaultGraphQLMaximumAvailable,
+			maximum:     defaultGraphQLMaximumAvailable,
+			restoreRate: defaultGraphQLRestoreRate,
+			updatedAt:   time.Now(),
+		}
+		t.buckets[shopDomain] = bucket
+	}
+	return bucket
+}
+
+// restore adds the points restored since the last update
+func (b *costBucket) restore(now time.Time) {
+	elapsed := now.Sub(b.updatedAt).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	b.available += elapsed * b.restoreRate
+	if b.available > b.maximum {
+		b.available = b.maximum
+	}
+	b.updatedAt = now
+}
+
+// queryHash identifies a query text without keeping it in memory
+func queryHash(query string) uint64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(query))
+	return hash.Sum64()
+}
@@ -0,0 +1,685 @@
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"archie-core-shopify-layer/internal/domain"
+)
+
+// Metafield definitions and metaobjects only exist in Shopify's GraphQL Admin API
+
+const metafieldDefinitionFields = `
+fragment MetafieldDefinitionFields on MetafieldDefinition {
+  id
+  name
+  namespace
+  key
+  description
+  ownerType
+  type { name }
+  validations { name value }
+  pinnedPosition
+}`
+
+const metafieldDefinitionsQuery = `
+query MetafieldDefinitions($ownerType: MetafieldOwnerType!, $namespace: String) {
+  metafieldDefinitions(first: 250, ownerType: $ownerType, namespace: $namespace) {
+    nodes { ...MetafieldDefinitionFields }
+  }
+}` + metafieldDefinitionFields
+
+const metafieldDefinitionCreateMutation = `
+mutation MetafieldDefinitionCreate($definition: MetafieldDefinitionInput!) {
+  metafieldDefinitionCreate(definition: $definition) {
+    createdDefinition { ...MetafieldDefinitionFields }
+    userErrors { field message }
+  }
+}` + metafieldDefinitionFields
+
+const metafieldDefinitionUpdateMutation = `
+mutation MetafieldDefinitionUpdate($definition: MetafieldDefinitionUpdateInput!) {
+  metafieldDefinitionUpdate(definition: $definition) {
+    updatedDefinition { ...MetafieldDefinitionFields }
+    userErrors { field message }
+  }
+}` + metafieldDefinitionFields
+
+const metafieldDefinitionDeleteMutation = `
+mutation MetafieldDefinitionDelete($id: ID!, $deleteMetafields: Boolean) {
+  metafieldDefinitionDelete(id: $id, deleteAllAssociatedMetafields: $deleteMetafields) {
+    deletedDefinitionId
+    userErrors { field message }
+  }
+}`
+
+const metaobjectDefinitionFields = `
+fragment MetaobjectDefinitionFields on MetaobjectDefinition {
+  id
+  type
+  name
+  description
+  displayNameKey
+  metaobjectsCount
+  fieldDefinitions {
+    key
+    name
+    description
+    required
+    type { name }
+    validations { name value }
+  }
+}`
+
+const metaobjectDefinitionsQuery = `
+query MetaobjectDefinitions {
+  metaobjectDefinitions(first: 250) {
+    nodes { ...MetaobjectDefinitionFields }
+  }
+}` + metaobjectDefinitionFields
+
+const metaobjectDefinitionQuery = `
+query MetaobjectDefinition($id: ID!) {
+  metaobjectDefinition(id: $id) { ...MetaobjectDefinitionFields }
+}` + metaobjectDefinitionFields
+
+const metaobjectDefinitionByTypeQuery = `
+query MetaobjectDefinitionByType($type: String!) {
+  metaobjectDefinitionByType(type: $type) { ...MetaobjectDefinitionFields }
+}` + metaobjectDefinitionFields
+
+const metaobjectDefinitionCreateMutation = `
+mutation MetaobjectDefinitionCreate($definition: MetaobjectDefinitionCreateInput!) {
+  metaobjectDefinitionCreate(definition: $definition) {
+    metaobjectDefinition { ...MetaobjectDefinitionFields }
+    userErrors { field message }
+  }
+}` + metaobjectDefinitionFields
+
+const metaobjectDefinitionUpdateMutation = `
+mutation MetaobjectDefinitionUpdate($id: ID!, $definition: MetaobjectDefinitionUpdateInput!) {
+  metaobjectDefinitionUpdate(id: $id, definition: $definition) {
+    metaobjectDefinition { ...MetaobjectDefinitionFields }
+    userErrors { field message }
+  }
+}` + metaobjectDefinitionFields
+
+const metaobjectDefinitionDeleteMutation = `
+mutation MetaobjectDefinitionDelete($id: ID!) {
+  metaobjectDefinitionDelete(id: $id) {
+    deletedId
+    userErrors { field message }
+  }
+}`
+
+const metaobjectFields = `
+fragment MetaobjectFields on Metaobject {
+  id
+  type
+  handle
+  displayName
+  updatedAt
+  fields { key type value }
+}`
+
+const metaobjectsQuery = `
+query Metaobjects($type: String!, $first: Int, $after: String, $last: Int, $before: String) {
+  metaobjects(type: $type, first: $first, after: $after, last: $last, before: $before) {
+    edges { cursor node { ...MetaobjectFields } }
+    pageInfo { hasNextPage hasPreviousPage startCursor endCursor }
+  }
+}` + metaobjectFields
+
+const metaobjectQuery = `
+query Metaobject($id: ID!) {
+  metaobject(id: $id) { ...MetaobjectFields }
+}` + metaobjectFields
+
+const metaobjectByHandleQuery = `
+query MetaobjectByHandle($handle: MetaobjectHandleInput!) {
+  metaobjectByHandle(handle: $handle) { ...MetaobjectFields }
+}` + metaobjectFields
+
+const metaobjectCreateMutation = `
+mutation MetaobjectCreate($metaobject: MetaobjectCreateInput!) {
+  metaobjectCreate(metaobject: $metaobject) {
+    metaobject { ...MetaobjectFields }
+    userErrors { field message }
+  }
+}` + metaobjectFields
+
+const metaobjectUpdateMutation = `
+mutation MetaobjectUpdate($id: ID!, $metaobject: MetaobjectUpdateInput!) {
+  metaobjectUpdate(id: $id, metaobject: $metaobject) {
+    metaobject { ...MetaobjectFields }
+    userErrors { field message }
+  }
+}` + metaobjectFields
+
+const metaobjectDeleteMutation = `
+mutation MetaobjectDelete($id: ID!) {
+  metaobjectDelete(id: $id) {
+    deletedId
+    userErrors { field message }
+  }
+}`
+
+// validationNode is a metafield or metaobject field validation
+type validationNode struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// typeNode is the type of a metafield definition or metaobject field definition
+type typeNode struct {
+	Name string `json:"name"`
+}
+
+// metafieldDefinitionNode is a metafield definition as selected by MetafieldDefinitionFields
+type metafieldDefinitionNode struct {
+	ID             string           `json:"id"`
+	Name           string           `json:"name"`
+	Namespace      string           `json:"namespace"`
+	Key            string           `json:"key"`
+	Description    string           `json:"description"`
+	OwnerType      string           `json:"ownerType"`
+	Type           typeNode         `json:"type"`
+	Validations    []validationNode `json:"validations"`
+	PinnedPosition *int             `json:"pinnedPosition"`
+}
+
+// metaobjectDefinitionNode is a metaobject definition as selected by MetaobjectDefinitionFields
+type metaobjectDefinitionNode struct {
+	ID               string `json:"id"`
+	Type             string `json:"type"`
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+	DisplayNameKey   string `json:"displayNameKey"`
+	MetaobjectsCount int    `json:"metaobjectsCount"`
+	FieldDefinitions []struct {
+		Key         string           `json:"key"`
+		Name        string           `json:"name"`
+		Description string           `json:"description"`
+		Required    bool             `json:"required"`
+		Type        typeNode         `json:"type"`
+		Validations []validationNode `json:"validations"`
+	} `json:"fieldDefinitions"`
+}
+
+// metaobjectNode is a metaobject as selected by MetaobjectFields
+type metaobjectNode struct {
+	ID          string     `json:"id"`
+	Type        string     `json:"type"`
+	Handle      string     `json:"handle"`
+	DisplayName string     `json:"displayName"`
+	UpdatedAt   *time.Time `json:"updatedAt"`
+	Fields      []struct {
+		Key   string  `json:"key"`
+		Type  string  `json:"type"`
+		Value *string `json:"value"`
+	} `json:"fields"`
+}
+
+// deletePayload is the payload of the delete mutations
+type deletePayload struct {
+	DeletedID           string      `json:"deletedId"`
+	DeletedDefinitionID string      `json:"deletedDefinitionId"`
+	UserErrors          []userError `json:"userErrors"`
+}
+
+// toDomainValidations converts definition validations
+func toDomainValidations(nodes []validationNode) []domain.MetafieldValidation {
+	validations := make([]domain.MetafieldValidation, len(nodes))
+	for i, node := range nodes {
+		validations[i] = domain.MetafieldValidation{Name: node.Name, Value: node.Value}
+	}
+	return validations
+}
+
+// validationsInput builds the validations of a definition input
+func validationsInput(validations []domain.MetafieldValidation) []map[string]interface{} {
+	input := make([]map[string]interface{}, len(validations))
+	for i, validation := range validations {
+		input[i] = map[string]interface{}{"name": validation.Name, "value": validation.Value}
+	}
+	return input
+}
+
+// toDomainMetafieldDefinition converts a GraphQL Admin API metafield definition
+func toDomainMetafieldDefinition(node *metafieldDefinitionNode) *domain.MetafieldDefinition {
+	return &domain.MetafieldDefinition{
+		ID:          legacyID(node.ID),
+		OwnerType:   domain.MetafieldOwnerTypeFromGraphQL(node.OwnerType),
+		Namespace:   node.Namespace,
+		Key:         node.Key,
+		Name:        node.Name,
+		Description: node.Description,
+		Type:        node.Type.Name,
+		Validations: toDomainValidations(node.Validations),
+		Pinned:      node.PinnedPosition != nil,
+	}
+}
+
+// toDomainMetaobjectDefinition converts a GraphQL Admin API metaobject definition
+func toDomainMetaobjectDefinition(node *metaobjectDefinitionNode) *domain.MetaobjectDefinition {
+	definition := &domain.MetaobjectDefinition{
+		ID:               legacyID(node.ID),
+		Type:             node.Type,
+		Name:             node.Name,
+		Description:      node.Description,
+		DisplayNameKey:   node.DisplayNameKey,
+		MetaobjectsCount: node.MetaobjectsCount,
+		Fields:           make([]domain.MetaobjectFieldDefinition, len(node.FieldDefinitions)),
+	}
+	for i, field := range node.FieldDefinitions {
+		definition.Fields[i] = domain.MetaobjectFieldDefinition{
+			Key:         field.Key,
+			Name:        field.Name,
+			Description: field.Description,
+			Type:        field.Type.Name,
+			Required:    field.Required,
+			Validations: toDomainValidations(field.Validations),
+		}
+	}
+	return definition
+}
+
+// toDomainMetaobject converts a GraphQL Admin API metaobject
+func toDomainMetaobject(node *metaobjectNode) *domain.Metaobject {
+	metaobject := &domain.Metaobject{
+		ID:          legacyID(node.ID),
+		Type:        node.Type,
+		Handle:      node.Handle,
+		DisplayName: node.DisplayName,
+		UpdatedAt:   node.UpdatedAt,
+		Fields:      make([]domain.MetaobjectField, len(node.Fields)),
+	}
+	for i, field := range node.Fields {
+		metaobject.Fields[i] = domain.MetaobjectField{Key: field.Key, Type: field.Type, Value: field.Value}
+	}
+	return metaobject
+}
+
+// metaobjectFieldsInput builds the field values of a metaobject input
+func metaobjectFieldsInput(fields []domain.MetaobjectField) []map[string]interface{} {
+	input := make([]map[string]interface{}, len(fields))
+	for i, field := range fields {
+		value := ""
+		if field.Value != nil {
+			value = *field.Value
+		}
+		input[i] = map[string]interface{}{"key": field.Key, "value": value}
+	}
+	return input
+}
+
+// metaobjectFieldDefinitionInput builds a field of a metaobject definition input, without its type on update
+func metaobjectFieldDefinitionInput(field domain.MetaobjectFieldDefinition, update bool) map[string]interface{} {
+	input := map[string]interface{}{
+		"key":         field.Key,
+		"name":        field.Name,
+		"description": field.Description,
+		"required":    field.Required,
+		"validations": validationsInput(field.Validations),
+	}
+	if !update {
+		input["type"] = field.Type
+	}
+	return input
+}
+
+func (c *client) ListMetafieldDefinitions(ctx context.Context, shopDomain string, accessToken string, ownerType domain.MetafieldOwnerType, namespace string) ([]domain.MetafieldDefinition, error) {
+	var response struct {
+		MetafieldDefinitions struct {
+			Nodes []metafieldDefinitionNode `json:"nodes"`
+		} `json:"metafieldDefinitions"`
+	}
+	variables := map[string]interface{}{"ownerType": ownerType.GraphQLOwnerType()}
+	if namespace != "" {
+		variables["namespace"] = namespace
+	}
+	if err := c.adminGraphQL(ctx, shopDomain, accessToken, metafieldDefinitionsQuery, variables, &response, "failed to list metafield definitions"); err != nil {
+		return nil, err
+	}
+	definitions := make([]domain.MetafieldDefinition, len(response.MetafieldDefinitions.Nodes))
+	for i := range response.MetafieldDefinitions.Nodes {
+		definitions[i] = *toDomainMetafieldDefinition(&response.MetafieldDefinitions.Nodes[i])
+	}
+	return definitions, nil
+}
+
+func (c *client) CreateMetafieldDefinition(ctx context.Context, shopDomain string, accessToken string, definition domain.MetafieldDefinition) (*domain.MetafieldDefinition, error) {
+	var response struct {
+		Payload struct {
+			CreatedDefinition *metafieldDefinitionNode `json:"createdDefinition"`
+			UserErrors        []userError              `json:"userErrors"`
+		} `json:"metafieldDefinitionCreate"`
+	}
+	variables := map[string]interface{}{
+		"definition": map[string]interface{}{
+			"ownerType":   definition.OwnerType.GraphQLOwnerType(),
+			"namespace":   definition.Namespace,
+			"key":         definition.Key,
+			"name":        definition.Name,
+			"description": definition.Description,
+			"type":        definition.Type,
+			"validations": validationsInput(definition.Validations),
+			"pin":         definition.Pinned,
+		},
+	}
+	if err := c.adminGraphQL(ctx, shopDomain, accessToken, metafieldDefinitionCreateMutation, variables, &response, "failed to create metafield definition"); err != nil {
+		return nil, err
+	}
+	if err := userErrorsToError("failed to create metafield definition", response.Payload.UserErrors); err != nil {
+		return nil, err
+	}
+	if response.Payload.CreatedDefinition == nil {
+		return nil, domain.NewShopifyAPIError("failed to create metafield definition: Shopify returned no definition", nil)
+	}
+	return toDomainMetafieldDefinition(response.Payload.CreatedDefinition), nil
+}
+
+func (c *client) UpdateMetafieldDefinition(ctx context.Context, shopDomain string, accessToken string, definition domain.MetafieldDefinition) (*domain.MetafieldDefinition, error) {
+	var response struct {
+		Payload struct {
+			UpdatedDefinition *metafieldDefinitionNode `json:"updatedDefinition"`
+			UserErrors        []userError              `json:"userErrors"`
+		} `json:"metafieldDefinitionUpdate"`
+	}
+	variables := map[string]interface{}{
+		"definition": map[string]interface{}{
+			"ownerType":   definition.OwnerType.GraphQLOwnerType(),
+			"namespace":   definition.Namespace,
+			"key":         definition.Key,
+			"name":        definition.Name,
+			"description": definition.Description,
+			"validations": validationsInput(definition.Validations),
+		},
+	}
+	if err := c.adminGraphQL(ctx, shopDomain, accessToken, metafieldDefinitionUpdateMutation, variables, &response, "failed to update metafield definition"); err != nil {
+		return nil, err
+	}
+	if err := userErrorsToError("failed to update metafield definition", response.Payload.UserErrors); err != nil {
+		return nil, err
+	}
+	if response.Payload.UpdatedDefinition == nil {
+		return nil, domain.NewNotFoundError(fmt.Sprintf("metafield definition %s.%s", definition.Namespace, definition.Key))
+	}
+	return toDomainMetafieldDefinition(response.Payload.UpdatedDefinition), nil
+}
+
+func (c *client) DeleteMetafieldDefinition(ctx context.Context, shopDomain string, accessToken string, definitionID int64, deleteMetafields bool) error {
+	var response struct {
+		Payload deletePayload `json:"metafieldDefinitionDelete"`
+	}
+	variables := map[string]interface{}{"id": globalID("MetafieldDefinition", definitionID), "deleteMetafields": deleteMetafields}
+	if err := c.adminGraphQL(ctx, shopDomain, accessToken, metafieldDefinitionDeleteMutation, variables, &response, "failed to delete metafield definition"); err != nil {
+		return err
+	}
+	return userErrorsToError("failed to delete metafield definition", response.Payload.UserErrors)
+}
+
+func (c *client) ListMetaobjectDefinitions(ctx context.Context, shopDomain string, accessToken string) ([]domain.MetaobjectDefinition, error) {
+	var response struct {
+		MetaobjectDefinitions struct {
+			Nodes []metaobjectDefinitionNode `json:"nodes"`
+		} `json:"metaobjectDefinitions"`
+	}
+	if err := c.adminGraphQL(ctx, shopDomain, accessToken, metaobjectDefinitionsQuery, nil, &response, "failed to list metaobject definitions"); err != nil {
+		return nil, err
+	}
+	definitions := make([]domain.MetaobjectDefinition, len(response.MetaobjectDefinitions.Nodes))
+	for i := range response.MetaobjectDefinitions.Nodes {
+		definitions[i] = *toDomainMetaobjectDefinition(&response.MetaobjectDefinitions.Nodes[i])
+	}
+	return definitions, nil
+}
+
+func (c *client) GetMetaobjectDefinitionByType(ctx context.Context, shopDomain string, accessToken string, objectType string) (*domain.MetaobjectDefinition, error) {
+	var response struct {
+		Definition *metaobjectDefinitionNode `json:"metaobjectDefinitionByType"`
+	}
+	variables := map[string]interface{}{"type": objectType}
+	if err := c.adminGraphQL(ctx, shopDomain, accessToken, metaobjectDefinitionByTypeQuery, variables, &response, "failed to get metaobject definition"); err != nil {
+		return nil, err
+	}
+	if response.Definition == nil {
+		return nil, domain.NewNotFoundError(fmt.Sprintf("metaobject definition %s", objectType))
+	}
+	return toDomainMetaobjectDefinition(response.Definition), nil
+}
+
+func (c *client) CreateMetaobjectDefinition(ctx context.Context, shopDomain string, accessToken string, definition domain.MetaobjectDefinition) (*domain.MetaobjectDefinition, error) {
+	var response struct {
+		Payload struct {
+			Definition *metaobjectDefinitionNode `json:"metaobjectDefinition"`
+			UserErrors []userError               `json:"userErrors"`
+		} `json:"metaobjectDefinitionCreate"`
+	}
+	fields := make([]map[string]interface{}, len(definition.Fields))
+	for i, field := range definition.Fields {
+		fields[i] = metaobjectFieldDefinitionInput(field, false)
+	}
+	input := map[string]interface{}{
+		"type":             definition.Type,
+		"name":             definition.Name,
+		"description":      definition.Description,
+		"fieldDefinitions": fields,
+	}
+	if definition.DisplayNameKey != "" {
+		input["displayNameKey"] = definition.DisplayNameKey
+	}
+	variables := map[string]interface{}{"definition": input}
+	if err := c.adminGraphQL(ctx, shopDomain, accessToken, metaobjectDefinitionCreateMutation, variables, &response, "failed to create metaobject definition"); err != nil {
+		return nil, err
+	}
+	if err := userErrorsToError("failed to create metaobject definition", response.Payload.UserErrors); err != nil {
+		return nil, err
+	}
+	if response.Payload.Definition == nil {
+		return nil, domain.NewShopifyAPIError("failed to create metaobject definition: Shopify returned no definition", nil)
+	}
+	return toDomainMetaobjectDefinition(response.Payload.Definition), nil
+}
+
+func (c *client) UpdateMetaobjectDefinition(ctx context.Context, shopDomain string, accessToken string, definitionID int64, update domain.MetaobjectDefinitionUpdate) (*domain.MetaobjectDefinition, error) {
+	id := globalID("MetaobjectDefinition", definitionID)
+
+	// Field operations differ for new and existing fields, so the current fields are read first
+	var current struct {
+		Definition *metaobjectDefinitionNode `json:"metaobjectDefinition"`
+	}
+	if err := c.adminGraphQL(ctx, shopDomain, accessToken, metaobjectDefinitionQuery, map[string]interface{}{"id": id}, &current, "failed to get metaobject definition"); err != nil {
+		return nil, err
+	}
+	if current.Definition == nil {
+		return nil, domain.NewNotFoundError(fmt.Sprintf("metaobject definition %d", definitionID))
+	}
+	existing := make(map[string]bool, len(current.Definition.FieldDefinitions))
+	for _, field := range current.Definition.FieldDefinitions {
+		existing[field.Key] = true
+	}
+
+	operations := make([]map[string]interface{}, 0, len(update.Fields)+len(update.DeleteFields))
+	for _, field := range update.Fields {
+		if existing[field.Key] {
+			operations = append(operations, map[string]interface{}{"update": metaobjectFieldDefinitionInput(field, true)})
+		} else {
+			operations = append(operations, map[string]interface{}{"create": metaobjectFieldDefinitionInput(field, false)})
+		}
+	}
+	for _, key := range update.DeleteFields {
+		operations = append(operations, map[string]interface{}{"delete": map[string]interface{}{"key": key}})
+	}
+
+	input := map[string]interface{}{"fieldDefinitions": operations}
+	if update.Name != nil {
+		input["name"] = *update.Name
+	}
+	if update.Description != nil {
+		input["description"] = *update.Description
+	}
+	if update.DisplayNameKey != nil {
+		input["displayNameKey"] = *update.DisplayNameKey
+	}
+
+	var response struct {
+		Payload struct {
+			Definition *metaobjectDefinitionNode `json:"metaobjectDefinition"`
+			UserErrors []userError               `json:"userErrors"`
+		} `json:"metaobjectDefinitionUpdate"`
+	}
+	variables := map[string]interface{}{"id": id, "definition": input}
+	if err := c.adminGraphQL(ctx, shopDomain, accessToken, metaobjectDefinitionUpdateMutation, variables, &response, "failed to update metaobject definition"); err != nil {
+		return nil, err
+	}
+	if err := userErrorsToError("failed to update metaobject definition", response.Payload.UserErrors); err != nil {
+		return nil, err
+	}
+	if response.Payload.Definition == nil {
+		return nil, domain.NewShopifyAPIError("failed to update metaobject definition: Shopify returned no definition", nil)
+	}
+	return toDomainMetaobjectDefinition(response.Payload.Definition), nil
+}
+
+func (c *client) DeleteMetaobjectDefinition(ctx context.Context, shopDomain string, accessToken string, definitionID int64) error {
+	var response struct {
+		Payload deletePayload `json:"metaobjectDefinitionDelete"`
+	}
+	variables := map[string]interface{}{"id": globalID("MetaobjectDefinition", definitionID)}
+	if err := c.adminGraphQL(ctx, shopDomain, accessToken, metaobjectDefinitionDeleteMutation, variables, &response, "failed to delete metaobject definition"); err != nil {
+		return err
+	}
+	return userErrorsToError("failed to delete metaobject definition", response.Payload.UserErrors)
+}
+
+func (c *client) ListMetaobjects(ctx context.Context, shopDomain string, accessToken string, objectType string, first int, after string, before string) (*domain.MetaobjectPage, error) {
+	var response struct {
+		Metaobjects struct {
+			Edges []struct {
+				Cursor string         `json:"cursor"`
+				Node   metaobjectNode `json:"node"`
+			} `json:"edges"`
+			PageInfo struct {
+				HasNextPage     bool   `json:"hasNextPage"`
+				HasPreviousPage bool   `json:"hasPreviousPage"`
+				StartCursor     string `json:"startCursor"`
+				EndCursor       string `json:"endCursor"`
+			} `json:"pageInfo"`
+		} `json:"metaobjects"`
+	}
+	variables := map[string]interface{}{"type": objectType}
+	if before != "" {
+		variables["last"] = first
+		variables["before"] = before
+	} else {
+		variables["first"] = first
+		if after != "" {
+			variables["after"] = after
+		}
+	}
+	if err := c.adminGraphQL(ctx, shopDomain, accessToken, metaobjectsQuery, variables, &response, "failed to list metaobjects"); err != nil {
+		return nil, err
+	}
+	page := &domain.MetaobjectPage{
+		Metaobjects:     make([]domain.Metaobject, len(response.Metaobjects.Edges)),
+		Cursors:         make([]string, len(response.Metaobjects.Edges)),
+		StartCursor:     response.Metaobjects.PageInfo.StartCursor,
+		EndCursor:       response.Metaobjects.PageInfo.EndCursor,
+		HasNextPage:     response.Metaobjects.PageInfo.HasNextPage,
+		HasPreviousPage: response.Metaobjects.PageInfo.HasPreviousPage,
+	}
+	for i := range response.Metaobjects.Edges {
+		page.Metaobjects[i] = *toDomainMetaobject(&response.Metaobjects.Edges[i].Node)
+		page.Cursors[i] = response.Metaobjects.Edges[i].Cursor
+	}
+	return page, nil
+}
+
+func (c *client) GetMetaobject(ctx context.Context, shopDomain string, accessToken string, metaobjectID int64) (*domain.Metaobject, error) {
+	var response struct {
+		Metaobject *metaobjectNode `json:"metaobject"`
+	}
+	variables := map[string]interface{}{"id": globalID("Metaobject", metaobjectID)}
+	if err := c.adminGraphQL(ctx, shopDomain, accessToken, metaobjectQuery, variables, &response, "failed to get metaobject"); err != nil {
+		return nil, err
+	}
+	if response.Metaobject == nil {
+		return nil, domain.NewNotFoundError(fmt.Sprintf("metaobject %d", metaobjectID))
+	}
+	return toDomainMetaobject(response.Metaobject), nil
+}
+
+func (c *client) GetMetaobjectByHandle(ctx context.Context, shopDomain string, accessToken string, objectType string, handle string) (*domain.Metaobject, error) {
+	var response struct {
+		Metaobject *metaobjectNode `json:"metaobjectByHandle"`
+	}
+	variables := map[string]interface{}{"handle": map[string]interface{}{"type": objectType, "handle": handle}}
+	if err := c.adminGraphQL(ctx, shopDomain, accessToken, metaobjectByHandleQuery, variables, &response, "failed to get metaobject"); err != nil {
+		return nil, err
+	}
+	if response.Metaobject == nil {
+		return nil, domain.NewNotFoundError(fmt.Sprintf("metaobject %s/%s", objectType, handle))
+	}
+	return toDomainMetaobject(response.Metaobject), nil
+}
+
+func (c *client) CreateMetaobject(ctx context.Context, shopDomain string, accessToken string, metaobject domain.Metaobject) (*domain.Metaobject, error) {
+	var response struct {
+		Payload struct {
+			Metaobject *metaobjectNode `json:"metaobject"`
+			UserErrors []userError     `json:"userErrors"`
+		} `json:"metaobjectCreate"`
+	}
+	input := map[string]interface{}{"type": metaobject.Type, "fields": metaobjectFieldsInput(metaobject.Fields)}
+	if metaobject.Handle != "" {
+		input["handle"] = metaobject.Handle
+	}
+	variables := map[string]interface{}{"metaobject": input}
+	if err := c.adminGraphQL(ctx, shopDomain, accessToken, metaobjectCreateMutation, variables, &response, "failed to create metaobject"); err != nil {
+		return nil, err
+	}
+	if err := userErrorsToError("failed to create metaobject", response.Payload.UserErrors); err != nil {
+		return nil, err
+	}
+	if response.Payload.Metaobject == nil {
+		return nil, domain.NewShopifyAPIError("failed to create metaobject: Shopify returned no metaobject", nil)
+	}
+	return toDomainMetaobject(response.Payload.Metaobject), nil
+}
+
+func (c *client) UpdateMetaobject(ctx context.Context, shopDomain string, accessToken string, metaobject domain.Metaobject) (*domain.Metaobject, error) {
+	var response struct {
+		Payload struct {
+			Metaobject *metaobjectNode `json:"metaobject"`
+			UserErrors []userError     `json:"userErrors"`
+		} `json:"metaobjectUpdate"`
+	}
+	input := map[string]interface{}{"fields": metaobjectFieldsInput(metaobject.Fields)}
+	if metaobject.Handle != "" {
+		input["handle"] = metaobject.Handle
+	}
+	variables := map[string]interface{}{"id": globalID("Metaobject", int64(metaobject.ID)), "metaobject": input}
+	if err := c.adminGraphQL(ctx, shopDomain, accessToken, metaobjectUpdateMutation, variables, &response, "failed to update metaobject"); err != nil {
+		return nil, err
+	}
+	if err := userErrorsToError("failed to update metaobject", response.Payload.UserErrors); err != nil {
+		return nil, err
+	}
+	if response.Payload.Metaobject == nil {
+		return nil, domain.NewNotFoundError(fmt.Sprintf("metaobject %d", metaobject.ID))
+	}
+	return toDomainMetaobject(response.Payload.Metaobject), nil
+}
+
+func (c *client) DeleteMetaobject(ctx context.Context, shopDomain string, accessToken string, metaobjectID int64) error {
+	var response struct {
+		Payload deletePayload `json:"metaobjectDelete"`
+	}
+	variables := map[string]interface{}{"id": globalID("Metaobject", metaobjectID)}
+	if err := c.adminGraphQL(ctx, shopDomain, accessToken, metaobjectDeleteMutation, variables, &response, "failed to delete metaobject"); err != nil {
+		return err
+	}
+	return userErrorsToError("failed to delete metaobject", response.Payload.UserErrors)
+}
@@ -0,0 +1,359 @@
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"archie-core-shopify-layer/internal/domain"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+)
+
+// Returns only exist in Shopify's GraphQL Admin API, so these methods query it instead of the REST API
+
+const returnFields = `
+fragment ReturnFields on Return {
+  id
+  name
+  status
+  totalQuantity
+  order { id }
+  returnLineItems(first: 250) {
+    nodes {
+      ... on ReturnLineItem {
+        id
+        quantity
+        returnReason
+        returnReasonNote
+        fulfillmentLineItem { id lineItem { id } }
+      }
+    }
+  }
+}`
+
+const orderReturnsQuery = `
+query OrderReturns($id: ID!) {
+  order(id: $id) {
+    returns(first: 250) { nodes { ...ReturnFields } }
+  }
+}` + returnFields
+
+const orderFulfillmentLineItemsQuery = `
+query OrderFulfillmentLineItems($id: ID!) {
+  order(id: $id) {
+    fulfillments(first: 250) {
+      fulfillmentLineItems(first: 250) {
+        nodes { id quantity lineItem { id } }
+      }
+    }
+  }
+}`
+
+const returnCreateMutation = `
+mutation ReturnCreate($input: ReturnInput!) {
+  returnCreate(returnInput: $input) {
+    return { ...ReturnFields }
+    userErrors { field message }
+  }
+}` + returnFields
+
+const returnApproveRequestMutation = `
+mutation ReturnApproveRequest($id: ID!) {
+  returnApproveRequest(input: {id: $id}) {
+    return { ...ReturnFields }
+    userErrors { field message }
+  }
+}` + returnFields
+
+const returnDeclineRequestMutation = `
+mutation ReturnDeclineRequest($id: ID!, $reason: ReturnDeclineReason!) {
+  returnDeclineRequest(input: {id: $id, declineReason: $reason}) {
+    return { ...ReturnFields }
+    userErrors { field message }
+  }
+}` + returnFields
+
+const returnCloseMutation = `
+mutation ReturnClose($id: ID!) {
+  returnClose(id: $id) {
+    return { ...ReturnFields }
+    userErrors { field message }
+  }
+}` + returnFields
+
+const returnCancelMutation = `
+mutation ReturnCancel($id: ID!) {
+  returnCancel(id: $id) {
+    return { ...ReturnFields }
+    userErrors { field message }
+  }
+}` + returnFields
+
+// returnNode is a return as selected by ReturnFields
+type returnNode struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Status        string `json:"status"`
+	TotalQuantity int    `json:"totalQuantity"`
+	Order         struct {
+		ID string `json:"id"`
+	} `json:"order"`
+	ReturnLineItems struct {
+		Nodes []struct {
+			ID                  string `json:"id"`
+			Quantity            int    `json:"quantity"`
+			ReturnReason        string `json:"returnReason"`
+			ReturnReasonNote    string `json:"returnReasonNote"`
+			FulfillmentLineItem struct {
+				ID       string `json:"id"`
+				LineItem struct {
+					ID string `json:"id"`
+				} `json:"lineItem"`
+			} `json:"fulfillmentLineItem"`
+		} `json:"nodes"`
+	} `json:"returnLineItems"`
+}
+
+// returnPayload is the payload of the return mutations
+type returnPayload struct {
+	Return     *returnNode `json:"return"`
+	UserErrors []userError `json:"userErrors"`
+}
+
+// userError is a GraphQL Admin API mutation error caused by the input
+type userError struct {
+	Field   []string `json:"field"`
+	Message string   `json:"message"`
+}
+
+// globalID formats the GraphQL Admin API ID of a resource
+func globalID(resource string, id int64) string {
+	return fmt.Sprintf("gid://shopify/%s/%d", resource, id)
+}
+
+// legacyID extracts the numeric ID of a GraphQL Admin API ID, 0 when it has none
+func legacyID(gid string) uint64 {
+	id, _ := strconv.ParseUint(gid[strings.LastIndex(gid, "/")+1:], 10, 64)
+	return id
+}
+
+// userErrorsToError turns mutation user errors into a validation error, nil when there are none
+func userErrorsToError(message string, errors []userError) error {
+	if len(errors) == 0 {
+		return nil
+	}
+	appErr := domain.NewValidationError(fmt.Sprintf("%s: %s", message, errors[0].Message), nil)
+	for _, userErr := range errors {
+		appErr = appErr.WithFieldError(strings.Join(userErr.Field, "."), userErr.Message)
+	}
+	return appErr
+}
+
+// toDomainReturn converts a GraphQL Admin API return
+func toDomainReturn(node *returnNode) *domain.OrderReturn {
+	result := &domain.OrderReturn{
+		ID:            legacyID(node.ID),
+		OrderID:       legacyID(node.Order.ID),
+		Name:          node.Name,
+		Status:        domain.ReturnStatus(strings.ToLower(node.Status)),
+		TotalQuantity: node.TotalQuantity,
+		LineItems:     make([]domain.ReturnLineItem, 0, len(node.ReturnLineItems.Nodes)),
+	}
+	for _, item := range node.ReturnLineItems.Nodes {
+		if item.ID == "" {
+			// Line items of other kinds, such as unverified ones, have none of the selected fields
+			continue
+		}
+		result.LineItems = append(result.LineItems, domain.ReturnLineItem{
+			ID:                    legacyID(item.ID),
+			FulfillmentLineItemID: legacyID(item.FulfillmentLineItem.ID),
+			LineItemID:            legacyID(item.FulfillmentLineItem.LineItem.ID),
+			Quantity:              item.Quantity,
+			Reason:                domain.ReturnReason(strings.ToLower(item.ReturnReason)),
+			ReasonNote:            item.ReturnReasonNote,
+		})
+	}
+	return result
+}
+
+func (c *client) ListOrderReturns(ctx context.Context, shopDomain string, accessToken string, orderID int64) ([]domain.OrderReturn, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	var response struct {
+		Order *struct {
+			Returns struct {
+				Nodes []returnNode `json:"nodes"`
+			} `json:"returns"`
+		} `json:"order"`
+	}
+	variables := map[string]interface{}{"id": globalID("Order", orderID)}
+	if err := client.GraphQL.Query(ctx, orderReturnsQuery, variables, &response); err != nil {
+		return nil, wrapError(ctx, "failed to list order returns", err)
+	}
+	if response.Order == nil {
+		return nil, domain.NewNotFoundError(fmt.Sprintf("order %d", orderID))
+	}
+	returns := make([]domain.OrderReturn, len(response.Order.Returns.Nodes))
+	for i := range response.Order.Returns.Nodes {
+		returns[i] = *toDomainReturn(&response.Order.Returns.Nodes[i])
+	}
+	return returns, nil
+}
+
+// fulfillmentLineItem is a fulfilled quantity of an order line item
+type fulfillmentLineItem struct {
+	ID       string `json:"id"`
+	Quantity int    `json:"quantity"`
+	LineItem struct {
+		ID string `json:"id"`
+	} `json:"lineItem"`
+}
+
+// listFulfillmentLineItems retrieves the fulfilled quantities of the line items of an order
+func listFulfillmentLineItems(ctx context.Context, client *goshopify.Client, orderID uint64) ([]fulfillmentLineItem, error) {
+	var response struct {
+		Order *struct {
+			Fulfillments []struct {
+				FulfillmentLineItems struct {
+					Nodes []fulfillmentLineItem `json:"nodes"`
+				} `json:"fulfillmentLineItems"`
+			} `json:"fulfillments"`
+		} `json:"order"`
+	}
+	variables := map[string]interface{}{"id": globalID("Order", int64(orderID))}
+	if err := client.GraphQL.Query(ctx, orderFulfillmentLineItemsQuery, variables, &response); err != nil {
+		return nil, wrapError(ctx, "failed to list fulfillment line items", err)
+	}
+	if response.Order == nil {
+		return nil, domain.NewNotFoundError(fmt.Sprintf("order %d", orderID))
+	}
+	var items []fulfillmentLineItem
+	for _, fulfillment := range response.Order.Fulfillments {
+		items = append(items, fulfillment.FulfillmentLineItems.Nodes...)
+	}
+	return items, nil
+}
+
+func (c *client) CreateReturn(ctx context.Context, shopDomain string, accessToken string, request domain.ReturnRequest) (*domain.OrderReturn, error) {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Returns take fulfillment line items, so each line item quantity is taken from the fulfillments that shipped it
+	fulfilled, err := listFulfillmentLineItems(ctx, client, request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	lineItems := make([]map[string]interface{}, 0, len(request.LineItems))
+	for i, item := range request.LineItems {
+		remaining := item.Quantity
+		for j := range fulfilled {
+			if remaining == 0 {
+				break
+			}
+			if legacyID(fulfilled[j].LineItem.ID) != item.LineItemID || fulfilled[j].Quantity == 0 {
+				continue
+			}
+			quantity := min(remaining, fulfilled[j].Quantity)
+			fulfilled[j].Quantity -= quantity
+			remaining -= quantity
+			lineItems = append(lineItems, map[string]interface{}{
+				"fulfillmentLineItemId": fulfilled[j].ID,
+				"quantity":              quantity,
+				"returnReason":          strings.ToUpper(string(item.Reason)),
+				"returnReasonNote":      item.ReasonNote,
+			})
+		}
+		if remaining > 0 {
+			return nil, domain.NewValidationError(fmt.Sprintf("line item %d has fewer than %d fulfilled units to return", item.LineItemID, item.Quantity), nil).
+				WithFieldError(fmt.Sprintf("lineItems[%d].quantity", i), "exceeds the fulfilled quantity")
+		}
+	}
+
+	var response struct {
+		ReturnCreate returnPayload `json:"returnCreate"`
+	}
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"orderId":         globalID("Order", int64(request.OrderID)),
+			"returnLineItems": lineItems,
+			"notifyCustomer":  request.NotifyCustomer,
+		},
+	}
+	if err := client.GraphQL.Query(ctx, returnCreateMutation, variables, &response); err != nil {
+		return nil, wrapError(ctx, "failed to create return", err)
+	}
+	return returnPayloadResult("failed to create return", response.ReturnCreate)
+}
+
+func (c *client) ApproveReturnRequest(ctx context.Context, shopDomain string, accessToken string, returnID int64) (*domain.OrderReturn, error) {
+	var response struct {
+		Payload returnPayload `json:"returnApproveRequest"`
+	}
+	variables := map[string]interface{}{"id": globalID("Return", returnID)}
+	if err := c.adminGraphQL(ctx, shopDomain, accessToken, returnApproveRequestMutation, variables, &response, "failed to approve return request"); err != nil {
+		return nil, err
+	}
+	return returnPayloadResult("failed to approve return request", response.Payload)
+}
+
+func (c *client) DeclineReturnRequest(ctx context.Context, shopDomain string, accessToken string, returnID int64, reason domain.ReturnDeclineReason) (*domain.OrderReturn, error) {
+	var response struct {
+		Payload returnPayload `json:"returnDeclineRequest"`
+	}
+	variables := map[string]interface{}{"id": globalID("Return", returnID), "reason": strings.ToUpper(string(reason))}
+	if err := c.adminGraphQL(ctx, shopDomain, accessToken, returnDeclineRequestMutation, variables, &response, "failed to decline return request"); err != nil {
+		return nil, err
+	}
+	return returnPayloadResult("failed to decline return request", response.Payload)
+}
+
+func (c *client) CloseReturn(ctx context.Context, shopDomain string, accessToken string, returnID int64) (*domain.OrderReturn, error) {
+	var response struct {
+		Payload returnPayload `json:"returnClose"`
+	}
+	variables := map[string]interface{}{"id": globalID("Return", returnID)}
+	if err := c.adminGraphQL(ctx, shopDomain, accessToken, returnCloseMutation, variables, &response, "failed to close return"); err != nil {
+		return nil, err
+	}
+	return returnPayloadResult("failed to close return", response.Payload)
+}
+
+func (c *client) CancelReturn(ctx context.Context, shopDomain string, accessToken string, returnID int64) (*domain.OrderReturn, error) {
+	var response struct {
+		Payload returnPayload `json:"returnCancel"`
+	}
+	variables := map[string]interface{}{"id": globalID("Return", returnID)}
+	if err := c.adminGraphQL(ctx, shopDomain, accessToken, returnCancelMutation, variables, &response, "failed to cancel return"); err != nil {
+		return nil, err
+	}
+	return returnPayloadResult("failed to cancel return", response.Payload)
+}
+
+// adminGraphQL runs a query or mutation of the GraphQL Admin API
+func (c *client) adminGraphQL(ctx context.Context, shopDomain string, accessToken string, mutation string, variables map[string]interface{}, response interface{}, message string) error {
+	ctx, client, err := c.createClient(ctx, shopDomain, accessToken)
+	if err != nil {
+		return err
+	}
+	if err := client.GraphQL.Query(ctx, mutation, variables, response); err != nil {
+		return wrapError(ctx, message, err)
+	}
+	return nil
+}
+
+// returnPayloadResult returns the return of a mutation payload, or its user errors
+func returnPayloadResult(message string, payload returnPayload) (*domain.OrderReturn, error) {
+	if err := userErrorsToError(message, payload.UserErrors); err != nil {
+		return nil, err
+	}
+	if payload.Return == nil {
+		return nil, domain.NewShopifyAPIError(message+": Shopify returned no return", nil)
+	}
+	return toDomainReturn(payload.Return), nil
+}
@@ -0,0 +1,107 @@
+package shopify
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"archie-core-shopify-layer/internal/domain"
+)
+
+// sessionTokenLeeway tolerates small clock skew between Shopify and this service
+const sessionTokenLeeway = 5 * time.Second
+
+// shopHostPattern matches the myshopify.com host of a shop, without a port
+var shopHostPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*\.myshopify\.com$`)
+
+// ParseSessionTokenUnverified decodes the claims of a session token without checking its signature
+// It is only used to find the app (aud) whose secret verifies the token
+func ParseSessionTokenUnverified(token string) (*domain.SessionTokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed session token")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode session token payload: %w", err)
+	}
+
+	var claims domain.SessionTokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("failed to parse session token claims: %w", err)
+	}
+
+	return &claims, nil
+}
+
+// VerifySessionToken validates an App Bridge session token signed with the app secret
+// It checks the HS256 signature, aud against the API key, the dest/iss shop and the exp/nbf window
+func VerifySessionToken(token string, apiKey string, apiSecret string, now time.Time) (*domain.SessionTokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed session token")
+	}
+
+	// Verify header algorithm
+	headerBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode session token header: %w", err)
+	}
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := json.Unmarshal(headerBytes, &header); err != nil {
+		return nil, fmt.Errorf("failed to parse session token header: %w", err)
+	}
+	if header.Alg != "HS256" {
+		return nil, fmt.Errorf("unsupported session token algorithm: %s", header.Alg)
+	}
+
+	// Verify signature
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode session token signature: %w", err)
+	}
+	mac := hmac.New(sha256.New, []byte(apiSecret))
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, fmt.Errorf("session token signature verification failed")
+	}
+
+	claims, err := ParseSessionTokenUnverified(token)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify audience
+	if claims.Aud != apiKey {
+		return nil, fmt.Errorf("session token audience does not match API key")
+	}
+
+	// Verify validity window
+	if claims.Exp == 0 || now.After(time.Unix(claims.Exp, 0).Add(sessionTokenLeeway)) {
+		return nil, fmt.Errorf("session token has expired")
+	}
+	if claims.Nbf != 0 && now.Add(sessionTokenLeeway).Before(time.Unix(claims.Nbf, 0)) {
+		return nil, fmt.Errorf("session token is not yet valid")
+	}
+
+	// Verify dest is a shop and iss belongs to the same shop
+	// The dest host becomes the shop domain of the token exchange, so it must be a myshopify.com host
+	dest, err := url.Parse(claims.Dest)
+	if err != nil || dest.Scheme != "https" || !shopHostPattern.MatchString(dest.Host) {
+		return nil, fmt.Errorf("session token dest is not a valid shop URL")
+	}
+	if iss, err := url.Parse(claims.Iss); err != nil || iss.Host != dest.Host {
+		return nil, fmt.Errorf("session token issuer does not match dest")
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,87 @@
+package shopify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"archie-core-shopify-layer/internal/domain"
+	"archie-core-shopify-layer/internal/ports"
+
+	"github.com/rs/zerolog"
+)
+
+// storefrontThrottleStatus is the status Shopify answers when it rejects Storefront requests as abusive
+const storefrontThrottleStatus = 430
+
+type storefrontClient struct {
+	httpClient *http.Client
+	logger     zerolog.Logger
+}
+
+// NewStorefrontClient creates a Shopify Storefront GraphQL API adapter
+// Storefront requests are not cost-limited per shop like the Admin API, so they are sent without a throttle
+func NewStorefrontClient(logger zerolog.Logger) ports.ShopifyStorefrontClient {
+	return &storefrontClient{
+		httpClient: &http.Client{Timeout: requestTimeout},
+		logger:     logger,
+	}
+}
+
+// storefrontEndpoint returns the Storefront GraphQL URL of a shop
+func storefrontEndpoint(shopDomain string) string {
+	return fmt.Sprintf("https://%s/api/%s/graphql.json", shopDomain, domain.ShopifyAPIVersion)
+}
+
+// Execute sends a request once with a public Storefront access token
+func (c *storefrontClient) Execute(ctx context.Context, shopDomain string, storefrontToken string, request domain.GraphQLRequest) (*domain.GraphQLResponse, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode Storefront request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, storefrontEndpoint(shopDomain), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Storefront request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Shopify-Storefront-Access-Token", storefrontToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, domain.NewShopifyAPIError("failed to call Shopify Storefront API", err)
+	}
+	defer resp.Body.Close()
+	requestID := resp.Header.Get("X-Request-Id")
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, domain.NewShopifyAPIError("failed to read Shopify Storefront response", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var appErr *domain.AppError
+		if resp.StatusCode == storefrontThrottleStatus {
+			appErr = domain.NewRateLimitError(1)
+			appErr.Message = "Shopify Storefront API rejected the request"
+		} else {
+			appErr = graphQLStatusError(resp, respBody)
+		}
+		c.logger.Warn().Str("shop", shopDomain).Int("status", resp.StatusCode).Msg("Shopify Storefront API request failed")
+		return nil, appErr.WithRequestID(requestID)
+	}
+
+	response := &domain.GraphQLResponse{}
+	if err := json.Unmarshal(respBody, response); err != nil {
+		appErr := domain.NewShopifyAPIError("invalid response from Shopify Storefront API", err)
+		return nil, appErr.WithRequestID(requestID)
+	}
+	return response, nil
+}
@@ -2,29 +2,193 @@ package shopify
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
+	"archie-core-shopify-layer/internal/domain"
+	"archie-core-shopify-layer/internal/infrastructure/metrics"
 	"archie-core-shopify-layer/internal/ports"
+
 	"github.com/rs/zerolog"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// tokenRefreshLockTTL bounds how long a replica may hold a shop's refresh lease
+	tokenRefreshLockTTL = 30 * time.Second
+	// tokenRefreshPollInterval is how often a replica waiting on another replica's refresh re-reads the shop
+	tokenRefreshPollInterval = 250 * time.Millisecond
+	// tokenRefreshTimeout bounds a shared refresh, within the refresh lease
+	tokenRefreshTimeout = 20 * time.Second
 )
 
 // TokenManager manages Shopify access tokens with refresh capabilities
+// Expiring offline tokens are refreshed proactively, single-flight per shop within a process
+// and coordinated across replicas through a lease stored on the shop document
 type TokenManager struct {
 	encryptionSvc ports.EncryptionService
+	repository    ports.Repository
 	logger        zerolog.Logger
+	refreshWindow time.Duration
+	owner         string
+	group         singleflight.Group
 }
 
 // NewTokenManager creates a new token manager
-func NewTokenManager(encryptionSvc ports.EncryptionService, logger zerolog.Logger) *TokenManager {
+func NewTokenManager(encryptionSvc ports.EncryptionService, repository ports.Repository, logger zerolog.Logger) *TokenManager {
 	return &TokenManager{
 		encryptionSvc: encryptionSvc,
+		repository:    repository,
 		logger:        logger,
+		refreshWindow: domain.DefaultTokenRefreshWindow,
+		owner:         newLockOwner(),
+	}
+}
+
+// newLockOwner generates a random identifier for this process's refresh leases
+func newLockOwner() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("owner-%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// GetAccessToken returns the decrypted access token for the shop, refreshing it first if it is about to expire
+func (tm *TokenManager) GetAccessToken(ctx context.Context, shop *domain.Shop, client ports.ShopifyClient) (string, error) {
+	if shop == nil || shop.AccessToken == "" {
+		return "", fmt.Errorf("shop has no access token")
+	}
+
+	if !shop.NeedsTokenRefresh(time.Now(), tm.refreshWindow) {
+		return tm.DecryptToken(shop.AccessToken)
+	}
+
+	// Collapse concurrent refreshes for the same shop in this process
+	// The refresh is shared by every waiting caller, so it runs detached from the first caller's cancellation with
+	// its own timeout; each caller still stops waiting when its own context ends
+	key := shop.Key()
+	results := tm.group.DoChan(key.String(), func() (interface{}, error) {
+		refreshCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), tokenRefreshTimeout)
+		defer cancel()
+		return tm.refresh(refreshCtx, key, client)
+	})
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case result := <-results:
+		if result.Err != nil {
+			return "", result.Err
+		}
+		return result.Val.(string), nil
 	}
 }
 
+// refresh refreshes the shop's token, or waits for another replica that is already refreshing it
+func (tm *TokenManager) refresh(ctx context.Context, key domain.ShopKey, client ports.ShopifyClient) (string, error) {
+	for {
+		// Re-read the shop, another replica may have refreshed it already
+		shop, err := tm.repository.GetShop(ctx, key)
+		if err != nil {
+			return "", fmt.Errorf("failed to get shop: %w", err)
+		}
+		if shop == nil {
+			return "", fmt.Errorf("shop not found: %s", key.Domain)
+		}
+		if !shop.NeedsTokenRefresh(time.Now(), tm.refreshWindow) {
+			return tm.DecryptToken(shop.AccessToken)
+		}
+
+		acquired, err := tm.repository.AcquireTokenRefreshLock(ctx, key, tm.owner, tokenRefreshLockTTL)
+		if err != nil {
+			return "", err
+		}
+		if acquired {
+			return tm.refreshLocked(ctx, shop, client)
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(tokenRefreshPollInterval):
+		}
+	}
+}
+
+// refreshLocked performs the refresh grant while holding the shop's refresh lease
+func (tm *TokenManager) refreshLocked(ctx context.Context, shop *domain.Shop, client ports.ShopifyClient) (string, error) {
+	defer func() {
+		if err := tm.repository.ReleaseTokenRefreshLock(context.WithoutCancel(ctx), shop.Key(), tm.owner); err != nil {
+			tm.logger.Warn().Err(err).Str("shop", shop.Domain).Msg("Failed to release token refresh lock")
+		}
+	}()
+
+	refreshToken, err := tm.DecryptToken(shop.RefreshToken)
+	if err != nil {
+		return tm.refreshFailed(ctx, shop, fmt.Errorf("failed to decrypt refresh token: %w", err))
+	}
+
+	token, err := client.RefreshAccessToken(ctx, shop.Domain, refreshToken)
+	if err != nil {
+		return tm.refreshFailed(ctx, shop, err)
+	}
+
+	encryptedAccess, err := tm.EncryptToken(token.AccessToken)
+	if err != nil {
+		return tm.refreshFailed(ctx, shop, fmt.Errorf("failed to encrypt access token: %w", err))
+	}
+
+	// Shopify rotates the refresh token on every refresh, keep the old one if none was returned
+	encryptedRefresh := shop.RefreshToken
+	if token.RefreshToken != "" {
+		encryptedRefresh, err = tm.EncryptToken(token.RefreshToken)
+		if err != nil {
+			return tm.refreshFailed(ctx, shop, fmt.Errorf("failed to encrypt refresh token: %w", err))
+		}
+	}
+
+	now := time.Now()
+	refreshExpiresAt := shop.RefreshTokenExpiresAt
+	shop.ApplyToken(token, encryptedAccess, encryptedRefresh, now)
+	if shop.RefreshTokenExpiresAt == nil {
+		shop.RefreshTokenExpiresAt = refreshExpiresAt
+	}
+	shop.TokenRefreshedAt = &now
+
+	if err := tm.repository.SaveShop(ctx, shop); err != nil {
+		metrics.TokenRefreshesTotal.WithLabelValues("failed").Inc()
+		return "", fmt.Errorf("failed to save refreshed token: %w", err)
+	}
+
+	metrics.TokenRefreshesTotal.WithLabelValues("success").Inc()
+	tm.logger.Info().
+		Str("shop", shop.Domain).
+		Time("expires_at", *shop.AccessTokenExpiresAt).
+		Msg("Refreshed expiring access token")
+
+	return token.AccessToken, nil
+}
+
+// refreshFailed records a refresh failure on the shop and falls back to the current token while it is still valid
+func (tm *TokenManager) refreshFailed(ctx context.Context, shop *domain.Shop, refreshErr error) (string, error) {
+	metrics.TokenRefreshesTotal.WithLabelValues("failed").Inc()
+	tm.logger.Error().Err(refreshErr).Str("shop", shop.Domain).Msg("Failed to refresh expiring access token")
+
+	shop.TokenRefreshError = refreshErr.Error()
+	if err := tm.repository.SaveShop(context.WithoutCancel(ctx), shop); err != nil {
+		tm.logger.Warn().Err(err).Str("shop", shop.Domain).Msg("Failed to record token refresh error")
+	}
+
+	if shop.AccessTokenExpiresAt != nil && time.Now().Before(*shop.AccessTokenExpiresAt) {
+		return tm.DecryptToken(shop.AccessToken)
+	}
+	return "", fmt.Errorf("access token expired and refresh failed: %w", refreshErr)
+}
+
 // EncryptToken encrypts an access token before storage
 func (tm *TokenManager) EncryptToken(token string) (string, error) {
 	if token == "" {
@@ -43,15 +207,15 @@ func (tm *TokenManager) DecryptToken(encryptedToken string) (string, error) {
 
 // TokenInfo represents token metadata
 type TokenInfo struct {
-	Token       string
-	ExpiresAt   *time.Time
-	Scopes      []string
-	ShopDomain  string
-	LastUsed    time.Time
+	Token      string
+	ExpiresAt  *time.Time
+	Scopes     []string
+	ShopDomain string
+	LastUsed   time.Time
 }
 
 // ValidateToken checks if a token is still valid by making a lightweight API call to Shopify
-// Note: Shopify access tokens don't expire unless revoked, but we can check if they're still valid
+// Note: Legacy offline tokens don't expire unless revoked, but we can check if they're still valid
 // This method requires a ShopifyClient to make the validation API call
 func (tm *TokenManager) ValidateToken(ctx context.Context, client ports.ShopifyClient, token string, shopDomain string) (bool, error) {
 	if token == "" {
@@ -119,7 +283,7 @@ func (tm *TokenManager) ValidateTokenWithHTTP(ctx context.Context, token string,
 
 	// Make a lightweight API call to Shopify Admin API
 	// Using the shop.json endpoint as it's the simplest
-	url := fmt.Sprintf("%s/admin/api/2024-01/shop.json", shopURL)
+	url := fmt.Sprintf("%s/admin/api/%s/shop.json", shopURL, domain.ShopifyAPIVersion)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
@@ -171,16 +335,15 @@ func (tm *TokenManager) ValidateTokenWithHTTP(ctx context.Context, token string,
 }
 
 // ShouldRefresh checks if a token should be refreshed
-// Shopify tokens don't expire, but they can be revoked
+// Expiring offline tokens are refreshed inside the refresh window, legacy tokens can only be revoked
 func (tm *TokenManager) ShouldRefresh(tokenInfo *TokenInfo) bool {
-	// Shopify access tokens don't expire, but we can check:
-	// 1. If token hasn't been used in a long time (stale)
-	// 2. If we've received errors indicating token is invalid
-
-	if tokenInfo.ExpiresAt != nil && time.Now().After(*tokenInfo.ExpiresAt) {
+	// Expiring tokens are refreshed shortly before they expire
+	if tokenInfo.ExpiresAt != nil && time.Now().Add(tm.refreshWindow).After(*tokenInfo.ExpiresAt) {
 		return true
 	}
 
+	// Legacy tokens don't expire, but we treat tokens not used in a long time as stale
+
 	// Check if token is stale (not used in 30 days)
 	staleThreshold := 30 * 24 * time.Hour
 	if time.Since(tokenInfo.LastUsed) > staleThreshold {
@@ -189,4 +352,3 @@ func (tm *TokenManager) ShouldRefresh(tokenInfo *TokenInfo) bool {
 
 	return false
 }
-
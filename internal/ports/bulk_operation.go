@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"archie-core-shopify-layer/internal/domain"
+)
+
+// BulkOperationRepository defines the interface for bulk operation persistence
+type BulkOperationRepository interface {
+	// Get retrieves a bulk operation of a shop by Shopify ID, or nil if none exists
+	Get(ctx context.Context, key domain.ShopKey, operationID uint64) (*domain.BulkOperation, error)
+
+	// Save creates or updates a bulk operation keyed by shop and Shopify ID
+	Save(ctx context.Context, operation *domain.BulkOperation) error
+
+	// List retrieves the most recent bulk operations of a shop, newest first
+	List(ctx context.Context, key domain.ShopKey, limit int) ([]*domain.BulkOperation, error)
+}
+
+// ShopifyBulkFileClient transfers the files of bulk operations, which live outside the Admin API
+type ShopifyBulkFileClient interface {
+	// Upload sends a file to a staged upload target
+	Upload(ctx context.Context, target domain.StagedUploadTarget, filename string, content io.Reader) error
+	// StreamJSONL downloads a JSONL file and calls handle for each line, stopping at the first error it returns
+	StreamJSONL(ctx context.Context, url string, handle func(line json.RawMessage) error) error
+}
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+
+	"archie-core-shopify-layer/internal/domain"
+)
+
+// InstallationRepository defines the interface for installation state persistence
+type InstallationRepository interface {
+	// Get retrieves the installation of an app for a project/environment/shop, or nil if none exists
+	Get(ctx context.Context, key domain.ShopKey) (*domain.Installation, error)
+
+	// Save creates or updates an installation, including its transition history
+	Save(ctx context.Context, installation *domain.Installation) error
+
+	// List retrieves the installations of every app of a project/environment, optionally filtered by state
+	List(ctx context.Context, projectID, environment string, state *domain.InstallState) ([]*domain.Installation, error)
+
+	// ListEnvironments retrieves the environments of a project that have installations
+	ListEnvironments(ctx context.Context, projectID string) ([]string, error)
+}
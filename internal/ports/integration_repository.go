@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"archie-core-shopify-layer/internal/domain"
 )
@@ -11,13 +12,28 @@ type IntegrationRepository interface {
 	// Create creates a new integration and returns it
 	Create(ctx context.Context, integration *domain.Integration) error
 
-	// GetByKey retrieves an integration by its key
-	GetByKey(ctx context.Context, key string) (*domain.Integration, error)
+	// GetByID retrieves an integration by its ID
+	GetByID(ctx context.Context, id string) (*domain.Integration, error)
 
-	// GetByProjectAndShop retrieves an integration by project ID, environment, and shop domain
-	GetByProjectAndShop(ctx context.Context, projectID, environment, shopDomain string) (*domain.Integration, error)
+	// GetByKeyHash retrieves an integration by the hash of its key
+	GetByKeyHash(ctx context.Context, keyHash string) (*domain.Integration, error)
 
-	// Delete deletes an integration by key
-	Delete(ctx context.Context, key string) error
-}
+	// UpgradeLegacyKey replaces a plaintext key stored before keys were hashed with its hash
+	// Returns nil if no integration stores that plaintext key
+	UpgradeLegacyKey(ctx context.Context, key string, keyHash string, keyPrefix string) (*domain.Integration, error)
+
+	// GetByProjectAndShop retrieves the newest non-revoked integration by project ID, environment, shop domain and app handle
+	GetByProjectAndShop(ctx context.Context, projectID, environment, shopDomain, appHandle string) (*domain.Integration, error)
+
+	// List retrieves the integrations of a project environment, newest first
+	List(ctx context.Context, projectID, environment string) ([]*domain.Integration, error)
 
+	// Update saves the lifecycle fields of an integration (scopes, expiry, revocation, rotation)
+	Update(ctx context.Context, integration *domain.Integration) error
+
+	// TouchLastUsed records a successful authentication
+	TouchLastUsed(ctx context.Context, id string, at time.Time) error
+
+	// Delete deletes an integration by ID
+	Delete(ctx context.Context, id string) error
+}
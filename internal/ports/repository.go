@@ -2,13 +2,21 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"archie-core-shopify-layer/internal/domain"
 )
 
 // ShopifyConfigRepository defines the interface for Shopify configuration persistence
 type ShopifyConfigRepository interface {
+	// GetByTenantID retrieves the config of the app handle in context, or the default/only app when none is set
 	GetByTenantID(ctx context.Context, tenantID string) (*domain.ShopifyConfig, error)
+	// ListByTenantID retrieves the configs of every app in the project environment
+	ListByTenantID(ctx context.Context, tenantID string) ([]*domain.ShopifyConfig, error)
+	// ListByProject retrieves the configs of every app in every environment of a project
+	ListByProject(ctx context.Context, projectID string) ([]*domain.ShopifyConfig, error)
+	// GetByAPIKey finds the configuration that owns an app API key (used to verify session tokens), erroring when several do
+	GetByAPIKey(ctx context.Context, apiKey string) (*domain.ShopifyConfig, error)
 	Create(ctx context.Context, config *domain.ShopifyConfig) error
 	Update(ctx context.Context, tenantID string, config *domain.ShopifyConfig) error
 	Delete(ctx context.Context, tenantID string) error
@@ -17,18 +25,26 @@ type ShopifyConfigRepository interface {
 // WebhookSubscriptionRepository defines the interface for webhook subscription persistence
 type WebhookSubscriptionRepository interface {
 	SaveWebhookSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error
-	GetWebhookSubscription(ctx context.Context, projectID string, environment string, shopDomain string, topic string) (*domain.WebhookSubscription, error)
-	ListWebhookSubscriptions(ctx context.Context, projectID string, environment string, shopDomain string) ([]*domain.WebhookSubscription, error)
+	GetWebhookSubscription(ctx context.Context, projectID string, environment string, appHandle string, shopDomain string, topic string) (*domain.WebhookSubscription, error)
+	ListWebhookSubscriptions(ctx context.Context, projectID string, environment string, appHandle string, shopDomain string) ([]*domain.WebhookSubscription, error)
+	// ListEnvironmentWebhookSubscriptions retrieves the subscriptions of every shop in a project environment
+	ListEnvironmentWebhookSubscriptions(ctx context.Context, projectID string, environment string) ([]*domain.WebhookSubscription, error)
 	DeleteWebhookSubscription(ctx context.Context, subscriptionID string) error
 }
 
 // Repository defines the interface for persistence
 // This is kept for backward compatibility but ShopifyConfigRepository should be used instead
 type Repository interface {
-	// Shop operations
+	// Shop operations, scoped to a project environment
+	// SaveShop requires shop.ProjectID; the environment and app handle default like NewShopKey
 	SaveShop(ctx context.Context, shop *domain.Shop) error
-	GetShop(ctx context.Context, domain string) (*domain.Shop, error)
-	ListShops(ctx context.Context) ([]*domain.Shop, error)
+	GetShop(ctx context.Context, key domain.ShopKey) (*domain.Shop, error)
+	ListShops(ctx context.Context, projectID string, environment string) ([]*domain.Shop, error)
+
+	// Token refresh coordination across replicas
+	// AcquireTokenRefreshLock returns true if the caller now holds the refresh lease for the shop
+	AcquireTokenRefreshLock(ctx context.Context, key domain.ShopKey, owner string, ttl time.Duration) (bool, error)
+	ReleaseTokenRefreshLock(ctx context.Context, key domain.ShopKey, owner string) error
 
 	// Webhook operations
 	LogWebhook(ctx context.Context, event *domain.WebhookEvent) error
@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+
+	"archie-core-shopify-layer/internal/domain"
+)
+
+// SessionRepository defines the interface for OAuth session persistence
+type SessionRepository interface {
+	CreateSession(ctx context.Context, session *domain.Session) error
+	GetSession(ctx context.Context, state string) (*domain.Session, error)
+	DeleteSession(ctx context.Context, state string) error
+
+	// ConsumeSession atomically retrieves and deletes a session so an OAuth state can only be used once
+	// Returns nil if the session does not exist or has expired
+	ConsumeSession(ctx context.Context, state string) (*domain.Session, error)
+
+	// CleanupExpiredSessions removes expired sessions
+	CleanupExpiredSessions(ctx context.Context) error
+}
@@ -3,6 +3,8 @@ package ports
 import (
 	"context"
 
+	"archie-core-shopify-layer/internal/domain"
+
 	shopify "github.com/bold-commerce/go-shopify/v4"
 )
 
@@ -10,36 +12,117 @@ import (
 type ShopifyClient interface {
 	// Authentication
 	GenerateAuthURL(shop string, scopes []string, redirectURI string, state string) (string, error)
-	ExchangeToken(ctx context.Context, shop string, code string, redirectURI string) (string, error)
+	// ExchangeToken exchanges an authorization code for an offline token
+	// When expiring is true, Shopify issues an expiring access token together with a refresh token
+	ExchangeToken(ctx context.Context, shop string, code string, redirectURI string, expiring bool) (*domain.Token, error)
+	// RefreshAccessToken rotates an expiring offline token using its refresh token
+	RefreshAccessToken(ctx context.Context, shop string, refreshToken string) (*domain.Token, error)
+	// ExchangeSessionToken exchanges an App Bridge session token for an online or offline access token
+	ExchangeSessionToken(ctx context.Context, shop string, sessionToken string, requestedTokenType domain.RequestedTokenType, expiring bool) (*domain.Token, error)
 
 	// Shop API
 	GetShop(ctx context.Context, shop string, accessToken string) (*shopify.Shop, error)
 
 	// Product API
-	GetProducts(ctx context.Context, shop string, accessToken string, options interface{}) ([]shopify.Product, error)
+	GetProducts(ctx context.Context, shop string, accessToken string, options interface{}) ([]shopify.Product, *shopify.Pagination, error)
 	GetProduct(ctx context.Context, shop string, accessToken string, productID int64) (*shopify.Product, error)
-	CreateProduct(ctx context.Context, shop string, accessToken string, product *shopify.Product) (*shopify.Product, error)
-	UpdateProduct(ctx context.Context, shop string, accessToken string, product *shopify.Product) (*shopify.Product, error)
+	CreateProduct(ctx context.Context, shop string, accessToken string, product *shopify.Product, variantSettings []domain.VariantSettings) (*shopify.Product, error)
+	UpdateProduct(ctx context.Context, shop string, accessToken string, product *shopify.Product, variantSettings []domain.VariantSettings) (*shopify.Product, error)
 	DeleteProduct(ctx context.Context, shop string, accessToken string, productID int64) error
 
 	// Order API
-	GetOrders(ctx context.Context, shop string, accessToken string, options interface{}) ([]shopify.Order, error)
+	GetOrders(ctx context.Context, shop string, accessToken string, options interface{}) ([]shopify.Order, *shopify.Pagination, error)
 	GetOrder(ctx context.Context, shop string, accessToken string, orderID int64) (*shopify.Order, error)
 	CreateOrder(ctx context.Context, shop string, accessToken string, order *shopify.Order) (*shopify.Order, error)
 	UpdateOrder(ctx context.Context, shop string, accessToken string, order *shopify.Order) (*shopify.Order, error)
-	CancelOrder(ctx context.Context, shop string, accessToken string, orderID int64) (*shopify.Order, error)
+	CancelOrder(ctx context.Context, shop string, accessToken string, orderID int64, cancellation domain.OrderCancellation) (*shopify.Order, error)
+	ListOrderTransactions(ctx context.Context, shop string, accessToken string, orderID int64) ([]shopify.Transaction, error)
+	CreateOrderTransaction(ctx context.Context, shop string, accessToken string, orderID int64, transaction *shopify.Transaction) (*shopify.Transaction, error)
+
+	// Refund API
+	ListOrderRefunds(ctx context.Context, shop string, accessToken string, orderID int64) ([]shopify.Refund, error)
+	GetRefund(ctx context.Context, shop string, accessToken string, orderID int64, refundID int64) (*shopify.Refund, error)
+	// CalculateRefund asks Shopify for the amounts and transactions of a refund without creating it
+	CalculateRefund(ctx context.Context, shop string, accessToken string, orderID int64, request domain.RefundRequest) (*domain.RefundCalculation, error)
+	CreateRefund(ctx context.Context, shop string, accessToken string, orderID int64, request domain.RefundRequest) (*shopify.Refund, error)
+
+	// Return API, only available through Shopify's GraphQL Admin API
+	ListOrderReturns(ctx context.Context, shop string, accessToken string, orderID int64) ([]domain.OrderReturn, error)
+	CreateReturn(ctx context.Context, shop string, accessToken string, request domain.ReturnRequest) (*domain.OrderReturn, error)
+	ApproveReturnRequest(ctx context.Context, shop string, accessToken string, returnID int64) (*domain.OrderReturn, error)
+	DeclineReturnRequest(ctx context.Context, shop string, accessToken string, returnID int64, reason domain.ReturnDeclineReason) (*domain.OrderReturn, error)
+	CloseReturn(ctx context.Context, shop string, accessToken string, returnID int64) (*domain.OrderReturn, error)
+	CancelReturn(ctx context.Context, shop string, accessToken string, returnID int64) (*domain.OrderReturn, error)
+
+	// Draft Order API
+	ListDraftOrders(ctx context.Context, shop string, accessToken string, options interface{}) ([]shopify.DraftOrder, *shopify.Pagination, error)
+	GetDraftOrder(ctx context.Context, shop string, accessToken string, draftOrderID int64) (*shopify.DraftOrder, error)
+	CreateDraftOrder(ctx context.Context, shop string, accessToken string, draftOrder *shopify.DraftOrder) (*shopify.DraftOrder, error)
+	// UpdateDraftOrder changes the fields that are set, except the applied discount and shipping line which are always sent so nil removes them
+	UpdateDraftOrder(ctx context.Context, shop string, accessToken string, draftOrder *shopify.DraftOrder) (*shopify.DraftOrder, error)
+	DeleteDraftOrder(ctx context.Context, shop string, accessToken string, draftOrderID int64) error
+	SendDraftOrderInvoice(ctx context.Context, shop string, accessToken string, draftOrderID int64, invoice shopify.DraftOrderInvoice) (*shopify.DraftOrderInvoice, error)
+	CompleteDraftOrder(ctx context.Context, shop string, accessToken string, draftOrderID int64, paymentPending bool) (*shopify.DraftOrder, error)
+
+	// Metafield API, on the owner's REST resource or on the shop
+	ListMetafields(ctx context.Context, shop string, accessToken string, owner domain.MetafieldOwner, options interface{}) ([]shopify.Metafield, *shopify.Pagination, error)
+	GetMetafield(ctx context.Context, shop string, accessToken string, owner domain.MetafieldOwner, metafieldID int64) (*shopify.Metafield, error)
+	CreateMetafield(ctx context.Context, shop string, accessToken string, owner domain.MetafieldOwner, metafield *shopify.Metafield) (*shopify.Metafield, error)
+	UpdateMetafield(ctx context.Context, shop string, accessToken string, owner domain.MetafieldOwner, metafield *shopify.Metafield) (*shopify.Metafield, error)
+	DeleteMetafield(ctx context.Context, shop string, accessToken string, owner domain.MetafieldOwner, metafieldID int64) error
+
+	// Metafield definition and metaobject API, only available through Shopify's GraphQL Admin API
+	ListMetafieldDefinitions(ctx context.Context, shop string, accessToken string, ownerType domain.MetafieldOwnerType, namespace string) ([]domain.MetafieldDefinition, error)
+	CreateMetafieldDefinition(ctx context.Context, shop string, accessToken string, definition domain.MetafieldDefinition) (*domain.MetafieldDefinition, error)
+	UpdateMetafieldDefinition(ctx context.Context, shop string, accessToken string, definition domain.MetafieldDefinition) (*domain.MetafieldDefinition, error)
+	DeleteMetafieldDefinition(ctx context.Context, shop string, accessToken string, definitionID int64, deleteMetafields bool) error
+	ListMetaobjectDefinitions(ctx context.Context, shop string, accessToken string) ([]domain.MetaobjectDefinition, error)
+	// GetMetaobjectDefinitionByType returns a not found error when no definition has the type
+	GetMetaobjectDefinitionByType(ctx context.Context, shop string, accessToken string, objectType string) (*domain.MetaobjectDefinition, error)
+	CreateMetaobjectDefinition(ctx context.Context, shop string, accessToken string, definition domain.MetaobjectDefinition) (*domain.MetaobjectDefinition, error)
+	UpdateMetaobjectDefinition(ctx context.Context, shop string, accessToken string, definitionID int64, update domain.MetaobjectDefinitionUpdate) (*domain.MetaobjectDefinition, error)
+	DeleteMetaobjectDefinition(ctx context.Context, shop string, accessToken string, definitionID int64) error
+	ListMetaobjects(ctx context.Context, shop string, accessToken string, objectType string, first int, after string, before string) (*domain.MetaobjectPage, error)
+	GetMetaobject(ctx context.Context, shop string, accessToken string, metaobjectID int64) (*domain.Metaobject, error)
+	GetMetaobjectByHandle(ctx context.Context, shop string, accessToken string, objectType string, handle string) (*domain.Metaobject, error)
+	CreateMetaobject(ctx context.Context, shop string, accessToken string, metaobject domain.Metaobject) (*domain.Metaobject, error)
+	// UpdateMetaobject sets the given fields and, when not empty, the handle
+	UpdateMetaobject(ctx context.Context, shop string, accessToken string, metaobject domain.Metaobject) (*domain.Metaobject, error)
+	DeleteMetaobject(ctx context.Context, shop string, accessToken string, metaobjectID int64) error
 
 	// Customer API
-	GetCustomers(ctx context.Context, shop string, accessToken string, options interface{}) ([]shopify.Customer, error)
+	GetCustomers(ctx context.Context, shop string, accessToken string, options interface{}) ([]shopify.Customer, *shopify.Pagination, error)
 	GetCustomer(ctx context.Context, shop string, accessToken string, customerID int64) (*shopify.Customer, error)
 	CreateCustomer(ctx context.Context, shop string, accessToken string, customer *shopify.Customer) (*shopify.Customer, error)
 	UpdateCustomer(ctx context.Context, shop string, accessToken string, customer *shopify.Customer) (*shopify.Customer, error)
 	DeleteCustomer(ctx context.Context, shop string, accessToken string, customerID int64) error
-	SearchCustomers(ctx context.Context, shop string, accessToken string, query string) ([]shopify.Customer, error)
+	SearchCustomers(ctx context.Context, shop string, accessToken string, options interface{}) ([]shopify.Customer, *shopify.Pagination, error)
+	ListCustomerOrders(ctx context.Context, shop string, accessToken string, customerID int64, options interface{}) ([]shopify.Order, error)
 
 	// Inventory API
-	GetInventoryLevels(ctx context.Context, shop string, accessToken string, options interface{}) ([]shopify.InventoryLevel, error)
-	UpdateInventoryLevel(ctx context.Context, shop string, accessToken string, inventoryLevel *shopify.InventoryLevel) (*shopify.InventoryLevel, error)
+	GetInventoryLevels(ctx context.Context, shop string, accessToken string, options interface{}) ([]shopify.InventoryLevel, *shopify.Pagination, error)
+	// SetInventoryLevel sets the available stock of an inventory item at a location, connecting them when needed
+	// disconnectIfNecessary lets Shopify disconnect the item from a location that cannot stock it with a fulfillment service
+	SetInventoryLevel(ctx context.Context, shop string, accessToken string, inventoryItemID int64, locationID int64, available int, disconnectIfNecessary bool) (*shopify.InventoryLevel, error)
+	AdjustInventoryLevel(ctx context.Context, shop string, accessToken string, adjustment domain.InventoryAdjustment) (*shopify.InventoryLevel, error)
+	// ConnectInventoryLevel stocks an inventory item at a location
+	// relocateIfNecessary moves the item away from a fulfillment service location that does not allow several locations
+	ConnectInventoryLevel(ctx context.Context, shop string, accessToken string, inventoryItemID int64, locationID int64, relocateIfNecessary bool) (*shopify.InventoryLevel, error)
+	DisconnectInventoryLevel(ctx context.Context, shop string, accessToken string, inventoryItemID int64, locationID int64) error
+	// ListInventoryItems retrieves inventory items by ID, at most 100 at once
+	ListInventoryItems(ctx context.Context, shop string, accessToken string, inventoryItemIDs []uint64) ([]shopify.InventoryItem, error)
+	GetInventoryItem(ctx context.Context, shop string, accessToken string, inventoryItemID int64) (*shopify.InventoryItem, error)
+	UpdateInventoryItem(ctx context.Context, shop string, accessToken string, inventoryItem *shopify.InventoryItem) (*shopify.InventoryItem, error)
+	GetVariant(ctx context.Context, shop string, accessToken string, variantID int64) (*shopify.Variant, error)
+
+	// Location API
+	ListLocations(ctx context.Context, shop string, accessToken string) ([]shopify.Location, error)
+	GetLocation(ctx context.Context, shop string, accessToken string, locationID int64) (*shopify.Location, error)
+
+	// Storefront access token API, a shop holds at most 100 tokens
+	ListStorefrontAccessTokens(ctx context.Context, shop string, accessToken string) ([]shopify.StorefrontAccessToken, error)
+	CreateStorefrontAccessToken(ctx context.Context, shop string, accessToken string, title string) (*shopify.StorefrontAccessToken, error)
+	DeleteStorefrontAccessToken(ctx context.Context, shop string, accessToken string, tokenID int64) error
 
 	// Webhook API
 	CreateWebhook(ctx context.Context, shop string, accessToken string, topic string, address string) (*shopify.Webhook, error)
@@ -50,25 +133,55 @@ type ShopifyClient interface {
 
 	// Collection API
 	GetCollection(ctx context.Context, shop string, accessToken string, collectionID int64) (*shopify.Collection, error)
-	ListCollectionProducts(ctx context.Context, shop string, accessToken string, collectionID int64, options interface{}) ([]shopify.Product, error)
+	ListCollectionProducts(ctx context.Context, shop string, accessToken string, collectionID int64, options interface{}) ([]shopify.Product, *shopify.Pagination, error)
 	GetCustomCollection(ctx context.Context, shop string, accessToken string, collectionID int64) (*shopify.CustomCollection, error)
-	ListCustomCollections(ctx context.Context, shop string, accessToken string, options interface{}) ([]shopify.CustomCollection, error)
+	ListCustomCollections(ctx context.Context, shop string, accessToken string, options interface{}) ([]shopify.CustomCollection, *shopify.Pagination, error)
 	CreateCustomCollection(ctx context.Context, shop string, accessToken string, collection *shopify.CustomCollection) (*shopify.CustomCollection, error)
 	UpdateCustomCollection(ctx context.Context, shop string, accessToken string, collection *shopify.CustomCollection) (*shopify.CustomCollection, error)
 	DeleteCustomCollection(ctx context.Context, shop string, accessToken string, collectionID int64) error
+	GetSmartCollection(ctx context.Context, shop string, accessToken string, collectionID int64) (*shopify.SmartCollection, error)
+	ListSmartCollections(ctx context.Context, shop string, accessToken string, options interface{}) ([]shopify.SmartCollection, *shopify.Pagination, error)
+	CreateSmartCollection(ctx context.Context, shop string, accessToken string, collection *shopify.SmartCollection) (*shopify.SmartCollection, error)
+	UpdateSmartCollection(ctx context.Context, shop string, accessToken string, collection *shopify.SmartCollection) (*shopify.SmartCollection, error)
+	DeleteSmartCollection(ctx context.Context, shop string, accessToken string, collectionID int64) error
+	ListCollects(ctx context.Context, shop string, accessToken string, options interface{}) ([]shopify.Collect, error)
+	CreateCollect(ctx context.Context, shop string, accessToken string, collect *shopify.Collect) (*shopify.Collect, error)
+	DeleteCollect(ctx context.Context, shop string, accessToken string, collectID int64) error
+
+	// Fulfillment order API
+	ListOrderFulfillmentOrders(ctx context.Context, shop string, accessToken string, orderID int64) ([]shopify.FulfillmentOrder, error)
+	ListAssignedFulfillmentOrders(ctx context.Context, shop string, accessToken string, options interface{}) ([]shopify.FulfillmentOrder, error)
+	GetFulfillmentOrder(ctx context.Context, shop string, accessToken string, fulfillmentOrderID int64) (*shopify.FulfillmentOrder, error)
+	AcceptFulfillmentRequest(ctx context.Context, shop string, accessToken string, fulfillmentOrderID int64, message string) (*shopify.FulfillmentOrder, error)
+	RejectFulfillmentRequest(ctx context.Context, shop string, accessToken string, fulfillmentOrderID int64, request shopify.FulfillmentRequest) (*shopify.FulfillmentOrder, error)
+	HoldFulfillmentOrder(ctx context.Context, shop string, accessToken string, fulfillmentOrderID int64, hold shopify.FulfillmentOrderHold, notifyMerchant bool) (*shopify.FulfillmentOrder, error)
+	ReleaseFulfillmentOrderHold(ctx context.Context, shop string, accessToken string, fulfillmentOrderID int64) (*shopify.FulfillmentOrder, error)
+	MoveFulfillmentOrder(ctx context.Context, shop string, accessToken string, fulfillmentOrderID int64, move shopify.FulfillmentOrderMoveRequest) (*shopify.FulfillmentOrderMoveResource, error)
 
 	// Fulfillment API
-	ListFulfillments(ctx context.Context, shop string, accessToken string, options interface{}) ([]shopify.Fulfillment, error)
-	GetFulfillment(ctx context.Context, shop string, accessToken string, fulfillmentID int64) (*shopify.Fulfillment, error)
+	ListOrderFulfillments(ctx context.Context, shop string, accessToken string, orderID int64, options interface{}) ([]shopify.Fulfillment, error)
 	CreateFulfillment(ctx context.Context, shop string, accessToken string, fulfillment *shopify.Fulfillment) (*shopify.Fulfillment, error)
-	UpdateFulfillment(ctx context.Context, shop string, accessToken string, fulfillment *shopify.Fulfillment) (*shopify.Fulfillment, error)
-	CompleteFulfillment(ctx context.Context, shop string, accessToken string, fulfillmentID int64) (*shopify.Fulfillment, error)
+	UpdateFulfillmentTracking(ctx context.Context, shop string, accessToken string, fulfillmentID int64, tracking shopify.FulfillmentTrackingInfo, notifyCustomer bool) (*shopify.Fulfillment, error)
 	CancelFulfillment(ctx context.Context, shop string, accessToken string, fulfillmentID int64) (*shopify.Fulfillment, error)
 
+	// Price Rule API
+	ListPriceRules(ctx context.Context, shop string, accessToken string, options interface{}) ([]shopify.PriceRule, *shopify.Pagination, error)
+	GetPriceRule(ctx context.Context, shop string, accessToken string, priceRuleID int64) (*shopify.PriceRule, error)
+	CreatePriceRule(ctx context.Context, shop string, accessToken string, priceRule *shopify.PriceRule) (*shopify.PriceRule, error)
+	// UpdatePriceRule replaces the price rule with the given fields; once per customer, usage limit and end date are always sent
+	UpdatePriceRule(ctx context.Context, shop string, accessToken string, priceRule *shopify.PriceRule) (*shopify.PriceRule, error)
+	DeletePriceRule(ctx context.Context, shop string, accessToken string, priceRuleID int64) error
+
 	// Discount Code API (requires PriceRule ID)
-	ListDiscountCodes(ctx context.Context, shop string, accessToken string, priceRuleID int64) ([]shopify.PriceRuleDiscountCode, error)
+	ListDiscountCodes(ctx context.Context, shop string, accessToken string, priceRuleID int64, options interface{}) ([]shopify.PriceRuleDiscountCode, *shopify.Pagination, error)
 	GetDiscountCode(ctx context.Context, shop string, accessToken string, priceRuleID int64, discountCodeID int64) (*shopify.PriceRuleDiscountCode, error)
 	CreateDiscountCode(ctx context.Context, shop string, accessToken string, priceRuleID int64, discountCode *shopify.PriceRuleDiscountCode) (*shopify.PriceRuleDiscountCode, error)
 	UpdateDiscountCode(ctx context.Context, shop string, accessToken string, priceRuleID int64, discountCode *shopify.PriceRuleDiscountCode) (*shopify.PriceRuleDiscountCode, error)
 	DeleteDiscountCode(ctx context.Context, shop string, accessToken string, priceRuleID int64, discountCodeID int64) error
+	// LookupDiscountCode finds a discount code of any price rule by its code
+	LookupDiscountCode(ctx context.Context, shop string, accessToken string, code string) (*shopify.PriceRuleDiscountCode, error)
+	CountDiscountCodes(ctx context.Context, shop string, accessToken string, options interface{}) (int, error)
+	CreateDiscountCodeBatch(ctx context.Context, shop string, accessToken string, priceRuleID int64, codes []string) (*domain.DiscountCodeBatch, error)
+	GetDiscountCodeBatch(ctx context.Context, shop string, accessToken string, priceRuleID int64, batchID int64) (*domain.DiscountCodeBatch, error)
+	ListDiscountCodeBatchCodes(ctx context.Context, shop string, accessToken string, priceRuleID int64, batchID int64) ([]domain.DiscountCodeBatchCode, error)
 }
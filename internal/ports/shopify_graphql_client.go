@@ -0,0 +1,41 @@
+package ports
+
+import (
+	"context"
+	"encoding/json"
+
+	"archie-core-shopify-layer/internal/domain"
+
+	shopify "github.com/bold-commerce/go-shopify/v4"
+)
+
+// ShopifyResourceReader holds the reads that ShopifyService can serve from either Shopify Admin API
+// Both ShopifyClient and ShopifyGraphQLClient implement it, see domain.RoutableShopifyOperations
+type ShopifyResourceReader interface {
+	GetProduct(ctx context.Context, shop string, accessToken string, productID int64) (*shopify.Product, error)
+	GetOrder(ctx context.Context, shop string, accessToken string, orderID int64) (*shopify.Order, error)
+	GetCustomer(ctx context.Context, shop string, accessToken string, customerID int64) (*shopify.Customer, error)
+}
+
+// ShopifyGraphQLClient defines the operations of Shopify's Admin GraphQL API
+// Requests wait for the shop's query cost bucket and retry when Shopify throttles them
+type ShopifyGraphQLClient interface {
+	ShopifyResourceReader
+
+	// Execute sends a request as is; GraphQL errors are returned in the response rather than as an error
+	Execute(ctx context.Context, shop string, accessToken string, request domain.GraphQLRequest) (*domain.GraphQLResponse, error)
+	// Query runs a query or mutation and decodes its data into response, GraphQL errors fail the call
+	Query(ctx context.Context, shop string, accessToken string, query string, variables map[string]interface{}, response interface{}) error
+	// Paginate runs a query for each page of the connection at path, the dot-separated fields below data
+	// The query takes the cursor as $after and selects pageInfo { hasNextPage endCursor } of the connection;
+	// handle receives the nodes of each page and stops the pagination by returning an error
+	Paginate(ctx context.Context, shop string, accessToken string, query string, variables map[string]interface{}, path string, handle func(nodes []json.RawMessage) error) error
+
+	// Bulk operations
+	RunBulkQuery(ctx context.Context, shop string, accessToken string, query string) (*domain.BulkOperation, error)
+	RunBulkMutation(ctx context.Context, shop string, accessToken string, mutation string, stagedUploadPath string) (*domain.BulkOperation, error)
+	GetBulkOperation(ctx context.Context, shop string, accessToken string, operationID uint64) (*domain.BulkOperation, error)
+	CancelBulkOperation(ctx context.Context, shop string, accessToken string, operationID uint64) (*domain.BulkOperation, error)
+	// CreateBulkMutationUpload reserves a staged upload for the JSONL variables of a bulk mutation
+	CreateBulkMutationUpload(ctx context.Context, shop string, accessToken string, filename string) (*domain.StagedUploadTarget, error)
+}
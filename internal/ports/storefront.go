@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+
+	"archie-core-shopify-layer/internal/domain"
+)
+
+// StorefrontTokenRepository defines the interface for Storefront access token persistence
+type StorefrontTokenRepository interface {
+	// Save creates or updates a token keyed by shop and Shopify ID
+	Save(ctx context.Context, token *domain.StorefrontToken) error
+
+	// List retrieves the tokens of a shop, newest first
+	List(ctx context.Context, key domain.ShopKey) ([]*domain.StorefrontToken, error)
+
+	// Delete removes a token of a shop, it is not an error if it does not exist
+	Delete(ctx context.Context, key domain.ShopKey, tokenID uint64) error
+}
+
+// ShopifyStorefrontClient sends requests to Shopify's Storefront GraphQL API
+type ShopifyStorefrontClient interface {
+	// Execute sends a request with a Storefront access token, GraphQL errors are returned in the response
+	Execute(ctx context.Context, shop string, storefrontToken string, request domain.GraphQLRequest) (*domain.GraphQLResponse, error)
+}
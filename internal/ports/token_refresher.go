@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"archie-core-shopify-layer/internal/domain"
+)
+
+// TokenRefresher resolves usable access tokens for shops, refreshing expiring offline tokens when needed
+type TokenRefresher interface {
+	// GetAccessToken returns the decrypted access token for the shop
+	// Expiring tokens close to expiry are refreshed first (single-flight per shop)
+	GetAccessToken(ctx context.Context, shop *domain.Shop, client ShopifyClient) (string, error)
+}
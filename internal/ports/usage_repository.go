@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// UsageRepository defines the interface for inbound rate limit windows and usage counters
+type UsageRepository interface {
+	// IncrementWindow counts a request in a fixed window and returns the count so far
+	// The window key identifies both the subject and the window, and expires after ttl
+	IncrementWindow(ctx context.Context, windowKey string, ttl time.Duration) (int64, error)
+
+	// IncrementUsage counts a request in the daily and monthly counters of a subject and returns the counts so far
+	IncrementUsage(ctx context.Context, subject string, at time.Time) (daily int64, monthly int64, err error)
+
+	// GetUsage returns the daily and monthly counters of a subject for the periods containing at
+	GetUsage(ctx context.Context, subject string, at time.Time) (daily int64, monthly int64, err error)
+}